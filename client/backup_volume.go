@@ -0,0 +1,85 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// BackupVolume writes a gzip compressed tar archive of the contents of a volume to file and
+// returns the size of the archive. The archive is downloaded in chunks followed by its checksum,
+// which is verified before file is written.
+func (c *Client) BackupVolume(ctx context.Context, name string, file string) (uint64, error) {
+	if c.ext == nil {
+		return 0, status.Error(codes.Unimplemented, "backing up volumes is not available without a client of the extension service")
+	}
+	req, err := options.NewExtensionRequest(options.ExportVolume, options.VolumeArchiveArgs{Name: name})
+	if err != nil {
+		return 0, err
+	}
+	dcli, err := c.ext.Download(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	w := io.MultiWriter(tmp, h)
+	var size uint64
+	for {
+		msg, err := dcli.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if _, err := w.Write(msg.GetValue()); err != nil {
+			return 0, err
+		}
+		size += uint64(len(msg.GetValue()))
+	}
+
+	checksum := dcli.Trailer().Get(options.ChecksumTrailer)
+	if len(checksum) == 0 {
+		return 0, status.Errorf(codes.DataLoss, "backup of volume %s is incomplete", name)
+	}
+	if sum := options.FormatChecksum(h.Sum(nil)); sum != checksum[0] {
+		return 0, status.Errorf(codes.DataLoss, "backup of volume %s has checksum %s, want %s", name, sum, checksum[0])
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
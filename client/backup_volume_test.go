@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func archiveChecksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return options.FormatChecksum(sum[:])
+}
+
+func TestBackupVolume(t *testing.T) {
+	archive := []byte("some compressed archive")
+	chunks := [][]byte{archive[:8], archive[8:]}
+
+	tests := []struct {
+		name       string
+		inChunks   [][]byte
+		inChecksum string
+		inErr      error
+		wantSize   uint64
+		wantFile   []byte
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			inChunks:   chunks,
+			inChecksum: archiveChecksum(archive),
+			wantSize:   uint64(len(archive)),
+			wantFile:   archive,
+		},
+		{
+			name:    "no-such-volume",
+			inErr:   status.Error(codes.NotFound, "volume some-volume not found"),
+			wantErr: status.Error(codes.NotFound, "volume some-volume not found"),
+		},
+		{
+			name:       "checksum-mismatch",
+			inChunks:   chunks,
+			inChecksum: archiveChecksum([]byte("other archive")),
+			wantErr:    status.Errorf(codes.DataLoss, "backup of volume %s has checksum %s, want %s", "some-volume", archiveChecksum(archive), archiveChecksum([]byte("other archive"))),
+		},
+		{
+			name:     "incomplete",
+			inChunks: chunks,
+			wantErr:  status.Errorf(codes.DataLoss, "backup of volume %s is incomplete", "some-volume"),
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fcm := &fakeExtensionServer{
+				err:      tc.inErr,
+				chunks:   tc.inChunks,
+				checksum: tc.inChecksum,
+			}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			file := filepath.Join(t.TempDir(), "backup.tar.gz")
+			size, err := cli.BackupVolume(ctx, "some-volume", file)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("BackupVolume(some-volume) returned an unexpected error (-want +got):\n%s", diff)
+			}
+			if size != tc.wantSize {
+				t.Errorf("BackupVolume(some-volume) returned size %d, want %d", size, tc.wantSize)
+			}
+
+			if fcm.recvOp != options.ExportVolume {
+				t.Errorf("BackupVolume(some-volume) performed operation %s, want %s", fcm.recvOp, options.ExportVolume)
+			}
+			if diff := cmp.Diff(map[string]any{"name": "some-volume"}, fcm.recvArgs); diff != "" {
+				t.Errorf("BackupVolume(some-volume) sent unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			got, err := os.ReadFile(file)
+			if tc.wantFile == nil {
+				if !os.IsNotExist(err) {
+					t.Errorf("BackupVolume(some-volume) wrote %s, want no file", file)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadFile(%s) returned an unexpected error: %v", file, err)
+			}
+			if diff := cmp.Diff(tc.wantFile, got); diff != "" {
+				t.Errorf("BackupVolume(some-volume) wrote an unexpected archive (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
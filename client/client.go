@@ -18,6 +18,7 @@ package client
 import (
 	"context"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -31,6 +32,9 @@ var (
 // Client is a grpc containerz client.
 type Client struct {
 	cli cpb.ContainerzClient
+	// ext performs the operations of the Extension service. It is nil if the client was built
+	// from a stub of the containerz service only.
+	ext options.ExtensionClient
 }
 
 // NewClient builds a new containerz client.
@@ -43,6 +47,7 @@ func NewClient(ctx context.Context, addr string) (*Client, error) {
 
 	return &Client{
 		cli: cpb.NewContainerzClient(conn),
+		ext: options.NewExtensionClient(conn),
 	}, nil
 }
 
@@ -50,13 +55,19 @@ func NewClient(ctx context.Context, addr string) (*Client, error) {
 func NewClientWithConn(conn *grpc.ClientConn) *Client {
 	return &Client{
 		cli: cpb.NewContainerzClient(conn),
+		ext: options.NewExtensionClient(conn),
 	}
 }
 
 // NewClientFromStub allows the creation of a client using a client
-// obtained via gnoigo.
-func NewClientFromStub(c cpb.ContainerzClient) *Client {
-	return &Client{
+// obtained via gnoigo. The operations of the Extension service are only available if a stub of
+// it is given too.
+func NewClientFromStub(c cpb.ContainerzClient, ext ...options.ExtensionClient) *Client {
+	client := &Client{
 		cli: c,
 	}
+	if len(ext) > 0 {
+		client.ext = ext[0]
+	}
+	return client
 }
@@ -22,6 +22,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -58,6 +59,9 @@ func newServer(t *testing.T, srv cpb.ContainerzServer) (string, func()) {
 	}
 
 	cpb.RegisterContainerzServer(s, srv)
+	if ext, ok := srv.(options.ExtensionServer); ok {
+		options.RegisterExtensionServer(s, ext)
+	}
 	go s.Serve(l)
 	return l.Addr().String(), s.Stop
 }
@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// chunkReader reads the file pushed in chunks.
+type chunkReader interface {
+	Read(chunkSize int32) ([]byte, error)
+	Size() uint64
+	Close() error
+}
+
+// compressingReader compresses a file on the fly and reads the result in chunks.
+type compressingReader struct {
+	f    *os.File
+	r    *io.PipeReader
+	size uint64
+}
+
+func newCompressingReader(file string, compression Compression) (*compressingReader, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	var w io.WriteCloser
+	switch compression {
+	case Gzip:
+		w = gzip.NewWriter(pw)
+	case Zstd:
+		if w, err = zstd.NewWriter(pw); err != nil {
+			f.Close()
+			return nil, err
+		}
+	default:
+		f.Close()
+		return nil, fmt.Errorf("unsupported compression %q, use %s or %s", compression, Gzip, Zstd)
+	}
+
+	go func() {
+		_, err := io.Copy(w, f)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return &compressingReader{
+		f:    f,
+		r:    pr,
+		size: compressedSizeBound(uint64(stat.Size())),
+	}, nil
+}
+
+// Read reads a chunk of the compressed file.
+func (r *compressingReader) Read(chunkSize int32) ([]byte, error) {
+	buf := make([]byte, chunkSize)
+	n, err := io.ReadFull(r.r, buf)
+	if err == io.ErrUnexpectedEOF {
+		return buf[:n], nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Size returns the maximum size of the compressed file, which is only known once it is read.
+func (r *compressingReader) Size() uint64 {
+	return r.size
+}
+
+// Close stops the compression and closes the file.
+func (r *compressingReader) Close() error {
+	r.r.Close()
+	return r.f.Close()
+}
+
+// compressedSizeBound returns the maximum size of a file of the given size once compressed with
+// gzip or zstd, whose output grows slightly when the input does not compress.
+func compressedSizeBound(size uint64) uint64 {
+	return size + size>>8 + 1024
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestCompressingReader(t *testing.T) {
+	tests := []struct {
+		name          string
+		inCompression Compression
+		inChunkSize   int32
+		decompress    func(io.Reader) (io.Reader, error)
+	}{
+		{
+			name:          "gzip",
+			inCompression: Gzip,
+			inChunkSize:   16,
+			decompress:    func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			name:          "zstd",
+			inCompression: Zstd,
+			inChunkSize:   1 << 16,
+			decompress: func(r io.Reader) (io.Reader, error) {
+				d, err := zstd.NewReader(r)
+				if err != nil {
+					return nil, err
+				}
+				return d.IOReadCloser(), nil
+			},
+		},
+	}
+
+	want, err := os.ReadFile("testdata/reader-data.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reader, err := newCompressingReader("testdata/reader-data.txt", tc.inCompression)
+			if err != nil {
+				t.Fatalf("newCompressingReader(%q) returned error: %v", tc.inCompression, err)
+			}
+			defer reader.Close()
+
+			var compressed []byte
+			for {
+				buf, err := reader.Read(tc.inChunkSize)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Read(%d) returned error: %v", tc.inChunkSize, err)
+				}
+				if len(buf) > int(tc.inChunkSize) {
+					t.Fatalf("Read(%d) returned %d bytes", tc.inChunkSize, len(buf))
+				}
+				compressed = append(compressed, buf...)
+			}
+			if got := uint64(len(compressed)); got > reader.Size() {
+				t.Errorf("Size() = %d, want at least the %d bytes read", reader.Size(), got)
+			}
+
+			r, err := tc.decompress(bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatalf("unable to decompress the data read: %v", err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unable to decompress the data read: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Read() returned %q once decompressed, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCompressingReaderUnsupported(t *testing.T) {
+	if _, err := newCompressingReader("testdata/reader-data.txt", "bzip2"); err == nil {
+		t.Errorf("newCompressingReader(%q) returned no error", "bzip2")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// CreateNetwork creates a network with the given driver, bridge if empty, driver options, subnets
+// and labels, and returns its name.
+func (c *Client) CreateNetwork(ctx context.Context, name, driver string, subnets []options.Subnet, driverOpts, labels map[string]string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("the name of the network must be provided")
+	}
+
+	args := options.NetworkArgs{
+		Name:    name,
+		Driver:  driver,
+		Options: driverOpts,
+		Subnets: subnets,
+		Labels:  labels,
+	}
+	var created string
+	if err := c.call(ctx, options.CreateNetwork, args, &created); err != nil {
+		return "", err
+	}
+	return created, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestCreateNetwork(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inName       string
+		inDriver     string
+		inSubnets    []options.Subnet
+		inDriverOpts map[string]string
+		inLabels     map[string]string
+
+		wantArgs map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "bridge",
+			inName:   "mgmt",
+			wantArgs: map[string]any{"name": "mgmt"},
+		},
+		{
+			name:         "macvlan",
+			inName:       "data",
+			inDriver:     "macvlan",
+			inSubnets:    []options.Subnet{{Subnet: "198.51.100.0/24", Gateway: "198.51.100.1"}, {Subnet: "2001:db8::/64"}},
+			inDriverOpts: map[string]string{"parent": "eth1"},
+			inLabels:     map[string]string{"role": "data"},
+			wantArgs: map[string]any{
+				"name":   "data",
+				"driver": "macvlan",
+				"subnets": []any{
+					map[string]any{"subnet": "198.51.100.0/24", "gateway": "198.51.100.1"},
+					map[string]any{"subnet": "2001:db8::/64"},
+				},
+				"options": map[string]any{"parent": "eth1"},
+				"labels":  map[string]any{"role": "data"},
+			},
+		},
+		{
+			name:    "no-name",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{result: tc.inName}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			got, err := cli.CreateNetwork(ctx, tc.inName, tc.inDriver, tc.inSubnets, tc.inDriverOpts, tc.inLabels)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("CreateNetwork(%q, %q) returned an unexpected error: %v", tc.inName, tc.inDriver, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("CreateNetwork(%q, %q) did not return an error", tc.inName, tc.inDriver)
+			}
+
+			if got != tc.inName {
+				t.Errorf("CreateNetwork(%q, %q) = %q, want %q", tc.inName, tc.inDriver, got, tc.inName)
+			}
+			if fcm.recvOp != options.CreateNetwork {
+				t.Errorf("CreateNetwork(%q, %q) performed operation %s, want %s", tc.inName, tc.inDriver, fcm.recvOp, options.CreateNetwork)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("CreateNetwork(%q, %q) returned an unexpected diff (-want +got):\n%s", tc.inName, tc.inDriver, diff)
+			}
+		})
+	}
+}
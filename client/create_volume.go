@@ -3,21 +3,27 @@ package client
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
 // CreateVolume creates a volume. If the name is empty, the target system will create one. The
 // driver default to the target defined option.
-func (c *Client) CreateVolume(ctx context.Context, name, driver string, labels, options map[string]string) (string, error) {
-	req, err := requestForDriver(driver, options)
+func (c *Client) CreateVolume(ctx context.Context, name, driver string, labels, driverOpts map[string]string) (string, error) {
+	req, err := requestForDriver(driver, driverOpts)
 	if err != nil {
 		return "", err
 	}
 
 	req.Name = name
-	req.Labels = labels
+	if req.Labels == nil {
+		req.Labels = labels
+	} else {
+		maps.Copy(req.Labels, labels)
+	}
 
 	resp, err := c.cli.CreateVolume(ctx, req)
 	if err != nil {
@@ -27,19 +33,23 @@ func (c *Client) CreateVolume(ctx context.Context, name, driver string, labels,
 }
 
 // requestForDriver returns a CreateVolumeRequest given the string representation of the driver name
-// and an arbitrary list of key-value entries representing options of the driver.
-func requestForDriver(driver string, options map[string]string) (*cpb.CreateVolumeRequest, error) {
+// and an arbitrary list of key-value entries representing options of the driver. The type of a
+// volume of the local driver is none, for a bind mount of the mountpoint, tmpfs, or the filesystem
+// type of the block device given as mountpoint.
+func requestForDriver(driver string, driverOpts map[string]string) (*cpb.CreateVolumeRequest, error) {
 	req := &cpb.CreateVolumeRequest{}
 	switch strings.ToLower(driver) {
 	case "local", "":
 		req.Driver = cpb.Driver_DS_LOCAL
 		localOpts := &cpb.LocalDriverOptions{}
-		for key, value := range options {
+		for key, value := range driverOpts {
 			switch strings.ToLower(key) {
 			case "type":
 				switch strings.ToLower(value) {
 				case "none", "":
 					localOpts.Type = cpb.LocalDriverOptions_TYPE_NONE
+				case "tmpfs", "ext4", "xfs", "btrfs":
+					req.Labels = map[string]string{options.VolumeMountTypeLabel: strings.ToLower(value)}
 				default:
 					return nil, fmt.Errorf("invalid type: %q", value)
 				}
@@ -59,7 +69,7 @@ func requestForDriver(driver string, options map[string]string) (*cpb.CreateVolu
 		req.Driver = cpb.Driver_DS_CUSTOM
 		req.Options = &cpb.CreateVolumeRequest_CustomOptions{
 			CustomOptions: &cpb.CustomOptions{
-				Options: options,
+				Options: driverOpts,
 			},
 		}
 	}
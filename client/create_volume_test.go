@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -143,6 +144,49 @@ func TestCreateVolume(t *testing.T) {
 			wantName: "simple",
 			inMsg:    &cpb.CreateVolumeResponse{Name: "simple"},
 		},
+		{
+			name:     "tmpfs",
+			inName:   "simple",
+			inDriver: "local",
+			inLabels: map[string]string{"label1": "value1"},
+			inOptions: map[string]string{
+				"type":    "tmpfs",
+				"options": "size=64m,mode=1770",
+			},
+			wantRequest: &cpb.CreateVolumeRequest{
+				Name:   "simple",
+				Driver: cpb.Driver_DS_LOCAL,
+				Options: &cpb.CreateVolumeRequest_LocalMountOptions{
+					LocalMountOptions: &cpb.LocalDriverOptions{
+						Options: []string{"size=64m", "mode=1770"},
+					},
+				},
+				Labels: map[string]string{"label1": "value1", options.VolumeMountTypeLabel: "tmpfs"},
+			},
+			wantName: "simple",
+			inMsg:    &cpb.CreateVolumeResponse{Name: "simple"},
+		},
+		{
+			name:     "block-device",
+			inName:   "simple",
+			inDriver: "local",
+			inOptions: map[string]string{
+				"type":       "EXT4",
+				"mountpoint": "/dev/sdb1",
+			},
+			wantRequest: &cpb.CreateVolumeRequest{
+				Name:   "simple",
+				Driver: cpb.Driver_DS_LOCAL,
+				Options: &cpb.CreateVolumeRequest_LocalMountOptions{
+					LocalMountOptions: &cpb.LocalDriverOptions{
+						Mountpoint: "/dev/sdb1",
+					},
+				},
+				Labels: map[string]string{options.VolumeMountTypeLabel: "ext4"},
+			},
+			wantName: "simple",
+			inMsg:    &cpb.CreateVolumeResponse{Name: "simple"},
+		},
 	}
 
 	ctx := context.Background()
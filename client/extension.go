@@ -0,0 +1,44 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// call performs an operation of the Extension service and unmarshals its result into result,
+// unless it is nil.
+func (c *Client) call(ctx context.Context, op options.Operation, args, result any) error {
+	if c.ext == nil {
+		return status.Errorf(codes.Unimplemented, "operation %s is not available without a client of the extension service", op)
+	}
+	req, err := options.NewExtensionRequest(op, args)
+	if err != nil {
+		return err
+	}
+	resp, err := c.ext.Call(ctx, req)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return options.ParseExtensionResult(resp, result)
+}
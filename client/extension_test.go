@@ -0,0 +1,152 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// fakeExtensionServer records the operation it is asked to perform, along with the data uploaded,
+// and returns result, or err. Downloads stream chunks followed by the checksum trailer.
+type fakeExtensionServer struct {
+	fakeContainerzServer
+
+	result   any
+	err      error
+	chunks   [][]byte
+	checksum string
+
+	recvOp   options.Operation
+	recvArgs map[string]any
+	recvData []byte
+}
+
+func (f *fakeExtensionServer) record(req *options.ExtensionRequest) error {
+	f.recvOp = req.Operation
+	f.recvArgs = nil
+	if len(req.Args) == 0 {
+		return nil
+	}
+	return json.Unmarshal(req.Args, &f.recvArgs)
+}
+
+func (f *fakeExtensionServer) Call(ctx context.Context, in *structpb.Struct) (*structpb.Value, error) {
+	req, err := options.ParseExtensionRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.record(req); err != nil {
+		return nil, err
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return options.NewExtensionResult(f.result)
+}
+
+func (f *fakeExtensionServer) Download(in *structpb.Struct, srv grpc.ServerStreamingServer[wrapperspb.BytesValue]) error {
+	req, err := options.ParseExtensionRequest(in)
+	if err != nil {
+		return err
+	}
+	if err := f.record(req); err != nil {
+		return err
+	}
+	if f.err != nil {
+		return f.err
+	}
+	for _, chunk := range f.chunks {
+		if err := srv.Send(wrapperspb.Bytes(chunk)); err != nil {
+			return err
+		}
+	}
+	if f.checksum != "" {
+		srv.SetTrailer(metadata.Pairs(options.ChecksumTrailer, f.checksum))
+	}
+	return nil
+}
+
+func (f *fakeExtensionServer) Upload(srv grpc.ClientStreamingServer[wrapperspb.BytesValue, structpb.Value]) error {
+	msg, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	req, err := options.UnmarshalExtensionRequest(msg.GetValue())
+	if err != nil {
+		return err
+	}
+	if err := f.record(req); err != nil {
+		return err
+	}
+	for {
+		msg, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		f.recvData = append(f.recvData, msg.GetValue()...)
+	}
+	if f.err != nil {
+		return f.err
+	}
+	out, err := options.NewExtensionResult(f.result)
+	if err != nil {
+		return err
+	}
+	return srv.SendAndClose(out)
+}
+
+func TestCallWithoutExtension(t *testing.T) {
+	cli := NewClientFromStub(cpb.NewContainerzClient(nil))
+	err := cli.call(context.Background(), options.RemoveNetwork, options.NetworkArgs{Name: "mgmt"}, nil)
+	if got := status.Code(err); got != codes.Unimplemented {
+		t.Errorf("call() returned error %v, want code %v", err, codes.Unimplemented)
+	}
+}
+
+func TestCallUnknownOperation(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeExtensionServer{err: status.Error(codes.Unimplemented, "unknown operation Unknown")}
+	addr, stop := newServer(t, fake)
+	defer stop()
+	cli, err := NewClient(ctx, addr)
+	if err != nil {
+		t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+	}
+
+	err = cli.call(ctx, "Unknown", nil, nil)
+	if got := status.Code(err); got != codes.Unimplemented {
+		t.Errorf("call() returned error %v, want code %v", err, codes.Unimplemented)
+	}
+	if diff := cmp.Diff(options.Operation("Unknown"), fake.recvOp); diff != "" {
+		t.Errorf("call() sent an unexpected operation (-want +got):\n%s", diff)
+	}
+}
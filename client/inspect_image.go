@@ -0,0 +1,31 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// InspectImage returns the details of an image, referenced by name, name:tag or ID, such as its
+// size, platform, default command, labels and layers.
+func (c *Client) InspectImage(ctx context.Context, image string) (*options.ImageDetails, error) {
+	details := &options.ImageDetails{}
+	if err := c.call(ctx, options.InspectImage, options.ImageArgs{Image: image}, details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
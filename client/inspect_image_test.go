@@ -0,0 +1,114 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestInspectImage(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inImage  string
+		inResult any
+		inErr    error
+
+		wantArgs    map[string]any
+		wantDetails *options.ImageDetails
+		wantErr     bool
+	}{
+		{
+			name:     "details",
+			inImage:  "server:v1",
+			inResult: json.RawMessage(`{"id":"sha256:server","repo_tags":["server:v1"],"repo_digests":[],"created":"2025-02-03T10:30:00Z","size":1024,"architecture":"arm64","variant":"v8","os":"linux","entrypoint":["/server"],"cmd":[],"exposed_ports":["8080/tcp"],"volumes":["/data"],"labels":{"version":"1"},"layers":["sha256:base"]}`),
+			wantArgs: map[string]any{"image": "server:v1"},
+			wantDetails: &options.ImageDetails{
+				ID:           "sha256:server",
+				RepoTags:     []string{"server:v1"},
+				RepoDigests:  []string{},
+				Created:      time.Date(2025, 2, 3, 10, 30, 0, 0, time.UTC),
+				Size:         1024,
+				Architecture: "arm64",
+				Variant:      "v8",
+				OS:           "linux",
+				Entrypoint:   []string{"/server"},
+				Cmd:          []string{},
+				ExposedPorts: []string{"8080/tcp"},
+				Volumes:      []string{"/data"},
+				Labels:       map[string]string{"version": "1"},
+				Layers:       []string{"sha256:base"},
+			},
+		},
+		{
+			name:     "bad-details",
+			inImage:  "server:v1",
+			inResult: "not-json",
+			wantErr:  true,
+		},
+		{
+			name:    "not-found",
+			inImage: "server:v1",
+			inErr:   status.Error(codes.NotFound, "image server:v1 not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{
+				result: tc.inResult,
+				err:    tc.inErr,
+			}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			details, err := cli.InspectImage(ctx, tc.inImage)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("InspectImage(%q) returned an unexpected error: %v", tc.inImage, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("InspectImage(%q) did not return an error", tc.inImage)
+			}
+
+			if fcm.recvOp != options.InspectImage {
+				t.Errorf("InspectImage(%q) performed operation %s, want %s", tc.inImage, fcm.recvOp, options.InspectImage)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("InspectImage(%q) sent unexpected arguments (-want +got):\n%s", tc.inImage, diff)
+			}
+			if diff := cmp.Diff(tc.wantDetails, details); diff != "" {
+				t.Errorf("InspectImage(%q) returned unexpected details (-want +got):\n%s", tc.inImage, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInspectPlugin(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inInstance string
+		inResult   any
+		inErr      error
+
+		wantArgs    map[string]any
+		wantDetails *options.PluginDetails
+		wantErr     bool
+	}{
+		{
+			name:       "details",
+			inInstance: "sshfs",
+			inResult:   json.RawMessage(`{"id":"some-id","name":"sshfs:latest","enabled":true,"capabilities":["docker.volumedriver/1.0"],"env":["DEBUG=1"],"args":[],"references":["data"]}`),
+			wantArgs:   map[string]any{"instance": "sshfs"},
+			wantDetails: &options.PluginDetails{
+				ID:           "some-id",
+				Name:         "sshfs:latest",
+				Enabled:      true,
+				Capabilities: []string{"docker.volumedriver/1.0"},
+				Env:          []string{"DEBUG=1"},
+				Args:         []string{},
+				References:   []string{"data"},
+			},
+		},
+		{
+			name:       "bad-details",
+			inInstance: "sshfs",
+			inResult:   "not-json",
+			wantErr:    true,
+		},
+		{
+			name:       "no-plugin",
+			inInstance: "sshfs",
+			inErr:      status.Error(codes.NotFound, "plugin sshfs not found"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{result: tc.inResult, err: tc.inErr}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			details, err := cli.InspectPlugin(ctx, tc.inInstance)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("InspectPlugin(%q) returned an unexpected error: %v", tc.inInstance, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("InspectPlugin(%q) did not return an error", tc.inInstance)
+			}
+
+			if fcm.recvOp != options.InspectPlugin {
+				t.Errorf("InspectPlugin(%q) performed operation %s, want %s", tc.inInstance, fcm.recvOp, options.InspectPlugin)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("InspectPlugin(%q) sent unexpected arguments (-want +got):\n%s", tc.inInstance, diff)
+			}
+			if diff := cmp.Diff(tc.wantDetails, details); diff != "" {
+				t.Errorf("InspectPlugin(%q) returned unexpected details (-want +got):\n%s", tc.inInstance, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestInspectVolume(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inName   string
+		inResult any
+		inErr    error
+
+		wantArgs    map[string]any
+		wantDetails *options.VolumeDetails
+		wantErr     bool
+	}{
+		{
+			name:     "details",
+			inName:   "data",
+			inResult: json.RawMessage(`{"name":"data","driver":"local","created":"2024-02-09T12:07:31Z","labels":{"role":"db"},"usage":4096,"containers":["db","web"]}`),
+			wantArgs: map[string]any{"name": "data"},
+			wantDetails: &options.VolumeDetails{
+				Name:       "data",
+				Driver:     "local",
+				Created:    time.Date(2024, 2, 9, 12, 7, 31, 0, time.UTC),
+				Labels:     map[string]string{"role": "db"},
+				Usage:      4096,
+				Containers: []string{"db", "web"},
+			},
+		},
+		{
+			name:     "bad-details",
+			inName:   "data",
+			inResult: "not-json",
+			wantErr:  true,
+		},
+		{
+			name:    "no-volume",
+			inName:  "data",
+			inErr:   status.Error(codes.NotFound, "volume data not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{result: tc.inResult, err: tc.inErr}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			details, err := cli.InspectVolume(ctx, tc.inName)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("InspectVolume(%q) returned an unexpected error: %v", tc.inName, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("InspectVolume(%q) did not return an error", tc.inName)
+			}
+
+			if fcm.recvOp != options.InspectVolume {
+				t.Errorf("InspectVolume(%q) performed operation %s, want %s", tc.inName, fcm.recvOp, options.InspectVolume)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("InspectVolume(%q) sent unexpected arguments (-want +got):\n%s", tc.inName, diff)
+			}
+			if diff := cmp.Diff(tc.wantDetails, details); diff != "" {
+				t.Errorf("InspectVolume(%q) returned unexpected details (-want +got):\n%s", tc.inName, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestKillContainer(t *testing.T) {
+	tests := []struct {
+		name     string
+		inSignal string
+		inErr    error
+
+		wantArgs map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "default-signal",
+			wantArgs: map[string]any{"instance": "test"},
+		},
+		{
+			name:     "signal",
+			inSignal: "HUP",
+			wantArgs: map[string]any{"instance": "test", "signal": "HUP"},
+		},
+		{
+			name:     "not-running",
+			inErr:    status.Error(codes.FailedPrecondition, "container test is exited, not running"),
+			wantArgs: map[string]any{"instance": "test"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{err: tc.inErr}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.KillContainer(ctx, "test", tc.inSignal); (err != nil) != tc.wantErr {
+				t.Fatalf("KillContainer(%q, %q) returned error: %v, want error: %t", "test", tc.inSignal, err, tc.wantErr)
+			}
+
+			if fcm.recvOp != options.KillContainer {
+				t.Errorf("KillContainer(%q, %q) performed operation %s, want %s", "test", tc.inSignal, fcm.recvOp, options.KillContainer)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("KillContainer(%q, %q) sent unexpected arguments (-want +got):\n%s", "test", tc.inSignal, diff)
+			}
+		})
+	}
+}
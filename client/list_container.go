@@ -17,9 +17,12 @@ package client
 import (
 	"context"
 	"io"
+	"sort"
+	"strconv"
 
-	"k8s.io/klog/v2"
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"k8s.io/klog/v2"
 )
 
 // ListContainer implements the client logic for listing the existing containers on the target system.
@@ -51,11 +54,19 @@ func (c *Client) ListContainer(ctx context.Context, all bool, limit int32, filte
 				return
 			}
 
+			labels := msg.GetLabels()
+			// The exit code and restart count are absent if the target does not report them.
+			exitCode, _ := strconv.Atoi(labels[options.ExitCodeLabel])
+			restartCount, _ := strconv.Atoi(labels[options.RestartCountLabel])
 			if nonBlockingChannelSend(ctx, ch, &ContainerInfo{
-				ID:        msg.GetId(),
-				Name:      msg.GetName(),
-				ImageName: msg.GetImageName(),
-				State:     msg.GetStatus().String(),
+				ID:           msg.GetId(),
+				Name:         msg.GetName(),
+				ImageName:    msg.GetImageName(),
+				State:        msg.GetStatus().String(),
+				Health:       labels[options.HealthLabel],
+				RuntimeState: labels[options.StateLabel],
+				ExitCode:     exitCode,
+				RestartCount: restartCount,
 			}) {
 				klog.Warningf("operation cancelled; returning")
 				return
@@ -66,7 +77,17 @@ func (c *Client) ListContainer(ctx context.Context, all bool, limit int32, filte
 	return ch, nil
 }
 
+// toFilter converts the filter map into request filters, ordered by key.
 func toFilter(m map[string][]string) []*cpb.ListContainerRequest_Filter {
-	// TODO(alshabib) implement this when filter field becomes a repeated.
-	return nil
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filters []*cpb.ListContainerRequest_Filter
+	for _, key := range keys {
+		filters = append(filters, &cpb.ListContainerRequest_Filter{Key: key, Value: m[key]})
+	}
+	return filters
 }
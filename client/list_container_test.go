@@ -19,8 +19,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 type fakeListingContainerzServer struct {
@@ -106,6 +107,74 @@ func TestList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "health-filter",
+			inAll: true,
+			inFilter: map[string][]string{
+				"health":      []string{"unhealthy"},
+				"application": []string{"bgp"},
+			},
+			inMsgs: []*cpb.ListContainerResponse{
+				{
+					Id:        "some-id",
+					Name:      "some-name",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_RUNNING,
+					Labels:    map[string]string{options.HealthLabel: "unhealthy"},
+				},
+			},
+			wantInfo: []*ContainerInfo{
+				&ContainerInfo{
+					ID:        "some-id",
+					Name:      "some-name",
+					ImageName: "some-image",
+					State:     "RUNNING",
+					Health:    "unhealthy",
+				},
+			},
+			wantMsgs: []*cpb.ListContainerRequest{
+				&cpb.ListContainerRequest{
+					All: true,
+					Filter: []*cpb.ListContainerRequest_Filter{
+						{Key: "application", Value: []string{"bgp"}},
+						{Key: "health", Value: []string{"unhealthy"}},
+					},
+				},
+			},
+		},
+		{
+			name:  "runtime-state",
+			inAll: true,
+			inMsgs: []*cpb.ListContainerResponse{
+				{
+					Id:        "some-id",
+					Name:      "some-name",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_STOPPED,
+					Labels: map[string]string{
+						options.StateLabel:        "exited",
+						options.ExitCodeLabel:     "137",
+						options.RestartCountLabel: "3",
+					},
+				},
+			},
+			wantInfo: []*ContainerInfo{
+				&ContainerInfo{
+					ID:           "some-id",
+					Name:         "some-name",
+					ImageName:    "some-image",
+					State:        "STOPPED",
+					RuntimeState: "exited",
+					ExitCode:     137,
+					RestartCount: 3,
+				},
+			},
+			wantMsgs: []*cpb.ListContainerRequest{
+				&cpb.ListContainerRequest{
+					All: true,
+				},
+			},
+		},
 	}
 
 	ctx := context.Background()
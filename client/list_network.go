@@ -0,0 +1,30 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// ListNetworks returns the networks of the target, along with the containers attached to them.
+func (c *Client) ListNetworks(ctx context.Context) ([]*options.NetworkInfo, error) {
+	var networks []*options.NetworkInfo
+	if err := c.call(ctx, options.ListNetworks, options.NetworkArgs{}, &networks); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestListNetworks(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inResult any
+
+		wantNetworks []*options.NetworkInfo
+		wantErr      bool
+	}{
+		{
+			name: "networks",
+			inResult: json.RawMessage(`[
+				{"id":"1","name":"mgmt","driver":"bridge","created":"2025-01-14T13:00:00Z","subnets":[{"subnet":"192.0.2.0/24","gateway":"192.0.2.1"}]},
+				{"id":"2","name":"data","driver":"macvlan","created":"2025-01-14T14:00:00Z","options":{"parent":"eth1"},"containers":["bgp"]}
+			]`),
+			wantNetworks: []*options.NetworkInfo{
+				{ID: "1", Name: "mgmt", Driver: "bridge", Created: time.Date(2025, 1, 14, 13, 0, 0, 0, time.UTC), Subnets: []options.Subnet{{Subnet: "192.0.2.0/24", Gateway: "192.0.2.1"}}},
+				{ID: "2", Name: "data", Driver: "macvlan", Created: time.Date(2025, 1, 14, 14, 0, 0, 0, time.UTC), Options: map[string]string{"parent": "eth1"}, Containers: []string{"bgp"}},
+			},
+		},
+		{
+			name:         "no-networks",
+			inResult:     json.RawMessage(`[]`),
+			wantNetworks: []*options.NetworkInfo{},
+		},
+		{
+			name:     "bad-network",
+			inResult: "not-json",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{result: tc.inResult}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			networks, err := cli.ListNetworks(ctx)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("ListNetworks() returned an unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				t.Fatalf("ListNetworks() did not return an error")
+			}
+
+			if fcm.recvOp != options.ListNetworks {
+				t.Errorf("ListNetworks() performed operation %s, want %s", fcm.recvOp, options.ListNetworks)
+			}
+			if diff := cmp.Diff(tc.wantNetworks, networks); diff != "" {
+				t.Errorf("ListNetworks() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestListPluginArtifacts(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inResult any
+
+		wantArtifacts []*options.PluginArtifact
+		wantErr       bool
+	}{
+		{
+			name: "artifacts",
+			inResult: json.RawMessage(`[
+				{"name":"logger","size":16,"hash":"sha256:01","uploaded":"2025-01-14T13:00:00Z","plugins":["logger:latest"]},
+				{"name":"sshfs","size":32,"hash":"sha256:02","uploaded":"2025-01-14T14:00:00Z","plugins":[]}
+			]`),
+			wantArtifacts: []*options.PluginArtifact{
+				{Name: "logger", Size: 16, Hash: "sha256:01", Uploaded: time.Date(2025, 1, 14, 13, 0, 0, 0, time.UTC), Plugins: []string{"logger:latest"}},
+				{Name: "sshfs", Size: 32, Hash: "sha256:02", Uploaded: time.Date(2025, 1, 14, 14, 0, 0, 0, time.UTC), Plugins: []string{}},
+			},
+		},
+		{
+			name:          "no-artifacts",
+			inResult:      json.RawMessage(`[]`),
+			wantArtifacts: []*options.PluginArtifact{},
+		},
+		{
+			name:     "bad-artifact",
+			inResult: "not-json",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{result: tc.inResult}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			artifacts, err := cli.ListPluginArtifacts(ctx)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("ListPluginArtifacts() returned an unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				t.Fatalf("ListPluginArtifacts() did not return an error")
+			}
+
+			if fcm.recvOp != options.ListPluginArtifacts {
+				t.Errorf("ListPluginArtifacts() performed operation %s, want %s", fcm.recvOp, options.ListPluginArtifacts)
+			}
+			if diff := cmp.Diff(tc.wantArtifacts, artifacts); diff != "" {
+				t.Errorf("ListPluginArtifacts() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// ListRevisions returns the revision history of the requested instance, oldest first. The last
+// revision is the one currently deployed.
+func (c *Client) ListRevisions(ctx context.Context, instance string) ([]options.Revision, error) {
+	var revs []options.Revision
+	if err := c.call(ctx, options.ListRevisions, options.ContainerArgs{Instance: instance}, &revs); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestListRevisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		inResult any
+		inErr    error
+
+		wantRevs []options.Revision
+		wantErr  bool
+	}{
+		{
+			name:     "revisions",
+			inResult: json.RawMessage(`[{"number":1,"name":"stable","image":"server","tag":"v1","created":"2025-02-03T10:30:00Z"},{"number":2,"image":"server","tag":"v2","created":"2025-02-04T10:30:00Z"}]`),
+			wantRevs: []options.Revision{
+				{Number: 1, Name: "stable", Image: "server", Tag: "v1", Created: time.Date(2025, 2, 3, 10, 30, 0, 0, time.UTC)},
+				{Number: 2, Image: "server", Tag: "v2", Created: time.Date(2025, 2, 4, 10, 30, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:    "unknown-instance",
+			inErr:   status.Error(codes.NotFound, "no revisions of instance test are known"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{
+				result: tc.inResult,
+				err:    tc.inErr,
+			}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			revs, err := cli.ListRevisions(ctx, "test")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ListRevisions(%q) returned error: %v, want error: %t", "test", err, tc.wantErr)
+			}
+
+			if fcm.recvOp != options.ListRevisions {
+				t.Errorf("ListRevisions(%q) performed operation %s, want %s", "test", fcm.recvOp, options.ListRevisions)
+			}
+			if diff := cmp.Diff(map[string]any{"instance": "test"}, fcm.recvArgs); diff != "" {
+				t.Errorf("ListRevisions(%q) sent unexpected arguments (-want +got):\n%s", "test", diff)
+			}
+			if diff := cmp.Diff(tc.wantRevs, revs); diff != "" {
+				t.Errorf("ListRevisions(%q) returned unexpected revisions (-want +got):\n%s", "test", diff)
+			}
+		})
+	}
+}
@@ -17,8 +17,12 @@ package client
 import (
 	"context"
 	"io"
+	"strconv"
 
+	"github.com/docker/go-units"
 	"k8s.io/klog/v2"
+
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -49,13 +53,7 @@ func (c *Client) ListVolume(ctx context.Context, filter map[string][]string) (<-
 				return
 			}
 
-			if nonBlockingChannelSend(ctx, ch, &VolumeInfo{
-				Name:         msg.GetName(),
-				Driver:       msg.GetDriver(),
-				Labels:       msg.GetLabels(),
-				Options:      msg.GetOptions(),
-				CreationTime: msg.GetCreated().AsTime(),
-			}) {
+			if nonBlockingChannelSend(ctx, ch, volumeInfo(msg)) {
 				klog.Warningf("operation cancelled; returning")
 				return
 			}
@@ -65,6 +63,28 @@ func (c *Client) ListVolume(ctx context.Context, filter map[string][]string) (<-
 	return ch, nil
 }
 
+// volumeInfo returns the volume information of a list response.
+func volumeInfo(msg *cpb.ListVolumeResponse) *VolumeInfo {
+	labels := msg.GetLabels()
+	info := &VolumeInfo{
+		Name:         msg.GetName(),
+		Driver:       msg.GetDriver(),
+		Labels:       labels,
+		Options:      msg.GetOptions(),
+		CreationTime: msg.GetCreated().AsTime(),
+		Usage:        -1,
+	}
+	// The usage is absent if the target does not report it.
+	if usage, err := strconv.ParseInt(labels[options.VolumeUsageLabel], 10, 64); err == nil {
+		info.Usage = usage
+	}
+	// The quota is absent if the volume was created without one.
+	if quota, err := units.RAMInBytes(labels[options.VolumeQuotaLabel]); err == nil {
+		info.Quota = quota
+	}
+	return info
+}
+
 func toVolumeFilter(m map[string][]string) []*cpb.ListVolumeRequest_Filter {
 	return nil
 }
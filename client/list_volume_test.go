@@ -19,10 +19,11 @@ import (
 	"testing"
 	"time"
 
-	tpb "google.golang.org/protobuf/types/known/timestamppb"
 	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/testing/protocmp"
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/protobuf/testing/protocmp"
+	tpb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type fakeListingVolumeServer struct {
@@ -69,6 +70,38 @@ func TestListVolume(t *testing.T) {
 					Name:         "some-name",
 					Driver:       "some-driver",
 					CreationTime: testTime,
+					Usage:        -1,
+				},
+			},
+			wantMsgs: []*cpb.ListVolumeRequest{
+				&cpb.ListVolumeRequest{},
+			},
+		},
+		{
+			name:     "usage-and-quota",
+			inFilter: map[string][]string{"volume": {"data"}},
+			inMsgs: []*cpb.ListVolumeResponse{
+				{
+					Name:    "data",
+					Driver:  "local",
+					Created: tpb.New(testTime),
+					Labels: map[string]string{
+						options.VolumeUsageLabel: "4096",
+						options.VolumeQuotaLabel: "1m",
+					},
+				},
+			},
+			wantInfo: []*VolumeInfo{
+				{
+					Name:   "data",
+					Driver: "local",
+					Labels: map[string]string{
+						options.VolumeUsageLabel: "4096",
+						options.VolumeQuotaLabel: "1m",
+					},
+					CreationTime: testTime,
+					Usage:        4096,
+					Quota:        1 << 20,
 				},
 			},
 			wantMsgs: []*cpb.ListVolumeRequest{
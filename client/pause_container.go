@@ -0,0 +1,31 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// PauseContainer suspends all processes of the requested running instance.
+func (c *Client) PauseContainer(ctx context.Context, instance string) error {
+	return c.call(ctx, options.PauseContainer, options.ContainerArgs{Instance: instance}, nil)
+}
+
+// ResumeContainer resumes all processes of the requested paused instance.
+func (c *Client) ResumeContainer(ctx context.Context, instance string) error {
+	return c.call(ctx, options.ResumeContainer, options.ContainerArgs{Instance: instance}, nil)
+}
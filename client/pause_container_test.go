@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestPauseContainer(t *testing.T) {
+	tests := []struct {
+		name     string
+		inResume bool
+		wantOp   options.Operation
+	}{
+		{
+			name:   "pause",
+			wantOp: options.PauseContainer,
+		},
+		{
+			name:     "resume",
+			inResume: true,
+			wantOp:   options.ResumeContainer,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if tc.inResume {
+				err = cli.ResumeContainer(ctx, "test")
+			} else {
+				err = cli.PauseContainer(ctx, "test")
+			}
+			if err != nil {
+				t.Fatalf("%s(%q) returned an unexpected error: %v", tc.wantOp, "test", err)
+			}
+
+			if fcm.recvOp != tc.wantOp {
+				t.Errorf("%s(%q) performed operation %s", tc.wantOp, "test", fcm.recvOp)
+			}
+			if diff := cmp.Diff(map[string]any{"instance": "test"}, fcm.recvArgs); diff != "" {
+				t.Errorf("%s(%q) sent unexpected arguments (-want +got):\n%s", tc.wantOp, "test", diff)
+			}
+		})
+	}
+}
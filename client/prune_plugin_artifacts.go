@@ -0,0 +1,31 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// PrunePluginArtifacts removes every plugin tarball of the target that no installed plugin uses
+// any more, and returns the removed tarballs. Calling it confirms their removal.
+func (c *Client) PrunePluginArtifacts(ctx context.Context) ([]*options.PluginArtifact, error) {
+	var removed []*options.PluginArtifact
+	if err := c.call(ctx, options.PrunePluginArtifacts, options.PluginArtifactArgs{Confirm: true}, &removed); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
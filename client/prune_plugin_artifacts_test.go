@@ -0,0 +1,56 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestPrunePluginArtifacts(t *testing.T) {
+	ctx := context.Background()
+	fcm := &fakeExtensionServer{
+		result: json.RawMessage(`[{"name":"sshfs","size":32,"hash":"sha256:02","uploaded":"2025-01-14T14:00:00Z","plugins":[]}]`),
+	}
+	addr, stop := newServer(t, fcm)
+	defer stop()
+	cli, err := NewClient(ctx, addr)
+	if err != nil {
+		t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+	}
+
+	removed, err := cli.PrunePluginArtifacts(ctx)
+	if err != nil {
+		t.Fatalf("PrunePluginArtifacts() returned an unexpected error: %v", err)
+	}
+
+	if fcm.recvOp != options.PrunePluginArtifacts {
+		t.Errorf("PrunePluginArtifacts() performed operation %s, want %s", fcm.recvOp, options.PrunePluginArtifacts)
+	}
+	if diff := cmp.Diff(map[string]any{"confirm": true}, fcm.recvArgs); diff != "" {
+		t.Errorf("PrunePluginArtifacts() sent unexpected arguments (-want +got):\n%s", diff)
+	}
+	want := []*options.PluginArtifact{
+		{Name: "sshfs", Size: 32, Hash: "sha256:02", Uploaded: time.Date(2025, 1, 14, 14, 0, 0, 0, time.UTC), Plugins: []string{}},
+	}
+	if diff := cmp.Diff(want, removed); diff != "" {
+		t.Errorf("PrunePluginArtifacts() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"io"
 
 	"github.com/openconfig/containerz/chunker"
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -51,14 +52,26 @@ const (
 	success
 )
 
-// PushImage implements the client logic to push an image to the target containerz server.
-func (c *Client) PushImage(ctx context.Context, image string, tag string, file string, isPlugin bool) (<-chan *Progress, error) {
+// PushImage implements the client logic to push an image to the target containerz server. The
+// image may be written by docker save or hold an OCI image layout, and be compressed with gzip or
+// zstd.
+func (c *Client) PushImage(ctx context.Context, image string, tag string, file string, isPlugin bool, opts ...PushOption) (<-chan *Progress, error) {
+	optionz := &pushOptions{}
+	for _, opt := range opts {
+		opt(optionz)
+	}
+
 	dcli, err := c.cli.Deploy(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	reader, err := chunker.NewReader(file)
+	var reader chunkReader
+	if optionz.compression != "" && !isPlugin {
+		reader, err = newCompressingReader(file, optionz.compression)
+	} else {
+		reader, err = chunker.NewReader(file)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +170,7 @@ func (c *Client) PushImage(ctx context.Context, image string, tag string, file s
 					Finished: true,
 					Image:    msg.ImageTransferSuccess.GetName(),
 					Tag:      msg.ImageTransferSuccess.GetTag(),
+					Digest:   imageDigest(dcli),
 				}) {
 					klog.Warningf("operation cancelled by client; returning")
 				}
@@ -168,6 +182,21 @@ func (c *Client) PushImage(ctx context.Context, image string, tag string, file s
 	return ch, nil
 }
 
+// imageDigest returns the digest of the image reported by the target in the trailer of the
+// deploy stream, which it ends once the transfer succeeded, or an empty string if it has none.
+func imageDigest(dcli cpb.Containerz_DeployClient) string {
+	// CloseSend always returns a nil error.
+	//nolint:errcheck
+	dcli.CloseSend()
+	if _, err := dcli.Recv(); err != io.EOF {
+		return ""
+	}
+	if digest := dcli.Trailer().Get(options.ImageDigestTrailer); len(digest) > 0 {
+		return digest[0]
+	}
+	return ""
+}
+
 type transferTypes interface {
 	*cpb.DeployResponse_ImageTransferReady |
 		*cpb.DeployResponse_ImageTransferProgress |
@@ -21,10 +21,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
-	cpb "github.com/openconfig/gnoi/containerz"
 )
 
 type fakePushingContainerzServer struct {
@@ -32,9 +34,11 @@ type fakePushingContainerzServer struct {
 
 	sendMsgs         []*cpb.DeployResponse
 	receivedMessages []*cpb.DeployRequest
+	trailer          metadata.MD
 }
 
 func (f *fakePushingContainerzServer) Deploy(srv cpb.Containerz_DeployServer) error {
+	srv.SetTrailer(f.trailer)
 	for {
 		msg, err := srv.Recv()
 		if err != nil {
@@ -61,12 +65,14 @@ func (f *fakePushingContainerzServer) Deploy(srv cpb.Containerz_DeployServer) er
 
 func TestPushImage(t *testing.T) {
 	tests := []struct {
-		name     string
-		inImage  string
-		inTag    string
-		inFile   string
-		inPlugin bool
-		inMsgs   []*cpb.DeployResponse
+		name      string
+		inImage   string
+		inTag     string
+		inFile    string
+		inPlugin  bool
+		inOpts    []PushOption
+		inTrailer metadata.MD
+		inMsgs    []*cpb.DeployResponse
 
 		wantProgress []*Progress
 		wantMsgs     []*cpb.DeployRequest
@@ -162,6 +168,98 @@ func TestPushImage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "valid-transfer-with-digest",
+			inImage:   "some-image",
+			inFile:    "testdata/reader-data.txt",
+			inTrailer: metadata.Pairs(options.ImageDigestTrailer, "sha256:abc"),
+			inMsgs: []*cpb.DeployResponse{
+				&cpb.DeployResponse{
+					Response: &cpb.DeployResponse_ImageTransferReady{
+						ImageTransferReady: &cpb.ImageTransferReady{
+							ChunkSize: 28,
+						},
+					},
+				},
+				&cpb.DeployResponse{
+					Response: &cpb.DeployResponse_ImageTransferProgress{
+						ImageTransferProgress: &cpb.ImageTransferProgress{
+							BytesReceived: 26,
+						},
+					},
+				},
+				&cpb.DeployResponse{
+					Response: &cpb.DeployResponse_ImageTransferSuccess{
+						ImageTransferSuccess: &cpb.ImageTransferSuccess{
+							Name: "some-image",
+							Tag:  "latest",
+						},
+					},
+				},
+			},
+
+			wantMsgs: []*cpb.DeployRequest{
+				&cpb.DeployRequest{
+					Request: &cpb.DeployRequest_ImageTransfer{
+						ImageTransfer: &cpb.ImageTransfer{
+							Name:      "some-image",
+							ImageSize: 26,
+						},
+					},
+				},
+				&cpb.DeployRequest{
+					Request: &cpb.DeployRequest_Content{
+						Content: []byte("some really important data"),
+					},
+				},
+				&cpb.DeployRequest{
+					Request: &cpb.DeployRequest_ImageTransferEnd{
+						ImageTransferEnd: &cpb.ImageTransferEnd{},
+					},
+				},
+			},
+			wantProgress: []*Progress{
+				&Progress{
+					BytesReceived: 26,
+				},
+				&Progress{
+					Finished: true,
+					Image:    "some-image",
+					Tag:      "latest",
+					Digest:   "sha256:abc",
+				},
+			},
+		},
+		{
+			name:    "compressed-transfer",
+			inImage: "some-image",
+			inTag:   "some-tag",
+			inFile:  "testdata/reader-data.txt",
+			inOpts:  []PushOption{WithCompression(Gzip)},
+			inMsgs: []*cpb.DeployResponse{
+				&cpb.DeployResponse{
+					Response: &cpb.DeployResponse_ImageTransferProgress{
+						ImageTransferProgress: &cpb.ImageTransferProgress{},
+					},
+				},
+			},
+			wantMsgs: []*cpb.DeployRequest{
+				&cpb.DeployRequest{
+					Request: &cpb.DeployRequest_ImageTransfer{
+						ImageTransfer: &cpb.ImageTransfer{
+							Name:      "some-image",
+							Tag:       "some-tag",
+							ImageSize: compressedSizeBound(26),
+						},
+					},
+				},
+			},
+			wantProgress: []*Progress{
+				&Progress{
+					Error: status.Errorf(codes.InvalidArgument, "received unexpected message type: *containerz.DeployResponse_ImageTransferProgress"),
+				},
+			},
+		},
 		{
 			name:     "valid-plugin-transfer",
 			inImage:  "some-image",
@@ -234,6 +332,7 @@ func TestPushImage(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			fcm := &fakePushingContainerzServer{
 				sendMsgs: tc.inMsgs,
+				trailer:  tc.inTrailer,
 			}
 			addr, stop := newServer(t, fcm)
 			defer stop()
@@ -245,7 +344,7 @@ func TestPushImage(t *testing.T) {
 			doneCh := make(chan struct{})
 			got := []*Progress{}
 
-			ch, err := cli.PushImage(ctx, tc.inImage, tc.inTag, tc.inFile, tc.inPlugin)
+			ch, err := cli.PushImage(ctx, tc.inImage, tc.inTag, tc.inFile, tc.inPlugin, tc.inOpts...)
 			if err != nil {
 				t.Fatalf("PushImage(%q, %q, %q, %t) returned an unexpected error: %v", tc.inImage, tc.inTag, tc.inFile, tc.inPlugin, err)
 			}
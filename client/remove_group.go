@@ -0,0 +1,32 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// RemoveGroup removes the members of an application group. Unless force is set, nothing is
+// removed while members are running.
+func (c *Client) RemoveGroup(ctx context.Context, group string, force bool) error {
+	if group == "" {
+		return fmt.Errorf("the name of the group must be provided")
+	}
+
+	return c.call(ctx, options.RemoveGroup, options.GroupArgs{Group: group, Force: force}, nil)
+}
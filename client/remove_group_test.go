@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestRemoveGroup(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inGroup string
+		inForce bool
+
+		wantArgs map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "remove",
+			inGroup:  "telemetry",
+			wantArgs: map[string]any{"group": "telemetry"},
+		},
+		{
+			name:     "forced",
+			inGroup:  "telemetry",
+			inForce:  true,
+			wantArgs: map[string]any{"group": "telemetry", "force": true},
+		},
+		{
+			name:    "no-group",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.RemoveGroup(ctx, tc.inGroup, tc.inForce); err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("RemoveGroup(%q, %v) returned an unexpected error: %v", tc.inGroup, tc.inForce, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("RemoveGroup(%q, %v) did not return an error", tc.inGroup, tc.inForce)
+			}
+
+			if fcm.recvOp != options.RemoveGroup {
+				t.Errorf("RemoveGroup(%q, %v) performed operation %s, want %s", tc.inGroup, tc.inForce, fcm.recvOp, options.RemoveGroup)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("RemoveGroup(%q, %v) returned an unexpected diff (-want +got):\n%s", tc.inGroup, tc.inForce, diff)
+			}
+		})
+	}
+}
@@ -3,11 +3,17 @@ package client
 import (
 	"context"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
-// RemovePlugin removes the requested plugin identified by instance.
-func (c *Client) RemovePlugin(ctx context.Context, instance string) error {
+// RemovePlugin removes the requested plugin identified by instance. Unless force is set, the
+// plugin is not removed while volumes use it.
+func (c *Client) RemovePlugin(ctx context.Context, instance string, force bool) error {
+	if force {
+		return c.call(ctx, options.RemovePlugin, options.PluginArgs{Instance: instance, Force: true}, nil)
+	}
+
 	if _, err := c.cli.RemovePlugin(ctx, &cpb.RemovePluginRequest{
 		InstanceName: instance,
 	}); err != nil {
@@ -0,0 +1,32 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// RemovePluginArtifact removes the named plugin tarball from the target. Unless force is set, the
+// tarball is not removed while plugins created from it are installed.
+func (c *Client) RemovePluginArtifact(ctx context.Context, name string, force bool) error {
+	if name == "" {
+		return fmt.Errorf("the name of the plugin artifact must be provided")
+	}
+
+	return c.call(ctx, options.RemovePluginArtifact, options.PluginArtifactArgs{Name: name, Force: force}, nil)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestRemovePluginArtifact(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inName  string
+		inForce bool
+
+		wantArgs map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "remove",
+			inName:   "sshfs",
+			wantArgs: map[string]any{"name": "sshfs"},
+		},
+		{
+			name:     "forced",
+			inName:   "sshfs",
+			inForce:  true,
+			wantArgs: map[string]any{"name": "sshfs", "force": true},
+		},
+		{
+			name:    "no-name",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.RemovePluginArtifact(ctx, tc.inName, tc.inForce); err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("RemovePluginArtifact(%q, %v) returned an unexpected error: %v", tc.inName, tc.inForce, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("RemovePluginArtifact(%q, %v) did not return an error", tc.inName, tc.inForce)
+			}
+
+			if fcm.recvOp != options.RemovePluginArtifact {
+				t.Errorf("RemovePluginArtifact(%q, %v) performed operation %s, want %s", tc.inName, tc.inForce, fcm.recvOp, options.RemovePluginArtifact)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("RemovePluginArtifact(%q, %v) returned an unexpected diff (-want +got):\n%s", tc.inName, tc.inForce, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// RestartContainer restarts the requested instance in place, preserving its ID and logs. Restart
+// can also force termination of the running container, or give it timeout to stop before it is
+// killed. A zero timeout uses the container's stop timeout, or the engine default.
+func (c *Client) RestartContainer(ctx context.Context, instance string, force bool, timeout time.Duration) error {
+	if timeout > 0 {
+		return c.call(ctx, options.RestartContainer, options.ContainerArgs{Instance: instance, Force: force, Timeout: timeout}, nil)
+	}
+
+	if _, err := c.cli.StopContainer(ctx, &cpb.StopContainerRequest{
+		InstanceName: instance,
+		Force:        force,
+		Restart:      true,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+type fakeRestartingServer struct {
+	fakeExtensionServer
+
+	receivedMsg *cpb.StopContainerRequest
+}
+
+func (f *fakeRestartingServer) StopContainer(ctx context.Context, req *cpb.StopContainerRequest) (*cpb.StopContainerResponse, error) {
+	f.receivedMsg = req
+	return &cpb.StopContainerResponse{}, nil
+}
+
+func TestRestart(t *testing.T) {
+	tests := []struct {
+		name       string
+		inInstance string
+		inForce    bool
+		inTimeout  time.Duration
+
+		wantMsg  *cpb.StopContainerRequest
+		wantArgs map[string]any
+	}{
+		{
+			name:       "simple",
+			inInstance: "some-instance",
+			wantMsg: &cpb.StopContainerRequest{
+				InstanceName: "some-instance",
+				Restart:      true,
+			},
+		},
+		{
+			name:       "force",
+			inInstance: "some-instance",
+			inForce:    true,
+			wantMsg: &cpb.StopContainerRequest{
+				InstanceName: "some-instance",
+				Force:        true,
+				Restart:      true,
+			},
+		},
+		{
+			name:       "timeout",
+			inInstance: "some-instance",
+			inTimeout:  30 * time.Second,
+			wantArgs:   map[string]any{"instance": "some-instance", "timeout": float64(30 * time.Second)},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fcm := &fakeRestartingServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.RestartContainer(ctx, tc.inInstance, tc.inForce, tc.inTimeout); err != nil {
+				t.Fatalf("Restart(%q, %t, %v) returned an unexpected error: %v", tc.inInstance, tc.inForce, tc.inTimeout, err)
+			}
+
+			if diff := cmp.Diff(fcm.receivedMsg, tc.wantMsg, protocmp.Transform()); diff != "" {
+				t.Errorf("Restart(%q, %t, %v) returned diff(-want, +got):\n%s", tc.inInstance, tc.inForce, tc.inTimeout, diff)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("Restart(%q, %t, %v) sent unexpected extension arguments (-want +got):\n%s", tc.inInstance, tc.inForce, tc.inTimeout, diff)
+			}
+		})
+	}
+}
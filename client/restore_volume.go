@@ -0,0 +1,95 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// uploadChunkSize is the size of the chunks of data uploaded to the target.
+const uploadChunkSize = 1 << 20
+
+// RestoreVolume restores the contents of a volume, creating it if needed, from a gzip compressed
+// tar archive such as one written by BackupVolume, and returns the size of the archive. The
+// archive is uploaded in chunks along with its size and checksum, which the target verifies
+// before restoring it.
+func (c *Client) RestoreVolume(ctx context.Context, name string, file string) (uint64, error) {
+	if c.ext == nil {
+		return 0, status.Error(codes.Unimplemented, "restoring volumes is not available without a client of the extension service")
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	req, err := options.MarshalExtensionRequest(options.ImportVolume, options.VolumeArchiveArgs{
+		Name:     name,
+		Size:     uint64(size),
+		Checksum: options.FormatChecksum(h.Sum(nil)),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ucli, err := c.ext.Upload(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// A failed Send means the target ended the upload, and CloseAndRecv returns the reason.
+	send := func(b []byte) bool {
+		return ucli.Send(wrapperspb.Bytes(b)) == nil
+	}
+	if send(req) {
+		buf := make([]byte, uploadChunkSize)
+		for {
+			n, err := f.Read(buf)
+			if n > 0 && !send(buf[:n]) {
+				break
+			}
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return 0, fmt.Errorf("failed to read %s: %w", file, err)
+			}
+		}
+	}
+	if _, err := ucli.CloseAndRecv(); err != nil {
+		return 0, err
+	}
+	return uint64(size), nil
+}
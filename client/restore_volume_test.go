@@ -0,0 +1,85 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestRestoreVolume(t *testing.T) {
+	data := []byte("some really important data")
+
+	tests := []struct {
+		name  string
+		inErr error
+
+		wantSize uint64
+		wantErr  error
+	}{
+		{
+			name:     "restored",
+			wantSize: uint64(len(data)),
+		},
+		{
+			name:    "checksum-mismatch",
+			inErr:   status.Error(codes.DataLoss, "volume archive checksum mismatch"),
+			wantErr: status.Error(codes.DataLoss, "volume archive checksum mismatch"),
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fcm := &fakeExtensionServer{err: tc.inErr}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			size, err := cli.RestoreVolume(ctx, "some-volume", "testdata/reader-data.txt")
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("RestoreVolume(some-volume) returned an unexpected error (-want +got):\n%s", diff)
+			}
+			if size != tc.wantSize {
+				t.Errorf("RestoreVolume(some-volume) returned size %d, want %d", size, tc.wantSize)
+			}
+
+			if fcm.recvOp != options.ImportVolume {
+				t.Errorf("RestoreVolume(some-volume) performed operation %s, want %s", fcm.recvOp, options.ImportVolume)
+			}
+			wantArgs := map[string]any{
+				"name":     "some-volume",
+				"size":     float64(len(data)),
+				"checksum": archiveChecksum(data),
+			}
+			if diff := cmp.Diff(wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("RestoreVolume(some-volume) sent unexpected arguments (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(data, fcm.recvData); diff != "" {
+				t.Errorf("RestoreVolume(some-volume) uploaded unexpected data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
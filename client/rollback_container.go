@@ -0,0 +1,33 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// RollbackContainer restores the revision, by number or name, of the requested instance, or its
+// previous revision if revision is empty. The replaced version is kept for keepPrevious, so that
+// the rollback can itself be rolled back.
+func (c *Client) RollbackContainer(ctx context.Context, instance, revision string, keepPrevious time.Duration) error {
+	return c.call(ctx, options.RollbackContainer, options.ContainerArgs{
+		Instance:     instance,
+		Revision:     revision,
+		KeepPrevious: keepPrevious,
+	}, nil)
+}
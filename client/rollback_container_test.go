@@ -0,0 +1,70 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestRollbackContainer(t *testing.T) {
+	tests := []struct {
+		name           string
+		inRevision     string
+		inKeepPrevious time.Duration
+
+		wantArgs map[string]any
+	}{
+		{
+			name:     "previous",
+			wantArgs: map[string]any{"instance": "test"},
+		},
+		{
+			name:           "revision",
+			inRevision:     "stable",
+			inKeepPrevious: time.Hour,
+			wantArgs:       map[string]any{"instance": "test", "revision": "stable", "keep_previous": float64(time.Hour)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.RollbackContainer(ctx, "test", tc.inRevision, tc.inKeepPrevious); err != nil {
+				t.Fatalf("RollbackContainer(%q, %q, %v) returned an unexpected error: %v", "test", tc.inRevision, tc.inKeepPrevious, err)
+			}
+
+			if fcm.recvOp != options.RollbackContainer {
+				t.Errorf("RollbackContainer(%q, %q, %v) performed operation %s, want %s", "test", tc.inRevision, tc.inKeepPrevious, fcm.recvOp, options.RollbackContainer)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("RollbackContainer(%q, %q, %v) sent unexpected arguments (-want +got):\n%s", "test", tc.inRevision, tc.inKeepPrevious, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// SetContainerResources changes the CPU and memory limits, resource controls and restart policy of
+// a running instance in place, without restarting it. Only the limits set by the options are
+// changed. The options supported are WithCPUs, WithSoftLimit, WithHardLimit, WithResources and
+// WithRestartPolicy.
+func (c *Client) SetContainerResources(ctx context.Context, instance string, opts ...StartOption) error {
+	opts = append(opts, WithUpdateStrategy(string(options.LiveStrategy)))
+	if _, err := c.UpdateContainer(ctx, "", "", "", instance, false, opts...); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+func TestSetContainerResources(t *testing.T) {
+	ctx := context.Background()
+	fcm := &fakeUpdatingContainerzServer{
+		sendMsg: &cpb.UpdateContainerResponse{
+			Response: &cpb.UpdateContainerResponse_UpdateOk{
+				UpdateOk: &cpb.UpdateOK{InstanceName: "some-instance"},
+			},
+		},
+	}
+	addr, stop := newServer(t, fcm)
+	defer stop()
+	cli, err := NewClient(ctx, addr)
+	if err != nil {
+		t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+	}
+
+	if err := cli.SetContainerResources(ctx, "some-instance", WithHardLimit(256<<20), WithResources(Resources{PidsLimit: 64})); err != nil {
+		t.Fatalf("SetContainerResources() returned an unexpected error: %v", err)
+	}
+
+	req := fcm.receivedMsg
+	if req.GetInstanceName() != "some-instance" || req.GetParams().GetImageName() != "" {
+		t.Errorf("SetContainerResources() sent request for instance %q and image %q, want instance %q and no image", req.GetInstanceName(), req.GetParams().GetImageName(), "some-instance")
+	}
+	if got := req.GetParams().GetLimits().GetHardMemBytes(); got != 256<<20 {
+		t.Errorf("SetContainerResources() sent hard memory limit %d, want %d", got, 256<<20)
+	}
+	want := map[string]string{
+		options.UpdateStrategyLabel: "live",
+		options.PidsLimitLabel:      "64",
+	}
+	if diff := cmp.Diff(want, req.GetParams().GetLabels()); diff != "" {
+		t.Errorf("SetContainerResources() returned diff in labels (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestSetPlugin(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inInstance string
+		inEnv      []string
+		inArgs     []string
+
+		wantArgs map[string]any
+	}{
+		{
+			name:       "env",
+			inInstance: "test",
+			inEnv:      []string{"DEBUG=1"},
+			wantArgs: map[string]any{
+				"instance": "test",
+				"settings": map[string]any{"env": []any{"DEBUG=1"}},
+			},
+		},
+		{
+			name:       "env-and-args",
+			inInstance: "test",
+			inEnv:      []string{"DEBUG=1"},
+			inArgs:     []string{"--verbose"},
+			wantArgs: map[string]any{
+				"instance": "test",
+				"settings": map[string]any{"env": []any{"DEBUG=1"}, "args": []any{"--verbose"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.SetPlugin(ctx, tc.inInstance, tc.inEnv, tc.inArgs); err != nil {
+				t.Fatalf("SetPlugin(%q, %v, %v) returned an unexpected error: %v", tc.inInstance, tc.inEnv, tc.inArgs, err)
+			}
+
+			if fcm.recvOp != options.SetPlugin {
+				t.Errorf("SetPlugin(%q, %v, %v) performed operation %s, want %s", tc.inInstance, tc.inEnv, tc.inArgs, fcm.recvOp, options.SetPlugin)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("SetPlugin(%q, %v, %v) returned an unexpected diff (-want +got):\n%s", tc.inInstance, tc.inEnv, tc.inArgs, diff)
+			}
+		})
+	}
+}
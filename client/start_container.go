@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -56,7 +58,60 @@ func startContainerRequestWithOptions(ctx context.Context, image string, tag str
 		opt(optionz)
 	}
 
-	portMappings, err := ports(optionz.ports)
+	portMappings, portBindings, err := ports(optionz.ports)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := optionz.labels
+	if len(portBindings) > 0 {
+		labels = withLabel(labels, options.PortsLabel, strings.Join(portBindings, ","))
+	}
+	networks, err := networkAttachments(optionz.networks)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := dependencies(optionz.dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	mounts, err := mounts(optionz.mounts)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range map[string]string{
+		options.NetworksLabel:           strings.Join(networks, ","),
+		options.HostnameLabel:           optionz.hostname,
+		options.DNSLabel:                strings.Join(optionz.dns, ","),
+		options.DNSSearchLabel:          strings.Join(optionz.dnsSearch, ","),
+		options.ExtraHostsLabel:         strings.Join(optionz.hosts, ","),
+		options.IPv4AddressLabel:        optionz.ipv4,
+		options.IPv6AddressLabel:        optionz.ipv6,
+		options.RevisionLabel:           optionz.revision,
+		options.DependsOnLabel:          strings.Join(deps, ","),
+		options.MountsLabel:             strings.Join(mounts, ";"),
+		options.DependencyTimeoutLabel:  durationLabel(optionz.depWait),
+		options.UpdateStrategyLabel:     optionz.strategy,
+		options.KeepPreviousLabel:       durationLabel(optionz.keep),
+		options.HealthTimeoutLabel:      durationLabel(optionz.health),
+		options.HealthCmdLabel:          optionz.check.cmd,
+		options.HealthIntervalLabel:     durationLabel(optionz.check.interval),
+		options.HealthCheckTimeoutLabel: durationLabel(optionz.check.timeout),
+		options.HealthStartPeriodLabel:  durationLabel(optionz.check.startPeriod),
+	} {
+		if value != "" {
+			labels = withLabel(labels, key, value)
+		}
+	}
+	if optionz.check.retries > 0 {
+		labels = withLabel(labels, options.HealthRetriesLabel, strconv.Itoa(optionz.check.retries))
+	}
+
+	labels, err = resourceLabels(labels, optionz.resources)
+	if err != nil {
+		return nil, err
+	}
+	labels, err = securityLabels(labels, optionz.security)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +159,7 @@ func startContainerRequestWithOptions(ctx context.Context, image string, tag str
 		Cap:          capabilities,
 		RunAs:        runAs,
 		Restart:      restartPolicy,
-		Labels:       optionz.labels,
+		Labels:       labels,
 		Limits: &cpb.StartContainerRequest_Limits{
 			MaxCpu:       optionz.cpus,
 			SoftMemBytes: optionz.softMem,
@@ -113,28 +168,135 @@ func startContainerRequestWithOptions(ctx context.Context, image string, tag str
 	}, nil
 }
 
-func ports(ports []string) ([]*cpb.StartContainerRequest_Port, error) {
+// ports splits the port definitions into plain TCP ports, which are carried in the request's
+// ports field, and bindings with a protocol or host address, which are carried in the ports label.
+func ports(ports []string) ([]*cpb.StartContainerRequest_Port, []string, error) {
 	mapping := make([]*cpb.StartContainerRequest_Port, 0, len(ports))
+	var bindings []string
 	for _, port := range ports {
-		parts := strings.SplitN(port, ":", 2)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("port definition %s is invalid", port)
+		binding, err := options.ParsePortBinding(port)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		in, err := strconv.Atoi(parts[0])
+		if binding.HostIP != "" || (binding.Protocol != "" && binding.Protocol != "tcp") {
+			bindings = append(bindings, binding.String())
+			continue
+		}
+		mapping = append(mapping, &cpb.StartContainerRequest_Port{Internal: binding.Internal, External: binding.External})
+	}
+
+	return mapping, bindings, nil
+}
+
+// networkAttachments validates the network attachments and returns them in canonical form.
+func networkAttachments(networks []string) ([]string, error) {
+	res := make([]string, 0, len(networks))
+	for _, spec := range networks {
+		attachment, err := options.ParseNetworkAttachment(spec)
 		if err != nil {
 			return nil, err
 		}
+		res = append(res, attachment.String())
+	}
+	return res, nil
+}
 
-		out, err := strconv.Atoi(parts[1])
+// dependencies validates the dependencies and returns them in their canonical format.
+func dependencies(deps []string) ([]string, error) {
+	res := make([]string, 0, len(deps))
+	for _, spec := range deps {
+		dep, err := options.ParseDependency(spec)
 		if err != nil {
 			return nil, err
 		}
+		res = append(res, dep.String())
+	}
+	return res, nil
+}
 
-		mapping = append(mapping, &cpb.StartContainerRequest_Port{Internal: uint32(in), External: uint32(out)})
+// mounts validates the mounts and returns them in their canonical format.
+func mounts(mounts []string) ([]string, error) {
+	res := make([]string, 0, len(mounts))
+	for _, spec := range mounts {
+		m, err := options.ParseMount(spec)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m.String())
 	}
+	return res, nil
+}
 
-	return mapping, nil
+// durationLabel returns the label value of a duration, or an empty string if it is unset.
+func durationLabel(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// resourceLabels returns a copy of labels with the resource controls set, validating the ulimits
+// and device limits and putting them in their canonical format.
+func resourceLabels(labels map[string]string, r Resources) (map[string]string, error) {
+	ulimits := make([]string, 0, len(r.Ulimits))
+	for _, spec := range r.Ulimits {
+		u, err := options.ParseUlimit(spec)
+		if err != nil {
+			return nil, err
+		}
+		ulimits = append(ulimits, u.String())
+	}
+
+	values := map[string]string{
+		options.CPUSetCPUsLabel: r.CPUSetCPUs,
+		options.CPUSetMemsLabel: r.CPUSetMems,
+		options.UlimitsLabel:    strings.Join(ulimits, ","),
+	}
+	for label, specs := range map[string][]string{
+		options.DeviceReadBPSLabel:   r.DeviceReadBPS,
+		options.DeviceWriteBPSLabel:  r.DeviceWriteBPS,
+		options.DeviceReadIOPSLabel:  r.DeviceReadIOPS,
+		options.DeviceWriteIOPSLabel: r.DeviceWriteIOPS,
+	} {
+		devices := make([]string, 0, len(specs))
+		for _, spec := range specs {
+			dev, err := options.ParseThrottleDevice(spec)
+			if err != nil {
+				return nil, err
+			}
+			devices = append(devices, dev.String())
+		}
+		values[label] = strings.Join(devices, ",")
+	}
+	for label, value := range map[string]int64{
+		options.CPUSharesLabel:   r.CPUShares,
+		options.PidsLimitLabel:   r.PidsLimit,
+		options.BlkioWeightLabel: int64(r.BlkioWeight),
+		options.MemorySwapLabel:  r.MemorySwap,
+		options.OOMScoreAdjLabel: int64(r.OOMScoreAdj),
+	} {
+		if value != 0 {
+			values[label] = strconv.FormatInt(value, 10)
+		}
+	}
+
+	for key, value := range values {
+		if value != "" {
+			labels = withLabel(labels, key, value)
+		}
+	}
+	return labels, nil
+}
+
+// withLabel returns a copy of labels with key set to value.
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	res := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		res[k] = v
+	}
+	res[key] = value
+	return res
 }
 
 func envs(envs []string) (map[string]string, error) {
@@ -281,3 +443,35 @@ func devices(devices []string) ([]*cpb.Device, error) {
 
 	return devs, nil
 }
+
+// securityLabels returns a copy of labels with the security settings set, validating the tmpfs
+// mounts and putting them in their canonical format.
+func securityLabels(labels map[string]string, sec Security) (map[string]string, error) {
+	tmpfs, err := options.ParseTmpfs(strings.Join(sec.Tmpfs, ";"))
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{
+		options.SeccompLabel:       sec.Seccomp,
+		options.AppArmorLabel:      sec.AppArmor,
+		options.SELinuxLabel:       strings.Join(sec.SELinux, ","),
+		options.TmpfsLabel:         options.FormatTmpfs(tmpfs),
+		options.MaskedPathsLabel:   strings.Join(sec.MaskedPaths, ","),
+		options.ReadonlyPathsLabel: strings.Join(sec.ReadonlyPaths, ","),
+		options.UsernsModeLabel:    sec.UsernsMode,
+	}
+	if sec.ReadOnlyRootfs {
+		values[options.ReadOnlyRootfsLabel] = "true"
+	}
+	if sec.NoNewPrivileges {
+		values[options.NoNewPrivilegesLabel] = "true"
+	}
+
+	for key, value := range values {
+		if value != "" {
+			labels = withLabel(labels, key, value)
+		}
+	}
+	return labels, nil
+}
@@ -17,12 +17,15 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 const (
@@ -477,3 +480,257 @@ func TestStart(t *testing.T) {
 		})
 	}
 }
+
+func TestPorts(t *testing.T) {
+	tests := []struct {
+		name         string
+		inPorts      []string
+		wantPorts    []*cpb.StartContainerRequest_Port
+		wantBindings []string
+		wantErr      bool
+	}{
+		{
+			name:    "tcp",
+			inPorts: []string{"1:2", "3:4/tcp"},
+			wantPorts: []*cpb.StartContainerRequest_Port{
+				{Internal: 1, External: 2},
+				{Internal: 3, External: 4},
+			},
+		},
+		{
+			name:         "udp-and-host-ip",
+			inPorts:      []string{"1:2", "514:514/udp", "10.0.0.1:179:179", "[fd00::1]:161:161/udp"},
+			wantPorts:    []*cpb.StartContainerRequest_Port{{Internal: 1, External: 2}},
+			wantBindings: []string{"514:514/udp", "10.0.0.1:179:179", "[fd00::1]:161:161/udp"},
+		},
+		{
+			name:    "bad-protocol",
+			inPorts: []string{"1:2/icmp"},
+			wantErr: true,
+		},
+		{
+			name:    "bad-host-ip",
+			inPorts: []string{"not-an-ip:1:2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotPorts, gotBindings, err := ports(tc.inPorts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ports(%v) returned error %v, want error %v", tc.inPorts, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantPorts, gotPorts, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ports(%v) returned diff in ports (-want, +got):\n%s", tc.inPorts, diff)
+			}
+			if diff := cmp.Diff(tc.wantBindings, gotBindings, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ports(%v) returned diff in bindings (-want, +got):\n%s", tc.inPorts, diff)
+			}
+		})
+	}
+}
+
+func TestNetworkSettingsLabels(t *testing.T) {
+	opts := []StartOption{
+		WithLabels(map[string]string{"app": "bgp"}),
+		WithNetworkAttachments([]string{"mgmt", "data;alias=speaker;ip=198.51.100.7"}),
+		WithHostname("router-app"),
+		WithDNS([]string{"192.0.2.53", "2001:db8::53"}, []string{"example.com"}),
+		WithExtraHosts([]string{"collector:192.0.2.10"}),
+		WithIPAddresses("192.0.2.2", ""),
+	}
+	req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", opts...)
+	if err != nil {
+		t.Fatalf("startContainerRequestWithOptions() returned an unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"app":                    "bgp",
+		options.NetworksLabel:    "mgmt,data;alias=speaker;ip=198.51.100.7",
+		options.HostnameLabel:    "router-app",
+		options.DNSLabel:         "192.0.2.53,2001:db8::53",
+		options.DNSSearchLabel:   "example.com",
+		options.ExtraHostsLabel:  "collector:192.0.2.10",
+		options.IPv4AddressLabel: "192.0.2.2",
+	}
+	if diff := cmp.Diff(want, req.GetLabels()); diff != "" {
+		t.Errorf("startContainerRequestWithOptions() returned diff in labels (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDependencyLabels(t *testing.T) {
+	opts := []StartOption{
+		WithDependsOn([]string{"db:running", "broker:tcp/9092"}),
+		WithDependencyTimeout(2 * time.Minute),
+	}
+	req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", opts...)
+	if err != nil {
+		t.Fatalf("startContainerRequestWithOptions() returned an unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		options.DependsOnLabel:         "db,broker:tcp/9092",
+		options.DependencyTimeoutLabel: "2m0s",
+	}
+	if diff := cmp.Diff(want, req.GetLabels()); diff != "" {
+		t.Errorf("startContainerRequestWithOptions() returned diff in labels (-want, +got):\n%s", diff)
+	}
+
+	if _, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", WithDependsOn([]string{"db:ready"})); err == nil {
+		t.Errorf("startContainerRequestWithOptions() with an invalid dependency returned no error")
+	}
+}
+
+func TestMountLabels(t *testing.T) {
+	opts := []StartOption{
+		WithMounts([]string{"type=bind,src=/var/log/app,dst=/logs,ro", "type=tmpfs,target=/cache,tmpfs-size=64m"}),
+	}
+	req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", opts...)
+	if err != nil {
+		t.Fatalf("startContainerRequestWithOptions() returned an unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		options.MountsLabel: "type=bind,source=/var/log/app,target=/logs,readonly;type=tmpfs,target=/cache,tmpfs-size=67108864",
+	}
+	if diff := cmp.Diff(want, req.GetLabels()); diff != "" {
+		t.Errorf("startContainerRequestWithOptions() returned diff in labels (-want, +got):\n%s", diff)
+	}
+
+	if _, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", WithMounts([]string{"type=bind,target=/logs"})); err == nil {
+		t.Errorf("startContainerRequestWithOptions() with an invalid mount returned no error")
+	}
+}
+
+func TestHealthCheckLabels(t *testing.T) {
+	opts := []StartOption{
+		WithHealthCheck("curl -f http://localhost:8080/healthz", 10*time.Second, 2*time.Second, 3, 30*time.Second),
+	}
+	req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", opts...)
+	if err != nil {
+		t.Fatalf("startContainerRequestWithOptions() returned an unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		options.HealthCmdLabel:          "curl -f http://localhost:8080/healthz",
+		options.HealthIntervalLabel:     "10s",
+		options.HealthCheckTimeoutLabel: "2s",
+		options.HealthRetriesLabel:      "3",
+		options.HealthStartPeriodLabel:  "30s",
+	}
+	if diff := cmp.Diff(want, req.GetLabels()); diff != "" {
+		t.Errorf("startContainerRequestWithOptions() returned diff in labels (-want, +got):\n%s", diff)
+	}
+}
+
+func TestResourceLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      Resources
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "no-resources",
+		},
+		{
+			name: "resources",
+			in: Resources{
+				CPUSetCPUs:     "2-3",
+				PidsLimit:      -1,
+				Ulimits:        []string{"nofile=1024:4096", "core=0:0"},
+				BlkioWeight:    500,
+				DeviceWriteBPS: []string{"/dev/sda:1048576"},
+				OOMScoreAdj:    -100,
+			},
+			want: map[string]string{
+				options.CPUSetCPUsLabel:     "2-3",
+				options.PidsLimitLabel:      "-1",
+				options.UlimitsLabel:        "nofile=1024:4096,core=0",
+				options.BlkioWeightLabel:    "500",
+				options.DeviceWriteBPSLabel: "/dev/sda:1048576",
+				options.OOMScoreAdjLabel:    "-100",
+			},
+		},
+		{
+			name:    "invalid-ulimit",
+			in:      Resources{Ulimits: []string{"nofile"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-device",
+			in:      Resources{DeviceReadIOPS: []string{"sda:100"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", WithResources(tc.in))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("startContainerRequestWithOptions(%+v) returned error %v, want error %v", tc.in, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, req.GetLabels()); diff != "" {
+				t.Errorf("startContainerRequestWithOptions(%+v) returned diff in labels (-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
+
+func TestSecurityLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      Security
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "no-security",
+		},
+		{
+			name: "security",
+			in: Security{
+				Seccomp:         "strict",
+				SELinux:         []string{"type:svirt_apache_t", "level:s0"},
+				ReadOnlyRootfs:  true,
+				Tmpfs:           []string{"/tmp", "/run:rw,size=64m"},
+				NoNewPrivileges: true,
+				MaskedPaths:     []string{"/etc/secret"},
+				UsernsMode:      "host",
+			},
+			want: map[string]string{
+				options.SeccompLabel:         "strict",
+				options.SELinuxLabel:         "type:svirt_apache_t,level:s0",
+				options.ReadOnlyRootfsLabel:  "true",
+				options.TmpfsLabel:           "/run:rw,size=64m;/tmp",
+				options.NoNewPrivilegesLabel: "true",
+				options.MaskedPathsLabel:     "/etc/secret",
+				options.UsernsModeLabel:      "host",
+			},
+		},
+		{
+			name:    "duplicate-tmpfs",
+			in:      Security{Tmpfs: []string{"/tmp", "/tmp:size=1m"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", WithSecurity(tc.in))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("startContainerRequestWithOptions(%+v) returned error %v, want error %v", tc.in, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, req.GetLabels()); diff != "" {
+				t.Errorf("startContainerRequestWithOptions(%+v) returned diff in labels (-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// StartGroup starts the members of an application group, each described by the request that would
+// start it on its own, after the members its depends-on label lists. It returns the names of the
+// members in the order they were started.
+func (c *Client) StartGroup(ctx context.Context, group string, members []*cpb.StartContainerRequest) ([]string, error) {
+	args, err := groupArgs(group, members)
+	if err != nil {
+		return nil, err
+	}
+
+	var started []string
+	if err := c.call(ctx, options.StartGroup, args, &started); err != nil {
+		return nil, err
+	}
+	return started, nil
+}
+
+// groupArgs returns the arguments of the operations on the group with the given members.
+func groupArgs(group string, members []*cpb.StartContainerRequest) (options.GroupArgs, error) {
+	if group == "" {
+		return options.GroupArgs{}, fmt.Errorf("the name of the group must be provided")
+	}
+
+	args := options.GroupArgs{Group: group}
+	for _, member := range members {
+		buf, err := protojson.Marshal(member)
+		if err != nil {
+			return options.GroupArgs{}, fmt.Errorf("invalid member %s of group %s: %w", member.GetInstanceName(), group, err)
+		}
+		args.Members = append(args.Members, json.RawMessage(buf))
+	}
+	return args, nil
+}
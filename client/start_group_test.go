@@ -0,0 +1,94 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+func TestStartGroup(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inGroup   string
+		inMembers []*cpb.StartContainerRequest
+		inResult  any
+
+		wantStarted []string
+		wantArgs    map[string]any
+		wantErr     bool
+	}{
+		{
+			name:    "start",
+			inGroup: "telemetry",
+			inMembers: []*cpb.StartContainerRequest{
+				{InstanceName: "collector", ImageName: "collector", Tag: "v2", Labels: map[string]string{options.DependsOnLabel: "db"}},
+				{InstanceName: "db", ImageName: "postgres", Tag: "16"},
+			},
+			inResult:    []string{"db", "collector"},
+			wantStarted: []string{"db", "collector"},
+			wantArgs: map[string]any{
+				"group": "telemetry",
+				"members": []any{
+					map[string]any{"instanceName": "collector", "imageName": "collector", "tag": "v2", "labels": map[string]any{options.DependsOnLabel: "db"}},
+					map[string]any{"instanceName": "db", "imageName": "postgres", "tag": "16"},
+				},
+			},
+		},
+		{
+			name:    "no-group",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{result: tc.inResult}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			started, err := cli.StartGroup(ctx, tc.inGroup, tc.inMembers)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("StartGroup(%q) returned an unexpected error: %v", tc.inGroup, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("StartGroup(%q) did not return an error", tc.inGroup)
+			}
+
+			if fcm.recvOp != options.StartGroup {
+				t.Errorf("StartGroup(%q) performed operation %s, want %s", tc.inGroup, fcm.recvOp, options.StartGroup)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("StartGroup(%q) sent an unexpected diff (-want +got):\n%s", tc.inGroup, diff)
+			}
+			if diff := cmp.Diff(tc.wantStarted, started); diff != "" {
+				t.Errorf("StartGroup(%q) returned an unexpected diff (-want +got):\n%s", tc.inGroup, diff)
+			}
+		})
+	}
+}
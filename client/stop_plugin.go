@@ -3,11 +3,17 @@ package client
 import (
 	"context"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
-// StopPlugin stops the requested plugin identified by instance.
-func (c *Client) StopPlugin(ctx context.Context, instance string) error {
+// StopPlugin stops the requested plugin identified by instance. Unless force is set, the plugin
+// is not stopped while volumes use it.
+func (c *Client) StopPlugin(ctx context.Context, instance string, force bool) error {
+	if force {
+		return c.call(ctx, options.StopPlugin, options.PluginArgs{Instance: instance, Force: true}, nil)
+	}
+
 	_, err := c.cli.StopPlugin(ctx, &cpb.StopPluginRequest{
 		InstanceName: instance,
 	})
@@ -5,12 +5,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/testing/protocmp"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 type fakeStopPluginServer struct {
-	fakeContainerzServer
+	fakeExtensionServer
 
 	recvMsg *cpb.StopPluginRequest
 }
@@ -25,8 +25,10 @@ func TestStopPlugin(t *testing.T) {
 		name string
 
 		inInstance string
+		inForce    bool
 
-		wantReq *cpb.StopPluginRequest
+		wantReq  *cpb.StopPluginRequest
+		wantArgs map[string]any
 	}{
 		{
 			name:       "stop-plugin",
@@ -35,6 +37,12 @@ func TestStopPlugin(t *testing.T) {
 				InstanceName: "test",
 			},
 		},
+		{
+			name:       "stop-plugin-forced",
+			inInstance: "test",
+			inForce:    true,
+			wantArgs:   map[string]any{"instance": "test", "force": true},
+		},
 	}
 
 	for _, tc := range tests {
@@ -48,14 +56,16 @@ func TestStopPlugin(t *testing.T) {
 				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
 			}
 
-			if err := cli.StopPlugin(ctx, tc.inInstance); err != nil {
-				t.Fatalf("StopPlugin(%v) returned an unexpected error: %v", tc.inInstance, err)
+			if err := cli.StopPlugin(ctx, tc.inInstance, tc.inForce); err != nil {
+				t.Fatalf("StopPlugin(%v, %v) returned an unexpected error: %v", tc.inInstance, tc.inForce, err)
 			}
 
 			if diff := cmp.Diff(tc.wantReq, fcm.recvMsg, protocmp.Transform()); diff != "" {
-				t.Errorf("StopPlugin(%v) returned an unexpected diff (-want +got):\n%s", tc.inInstance, diff)
+				t.Errorf("StopPlugin(%v, %v) returned an unexpected diff (-want +got):\n%s", tc.inInstance, tc.inForce, diff)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("StopPlugin(%v, %v) sent unexpected extension arguments (-want +got):\n%s", tc.inInstance, tc.inForce, diff)
 			}
-
 		})
 	}
 }
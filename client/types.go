@@ -23,9 +23,12 @@ import (
 
 // Progress contains progress information about this operation.
 type Progress struct {
-	Finished      bool
-	Image         string
-	Tag           string
+	Finished bool
+	Image    string
+	Tag      string
+	// Digest is the ID, i.e. the digest of the configuration, of the image pushed, if the target
+	// reports it.
+	Digest        string
 	BytesReceived uint64
 	Error         error
 }
@@ -36,6 +39,16 @@ type ContainerInfo struct {
 	Name      string
 	ImageName string
 	State     string
+	// Health is the health status of the container, starting, healthy or unhealthy, if it has a
+	// health check.
+	Health string
+	// RuntimeState is the state of the container on the target, e.g. created, running, paused,
+	// restarting, removing, exited or dead, if reported.
+	RuntimeState string
+	// ExitCode is the exit code of the last run of an exited or restarting container.
+	ExitCode int
+	// RestartCount is the number of times the container has been restarted by its restart policy.
+	RestartCount int
 
 	Error error
 }
@@ -56,7 +69,11 @@ type VolumeInfo struct {
 	Labels       map[string]string
 	Options      map[string]string
 	CreationTime time.Time
-	Error        error
+	// Usage is the disk space used by the volume in bytes, or -1 if it is not reported.
+	Usage int64
+	// Quota is the maximum size of the volume in bytes, or 0 if it has no quota.
+	Quota int64
+	Error error
 }
 
 // LogMessage contains the log message retrieved from the target system as well as any error that
@@ -73,6 +90,20 @@ type startOptions struct {
 	volumes   []string
 	devices   []string
 	network   string
+	networks  []string
+	hostname  string
+	dns       []string
+	dnsSearch []string
+	hosts     []string
+	ipv4      string
+	ipv6      string
+	revision  string
+	dependsOn []string
+	depWait   time.Duration
+	check     healthCheck
+	strategy  string
+	keep      time.Duration
+	health    time.Duration
 	capAdd    []string
 	capRemove []string
 	policy    string
@@ -81,6 +112,34 @@ type startOptions struct {
 	cpus      float64
 	softMem   int64
 	hardMem   int64
+	resources Resources
+	security  Security
+	mounts    []string
+}
+
+// Compression is the compression applied to an image while it is pushed.
+type Compression string
+
+const (
+	// Gzip compresses the image with gzip.
+	Gzip Compression = "gzip"
+	// Zstd compresses the image with zstd.
+	Zstd Compression = "zstd"
+)
+
+type pushOptions struct {
+	compression Compression
+}
+
+// PushOption is an option passed to a push image call.
+type PushOption func(*pushOptions)
+
+// WithCompression compresses the image on the fly while it is pushed, which the target
+// decompresses before loading it. Plugins are pushed as is.
+func WithCompression(compression Compression) PushOption {
+	return func(opt *pushOptions) {
+		opt.compression = compression
+	}
 }
 
 type nonBlockTypes interface {
@@ -111,6 +170,16 @@ func WithVolumes(volumes []string) StartOption {
 	}
 }
 
+// WithMounts sets the bind, tmpfs and volume mounts (format:
+// type=<bind|tmpfs|volume>,[source=<source>,]target=<path>[,readonly][,bind-propagation=<mode>]
+// [,tmpfs-size=<size>][,tmpfs-mode=<octal mode>]) to be passed to the start operation. Bind mounts
+// must be of host paths allowed by the server.
+func WithMounts(mounts []string) StartOption {
+	return func(opt *startOptions) {
+		opt.mounts = mounts
+	}
+}
+
 // WithDevices sets the devices to be passed to the start operation.
 func WithDevices(devices []string) StartOption {
 	return func(opt *startOptions) {
@@ -125,6 +194,188 @@ func WithNetwork(network string) StartOption {
 	}
 }
 
+// WithNetworkAttachments sets the networks (format:
+// <network>[;alias=<alias>]...[;ip=<ipv4>][;ip6=<ipv6>]) to be passed to the start operation. The
+// container is created on the first network and connected to the others before it is started.
+func WithNetworkAttachments(networks []string) StartOption {
+	return func(opt *startOptions) {
+		opt.networks = networks
+	}
+}
+
+// healthCheck holds the health check settings of a start operation.
+type healthCheck struct {
+	cmd         string
+	interval    time.Duration
+	timeout     time.Duration
+	retries     int
+	startPeriod time.Duration
+}
+
+// WithHealthCheck sets the health check to be passed to the start operation. The command is run
+// with the shell, and "none" disables the health check defined by the image. Unset values are
+// inherited from the image.
+func WithHealthCheck(cmd string, interval, timeout time.Duration, retries int, startPeriod time.Duration) StartOption {
+	return func(opt *startOptions) {
+		opt.check = healthCheck{
+			cmd:         cmd,
+			interval:    interval,
+			timeout:     timeout,
+			retries:     retries,
+			startPeriod: startPeriod,
+		}
+	}
+}
+
+// Resources holds resource controls, besides the CPU and memory limits, of a start operation.
+// Zero values are left to the runtime defaults.
+type Resources struct {
+	// CPUSetCPUs are the CPUs, e.g. 0-3,6, the container may run on.
+	CPUSetCPUs string
+	// CPUSetMems are the memory nodes, e.g. 0,1, the container may allocate memory from.
+	CPUSetMems string
+	// CPUShares is the relative CPU weight of the container.
+	CPUShares int64
+	// PidsLimit is the maximum number of processes in the container, or -1 for no limit.
+	PidsLimit int64
+	// Ulimits are the resource limits (format: <name>=<soft>[:<hard>]), e.g. nofile=1024:4096.
+	Ulimits []string
+	// BlkioWeight is the relative block IO weight of the container, between 10 and 1000.
+	BlkioWeight uint16
+	// DeviceReadBPS limits the read rate from devices (format: <device path>:<bytes per second>).
+	DeviceReadBPS []string
+	// DeviceWriteBPS limits the write rate to devices (format: <device path>:<bytes per second>).
+	DeviceWriteBPS []string
+	// DeviceReadIOPS limits the read rate from devices (format: <device path>:<operations per second>).
+	DeviceReadIOPS []string
+	// DeviceWriteIOPS limits the write rate to devices (format: <device path>:<operations per second>).
+	DeviceWriteIOPS []string
+	// MemorySwap is the limit of memory plus swap in bytes, or -1 for unlimited swap. It requires a
+	// hard memory limit.
+	MemorySwap int64
+	// OOMScoreAdj adjusts the OOM killer score of the container, between -1000 and 1000.
+	OOMScoreAdj int
+}
+
+// WithResources sets resource controls, e.g. cpusets, a pids limit or ulimits, to be passed to
+// the start operation.
+func WithResources(resources Resources) StartOption {
+	return func(opt *startOptions) {
+		opt.resources = resources
+	}
+}
+
+// Security holds the security settings, besides the capabilities, of a start operation. Zero
+// values are left to the runtime defaults or the policy of the server.
+type Security struct {
+	// Seccomp is the seccomp profile: default, unconfined or the name of a profile stored on the
+	// device.
+	Seccomp string
+	// AppArmor is the AppArmor profile, or unconfined.
+	AppArmor string
+	// SELinux are the SELinux label options, e.g. type:svirt_apache_t, or disable.
+	SELinux []string
+	// ReadOnlyRootfs mounts the root filesystem of the container read-only.
+	ReadOnlyRootfs bool
+	// Tmpfs are the tmpfs mounts (format: <mount point>[:<mount options>]), e.g. /run:size=64m.
+	Tmpfs []string
+	// NoNewPrivileges prevents the processes of the container from gaining privileges.
+	NoNewPrivileges bool
+	// MaskedPaths are masked in the container in addition to the runtime defaults.
+	MaskedPaths []string
+	// ReadonlyPaths are read-only in the container in addition to the runtime defaults.
+	ReadonlyPaths []string
+	// UsernsMode is host to opt out of the user namespace remapping of the runtime, or private to
+	// require it.
+	UsernsMode string
+}
+
+// WithSecurity sets security settings, e.g. a seccomp profile or a read-only root filesystem, to
+// be passed to the start operation.
+func WithSecurity(security Security) StartOption {
+	return func(opt *startOptions) {
+		opt.security = security
+	}
+}
+
+// WithDependsOn sets the containers (format: <instance>[:running|:healthy|:tcp/<port>]) that must
+// be ready before the container is started. They are also honoured when the device boots.
+func WithDependsOn(deps []string) StartOption {
+	return func(opt *startOptions) {
+		opt.dependsOn = deps
+	}
+}
+
+// WithDependencyTimeout sets how long the start waits for the dependencies to be ready. If unset,
+// the start fails as soon as a dependency is not ready.
+func WithDependencyTimeout(d time.Duration) StartOption {
+	return func(opt *startOptions) {
+		opt.depWait = d
+	}
+}
+
+// WithRevisionName names the revision of the instance created by the start or update operation.
+func WithRevisionName(name string) StartOption {
+	return func(opt *startOptions) {
+		opt.revision = name
+	}
+}
+
+// WithHostname sets the hostname to be passed to the start operation.
+func WithHostname(hostname string) StartOption {
+	return func(opt *startOptions) {
+		opt.hostname = hostname
+	}
+}
+
+// WithDNS sets the DNS servers and search domains to be passed to the start operation.
+func WithDNS(servers, search []string) StartOption {
+	return func(opt *startOptions) {
+		opt.dns = servers
+		opt.dnsSearch = search
+	}
+}
+
+// WithExtraHosts sets the /etc/hosts entries (format: <hostname>:<ip>) to be passed to the start
+// operation.
+func WithExtraHosts(hosts []string) StartOption {
+	return func(opt *startOptions) {
+		opt.hosts = hosts
+	}
+}
+
+// WithIPAddresses sets the static IPv4 and/or IPv6 address to be passed to the start operation.
+func WithIPAddresses(ipv4, ipv6 string) StartOption {
+	return func(opt *startOptions) {
+		opt.ipv4 = ipv4
+		opt.ipv6 = ipv6
+	}
+}
+
+// WithUpdateStrategy sets the update strategy (recreate, blue-green or live) to be passed to the update
+// operation.
+func WithUpdateStrategy(strategy string) StartOption {
+	return func(opt *startOptions) {
+		opt.strategy = strategy
+	}
+}
+
+// WithKeepPrevious sets how long the replaced container is kept for rollback after a blue/green
+// update.
+func WithKeepPrevious(d time.Duration) StartOption {
+	return func(opt *startOptions) {
+		opt.keep = d
+	}
+}
+
+// WithHealthTimeout sets how long a blue/green update waits for the new container to become
+// healthy.
+func WithHealthTimeout(d time.Duration) StartOption {
+	return func(opt *startOptions) {
+		opt.health = d
+	}
+}
+
 // WithCapabilities sets the capablities to be passed to the start operation.
 func WithCapabilities(add, remove []string) StartOption {
 	return func(opt *startOptions) {
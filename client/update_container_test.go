@@ -17,9 +17,11 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -309,3 +311,26 @@ func TestUpdateContainer(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateStrategyLabels(t *testing.T) {
+	opts := []StartOption{
+		WithUpdateStrategy("blue-green"),
+		WithKeepPrevious(time.Hour),
+		WithHealthTimeout(30 * time.Second),
+		WithRevisionName("release-2"),
+	}
+	req, err := startContainerRequestWithOptions(context.Background(), "some-image", "some-tag", "some-cmd", "some-instance", opts...)
+	if err != nil {
+		t.Fatalf("startContainerRequestWithOptions() returned an unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		options.UpdateStrategyLabel: "blue-green",
+		options.KeepPreviousLabel:   "1h0m0s",
+		options.HealthTimeoutLabel:  "30s",
+		options.RevisionLabel:       "release-2",
+	}
+	if diff := cmp.Diff(want, req.GetLabels()); diff != "" {
+		t.Errorf("startContainerRequestWithOptions() returned diff in labels (-want, +got):\n%s", diff)
+	}
+}
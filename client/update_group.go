@@ -0,0 +1,34 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// UpdateGroup updates an application group to the given members: existing members are updated,
+// new ones are started and the others are removed. If a member fails to update, the whole group is
+// rolled back.
+func (c *Client) UpdateGroup(ctx context.Context, group string, members []*cpb.StartContainerRequest) error {
+	args, err := groupArgs(group, members)
+	if err != nil {
+		return err
+	}
+
+	return c.call(ctx, options.UpdateGroup, args, nil)
+}
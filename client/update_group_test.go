@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+func TestUpdateGroup(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inGroup   string
+		inMembers []*cpb.StartContainerRequest
+
+		wantArgs map[string]any
+		wantErr  bool
+	}{
+		{
+			name:      "update",
+			inGroup:   "telemetry",
+			inMembers: []*cpb.StartContainerRequest{{InstanceName: "db", ImageName: "postgres", Tag: "17"}},
+			wantArgs: map[string]any{
+				"group":   "telemetry",
+				"members": []any{map[string]any{"instanceName": "db", "imageName": "postgres", "tag": "17"}},
+			},
+		},
+		{
+			name:    "no-group",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.UpdateGroup(ctx, tc.inGroup, tc.inMembers); err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("UpdateGroup(%q) returned an unexpected error: %v", tc.inGroup, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("UpdateGroup(%q) did not return an error", tc.inGroup)
+			}
+
+			if fcm.recvOp != options.UpdateGroup {
+				t.Errorf("UpdateGroup(%q) performed operation %s, want %s", tc.inGroup, fcm.recvOp, options.UpdateGroup)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("UpdateGroup(%q) returned an unexpected diff (-want +got):\n%s", tc.inGroup, diff)
+			}
+		})
+	}
+}
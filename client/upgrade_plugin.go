@@ -0,0 +1,47 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	options "github.com/openconfig/containerz/containers"
+)
+
+// UpgradePlugin upgrades the plugin identified by instance to the deployed plugin name, keeping
+// its settings. The config of the plugin is replaced by the contents of configFile, if not empty.
+func (c *Client) UpgradePlugin(ctx context.Context, name, instance, configFile string) error {
+	var config string
+	if configFile != "" {
+		buf, err := os.ReadFile(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		if !json.Valid(buf) {
+			return fmt.Errorf("invalid json in config file %s", configFile)
+		}
+		config = string(buf)
+	}
+
+	return c.call(ctx, options.UpgradePlugin, options.PluginArgs{
+		Instance: instance,
+		Name:     name,
+		Config:   config,
+	}, nil)
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	options "github.com/openconfig/containerz/containers"
+)
+
+func TestUpgradePlugin(t *testing.T) {
+	tests := []struct {
+		name string
+
+		inInstance string
+		inName     string
+		inConfig   string
+
+		wantArgs map[string]any
+		wantErr  bool
+	}{
+		{
+			name:       "keep-config",
+			inInstance: "test",
+			inName:     "test-v2",
+			wantArgs:   map[string]any{"instance": "test", "name": "test-v2"},
+		},
+		{
+			name:       "new-config",
+			inInstance: "test",
+			inName:     "test-v2",
+			inConfig:   "testdata/good.json",
+			wantArgs: map[string]any{
+				"instance": "test",
+				"name":     "test-v2",
+				"config":   "{\n  \"i-am\": \"good-json\"\n}",
+			},
+		},
+		{
+			name:       "bad-json",
+			inInstance: "test",
+			inName:     "test-v2",
+			inConfig:   "testdata/bad.json",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fcm := &fakeExtensionServer{}
+			addr, stop := newServer(t, fcm)
+			defer stop()
+			cli, err := NewClient(ctx, addr)
+			if err != nil {
+				t.Fatalf("NewClient(%v) returned an unexpected error: %v", addr, err)
+			}
+
+			if err := cli.UpgradePlugin(ctx, tc.inName, tc.inInstance, tc.inConfig); err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("UpgradePlugin(%q, %q, %q) returned an unexpected error: %v", tc.inName, tc.inInstance, tc.inConfig, err)
+			}
+			if tc.wantErr {
+				t.Fatalf("UpgradePlugin(%q, %q, %q) did not return an error", tc.inName, tc.inInstance, tc.inConfig)
+			}
+
+			if fcm.recvOp != options.UpgradePlugin {
+				t.Errorf("UpgradePlugin(%q, %q, %q) performed operation %s, want %s", tc.inName, tc.inInstance, tc.inConfig, fcm.recvOp, options.UpgradePlugin)
+			}
+			if diff := cmp.Diff(tc.wantArgs, fcm.recvArgs); diff != "" {
+				t.Errorf("UpgradePlugin(%q, %q, %q) returned an unexpected diff (-want +got):\n%s", tc.inName, tc.inInstance, tc.inConfig, diff)
+			}
+		})
+	}
+}
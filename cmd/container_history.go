@@ -0,0 +1,58 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var cntHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the revisions of a container by instance name, oldest first",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		revs, err := containerzClient.ListRevisions(command.Context(), instance)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+		fmt.Fprint(writer, "Revision\tName\tImage\tCreated\n")
+		defer writer.Flush()
+		for i, rev := range revs {
+			number := fmt.Sprint(rev.Number)
+			if i == len(revs)-1 {
+				number += " (current)"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s:%s\t%s\n", number, rev.Name, rev.Image, rev.Tag, rev.Created.Format(time.RFC822))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	containerCmd.AddCommand(cntHistoryCmd)
+
+	cntHistoryCmd.PersistentFlags().StringVar(&instance, "instance", "", "Container instance to list the revisions of.")
+}
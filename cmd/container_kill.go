@@ -0,0 +1,49 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	killSignal string
+)
+
+var cntKillCmd = &cobra.Command{
+	Use:   "kill",
+	Short: "send a signal to the main process of a container by instance name",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		if err := containerzClient.KillContainer(command.Context(), instance, killSignal); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully signalled %s\n", instance)
+		return nil
+	},
+}
+
+func init() {
+	containerCmd.AddCommand(cntKillCmd)
+
+	cntKillCmd.PersistentFlags().StringVar(&instance, "instance", "", "Container instance to signal.")
+	cntKillCmd.PersistentFlags().StringVar(&killSignal, "signal", "", "Signal to send, by name (e.g. HUP or SIGHUP) or number. Defaults to SIGKILL.")
+}
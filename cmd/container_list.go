@@ -17,33 +17,52 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	all   bool
-	limit int32
+	all     bool
+	limit   int32
+	filters []string
 )
 
 var cntListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List containers",
 	RunE: func(command *cobra.Command, args []string) error {
-		ch, err := containerzClient.ListContainer(command.Context(), all, limit, nil)
+		filter := map[string][]string{}
+		for _, f := range filters {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid filter %q (format: <key>=<value>)", f)
+			}
+			filter[key] = append(filter[key], value)
+		}
+
+		ch, err := containerzClient.ListContainer(command.Context(), all, limit, filter)
 		if err != nil {
 			return err
 		}
 
 		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-		fmt.Fprint(writer, "ID\tName\tImage\tState\n")
+		fmt.Fprint(writer, "ID\tName\tImage\tState\tHealth\tRestarts\n")
 		defer writer.Flush()
 		for info := range ch {
 			if info.Error != nil {
 				return info.Error
 			}
-			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", info.ID[:5], info.Name, info.ImageName, info.State)
+			state := info.State
+			switch info.RuntimeState {
+			case "":
+			case "exited", "restarting":
+				state = fmt.Sprintf("%s (%d)", info.RuntimeState, info.ExitCode)
+			default:
+				state = info.RuntimeState
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%d\n", info.ID[:5], info.Name, info.ImageName, state, info.Health, info.RestartCount)
 		}
 
 		return nil
@@ -55,4 +74,5 @@ func init() {
 
 	cntListCmd.PersistentFlags().BoolVar(&all, "all", false, "Return all containers.")
 	cntListCmd.PersistentFlags().Int32Var(&limit, "limit", -1, "number of containers to return")
+	cntListCmd.PersistentFlags().StringArrayVar(&filters, "filter", []string{}, "Filters to apply, e.g. application=<group>, state=exited or health=unhealthy (format: <key>=<value>).")
 }
@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	stopTimeout time.Duration
+)
+
+var cntRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "restart a container in place by instance name",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		if err := containerzClient.RestartContainer(command.Context(), instance, force, stopTimeout); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully restarted %s\n", instance)
+		return nil
+	},
+}
+
+func init() {
+	containerCmd.AddCommand(cntRestartCmd)
+
+	cntRestartCmd.PersistentFlags().StringVar(&instance, "instance", "", "Container instance to restart.")
+	cntRestartCmd.PersistentFlags().BoolVar(&force, "force", false, "Forcefully stop the container before starting it again.")
+	cntRestartCmd.PersistentFlags().DurationVar(&stopTimeout, "timeout", 0, "How long to wait for the container to stop before killing it. "+
+		"Defaults to the stop timeout of the container, or the engine default.")
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackRevision string
+)
+
+var cntRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "restore an earlier revision of a container by instance name",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		if err := containerzClient.RollbackContainer(command.Context(), instance, rollbackRevision, keepPrevious); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully rolled back %s\n", instance)
+		return nil
+	},
+}
+
+func init() {
+	containerCmd.AddCommand(cntRollbackCmd)
+
+	cntRollbackCmd.PersistentFlags().StringVar(&instance, "instance", "", "Container instance to roll back.")
+	cntRollbackCmd.PersistentFlags().StringVar(&rollbackRevision, "revision", "", "Revision, by number or name, to restore. Defaults to the previous revision.")
+	cntRollbackCmd.PersistentFlags().DurationVar(&keepPrevious, "keep_previous", 0, "How long to keep the replaced container so that the rollback can be rolled back.")
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/openconfig/containerz/client"
+	"github.com/spf13/cobra"
+)
+
+var cntSetResourcesCmd = &cobra.Command{
+	Use:   "set-resources",
+	Short: "change the resource limits of a running container without restarting it",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		opts := []client.StartOption{client.WithResources(resources)}
+		if cpus > 0 {
+			opts = append(opts, client.WithCPUs(cpus))
+		}
+		if softMem > 0 {
+			opts = append(opts, client.WithSoftLimit(softMem))
+		}
+		if hardMem > 0 {
+			opts = append(opts, client.WithHardLimit(hardMem))
+		}
+		if restartPolicy != "" {
+			opts = append(opts, client.WithRestartPolicy(restartPolicy))
+		}
+
+		if err := containerzClient.SetContainerResources(command.Context(), instance, opts...); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully updated the resources of %s\n", instance)
+		return nil
+	},
+}
+
+func init() {
+	containerCmd.AddCommand(cntSetResourcesCmd)
+
+	cntSetResourcesCmd.PersistentFlags().StringVar(&instance, "instance", "", "Container instance to update.")
+	cntSetResourcesCmd.PersistentFlags().Float64Var(&cpus, "cpus", 0.0, "CPU limit to set.")
+	cntSetResourcesCmd.PersistentFlags().Int64Var(&softMem, "soft_mem", 0, "Soft memory limit to set.")
+	cntSetResourcesCmd.PersistentFlags().Int64Var(&hardMem, "hard_mem", 0, "Hard memory limit to set. It must not be below the current memory usage.")
+	cntSetResourcesCmd.PersistentFlags().Int64Var(&resources.MemorySwap, "memory_swap", 0, "Limit of memory plus swap, or -1 for unlimited swap.")
+	cntSetResourcesCmd.PersistentFlags().StringVar(&resources.CPUSetCPUs, "cpuset_cpus", "", "CPUs the container may run on (format: <cpu>[-<cpu>][,...]), e.g. 0-3,6.")
+	cntSetResourcesCmd.PersistentFlags().StringVar(&resources.CPUSetMems, "cpuset_mems", "", "Memory nodes the container may allocate memory from (format: <node>[-<node>][,...]).")
+	cntSetResourcesCmd.PersistentFlags().Int64Var(&resources.CPUShares, "cpu_shares", 0, "Relative CPU weight of the container.")
+	cntSetResourcesCmd.PersistentFlags().Int64Var(&resources.PidsLimit, "pids_limit", 0, "Maximum number of processes in the container, or -1 for no limit. It must not be below the current number of processes.")
+	cntSetResourcesCmd.PersistentFlags().Uint16Var(&resources.BlkioWeight, "blkio_weight", 0, "Relative block IO weight of the container, between 10 and 1000.")
+	cntSetResourcesCmd.PersistentFlags().StringVar(&restartPolicy, "restart_policy", "", "Restart policy to use. "+
+		"Valid policies are \"always\", \"on-failure\", \"unless-stopped\", and \"none\". "+
+		"(format: <policy>[:<max_attempts>])")
+}
@@ -17,6 +17,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/openconfig/containerz/client"
 	"github.com/spf13/cobra"
@@ -27,8 +28,24 @@ var (
 	ports                []string
 	envs                 []string
 	volumes              []string
+	mounts               []string
 	devices              []string
 	network              string
+	attachments          []string
+	hostname             string
+	revisionName         string
+	dependsOn            []string
+	dependencyTimeout    time.Duration
+	healthCmd            string
+	healthInterval       time.Duration
+	healthCheckTimeout   time.Duration
+	healthRetries        int
+	healthStartPeriod    time.Duration
+	dnsServers           []string
+	dnsSearch            []string
+	extraHosts           []string
+	ipv4                 string
+	ipv6                 string
 	runAs                string
 	restartPolicy        string
 	addCaps              []string
@@ -36,6 +53,8 @@ var (
 	cpus                 float64
 	softMem              int64
 	hardMem              int64
+	resources            client.Resources
+	security             client.Security
 )
 
 var cntStartCmd = &cobra.Command{
@@ -56,12 +75,42 @@ var cntStartCmd = &cobra.Command{
 		if len(volumes) > 0 {
 			opts = append(opts, client.WithVolumes(volumes))
 		}
+		if len(mounts) > 0 {
+			opts = append(opts, client.WithMounts(mounts))
+		}
 		if len(devices) > 0 {
 			opts = append(opts, client.WithDevices(devices))
 		}
 		if network != "" {
 			opts = append(opts, client.WithNetwork(network))
 		}
+		if len(attachments) > 0 {
+			opts = append(opts, client.WithNetworkAttachments(attachments))
+		}
+		if hostname != "" {
+			opts = append(opts, client.WithHostname(hostname))
+		}
+		if revisionName != "" {
+			opts = append(opts, client.WithRevisionName(revisionName))
+		}
+		if len(dependsOn) > 0 {
+			opts = append(opts, client.WithDependsOn(dependsOn))
+		}
+		if dependencyTimeout > 0 {
+			opts = append(opts, client.WithDependencyTimeout(dependencyTimeout))
+		}
+		if healthCmd != "" || healthInterval > 0 || healthCheckTimeout > 0 || healthRetries > 0 || healthStartPeriod > 0 {
+			opts = append(opts, client.WithHealthCheck(healthCmd, healthInterval, healthCheckTimeout, healthRetries, healthStartPeriod))
+		}
+		if len(dnsServers) > 0 || len(dnsSearch) > 0 {
+			opts = append(opts, client.WithDNS(dnsServers, dnsSearch))
+		}
+		if len(extraHosts) > 0 {
+			opts = append(opts, client.WithExtraHosts(extraHosts))
+		}
+		if ipv4 != "" || ipv6 != "" {
+			opts = append(opts, client.WithIPAddresses(ipv4, ipv6))
+		}
 		if runAs != "" {
 			opts = append(opts, client.WithRunAs(runAs))
 		}
@@ -88,6 +137,8 @@ var cntStartCmd = &cobra.Command{
 		if hardMem > 0 {
 			opts = append(opts, client.WithHardLimit(hardMem))
 		}
+		opts = append(opts, client.WithResources(resources))
+		opts = append(opts, client.WithSecurity(security))
 
 		id, err := containerzClient.StartContainer(command.Context(), image, tag, cntCommand, instance, opts...)
 		if err != nil {
@@ -105,14 +156,35 @@ func init() {
 	cntStartCmd.PersistentFlags().StringVar(&cntCommand, "command", "/bin/bash", "command to run.")
 	cntStartCmd.PersistentFlags().StringVar(&instance, "instance", "", "Name to give to the container.")
 	cntStartCmd.PersistentFlags().StringVar(&network, "network", "", "Network to attach container to.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&attachments, "attach", []string{}, "Networks to attach the container to, the first one being used to create it. "+
+		"Cannot be combined with --network, --ip or --ip6 (format: <network>[;alias=<alias>]...[;ip=<ipv4>][;ip6=<ipv6>]).")
+	cntStartCmd.PersistentFlags().StringVar(&hostname, "hostname", "", "Hostname to give to the container.")
+	cntStartCmd.PersistentFlags().StringVar(&revisionName, "revision_name", "", "Name of the revision of the instance being started.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&dependsOn, "depends_on", []string{}, "Containers that must be ready before this one is started "+
+		"(format: <instance>[:running|:healthy|:tcp/<port>]). The restart policy of a container with dependencies is applied by containerz "+
+		"when the device boots, in dependency order, and the container is not restarted when it exits.")
+	cntStartCmd.PersistentFlags().DurationVar(&dependencyTimeout, "dependency_timeout", 0, "How long to wait for the dependencies to be ready. "+
+		"If unset, the start fails as soon as a dependency is not ready.")
+	cntStartCmd.PersistentFlags().StringVar(&healthCmd, "health_cmd", "", "Shell command to check the health of the container, or \"none\" to disable the image's health check.")
+	cntStartCmd.PersistentFlags().DurationVar(&healthInterval, "health_interval", 0, "Time between two health checks.")
+	cntStartCmd.PersistentFlags().DurationVar(&healthCheckTimeout, "health_check_timeout", 0, "How long a health check may run before it is considered to have failed.")
+	cntStartCmd.PersistentFlags().IntVar(&healthRetries, "health_retries", 0, "Number of consecutive failed health checks after which the container is unhealthy.")
+	cntStartCmd.PersistentFlags().DurationVar(&healthStartPeriod, "health_start_period", 0, "How long the container is given to start before failed health checks count.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&dnsServers, "dns", []string{}, "DNS servers to use.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&dnsSearch, "dns_search", []string{}, "DNS search domains to use.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&extraHosts, "add_host", []string{}, "Entries to add to /etc/hosts (format: <hostname>:<ip>).")
+	cntStartCmd.PersistentFlags().StringVar(&ipv4, "ip", "", "Static IPv4 address on the network. Requires a user-defined network.")
+	cntStartCmd.PersistentFlags().StringVar(&ipv6, "ip6", "", "Static IPv6 address on the network. Requires a user-defined network.")
 	cntStartCmd.PersistentFlags().StringVar(&runAs, "runas", "", "User to use (format: <user>[:<group>]")
 	cntStartCmd.PersistentFlags().StringVar(&restartPolicy, "restart_policy", "", "Restart policy to use. "+
 		"Valid policies are \"always\", \"on-failure\", \"unless-stopped\", and \"none\". "+
 		"Some policies (e.g., \"on-failure\") optionally accept a maximum number of restart attempts. "+
 		"(format: <policy>[:<max_attempts>])")
-	cntStartCmd.PersistentFlags().StringArrayVar(&ports, "port", []string{}, "Ports to expose (format: <internal_port>:<external_port>")
+	cntStartCmd.PersistentFlags().StringArrayVar(&ports, "port", []string{}, "Ports to expose (format: [<host_ip>:]<internal_port>:<external_port>[/<tcp|udp|sctp>]). IPv6 host addresses must be enclosed in square brackets.")
 	cntStartCmd.PersistentFlags().StringArrayVar(&envs, "env", []string{}, "Environment vars to set (format: <VAR_NAMEt>=<VAR_VALUE>")
 	cntStartCmd.PersistentFlags().StringArrayVarP(&volumes, "volume", "v", []string{}, "Volumes to attach to the container (format: <volume-name>:<mountpoint>[:ro])")
+	cntStartCmd.PersistentFlags().StringArrayVar(&mounts, "mount", []string{}, "Bind, tmpfs or volume mounts (format: type=<bind|tmpfs|volume>,[source=<source>,]target=<path>[,readonly]"+
+		"[,bind-propagation=<mode>][,tmpfs-size=<size>][,tmpfs-mode=<octal mode>]). Bind mounts must be of host paths allowed by the server.")
 	cntStartCmd.PersistentFlags().StringArrayVarP(&devices, "device", "d", []string{}, "Devices to attach to the container (format: <src-path>[:<dst-path>[:<permissions>]])")
 	cntStartCmd.PersistentFlags().StringArrayVar(&addCaps, "add_caps", []string{}, "Capabilities to add.")
 	cntStartCmd.PersistentFlags().StringArrayVar(&delCaps, "del_caps", []string{}, "Capabilities to remove.")
@@ -120,4 +192,25 @@ func init() {
 	cntStartCmd.PersistentFlags().Float64Var(&cpus, "cpus", 0.0, "CPU limit to set.")
 	cntStartCmd.PersistentFlags().Int64Var(&softMem, "soft_mem", 0, "Soft memory limit to set.")
 	cntStartCmd.PersistentFlags().Int64Var(&hardMem, "hard_mem", 0, "Hard memory limit to set.")
+	cntStartCmd.PersistentFlags().StringVar(&resources.CPUSetCPUs, "cpuset_cpus", "", "CPUs the container may run on (format: <cpu>[-<cpu>][,...]), e.g. 0-3,6.")
+	cntStartCmd.PersistentFlags().StringVar(&resources.CPUSetMems, "cpuset_mems", "", "Memory nodes the container may allocate memory from (format: <node>[-<node>][,...]).")
+	cntStartCmd.PersistentFlags().Int64Var(&resources.CPUShares, "cpu_shares", 0, "Relative CPU weight of the container.")
+	cntStartCmd.PersistentFlags().Int64Var(&resources.PidsLimit, "pids_limit", 0, "Maximum number of processes in the container, or -1 for no limit.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&resources.Ulimits, "ulimit", []string{}, "Ulimits to set (format: <name>=<soft>[:<hard>]), e.g. nofile=1024:4096.")
+	cntStartCmd.PersistentFlags().Uint16Var(&resources.BlkioWeight, "blkio_weight", 0, "Relative block IO weight of the container, between 10 and 1000.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&resources.DeviceReadBPS, "device_read_bps", []string{}, "Read rate limit from a device (format: <device path>:<bytes per second>).")
+	cntStartCmd.PersistentFlags().StringArrayVar(&resources.DeviceWriteBPS, "device_write_bps", []string{}, "Write rate limit to a device (format: <device path>:<bytes per second>).")
+	cntStartCmd.PersistentFlags().StringArrayVar(&resources.DeviceReadIOPS, "device_read_iops", []string{}, "Read rate limit from a device (format: <device path>:<operations per second>).")
+	cntStartCmd.PersistentFlags().StringArrayVar(&resources.DeviceWriteIOPS, "device_write_iops", []string{}, "Write rate limit to a device (format: <device path>:<operations per second>).")
+	cntStartCmd.PersistentFlags().Int64Var(&resources.MemorySwap, "memory_swap", 0, "Limit of memory plus swap, or -1 for unlimited swap. Requires --hard_mem.")
+	cntStartCmd.PersistentFlags().IntVar(&resources.OOMScoreAdj, "oom_score_adj", 0, "Adjustment of the OOM killer score of the container, between -1000 and 1000.")
+	cntStartCmd.PersistentFlags().StringVar(&security.Seccomp, "seccomp", "", "Seccomp profile: \"default\", \"unconfined\" or the name of a profile stored on the device.")
+	cntStartCmd.PersistentFlags().StringVar(&security.AppArmor, "apparmor", "", "AppArmor profile, or \"unconfined\".")
+	cntStartCmd.PersistentFlags().StringArrayVar(&security.SELinux, "selinux", []string{}, "SELinux label options, e.g. type:svirt_apache_t, or \"disable\".")
+	cntStartCmd.PersistentFlags().BoolVar(&security.ReadOnlyRootfs, "read_only", false, "Mount the root filesystem of the container read-only.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&security.Tmpfs, "tmpfs", []string{}, "Tmpfs mounts (format: <mount point>[:<mount options>]), e.g. /run:size=64m.")
+	cntStartCmd.PersistentFlags().BoolVar(&security.NoNewPrivileges, "no_new_privileges", false, "Prevent the processes of the container from gaining privileges.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&security.MaskedPaths, "masked_path", []string{}, "Paths to mask in the container, in addition to the runtime defaults.")
+	cntStartCmd.PersistentFlags().StringArrayVar(&security.ReadonlyPaths, "readonly_path", []string{}, "Paths to make read-only in the container, in addition to the runtime defaults.")
+	cntStartCmd.PersistentFlags().StringVar(&security.UsernsMode, "userns", "", "User namespace mode, \"host\" to opt out of the user namespace remapping or \"private\" to require it.")
 }
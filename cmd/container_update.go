@@ -16,13 +16,17 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/openconfig/containerz/client"
 	"github.com/spf13/cobra"
 )
 
 var (
-	async bool
+	async         bool
+	strategy      string
+	keepPrevious  time.Duration
+	healthTimeout time.Duration
 )
 
 var cntUpdateCmd = &cobra.Command{
@@ -46,12 +50,42 @@ var cntUpdateCmd = &cobra.Command{
 		if len(volumes) > 0 {
 			opts = append(opts, client.WithVolumes(volumes))
 		}
+		if len(mounts) > 0 {
+			opts = append(opts, client.WithMounts(mounts))
+		}
 		if len(devices) > 0 {
 			opts = append(opts, client.WithDevices(devices))
 		}
 		if network != "" {
 			opts = append(opts, client.WithNetwork(network))
 		}
+		if len(attachments) > 0 {
+			opts = append(opts, client.WithNetworkAttachments(attachments))
+		}
+		if hostname != "" {
+			opts = append(opts, client.WithHostname(hostname))
+		}
+		if revisionName != "" {
+			opts = append(opts, client.WithRevisionName(revisionName))
+		}
+		if len(dependsOn) > 0 {
+			opts = append(opts, client.WithDependsOn(dependsOn))
+		}
+		if dependencyTimeout > 0 {
+			opts = append(opts, client.WithDependencyTimeout(dependencyTimeout))
+		}
+		if healthCmd != "" || healthInterval > 0 || healthCheckTimeout > 0 || healthRetries > 0 || healthStartPeriod > 0 {
+			opts = append(opts, client.WithHealthCheck(healthCmd, healthInterval, healthCheckTimeout, healthRetries, healthStartPeriod))
+		}
+		if len(dnsServers) > 0 || len(dnsSearch) > 0 {
+			opts = append(opts, client.WithDNS(dnsServers, dnsSearch))
+		}
+		if len(extraHosts) > 0 {
+			opts = append(opts, client.WithExtraHosts(extraHosts))
+		}
+		if ipv4 != "" || ipv6 != "" {
+			opts = append(opts, client.WithIPAddresses(ipv4, ipv6))
+		}
 		if runAs != "" {
 			opts = append(opts, client.WithRunAs(runAs))
 		}
@@ -61,6 +95,17 @@ var cntUpdateCmd = &cobra.Command{
 		if len(addCaps) > 0 || len(delCaps) > 0 {
 			opts = append(opts, client.WithCapabilities(addCaps, delCaps))
 		}
+		opts = append(opts, client.WithSecurity(security))
+
+		if strategy != "" {
+			opts = append(opts, client.WithUpdateStrategy(strategy))
+		}
+		if keepPrevious > 0 {
+			opts = append(opts, client.WithKeepPrevious(keepPrevious))
+		}
+		if healthTimeout > 0 {
+			opts = append(opts, client.WithHealthTimeout(healthTimeout))
+		}
 
 		id, err := containerzClient.UpdateContainer(command.Context(), image, tag, cntCommand, instance, async, opts...)
 		if err != nil {
@@ -82,18 +127,52 @@ func init() {
 	cntUpdateCmd.PersistentFlags().BoolVar(&async, "async", false, "Perform an asynchroneous "+
 		"update. If set, this command performs basic sanity checks on the request, but does not "+
 		"follow the update process, i.e., it can not provide the outcome of the update process.")
+	cntUpdateCmd.PersistentFlags().StringVar(&strategy, "strategy", "", "Update strategy to use. "+
+		"\"recreate\" (the default) stops the container before starting the new version, "+
+		"\"blue-green\" starts the new version alongside the running one and only swaps them once it is healthy.")
+	cntUpdateCmd.PersistentFlags().DurationVar(&keepPrevious, "keep_previous", 0, "How long to keep the replaced container for rollback after a blue/green update.")
+	cntUpdateCmd.PersistentFlags().DurationVar(&healthTimeout, "health_timeout", 0, "How long a blue/green update waits for the new version to become healthy.")
 	cntUpdateCmd.PersistentFlags().StringVar(&cntCommand, "command", "/bin/bash", "command to run.")
 	cntUpdateCmd.PersistentFlags().StringVar(&instance, "instance", "", "Container to update.")
 	cntUpdateCmd.PersistentFlags().StringVar(&network, "network", "", "Network to attach container to.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&attachments, "attach", []string{}, "Networks to attach the container to, the first one being used to create it. "+
+		"Cannot be combined with --network, --ip or --ip6 (format: <network>[;alias=<alias>]...[;ip=<ipv4>][;ip6=<ipv6>]).")
+	cntUpdateCmd.PersistentFlags().StringVar(&hostname, "hostname", "", "Hostname to give to the container.")
+	cntUpdateCmd.PersistentFlags().StringVar(&revisionName, "revision_name", "", "Name of the revision of the instance being deployed.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&dependsOn, "depends_on", []string{}, "Containers that must be ready before this one is started "+
+		"(format: <instance>[:running|:healthy|:tcp/<port>]).")
+	cntUpdateCmd.PersistentFlags().DurationVar(&dependencyTimeout, "dependency_timeout", 0, "How long to wait for the dependencies to be ready. "+
+		"If unset, the start fails as soon as a dependency is not ready.")
+	cntUpdateCmd.PersistentFlags().StringVar(&healthCmd, "health_cmd", "", "Shell command to check the health of the container, or \"none\" to disable the image's health check.")
+	cntUpdateCmd.PersistentFlags().DurationVar(&healthInterval, "health_interval", 0, "Time between two health checks.")
+	cntUpdateCmd.PersistentFlags().DurationVar(&healthCheckTimeout, "health_check_timeout", 0, "How long a health check may run before it is considered to have failed.")
+	cntUpdateCmd.PersistentFlags().IntVar(&healthRetries, "health_retries", 0, "Number of consecutive failed health checks after which the container is unhealthy.")
+	cntUpdateCmd.PersistentFlags().DurationVar(&healthStartPeriod, "health_start_period", 0, "How long the container is given to start before failed health checks count.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&dnsServers, "dns", []string{}, "DNS servers to use.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&dnsSearch, "dns_search", []string{}, "DNS search domains to use.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&extraHosts, "add_host", []string{}, "Entries to add to /etc/hosts (format: <hostname>:<ip>).")
+	cntUpdateCmd.PersistentFlags().StringVar(&ipv4, "ip", "", "Static IPv4 address on the network. Requires a user-defined network.")
+	cntUpdateCmd.PersistentFlags().StringVar(&ipv6, "ip6", "", "Static IPv6 address on the network. Requires a user-defined network.")
 	cntUpdateCmd.PersistentFlags().StringVar(&runAs, "runas", "", "User to use (format: <user>[:<group>]")
 	cntUpdateCmd.PersistentFlags().StringVar(&restartPolicy, "restart_policy", "", "Restart policy to use. "+
 		"Valid policies are \"always\", \"on-failure\", \"unless-stopped\", and \"none\". "+
 		"Some policies (e.g., \"on-failure\") optionally accept a maximum number of restart attempts. "+
 		"(format: <policy>[:<max_attempts>])")
-	cntUpdateCmd.PersistentFlags().StringArrayVar(&ports, "port", []string{}, "Ports to expose (format: <internal_port>:<external_port>")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&ports, "port", []string{}, "Ports to expose (format: [<host_ip>:]<internal_port>:<external_port>[/<tcp|udp|sctp>]). IPv6 host addresses must be enclosed in square brackets.")
 	cntUpdateCmd.PersistentFlags().StringArrayVar(&envs, "env", []string{}, "Environment vars to set (format: <VAR_NAMEt>=<VAR_VALUE>")
 	cntUpdateCmd.PersistentFlags().StringArrayVarP(&volumes, "volume", "v", []string{}, "Volumes to attach to the container (format: <volume-name>:<mountpoint>[:ro])")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&mounts, "mount", []string{}, "Bind, tmpfs or volume mounts (format: type=<bind|tmpfs|volume>,[source=<source>,]target=<path>[,readonly]"+
+		"[,bind-propagation=<mode>][,tmpfs-size=<size>][,tmpfs-mode=<octal mode>]). Bind mounts must be of host paths allowed by the server.")
 	cntUpdateCmd.PersistentFlags().StringArrayVarP(&devices, "device", "d", []string{}, "Devices to attach to the container (format: <src-path>[:<dst-path>[:<permissions>]])")
 	cntUpdateCmd.PersistentFlags().StringArrayVar(&addCaps, "add_caps", []string{}, "Capabilities to add.")
 	cntUpdateCmd.PersistentFlags().StringArrayVar(&delCaps, "del_caps", []string{}, "Capabilities to remove.")
+	cntUpdateCmd.PersistentFlags().StringVar(&security.Seccomp, "seccomp", "", "Seccomp profile: \"default\", \"unconfined\" or the name of a profile stored on the device.")
+	cntUpdateCmd.PersistentFlags().StringVar(&security.AppArmor, "apparmor", "", "AppArmor profile, or \"unconfined\".")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&security.SELinux, "selinux", []string{}, "SELinux label options, e.g. type:svirt_apache_t, or \"disable\".")
+	cntUpdateCmd.PersistentFlags().BoolVar(&security.ReadOnlyRootfs, "read_only", false, "Mount the root filesystem of the container read-only.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&security.Tmpfs, "tmpfs", []string{}, "Tmpfs mounts (format: <mount point>[:<mount options>]), e.g. /run:size=64m.")
+	cntUpdateCmd.PersistentFlags().BoolVar(&security.NoNewPrivileges, "no_new_privileges", false, "Prevent the processes of the container from gaining privileges.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&security.MaskedPaths, "masked_path", []string{}, "Paths to mask in the container, in addition to the runtime defaults.")
+	cntUpdateCmd.PersistentFlags().StringArrayVar(&security.ReadonlyPaths, "readonly_path", []string{}, "Paths to make read-only in the container, in addition to the runtime defaults.")
+	cntUpdateCmd.PersistentFlags().StringVar(&security.UsernsMode, "userns", "", "User namespace mode, \"host\" to opt out of the user namespace remapping or \"private\" to require it.")
 }
@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+var membersFile string
+
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Application group operations",
+	Long: "Application groups are sets of containers managed together, each member starting after the members it depends on.\n" +
+		"Members are described in a JSON file holding a list of containerz StartContainerRequests, e.g.\n" +
+		`[{"instance_name": "db", "image_name": "postgres", "tag": "16"},` + "\n" +
+		` {"instance_name": "collector", "image_name": "collector", "tag": "v2", "labels": {"net.openconfig.containerz.depends-on": "db:healthy"}}]`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if grpcMetadata != nil {
+			ctx := metadata.NewOutgoingContext(cmd.Context(), metadata.New(grpcMetadata))
+			cmd.SetContext(ctx)
+		}
+		var err error
+		containerzClient, err = NewClient(cmd.Context(), addr)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// readGroupMembers reads the members of a group from a JSON file holding a list of
+// StartContainerRequests.
+func readGroupMembers(path string) ([]*cpb.StartContainerRequest, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the file describing the members of the group must be provided")
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return nil, fmt.Errorf("%s must hold a list of members: %w", path, err)
+	}
+
+	members := make([]*cpb.StartContainerRequest, 0, len(raw))
+	for i, r := range raw {
+		member := &cpb.StartContainerRequest{}
+		if err := protojson.Unmarshal(r, member); err != nil {
+			return nil, fmt.Errorf("member %d of %s is invalid: %w", i+1, path, err)
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func init() {
+	RootCmd.AddCommand(groupCmd)
+}
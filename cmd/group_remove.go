@@ -0,0 +1,41 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var groupRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove the members of an application group",
+	RunE: func(command *cobra.Command, args []string) error {
+		if err := containerzClient.RemoveGroup(command.Context(), name, force); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully removed group %q\n", name)
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupRemoveCmd)
+
+	groupRemoveCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the group to remove.")
+	groupRemoveCmd.PersistentFlags().BoolVar(&force, "force", false, "Remove the members even if they are running.")
+}
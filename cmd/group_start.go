@@ -0,0 +1,48 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var groupStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start an application group",
+	RunE: func(command *cobra.Command, args []string) error {
+		members, err := readGroupMembers(membersFile)
+		if err != nil {
+			return err
+		}
+
+		started, err := containerzClient.StartGroup(command.Context(), name, members)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Group %q started: %s\n", name, strings.Join(started, ", "))
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupStartCmd)
+
+	groupStartCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the group to start.")
+	groupStartCmd.PersistentFlags().StringVar(&membersFile, "members", "", "JSON file describing the members of the group.")
+}
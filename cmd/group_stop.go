@@ -0,0 +1,41 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var groupStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the members of an application group",
+	RunE: func(command *cobra.Command, args []string) error {
+		if err := containerzClient.StopGroup(command.Context(), name, force); err != nil {
+			return err
+		}
+
+		fmt.Printf("Group %q stopped\n", name)
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupStopCmd)
+
+	groupStopCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the group to stop.")
+	groupStopCmd.PersistentFlags().BoolVar(&force, "force", false, "Kill the members that do not stop in time.")
+}
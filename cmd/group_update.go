@@ -0,0 +1,46 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var groupUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update an application group, as a whole or not at all",
+	RunE: func(command *cobra.Command, args []string) error {
+		members, err := readGroupMembers(membersFile)
+		if err != nil {
+			return err
+		}
+
+		if err := containerzClient.UpdateGroup(command.Context(), name, members); err != nil {
+			return err
+		}
+
+		fmt.Printf("Group %q updated\n", name)
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupUpdateCmd)
+
+	groupUpdateCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the group to update.")
+	groupUpdateCmd.PersistentFlags().StringVar(&membersFile, "members", "", "JSON file describing the members the group is updated to. Members no longer listed are removed.")
+}
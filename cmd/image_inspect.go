@@ -0,0 +1,89 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var imageInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Show the size, platform, configuration and layers of an image",
+	Long:  "Show the size, platform, configuration and layers of the image --image:--tag. An image may also be inspected by ID, given as --image with an empty --tag.",
+	RunE: func(command *cobra.Command, args []string) error {
+		if image == "" {
+			return fmt.Errorf("--image must be provided")
+		}
+
+		ref := image
+		if tag != "" {
+			ref += ":" + tag
+		}
+		details, err := containerzClient.InspectImage(command.Context(), ref)
+		if err != nil {
+			return err
+		}
+
+		platform := details.OS + "/" + details.Architecture
+		if details.Variant != "" {
+			platform += "/" + details.Variant
+		}
+		labels := make([]string, 0, len(details.Labels))
+		for k, v := range details.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+		defer writer.Flush()
+		fmt.Fprintf(writer, "ID:\t%s\n", details.ID)
+		fmt.Fprintf(writer, "Tags:\t%s\n", listOrNone(details.RepoTags))
+		fmt.Fprintf(writer, "Digests:\t%s\n", listOrNone(details.RepoDigests))
+		created := "unknown"
+		if !details.Created.IsZero() {
+			created = details.Created.Format(time.RFC822)
+		}
+		fmt.Fprintf(writer, "Creation Time:\t%s\n", created)
+		fmt.Fprintf(writer, "Size:\t%s\n", units.BytesSize(float64(details.Size)))
+		fmt.Fprintf(writer, "Platform:\t%s\n", platform)
+		fmt.Fprintf(writer, "Entrypoint:\t%v\n", details.Entrypoint)
+		fmt.Fprintf(writer, "Cmd:\t%v\n", details.Cmd)
+		fmt.Fprintf(writer, "Exposed Ports:\t%s\n", listOrNone(details.ExposedPorts))
+		fmt.Fprintf(writer, "Volumes:\t%s\n", listOrNone(details.Volumes))
+		fmt.Fprintf(writer, "Labels:\t%s\n", listOrNone(labels))
+		fmt.Fprintf(writer, "Layers:\t%s\n", listOrNone(details.Layers))
+		return nil
+	},
+}
+
+// listOrNone joins the elements of list, or returns none if it is empty.
+func listOrNone(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ", ")
+}
+
+func init() {
+	imageCmd.AddCommand(imageInspectCmd)
+}
@@ -18,19 +18,21 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/spf13/cobra"
 	"github.com/briandowns/spinner"
+	"github.com/openconfig/containerz/client"
+	"github.com/spf13/cobra"
 )
 
 var (
 	file     string
 	isPlugin bool
+	compress string
 )
 
 var pushCmd = &cobra.Command{
 	Use:   "push",
 	Short: "Push a local image tarball to the containerz server.",
-	Long:  "Push the result of 'docker save' to the containerz server.",
+	Long:  "Push the result of 'docker save' or an OCI image layout tarball, optionally compressed with gzip or zstd, to the containerz server.",
 	RunE: func(command *cobra.Command, args []string) error {
 		if file == "" {
 			return fmt.Errorf("--file cannot be empty")
@@ -41,7 +43,12 @@ var pushCmd = &cobra.Command{
 			output = "image"
 		}
 
-		ch, err := containerzClient.PushImage(command.Context(), image, tag, file, isPlugin)
+		var opts []client.PushOption
+		if compress != "" {
+			opts = append(opts, client.WithCompression(client.Compression(compress)))
+		}
+
+		ch, err := containerzClient.PushImage(command.Context(), image, tag, file, isPlugin, opts...)
 		if err != nil {
 			return err
 		}
@@ -59,6 +66,9 @@ var pushCmd = &cobra.Command{
 
 			if prog.Finished {
 				s.FinalMSG = fmt.Sprintf("Pushed %s/%s\n", prog.Image, prog.Tag)
+				if prog.Digest != "" {
+					s.FinalMSG = fmt.Sprintf("Pushed %s/%s (%s)\n", prog.Image, prog.Tag, prog.Digest)
+				}
 			} else {
 				s.Suffix = fmt.Sprintf(" %d", prog.BytesReceived)
 			}
@@ -72,4 +82,5 @@ func init() {
 	imageCmd.AddCommand(pushCmd)
 	pushCmd.PersistentFlags().StringVar(&file, "file", "", "Image tar to upload.")
 	pushCmd.PersistentFlags().BoolVar(&isPlugin, "is_plugin", false, "If set to true, a plugin will be uploaded rather than loading the image into the container runtime")
+	pushCmd.PersistentFlags().StringVar(&compress, "compress", "", "Compress the image while it is uploaded, with gzip or zstd.")
 }
@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	containers "github.com/openconfig/containerz/containers"
+	"github.com/spf13/cobra"
+)
+
+var subnets []string
+
+var networkCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create networks",
+	RunE: func(command *cobra.Command, args []string) error {
+		opts := map[string]string{}
+		for _, o := range options {
+			parts := strings.SplitN(o, "=", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid driver option %q, want k=v", o)
+			}
+			opts[parts[0]] = parts[1]
+		}
+
+		lbls := map[string]string{}
+		for _, l := range labels {
+			parts := strings.SplitN(l, "=", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid label %q, want k=v", l)
+			}
+			lbls[parts[0]] = parts[1]
+		}
+
+		var subs []containers.Subnet
+		for _, spec := range subnets {
+			s, err := containers.ParseSubnet(spec)
+			if err != nil {
+				return err
+			}
+			subs = append(subs, s)
+		}
+
+		resp, err := containerzClient.CreateNetwork(command.Context(), name, driver, subs, opts, lbls)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Network %q created!\n", resp)
+		return nil
+	},
+}
+
+func init() {
+	networkCmd.AddCommand(networkCreateCmd)
+
+	networkCreateCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the network to create.")
+	networkCreateCmd.PersistentFlags().StringVar(&driver, "driver", "", "Driver of the network: bridge (the default), macvlan or ipvlan.")
+	networkCreateCmd.PersistentFlags().StringSliceVarP(&options, "options", "o", []string{}, "Options to pass to the driver in the form k1=v1,k2=v2,..., e.g. parent=eth1 for macvlan networks.")
+	networkCreateCmd.PersistentFlags().StringArrayVar(&subnets, "subnet", []string{}, "Subnets of the network (format: <subnet>[;gateway=<ip>][;ip_range=<subnet>]).")
+	networkCreateCmd.PersistentFlags().StringSliceVarP(&labels, "labels", "l", []string{}, "Labels to tag the network with, in the form k1=v1")
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var networkListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List networks and the containers attached to them",
+	RunE: func(command *cobra.Command, args []string) error {
+		networks, err := containerzClient.ListNetworks(command.Context())
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+		fmt.Fprint(writer, "Name\tDriver\tSubnets\tContainers\tLabels\tCreation Time\n")
+		defer writer.Flush()
+		for _, n := range networks {
+			var subs []string
+			for _, s := range n.Subnets {
+				subs = append(subs, s.String())
+			}
+			cnts := "none"
+			if len(n.Containers) > 0 {
+				cnts = strings.Join(n.Containers, ", ")
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%v\t%s\n", n.Name, n.Driver, strings.Join(subs, ", "), cnts, n.Labels, n.Created.Format(time.RFC822))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	networkCmd.AddCommand(networkListCmd)
+}
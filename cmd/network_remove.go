@@ -0,0 +1,41 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var networkRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove networks",
+	RunE: func(command *cobra.Command, args []string) error {
+		if err := containerzClient.RemoveNetwork(command.Context(), name, force); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully removed network %q\n", name)
+		return nil
+	},
+}
+
+func init() {
+	networkCmd.AddCommand(networkRemoveCmd)
+
+	networkRemoveCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the network to remove.")
+	networkRemoveCmd.PersistentFlags().BoolVar(&force, "force", false, "Disconnect the containers still attached to the network before removing it.")
+}
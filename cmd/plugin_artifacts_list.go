@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var pluginArtifactsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the plugin tarballs pushed to the target and the plugins created from them",
+	RunE: func(command *cobra.Command, args []string) error {
+		artifacts, err := containerzClient.ListPluginArtifacts(command.Context())
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+		fmt.Fprint(writer, "Name\tSize\tHash\tUploaded\tPlugins\n")
+		defer writer.Flush()
+		for _, artifact := range artifacts {
+			plugins := "none"
+			if len(artifact.Plugins) > 0 {
+				plugins = strings.Join(artifact.Plugins, ", ")
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", artifact.Name, units.BytesSize(float64(artifact.Size)), artifact.Hash, artifact.Uploaded.Format(time.RFC822), plugins)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	pluginArtifactsCmd.AddCommand(pluginArtifactsListCmd)
+}
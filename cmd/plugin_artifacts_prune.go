@@ -0,0 +1,49 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var confirmPrune bool
+
+var pluginArtifactsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove the plugin tarballs no installed plugin uses any more",
+	RunE: func(command *cobra.Command, args []string) error {
+		if !confirmPrune {
+			return fmt.Errorf("pruning removes every unused plugin tarball, pass --yes to confirm")
+		}
+
+		removed, err := containerzClient.PrunePluginArtifacts(command.Context())
+		if err != nil {
+			return err
+		}
+
+		for _, artifact := range removed {
+			fmt.Printf("Removed %s\n", artifact.Name)
+		}
+		fmt.Printf("Successfully pruned %d plugin artifacts\n", len(removed))
+		return nil
+	},
+}
+
+func init() {
+	pluginArtifactsCmd.AddCommand(pluginArtifactsPruneCmd)
+	pluginArtifactsPruneCmd.PersistentFlags().BoolVar(&confirmPrune, "yes", false, "Confirm the removal of every unused plugin tarball.")
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var pluginArtifactsRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a plugin tarball from the target",
+	RunE: func(command *cobra.Command, args []string) error {
+		if name == "" {
+			return fmt.Errorf("--name must be provided")
+		}
+
+		if err := containerzClient.RemovePluginArtifact(command.Context(), name, force); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully removed plugin artifact %s\n", name)
+		return nil
+	},
+}
+
+func init() {
+	pluginArtifactsCmd.AddCommand(pluginArtifactsRemoveCmd)
+
+	pluginArtifactsRemoveCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the plugin tarball to remove, as listed by 'plugin artifacts list'.")
+	pluginArtifactsRemoveCmd.PersistentFlags().BoolVar(&force, "force", false, "Remove the tarball even if installed plugins were created from it.")
+}
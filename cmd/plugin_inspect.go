@@ -0,0 +1,58 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var pluginInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Show the status, settings and users of a plugin",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		details, err := containerzClient.InspectPlugin(command.Context(), instance)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+		defer writer.Flush()
+		fmt.Fprintf(writer, "ID:\t%s\n", details.ID)
+		fmt.Fprintf(writer, "Name:\t%s\n", details.Name)
+		fmt.Fprintf(writer, "Enabled:\t%t\n", details.Enabled)
+		fmt.Fprintf(writer, "Capabilities:\t%s\n", strings.Join(details.Capabilities, ", "))
+		fmt.Fprintf(writer, "Env:\t%v\n", details.Env)
+		fmt.Fprintf(writer, "Args:\t%v\n", details.Args)
+		references := "none"
+		if len(details.References) > 0 {
+			references = strings.Join(details.References, ", ")
+		}
+		fmt.Fprintf(writer, "Volumes:\t%s\n", references)
+		return nil
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginInspectCmd)
+}
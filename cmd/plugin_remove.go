@@ -28,7 +28,7 @@ var pluginRemoveCmd = &cobra.Command{
 			fmt.Println("--instance must be provided")
 		}
 
-		if err := containerzClient.RemovePlugin(command.Context(), instance); err != nil {
+		if err := containerzClient.RemovePlugin(command.Context(), instance, force); err != nil {
 			return err
 		}
 
@@ -39,4 +39,6 @@ var pluginRemoveCmd = &cobra.Command{
 
 func init() {
 	pluginCmd.AddCommand(pluginRemoveCmd)
+
+	pluginRemoveCmd.PersistentFlags().BoolVar(&force, "force", false, "Remove the plugin even if volumes use it.")
 }
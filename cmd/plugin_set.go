@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pluginEnv  []string
+	pluginArgs []string
+)
+
+var pluginSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change the environment variables or arguments of a plugin",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		if len(pluginEnv) == 0 && len(pluginArgs) == 0 {
+			return fmt.Errorf("--env or --args must be provided")
+		}
+
+		if err := containerzClient.SetPlugin(command.Context(), instance, pluginEnv, pluginArgs); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully set %s\n", instance)
+		return nil
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginSetCmd)
+
+	pluginSetCmd.PersistentFlags().StringArrayVar(&pluginEnv, "env", []string{}, "Environment variables to set (format: <name>=<value>).")
+	pluginSetCmd.PersistentFlags().StringArrayVar(&pluginArgs, "args", []string{}, "Arguments to replace those of the plugin with.")
+}
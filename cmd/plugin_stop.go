@@ -28,7 +28,7 @@ var pluginStopCmd = &cobra.Command{
 			fmt.Println("--instance must be provided")
 		}
 
-		if err := containerzClient.StopPlugin(command.Context(), instance); err != nil {
+		if err := containerzClient.StopPlugin(command.Context(), instance, force); err != nil {
 			return err
 		}
 
@@ -39,4 +39,6 @@ var pluginStopCmd = &cobra.Command{
 
 func init() {
 	pluginCmd.AddCommand(pluginStopCmd)
+
+	pluginStopCmd.PersistentFlags().BoolVar(&force, "force", false, "Stop the plugin even if volumes use it.")
 }
@@ -0,0 +1,49 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var pluginUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade a plugin to a newly pushed version, keeping its settings",
+	RunE: func(command *cobra.Command, args []string) error {
+		if instance == "" {
+			return fmt.Errorf("--instance must be provided")
+		}
+
+		if name == "" {
+			return fmt.Errorf("--name must be provided")
+		}
+
+		if err := containerzClient.UpgradePlugin(command.Context(), name, instance, configFile); err != nil {
+			return err
+		}
+
+		fmt.Printf("Successfully upgraded %s to %s\n", instance, name)
+		return nil
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginUpgradeCmd)
+
+	pluginUpgradeCmd.PersistentFlags().StringVar(&name, "name", "", "name of the pushed plugin to upgrade to")
+	pluginUpgradeCmd.PersistentFlags().StringVar(&configFile, "config", "", "plugin config file, the current config is kept if not provided")
+}
@@ -18,17 +18,34 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strings"
+	"time"
 
-	"github.com/spf13/cobra"
 	"github.com/docker/docker/client"
+	containers "github.com/openconfig/containerz/containers"
 	"github.com/openconfig/containerz/containers/docker"
 	"github.com/openconfig/containerz/server"
+	"github.com/spf13/cobra"
 )
 
 var (
 	dockerHost string
 	chunkSize  int
 	useALTS    bool
+
+	seccompProfileDir  string
+	seccompProfiles    []string
+	securityDefaults   containers.Security
+	defaultTmpfs       []string
+	requireUsernsRemap bool
+	bindMountAllowlist []string
+	deviceAllowlist    []string
+	volumeHelperImage  string
+	historyLocation    string
+	pluginLocation     string
+	stagingLocation    string
+	pluginRetention    time.Duration
+	imagePlatform      string
 )
 
 var startCmd = &cobra.Command{
@@ -47,14 +64,41 @@ var startCmd = &cobra.Command{
 		opts := []server.Option{
 			server.WithAddr(addr),
 			server.WithChunkSize(chunkSize),
+			server.WithPluginLocation(pluginLocation),
 		}
 
 		if useALTS {
 			opts = append(opts, server.UseALTS())
 		}
 
-		mgr := docker.New(cli)
-		s := server.New(docker.New(cli), opts...)
+		if securityDefaults.Tmpfs, err = containers.ParseTmpfs(strings.Join(defaultTmpfs, ";")); err != nil {
+			return err
+		}
+		opts = append(opts, server.WithSecurityPolicy(server.SecurityPolicy{
+			Defaults:           securityDefaults,
+			SeccompProfiles:    seccompProfiles,
+			RequireUsernsRemap: requireUsernsRemap,
+		}))
+		opts = append(opts, server.WithBindMountAllowlist(bindMountAllowlist))
+		opts = append(opts, server.WithVolumeDeviceAllowlist(deviceAllowlist))
+
+		mgrOpts := []docker.Option{
+			docker.WithSeccompProfileDir(seccompProfileDir),
+			docker.WithHistoryLocation(historyLocation),
+			docker.WithHelperImage(volumeHelperImage),
+			docker.WithPluginLocation(pluginLocation),
+			docker.WithStagingLocation(stagingLocation),
+			docker.WithPluginRetention(pluginRetention),
+		}
+		if imagePlatform != "" {
+			p, err := docker.ParsePlatform(imagePlatform)
+			if err != nil {
+				return err
+			}
+			mgrOpts = append(mgrOpts, docker.WithPlatform(p))
+		}
+		mgr := docker.New(cli, mgrOpts...)
+		s := server.New(mgr, opts...)
 		mgr.Start(ctx)
 
 		// listen for ctrl-c
@@ -76,4 +120,23 @@ func init() {
 	startCmd.PersistentFlags().StringVar(&dockerHost, "docker_host", "unix:///var/run/docker.sock", "Docker host to connect to.")
 	startCmd.PersistentFlags().IntVar(&chunkSize, "chunk_size", 3000000, "the size of the chunks supported by this server")
 	startCmd.PersistentFlags().BoolVar(&useALTS, "use_alts", false, "Use ALTS authentication.")
+	startCmd.PersistentFlags().StringVar(&seccompProfileDir, "seccomp_profile_dir", "", "Directory of the seccomp profiles containers may request by name, each stored as <name>.json.")
+	startCmd.PersistentFlags().StringVar(&volumeHelperImage, "volume_helper_image", "", "Image of the containers used to back up and restore volumes, which are unavailable without it. The containers are never started, so any image present on the host will do.")
+	startCmd.PersistentFlags().StringVar(&historyLocation, "history_location", "/history", "Directory the revision history of each container, and the previous versions kept for rollback, are persisted to. If empty, both are lost when containerz restarts.")
+	startCmd.PersistentFlags().StringVar(&pluginLocation, "plugin_location", "/plugins", "Directory the deployed plugins are stored in.")
+	startCmd.PersistentFlags().StringVar(&stagingLocation, "staging_location", "/staging", "Directory the plugins are extracted to before being created.")
+	startCmd.PersistentFlags().DurationVar(&pluginRetention, "plugin_retention", 0, "If set, remove the plugin tarballs no installed plugin uses any more once they were uploaded for that long. Zero (the default) keeps them forever.")
+	startCmd.PersistentFlags().StringVar(&imagePlatform, "platform", "", "Platform (format: <os>/<arch>[/<variant>]) images are pulled for and must be built for. Defaults to the platform of the host.")
+	startCmd.PersistentFlags().StringVar(&securityDefaults.Seccomp, "default_seccomp", "", "Seccomp profile of containers that do not request one. Containers may not run unconfined, nor request another profile than those of --seccomp_profile_allowlist, if set.")
+	startCmd.PersistentFlags().StringArrayVar(&seccompProfiles, "seccomp_profile_allowlist", []string{}, "Seccomp profiles, besides the default one, containers may request. Containers may only run unconfined if \"unconfined\" is listed. Containers may request any profile if empty and no default is set.")
+	startCmd.PersistentFlags().StringVar(&securityDefaults.AppArmor, "default_apparmor", "", "AppArmor profile of containers that do not request one. Containers may not run unconfined if set.")
+	startCmd.PersistentFlags().StringArrayVar(&securityDefaults.SELinux, "default_selinux", []string{}, "SELinux label options of containers that do not request any. Containers may not disable labelling if set.")
+	startCmd.PersistentFlags().BoolVar(&securityDefaults.ReadOnlyRootfs, "require_read_only", false, "Mount the root filesystem of every container read-only.")
+	startCmd.PersistentFlags().StringArrayVar(&defaultTmpfs, "default_tmpfs", []string{}, "Tmpfs mounts of every container (format: <mount point>[:<mount options>]).")
+	startCmd.PersistentFlags().BoolVar(&securityDefaults.NoNewPrivileges, "require_no_new_privileges", false, "Prevent the processes of every container from gaining privileges.")
+	startCmd.PersistentFlags().StringArrayVar(&securityDefaults.MaskedPaths, "masked_path", []string{}, "Paths to mask in every container, in addition to the runtime defaults.")
+	startCmd.PersistentFlags().StringArrayVar(&securityDefaults.ReadonlyPaths, "readonly_path", []string{}, "Paths to make read-only in every container, in addition to the runtime defaults.")
+	startCmd.PersistentFlags().StringArrayVar(&bindMountAllowlist, "bind_mount_allowlist", []string{}, "Host paths, along with everything below them, that containers and volumes may bind mount.")
+	startCmd.PersistentFlags().StringArrayVar(&deviceAllowlist, "volume_device_allowlist", []string{}, "Block devices that volumes may mount.")
+	startCmd.PersistentFlags().BoolVar(&requireUsernsRemap, "require_userns_remap", false, "Reject containers that opt out of the user namespace remapping. Containers may require it with the private user namespace mode.")
 }
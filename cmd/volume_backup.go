@@ -0,0 +1,51 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var volBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Save the contents of a volume to a local archive",
+	Long:  "Save a gzip compressed tar archive of the contents of a volume to --file. The checksum of the archive is verified once it is received.",
+	RunE: func(command *cobra.Command, args []string) error {
+		if name == "" {
+			return fmt.Errorf("--name must be provided")
+		}
+		if file == "" {
+			return fmt.Errorf("--file cannot be empty")
+		}
+
+		size, err := containerzClient.BackupVolume(command.Context(), name, file)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Saved volume %s to %s (%s)\n", name, file, units.BytesSize(float64(size)))
+		return nil
+	},
+}
+
+func init() {
+	volumesCmd.AddCommand(volBackupCmd)
+
+	volBackupCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the volume to back up.")
+	volBackupCmd.PersistentFlags().StringVar(&file, "file", "", "Archive to save the volume to.")
+}
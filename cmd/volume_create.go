@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 
+	containers "github.com/openconfig/containerz/containers"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +27,9 @@ var (
 	driver  string
 	options []string
 	labels  []string
+	quota   string
+
+	driverName string
 )
 
 var volCreateCmd = &cobra.Command{
@@ -44,6 +48,20 @@ var volCreateCmd = &cobra.Command{
 			lbls[parts[0]] = parts[1]
 		}
 
+		if quota != "" {
+			lbls[containers.VolumeQuotaLabel] = quota
+		}
+
+		if driverName != "" {
+			switch strings.ToLower(driver) {
+			case "":
+				driver = "custom"
+			case "local":
+				return fmt.Errorf("--driver_name cannot be used with the local driver")
+			}
+			lbls[containers.VolumeDriverLabel] = driverName
+		}
+
 		resp, err := containerzClient.CreateVolume(command.Context(), name, driver, lbls, opts)
 		if err != nil {
 			return err
@@ -60,5 +78,7 @@ func init() {
 	volCreateCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the volume to create.")
 	volCreateCmd.PersistentFlags().StringVar(&driver, "driver", "", "Type of driver to use to create the volume.")
 	volCreateCmd.PersistentFlags().StringSliceVarP(&options, "options", "o", []string{}, "Options to pass to the driver in the form k1=v1,k2=v2,...")
+	volCreateCmd.PersistentFlags().StringVar(&driverName, "driver_name", "", "Name of the installed volume driver plugin, e.g. vieux/sshfs, to create the volume with. Implies a custom --driver.")
+	volCreateCmd.PersistentFlags().StringVar(&quota, "quota", "", "Maximum size of the volume, e.g. 512m. Only supported by the local driver on filesystems with project quotas.")
 	volCreateCmd.PersistentFlags().StringSliceVarP(&labels, "labels", "l", []string{}, "Labels to tag. the volume with, in the form k1=v1")
 }
@@ -0,0 +1,67 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	containers "github.com/openconfig/containerz/containers"
+	"github.com/spf13/cobra"
+)
+
+var volInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Show the details, disk usage and users of a volume",
+	RunE: func(command *cobra.Command, args []string) error {
+		if name == "" {
+			return fmt.Errorf("--name must be provided")
+		}
+
+		details, err := containerzClient.InspectVolume(command.Context(), name)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+		defer writer.Flush()
+		fmt.Fprintf(writer, "Name:\t%s\n", details.Name)
+		fmt.Fprintf(writer, "Driver:\t%s\n", details.Driver)
+		fmt.Fprintf(writer, "Options:\t%v\n", details.Options)
+		fmt.Fprintf(writer, "Labels:\t%v\n", details.Labels)
+		fmt.Fprintf(writer, "Creation Time:\t%s\n", details.Created.Format(time.RFC822))
+		fmt.Fprintf(writer, "Usage:\t%s\n", volumeUsage(details.Usage))
+		quota := details.Labels[containers.VolumeQuotaLabel]
+		if quota == "" {
+			quota = "none"
+		}
+		fmt.Fprintf(writer, "Quota:\t%s\n", quota)
+		users := "none"
+		if len(details.Containers) > 0 {
+			users = strings.Join(details.Containers, ", ")
+		}
+		fmt.Fprintf(writer, "Containers:\t%s\n", users)
+		return nil
+	},
+}
+
+func init() {
+	volumesCmd.AddCommand(volInspectCmd)
+
+	volInspectCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the volume to inspect.")
+}
@@ -20,6 +20,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
 
@@ -33,19 +34,27 @@ var volListCmd = &cobra.Command{
 		}
 
 		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-		fmt.Fprint(writer, "Name\tDriver\tOptions\tLabels\tCreation Time\n")
+		fmt.Fprint(writer, "Name\tDriver\tUsage\tOptions\tLabels\tCreation Time\n")
 		defer writer.Flush()
 		for info := range ch {
 			if info.Error != nil {
 				return info.Error
 			}
-			fmt.Fprintf(writer, "%s\t%s\t%v\t%v\t%s\n", info.Name, info.Driver, info.Options, info.Labels, info.CreationTime.Format(time.RFC822))
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%v\t%v\t%s\n", info.Name, info.Driver, volumeUsage(info.Usage), info.Options, info.Labels, info.CreationTime.Format(time.RFC822))
 		}
 
 		return nil
 	},
 }
 
+// volumeUsage returns the disk usage of a volume in a human readable form.
+func volumeUsage(usage int64) string {
+	if usage < 0 {
+		return "n/a"
+	}
+	return units.BytesSize(float64(usage))
+}
+
 func init() {
 	volumesCmd.AddCommand(volListCmd)
 }
@@ -0,0 +1,57 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var volRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the contents of a volume from a local archive",
+	Long:  "Restore the contents of a volume, creating it if needed, from a gzip compressed tar archive such as one saved by 'volume backup'. Files of the volume that are not in the archive are left in place.",
+	RunE: func(command *cobra.Command, args []string) error {
+		if name == "" {
+			return fmt.Errorf("--name must be provided")
+		}
+		if file == "" {
+			return fmt.Errorf("--file cannot be empty")
+		}
+
+		s := spinner.New(spinner.CharSets[69], 100*time.Millisecond)
+		s.Prefix = fmt.Sprintf("Restoring volume %s ", name)
+		s.Start()
+		size, err := containerzClient.RestoreVolume(command.Context(), name, file)
+		s.Stop()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Restored volume %s from %s (%s)\n", name, file, units.BytesSize(float64(size)))
+		return nil
+	},
+}
+
+func init() {
+	volumesCmd.AddCommand(volRestoreCmd)
+
+	volRestoreCmd.PersistentFlags().StringVar(&name, "name", "", "Name of the volume to restore.")
+	volRestoreCmd.PersistentFlags().StringVar(&file, "file", "", "Archive to restore the volume from.")
+}
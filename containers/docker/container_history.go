@@ -0,0 +1,244 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// maxRevisions caps the number of revisions kept per instance.
+const maxRevisions = 10
+
+// revision is a version of an instance along with everything needed to recreate it.
+type revision struct {
+	options.Revision
+
+	config           *container.Config
+	hostConfig       *container.HostConfig
+	networkingConfig *network.NetworkingConfig
+	connects         map[string]*network.EndpointSettings
+}
+
+// storedRevision is a revision as persisted to the history location.
+type storedRevision struct {
+	options.Revision
+
+	Config           *container.Config                    `json:"config,omitempty"`
+	HostConfig       *container.HostConfig                `json:"host_config,omitempty"`
+	NetworkingConfig *network.NetworkingConfig            `json:"networking_config,omitempty"`
+	Connects         map[string]*network.EndpointSettings `json:"connects,omitempty"`
+}
+
+// ContainerHistory returns the revisions of an instance, oldest first. The last revision is the one
+// currently deployed.
+func (m *Manager) ContainerHistory(ctx context.Context, instance string, opts ...options.Option) ([]options.Revision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revs := m.revisions(instance)
+	if len(revs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no revisions of instance %s are known", instance)
+	}
+
+	res := make([]options.Revision, 0, len(revs))
+	for _, rev := range revs {
+		res = append(res, rev.Revision)
+	}
+	return res, nil
+}
+
+// record appends the revision to the history of the instance, numbering it after the last one.
+func (m *Manager) record(instance string, rev *revision) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revs := m.revisions(instance)
+	rev.Number = 1
+	if len(revs) > 0 {
+		rev.Number = revs[len(revs)-1].Number + 1
+	}
+	revs = append(revs, rev)
+	if len(revs) > maxRevisions {
+		revs = revs[len(revs)-maxRevisions:]
+	}
+	m.history[instance] = revs
+	m.persist(instance)
+}
+
+// amend applies the change to the host configuration of the current revision of the instance, if
+// any, so that recreating the revision preserves changes made in place.
+func (m *Manager) amend(instance string, change func(*container.HostConfig)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revs := m.revisions(instance)
+	if len(revs) == 0 {
+		return
+	}
+	// The host configuration may be shared with earlier revisions restored from this one.
+	rev := revs[len(revs)-1]
+	hostConfig := container.HostConfig{}
+	if rev.hostConfig != nil {
+		hostConfig = *rev.hostConfig
+	}
+	change(&hostConfig)
+	rev.hostConfig = &hostConfig
+	m.persist(instance)
+}
+
+// forget drops the history of the instance.
+func (m *Manager) forget(instance string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.history, instance)
+	if m.historyLocation == "" {
+		return
+	}
+	if err := os.Remove(m.historyFile(instance)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		klog.Warningf("unable to remove the history of instance %s: %v", instance, err)
+	}
+}
+
+// findRevision returns the revision of the instance matching the number or name. If ref is empty,
+// the revision preceding the current one is returned.
+func (m *Manager) findRevision(instance, ref string) (*revision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revs := m.revisions(instance)
+	if ref == "" {
+		if len(revs) < 2 {
+			return nil, status.Errorf(codes.FailedPrecondition, "instance %s has no previous revision", instance)
+		}
+		return revs[len(revs)-2], nil
+	}
+
+	number, err := strconv.Atoi(ref)
+	for i := len(revs) - 1; i >= 0; i-- {
+		if (err == nil && revs[i].Number == number) || revs[i].Name == ref {
+			return revs[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "revision %s of instance %s not found", ref, instance)
+}
+
+// currentRevision returns the revision of the instance currently deployed, if any is known.
+func (m *Manager) currentRevision(instance string) (*revision, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revs := m.revisions(instance)
+	if len(revs) == 0 {
+		return nil, false
+	}
+	return revs[len(revs)-1], true
+}
+
+// revisions returns the history of the instance, reading it from the history location the first
+// time it is needed. m.mu must be held.
+func (m *Manager) revisions(instance string) []*revision {
+	if revs, ok := m.history[instance]; ok || m.historyLocation == "" {
+		return revs
+	}
+
+	var revs []*revision
+	buf, err := os.ReadFile(m.historyFile(instance))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		klog.Warningf("unable to read the history of instance %s: %v", instance, err)
+	default:
+		var stored []storedRevision
+		if err := json.Unmarshal(buf, &stored); err != nil {
+			klog.Warningf("unable to parse the history of instance %s: %v", instance, err)
+			break
+		}
+		for _, s := range stored {
+			revs = append(revs, &revision{
+				Revision:         s.Revision,
+				config:           s.Config,
+				hostConfig:       s.HostConfig,
+				networkingConfig: s.NetworkingConfig,
+				connects:         s.Connects,
+			})
+		}
+	}
+	m.history[instance] = revs
+	return revs
+}
+
+// persist writes the history of the instance to the history location, if any. The history is
+// still kept in memory if it cannot be written. m.mu must be held.
+func (m *Manager) persist(instance string) {
+	if m.historyLocation == "" {
+		return
+	}
+
+	stored := make([]storedRevision, 0, len(m.history[instance]))
+	for _, rev := range m.history[instance] {
+		stored = append(stored, storedRevision{
+			Revision:         rev.Revision,
+			Config:           rev.config,
+			HostConfig:       rev.hostConfig,
+			NetworkingConfig: rev.networkingConfig,
+			Connects:         rev.connects,
+		})
+	}
+	if err := writeJSON(m.historyFile(instance), stored); err != nil {
+		klog.Warningf("unable to persist the history of instance %s: %v", instance, err)
+	}
+}
+
+// historyFile returns the file the history of the instance is persisted to.
+func (m *Manager) historyFile(instance string) string {
+	return filepath.Join(m.historyLocation, instance+".json")
+}
+
+// writeJSON atomically replaces the file with the JSON encoding of v.
+func writeJSON(path string, v any) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
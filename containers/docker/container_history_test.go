@@ -0,0 +1,148 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestContainerHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		inRecord int
+		want     []options.Revision
+		wantErr  error
+	}{
+		{
+			name:     "single-revision",
+			inRecord: 1,
+			want:     []options.Revision{{Number: 1, Name: "r1"}},
+		},
+		{
+			name:     "multiple-revisions",
+			inRecord: 3,
+			want: []options.Revision{
+				{Number: 1, Name: "r1"},
+				{Number: 2, Name: "r2"},
+				{Number: 3, Name: "r3"},
+			},
+		},
+		{
+			name:     "oldest-revisions-dropped",
+			inRecord: maxRevisions + 2,
+			want: func() []options.Revision {
+				var revs []options.Revision
+				for i := 3; i <= maxRevisions+2; i++ {
+					revs = append(revs, options.Revision{Number: i, Name: fmt.Sprintf("r%d", i)})
+				}
+				return revs
+			}(),
+		},
+		{
+			name:    "no-revisions",
+			wantErr: status.Errorf(codes.NotFound, "no revisions of instance %s are known", "app"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mgr := New(&fakeDocker{})
+			for i := 1; i <= tc.inRecord; i++ {
+				mgr.record("app", &revision{Revision: options.Revision{Name: fmt.Sprintf("r%d", i)}})
+			}
+
+			got, err := mgr.ContainerHistory(context.Background(), "app")
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerHistory() returned unexpected error (-want, +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ContainerHistory() returned diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContainerStartRecordsRevision(t *testing.T) {
+	fbd := &fakeBlueGreenDocker{}
+	mgr := New(fbd)
+
+	if _, err := mgr.ContainerStart(context.Background(), "my-image", "v2", "", options.WithInstanceName("app"), options.WithRevisionName("first")); err != nil {
+		t.Fatalf("ContainerStart() returned error: %v", err)
+	}
+
+	got, err := mgr.ContainerHistory(context.Background(), "app")
+	if err != nil {
+		t.Fatalf("ContainerHistory() returned error: %v", err)
+	}
+	want := []options.Revision{{Number: 1, Name: "first", Image: "my-image", Tag: "v2"}}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(options.Revision{}, "Created")); diff != "" {
+		t.Errorf("ContainerHistory() returned diff(-want, +got):\n%s", diff)
+	}
+
+	if err := mgr.ContainerRemove(context.Background(), "app", options.Force()); err != nil {
+		t.Fatalf("ContainerRemove() returned error: %v", err)
+	}
+	if _, err := mgr.ContainerHistory(context.Background(), "app"); status.Code(err) != codes.NotFound {
+		t.Errorf("ContainerHistory() after removal returned %v, want NotFound", err)
+	}
+}
+
+func TestContainerHistoryPersisted(t *testing.T) {
+	dir := t.TempDir()
+	mgr := New(&fakeDocker{}, WithHistoryLocation(dir))
+	mgr.record("app", &revision{
+		Revision:   options.Revision{Name: "r1", Image: "my-image", Tag: "v1"},
+		config:     &container.Config{Image: "my-image:v1"},
+		hostConfig: &container.HostConfig{NetworkMode: "host"},
+	})
+	mgr.record("app", &revision{Revision: options.Revision{Name: "r2", Image: "my-image", Tag: "v2"}})
+
+	// A manager started afterwards, e.g. once containerz restarted, knows the revisions.
+	restarted := New(&fakeDocker{}, WithHistoryLocation(dir))
+	got, err := restarted.ContainerHistory(context.Background(), "app")
+	if err != nil {
+		t.Fatalf("ContainerHistory() returned error: %v", err)
+	}
+	want := []options.Revision{
+		{Number: 1, Name: "r1", Image: "my-image", Tag: "v1"},
+		{Number: 2, Name: "r2", Image: "my-image", Tag: "v2"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(options.Revision{}, "Created")); diff != "" {
+		t.Errorf("ContainerHistory() returned diff(-want, +got):\n%s", diff)
+	}
+
+	rev, err := restarted.findRevision("app", "r1")
+	if err != nil {
+		t.Fatalf("findRevision(%q) returned error: %v", "r1", err)
+	}
+	if rev.config.Image != "my-image:v1" || rev.hostConfig.NetworkMode != "host" {
+		t.Errorf("findRevision(%q) returned config %+v and host config %+v, want those recorded", "r1", rev.config, rev.hostConfig)
+	}
+
+	restarted.forget("app")
+	if _, err := New(&fakeDocker{}, WithHistoryLocation(dir)).ContainerHistory(context.Background(), "app"); status.Code(err) != codes.NotFound {
+		t.Errorf("ContainerHistory() after forget returned %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ContainerKill sends a signal to the main process of a running or paused container. The signal
+// may be given by name, with or without the SIG prefix, or by number. If no signal is provided,
+// SIGKILL is sent.
+func (m *Manager) ContainerKill(ctx context.Context, instance, signal string, opts ...options.Option) error {
+	sig, err := normalizeSignal(signal)
+	if err != nil {
+		return err
+	}
+
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	cnt, err := findInstance(instance, cnts)
+	if err != nil {
+		return err
+	}
+	if cnt.State != container.StateRunning && cnt.State != container.StatePaused {
+		return status.Errorf(codes.FailedPrecondition, "container %s is %s, not running", instance, cnt.State)
+	}
+
+	if err := m.client.ContainerKill(ctx, cnt.ID, sig); err != nil {
+		return status.Errorf(codes.Unknown, "failed to signal container %s with error %s", instance, err)
+	}
+
+	return nil
+}
+
+// normalizeSignal returns the canonical name of the signal, e.g. SIGHUP for hup.
+func normalizeSignal(signal string) (string, error) {
+	if signal == "" {
+		return "SIGKILL", nil
+	}
+	if num, err := strconv.Atoi(signal); err == nil {
+		if name := unix.SignalName(unix.Signal(num)); name != "" {
+			return name, nil
+		}
+		return "", status.Errorf(codes.InvalidArgument, "unknown signal %q", signal)
+	}
+
+	name := strings.ToUpper(signal)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if unix.SignalNum(name) == 0 {
+		return "", status.Errorf(codes.InvalidArgument, "unknown signal %q", signal)
+	}
+	return name, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeKillingDocker struct {
+	fakeDocker
+	cnts []types.Container
+
+	ID     string
+	Signal string
+}
+
+func (f *fakeKillingDocker) ContainerKill(_ context.Context, container, signal string) error {
+	f.ID = container
+	f.Signal = signal
+	return nil
+}
+
+func (f fakeKillingDocker) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
+	return f.cnts, nil
+}
+
+func TestContainerKill(t *testing.T) {
+	cnts := []types.Container{
+		{
+			ID:    "running-id",
+			Names: []string{"/running"},
+			State: container.StateRunning,
+		},
+		{
+			ID:    "exited-id",
+			Names: []string{"/exited"},
+			State: container.StateExited,
+		},
+	}
+	tests := []struct {
+		name       string
+		inInstance string
+		inSignal   string
+		wantState  *fakeKillingDocker
+		wantErr    error
+	}{
+		{
+			name:       "default-signal",
+			inInstance: "running",
+			wantState:  &fakeKillingDocker{ID: "running-id", Signal: "SIGKILL"},
+		},
+		{
+			name:       "signal-by-short-name",
+			inInstance: "running",
+			inSignal:   "hup",
+			wantState:  &fakeKillingDocker{ID: "running-id", Signal: "SIGHUP"},
+		},
+		{
+			name:       "signal-by-number",
+			inInstance: "running",
+			inSignal:   "15",
+			wantState:  &fakeKillingDocker{ID: "running-id", Signal: "SIGTERM"},
+		},
+		{
+			name:       "unknown-signal",
+			inInstance: "running",
+			inSignal:   "SIGFOO",
+			wantErr:    status.Errorf(codes.InvalidArgument, "unknown signal %q", "SIGFOO"),
+		},
+		{
+			name:       "not-running",
+			inInstance: "exited",
+			inSignal:   "SIGHUP",
+			wantErr:    status.Errorf(codes.FailedPrecondition, "container %s is %s, not running", "exited", "exited"),
+		},
+		{
+			name:       "no-such-instance",
+			inInstance: "no-such-instance",
+			wantErr:    status.Errorf(codes.NotFound, "container %s was not found", "no-such-instance"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fkd := &fakeKillingDocker{cnts: cnts}
+			mgr := New(fkd)
+
+			err := mgr.ContainerKill(context.Background(), tc.inInstance, tc.inSignal)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerKill(%q, %q) returned unexpected error (-want, +got):\n%s", tc.inInstance, tc.inSignal, diff)
+			}
+
+			if tc.wantState != nil {
+				if diff := cmp.Diff(tc.wantState, fkd, cmpopts.IgnoreUnexported(fakeKillingDocker{})); diff != "" {
+					t.Errorf("ContainerKill(%q, %q) returned diff(-want, +got):\n%s", tc.inInstance, tc.inSignal, diff)
+				}
+			}
+		})
+	}
+}
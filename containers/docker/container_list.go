@@ -3,14 +3,20 @@ package docker
 import (
 	"context"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"k8s.io/klog/v2"
 )
 
+// maxRestartCountInspects bounds the number of containers ContainerList inspects to report their
+// restart count, which the container summaries lack.
+const maxRestartCountInspects = 32
+
 // ContainerList lists the containers present on the target.
 func (m *Manager) ContainerList(ctx context.Context, all bool, limit int32, srv options.ListContainerStreamer, opts ...options.Option) error {
 	optionz := options.ApplyOptions(opts...)
@@ -20,6 +26,22 @@ func (m *Manager) ContainerList(ctx context.Context, all bool, limit int32, srv
 	kvPairs := []filters.KeyValuePair{}
 	for key, values := range optionz.Filter {
 		for _, value := range values {
+			// Application groups are tracked with a label.
+			if key == options.Application {
+				kvPairs = append(kvPairs, filters.KeyValuePair{Key: "label", Value: options.GroupLabel + "=" + value})
+				continue
+			}
+			// Docker filters on the runtime state with the status key.
+			if key == options.State {
+				states, err := stateFilter(value)
+				if err != nil {
+					return err
+				}
+				for _, state := range states {
+					kvPairs = append(kvPairs, filters.KeyValuePair{Key: "status", Value: state})
+				}
+				continue
+			}
 			kvPairs = append(kvPairs, filters.KeyValuePair{Key: string(key), Value: value})
 		}
 	}
@@ -31,13 +53,31 @@ func (m *Manager) ContainerList(ctx context.Context, all bool, limit int32, srv
 		return err
 	}
 
-	for _, cnt := range cnts {
+	for i, cnt := range cnts {
+		labels := make(map[string]string, len(cnt.Labels)+4)
+		for key, value := range cnt.Labels {
+			labels[key] = value
+		}
+		if cnt.State != "" {
+			labels[options.StateLabel] = cnt.State
+		}
+		if code, ok := statusToExitCode(cnt.State, cnt.Status); ok {
+			labels[options.ExitCodeLabel] = strconv.Itoa(code)
+		}
+		if health := statusToHealth(cnt.Status); health != "" {
+			labels[options.HealthLabel] = health
+		}
+		if i < maxRestartCountInspects {
+			m.restartCountLabel(ctx, cnt.ID, labels)
+		}
+
 		if err := srv.Send(&cpb.ListContainerResponse{
 			Id: cnt.ID,
 			// TODO(alshabib): make Name a repeated field.
 			Name:      strings.Join(cnt.Names, ","),
 			ImageName: cnt.Image,
-			Status:    stringToStatus(cnt.Status),
+			Status:    stateToStatus(cnt.State),
+			Labels:    labels,
 		}); err != nil {
 			if err == io.EOF {
 				return nil
@@ -49,13 +89,49 @@ func (m *Manager) ContainerList(ctx context.Context, all bool, limit int32, srv
 	return nil
 }
 
-func stringToStatus(state string) cpb.ListContainerResponse_Status {
+// restartCountLabel adds the number of times the container has been restarted by its restart
+// policy to the labels reported by ListContainer. It is only available from the container details.
+func (m *Manager) restartCountLabel(ctx context.Context, id string, labels map[string]string) {
+	info, err := m.client.ContainerInspect(ctx, id)
+	if err != nil || info.ContainerJSONBase == nil {
+		klog.Warningf("unable to inspect container %s: %v", id, err)
+		return
+	}
+	labels[options.RestartCountLabel] = strconv.Itoa(info.RestartCount)
+}
+
+// statusToExitCode returns the exit code of the last run of an exited or restarting container,
+// reported in its status, e.g. "Exited (137) 5 minutes ago".
+func statusToExitCode(state container.ContainerState, status string) (int, bool) {
+	if state != container.StateExited && state != container.StateRestarting {
+		return 0, false
+	}
+	_, rest, ok := strings.Cut(status, "(")
+	if !ok {
+		return 0, false
+	}
+	code, _, ok := strings.Cut(rest, ")")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// statusToHealth returns the health status, starting, healthy or unhealthy, reported in the status
+// of a container, e.g. "Up 5 minutes (healthy)". It is empty if the container has no health check.
+func statusToHealth(state string) string {
 	switch {
-	case strings.Contains(state, "Up"):
-		return cpb.ListContainerResponse_RUNNING
-	case strings.Contains(state, "Exited"):
-		return cpb.ListContainerResponse_STOPPED
+	case strings.Contains(state, "(health: starting)"):
+		return string(container.Starting)
+	case strings.Contains(state, "(unhealthy)"):
+		return string(container.Unhealthy)
+	case strings.Contains(state, "(healthy)"):
+		return string(container.Healthy)
 	default:
-		return cpb.ListContainerResponse_UNSPECIFIED
+		return ""
 	}
 }
@@ -2,16 +2,19 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/api/types"
-	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 type fakeListContainerStreamer struct {
@@ -25,7 +28,8 @@ func (f *fakeListContainerStreamer) Send(msg *cpb.ListContainerResponse) error {
 
 type fakeListingDocker struct {
 	fakeDocker
-	cnts []types.Container
+	cnts    []types.Container
+	inspect map[string]container.InspectResponse
 
 	Opts container.ListOptions
 }
@@ -36,11 +40,20 @@ func (f *fakeListingDocker) ContainerList(ctx context.Context, options container
 	return f.cnts, nil
 }
 
+func (f *fakeListingDocker) ContainerInspect(ctx context.Context, cnt string) (container.InspectResponse, error) {
+	info, ok := f.inspect[cnt]
+	if !ok {
+		return container.InspectResponse{}, fmt.Errorf("no such container: %s", cnt)
+	}
+	return info, nil
+}
+
 func TestContainerList(t *testing.T) {
 	tests := []struct {
 		name      string
 		inOpts    []options.Option
 		inCnts    []types.Container
+		inInspect map[string]container.InspectResponse
 		inAll     bool
 		inLimit   int32
 		wantState *fakeListingDocker
@@ -92,6 +105,135 @@ func TestContainerList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "containers-with-health",
+			inAll:   true,
+			inLimit: 10,
+			inOpts: []options.Option{options.WithFilter(map[options.FilterKey][]string{
+				options.Health: []string{"unhealthy"},
+			})},
+			wantState: &fakeListingDocker{
+				Opts: container.ListOptions{
+					Limit:   10,
+					All:     true,
+					Filters: filters.NewArgs(filters.Arg("health", "unhealthy")),
+				},
+			},
+			inCnts: []types.Container{
+				types.Container{
+					ID:     "some-id",
+					Image:  "some-image",
+					Names:  []string{"some-name"},
+					State:  container.StateRunning,
+					Status: "Up 5 minutes (unhealthy)",
+					Labels: map[string]string{"app": "bgp"},
+				},
+			},
+			wantMsgs: []*cpb.ListContainerResponse{
+				&cpb.ListContainerResponse{
+					Id:        "some-id",
+					Name:      "some-name",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_RUNNING,
+					Labels: map[string]string{
+						"app":               "bgp",
+						options.StateLabel:  "running",
+						options.HealthLabel: "unhealthy",
+					},
+				},
+			},
+		},
+		{
+			name:    "containers-with-state",
+			inAll:   true,
+			inLimit: 10,
+			wantState: &fakeListingDocker{
+				Opts: container.ListOptions{
+					Limit: 10,
+					All:   true,
+				},
+			},
+			inCnts: []types.Container{
+				types.Container{
+					ID:    "created-id",
+					Image: "some-image",
+					Names: []string{"created"},
+					State: container.StateCreated,
+				},
+				types.Container{
+					ID:    "paused-id",
+					Image: "some-image",
+					Names: []string{"paused"},
+					State: container.StatePaused,
+				},
+				types.Container{
+					ID:     "restarting-id",
+					Image:  "some-image",
+					Names:  []string{"restarting"},
+					State:  container.StateRestarting,
+					Status: "Restarting (1) 2 seconds ago",
+				},
+				types.Container{
+					ID:     "exited-id",
+					Image:  "some-image",
+					Names:  []string{"exited"},
+					State:  container.StateExited,
+					Status: "Exited (137) 5 minutes ago",
+				},
+			},
+			inInspect: map[string]container.InspectResponse{
+				"paused-id": container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{},
+				},
+				"restarting-id": container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{RestartCount: 5},
+				},
+				"exited-id": container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{RestartCount: 3},
+				},
+			},
+			wantMsgs: []*cpb.ListContainerResponse{
+				&cpb.ListContainerResponse{
+					Id:        "created-id",
+					Name:      "created",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_PRESENT,
+					Labels:    map[string]string{options.StateLabel: "created"},
+				},
+				&cpb.ListContainerResponse{
+					Id:        "paused-id",
+					Name:      "paused",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_RUNNING,
+					Labels: map[string]string{
+						options.StateLabel:        "paused",
+						options.RestartCountLabel: "0",
+					},
+				},
+				&cpb.ListContainerResponse{
+					Id:        "restarting-id",
+					Name:      "restarting",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_RUNNING,
+					Labels: map[string]string{
+						options.StateLabel:        "restarting",
+						options.ExitCodeLabel:     "1",
+						options.RestartCountLabel: "5",
+					},
+				},
+				&cpb.ListContainerResponse{
+					Id:        "exited-id",
+					Name:      "exited",
+					ImageName: "some-image",
+					Status:    cpb.ListContainerResponse_STOPPED,
+					Labels: map[string]string{
+						options.StateLabel:        "exited",
+						options.ExitCodeLabel:     "137",
+						options.RestartCountLabel: "3",
+					},
+				},
+			},
+		},
 		{
 			name:    "filter",
 			inAll:   true,
@@ -100,11 +242,31 @@ func TestContainerList(t *testing.T) {
 				options.Image: []string{"some-image"},
 				options.State: []string{"RUNNING"},
 			})},
+			wantState: &fakeListingDocker{
+				Opts: container.ListOptions{
+					Limit: 10,
+					All:   true,
+					Filters: filters.NewArgs(
+						filters.Arg("image", "some-image"),
+						filters.Arg("status", "running"),
+						filters.Arg("status", "paused"),
+						filters.Arg("status", "restarting"),
+					),
+				},
+			},
+		},
+		{
+			name:    "filter-runtime-state",
+			inAll:   true,
+			inLimit: 10,
+			inOpts: []options.Option{options.WithFilter(map[options.FilterKey][]string{
+				options.State: []string{"exited", "PRESENT"},
+			})},
 			wantState: &fakeListingDocker{
 				Opts: container.ListOptions{
 					Limit:   10,
 					All:     true,
-					Filters: filters.NewArgs(filters.Arg("image", "some-image"), filters.Arg("state", "RUNNING")),
+					Filters: filters.NewArgs(filters.Arg("status", "exited"), filters.Arg("status", "created")),
 				},
 			},
 		},
@@ -114,7 +276,8 @@ func TestContainerList(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			fsd := &fakeListingDocker{
-				cnts: tc.inCnts,
+				cnts:    tc.inCnts,
+				inspect: tc.inInspect,
 			}
 			mgr := New(fsd)
 
@@ -136,3 +299,103 @@ func TestContainerList(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerListApplicationFilter(t *testing.T) {
+	fsd := &fakeListingDocker{}
+	mgr := New(fsd)
+
+	opts := []options.Option{options.WithFilter(map[options.FilterKey][]string{
+		options.Application: []string{"bgp"},
+	})}
+	if err := mgr.ContainerList(context.Background(), true, -1, &fakeListContainerStreamer{}, opts...); err != nil {
+		t.Fatalf("ContainerList(%+v) returned error: %v", opts, err)
+	}
+
+	want := []string{options.GroupLabel + "=bgp"}
+	if diff := cmp.Diff(want, fsd.Opts.Filters.Get("label")); diff != "" {
+		t.Errorf("ContainerList(%+v) returned diff in label filter (-want, +got):\n%s", opts, diff)
+	}
+	if got := fsd.Opts.Filters.Get(string(options.Application)); len(got) != 0 {
+		t.Errorf("ContainerList(%+v) passed application filter %v to docker", opts, got)
+	}
+}
+
+func TestContainerListInvalidState(t *testing.T) {
+	mgr := New(&fakeListingDocker{})
+
+	opts := []options.Option{options.WithFilter(map[options.FilterKey][]string{
+		options.State: []string{"sleeping"},
+	})}
+	want := status.Errorf(codes.InvalidArgument, "invalid state filter %q", "sleeping")
+	err := mgr.ContainerList(context.Background(), true, -1, &fakeListContainerStreamer{}, opts...)
+	if diff := cmp.Diff(want, err, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("ContainerList(%+v) returned diff in error (-want, +got):\n%s", opts, diff)
+	}
+}
+
+func TestStatusToHealth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Up 5 minutes (healthy)", want: "healthy"},
+		{in: "Up 5 minutes (unhealthy)", want: "unhealthy"},
+		{in: "Up 3 seconds (health: starting)", want: "starting"},
+		{in: "Up 5 minutes", want: ""},
+		{in: "Exited (0) 2 hours ago", want: ""},
+	}
+
+	for _, tc := range tests {
+		if got := statusToHealth(tc.in); got != tc.want {
+			t.Errorf("statusToHealth(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestContainerListBoundsInspects(t *testing.T) {
+	fsd := &fakeListingDocker{inspect: map[string]container.InspectResponse{}}
+	for i := 0; i < maxRestartCountInspects+1; i++ {
+		id := fmt.Sprintf("id-%d", i)
+		fsd.cnts = append(fsd.cnts, types.Container{ID: id, Names: []string{id}, State: container.StateRunning})
+		fsd.inspect[id] = container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{RestartCount: 1}}
+	}
+	mgr := New(fsd)
+
+	stream := &fakeListContainerStreamer{}
+	if err := mgr.ContainerList(context.Background(), true, -1, stream); err != nil {
+		t.Fatalf("ContainerList() returned error: %v", err)
+	}
+
+	if got, want := len(stream.msgs), maxRestartCountInspects+1; got != want {
+		t.Fatalf("ContainerList() returned %d containers, want %d", got, want)
+	}
+	if _, ok := stream.msgs[maxRestartCountInspects-1].GetLabels()[options.RestartCountLabel]; !ok {
+		t.Errorf("ContainerList() did not report the restart count of container %d", maxRestartCountInspects-1)
+	}
+	if _, ok := stream.msgs[maxRestartCountInspects].GetLabels()[options.RestartCountLabel]; ok {
+		t.Errorf("ContainerList() inspected more than %d containers", maxRestartCountInspects)
+	}
+}
+
+func TestStatusToExitCode(t *testing.T) {
+	tests := []struct {
+		inState  container.ContainerState
+		inStatus string
+		want     int
+		wantOK   bool
+	}{
+		{inState: container.StateExited, inStatus: "Exited (137) 5 minutes ago", want: 137, wantOK: true},
+		{inState: container.StateExited, inStatus: "Exited (0) 2 hours ago", want: 0, wantOK: true},
+		{inState: container.StateRestarting, inStatus: "Restarting (1) 2 seconds ago", want: 1, wantOK: true},
+		{inState: container.StateRunning, inStatus: "Up 5 minutes (healthy)"},
+		{inState: container.StateDead, inStatus: "Dead"},
+		{inState: container.StateExited, inStatus: "Exited"},
+	}
+
+	for _, tc := range tests {
+		got, ok := statusToExitCode(tc.inState, tc.inStatus)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("statusToExitCode(%q, %q) = %d, %t, want %d, %t", tc.inState, tc.inStatus, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
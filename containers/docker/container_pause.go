@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ContainerPause suspends all processes of a running container.
+func (m *Manager) ContainerPause(ctx context.Context, instance string, opts ...options.Option) error {
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	cnt, err := findInstance(instance, cnts)
+	if err != nil {
+		return err
+	}
+	if cnt.State != container.StateRunning {
+		return status.Errorf(codes.FailedPrecondition, "container %s is %s, not running", instance, cnt.State)
+	}
+
+	if err := m.client.ContainerPause(ctx, cnt.ID); err != nil {
+		return status.Errorf(codes.Unknown, "failed to pause container %s with error %s", instance, err)
+	}
+
+	return nil
+}
+
+// ContainerUnpause resumes all processes of a paused container.
+func (m *Manager) ContainerUnpause(ctx context.Context, instance string, opts ...options.Option) error {
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	cnt, err := findInstance(instance, cnts)
+	if err != nil {
+		return err
+	}
+	if cnt.State != container.StatePaused {
+		return status.Errorf(codes.FailedPrecondition, "container %s is %s, not paused", instance, cnt.State)
+	}
+
+	if err := m.client.ContainerUnpause(ctx, cnt.ID); err != nil {
+		return status.Errorf(codes.Unknown, "failed to unpause container %s with error %s", instance, err)
+	}
+
+	return nil
+}
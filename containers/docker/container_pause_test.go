@@ -0,0 +1,143 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakePausingDocker struct {
+	fakeDocker
+	cnts []types.Container
+
+	Paused   string
+	Unpaused string
+}
+
+func (f *fakePausingDocker) ContainerPause(_ context.Context, container string) error {
+	f.Paused = container
+	return nil
+}
+
+func (f *fakePausingDocker) ContainerUnpause(_ context.Context, container string) error {
+	f.Unpaused = container
+	return nil
+}
+
+func (f fakePausingDocker) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
+	return f.cnts, nil
+}
+
+var pausingCnts = []types.Container{
+	{
+		ID:    "running-id",
+		Names: []string{"/running"},
+		State: container.StateRunning,
+	},
+	{
+		ID:    "paused-id",
+		Names: []string{"/paused"},
+		State: container.StatePaused,
+	},
+}
+
+func TestContainerPause(t *testing.T) {
+	tests := []struct {
+		name       string
+		inInstance string
+		wantState  *fakePausingDocker
+		wantErr    error
+	}{
+		{
+			name:       "running",
+			inInstance: "running",
+			wantState:  &fakePausingDocker{Paused: "running-id"},
+		},
+		{
+			name:       "already-paused",
+			inInstance: "paused",
+			wantErr:    status.Errorf(codes.FailedPrecondition, "container %s is %s, not running", "paused", "paused"),
+		},
+		{
+			name:       "no-such-instance",
+			inInstance: "no-such-instance",
+			wantErr:    status.Errorf(codes.NotFound, "container %s was not found", "no-such-instance"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fpd := &fakePausingDocker{cnts: pausingCnts}
+			mgr := New(fpd)
+
+			err := mgr.ContainerPause(context.Background(), tc.inInstance)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerPause(%q) returned unexpected error (-want, +got):\n%s", tc.inInstance, diff)
+			}
+
+			if tc.wantState != nil {
+				if diff := cmp.Diff(tc.wantState, fpd, cmpopts.IgnoreUnexported(fakePausingDocker{})); diff != "" {
+					t.Errorf("ContainerPause(%q) returned diff(-want, +got):\n%s", tc.inInstance, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestContainerUnpause(t *testing.T) {
+	tests := []struct {
+		name       string
+		inInstance string
+		wantState  *fakePausingDocker
+		wantErr    error
+	}{
+		{
+			name:       "paused",
+			inInstance: "paused",
+			wantState:  &fakePausingDocker{Unpaused: "paused-id"},
+		},
+		{
+			name:       "not-paused",
+			inInstance: "running",
+			wantErr:    status.Errorf(codes.FailedPrecondition, "container %s is %s, not paused", "running", "running"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fpd := &fakePausingDocker{cnts: pausingCnts}
+			mgr := New(fpd)
+
+			err := mgr.ContainerUnpause(context.Background(), tc.inInstance)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerUnpause(%q) returned unexpected error (-want, +got):\n%s", tc.inInstance, diff)
+			}
+
+			if tc.wantState != nil {
+				if diff := cmp.Diff(tc.wantState, fpd, cmpopts.IgnoreUnexported(fakePausingDocker{})); diff != "" {
+					t.Errorf("ContainerUnpause(%q) returned diff(-want, +got):\n%s", tc.inInstance, diff)
+				}
+			}
+		})
+	}
+}
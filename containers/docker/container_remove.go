@@ -26,7 +26,7 @@ func (m *Manager) ContainerRemove(ctx context.Context, cnt string, opts ...optio
 		for _, name := range c.Names {
 			strippedname := strings.Replace(name, "/", "", 1)
 			if strippedname == cnt {
-				if stringToStatus(c.Status) == cpb.ListContainerResponse_RUNNING && !optionz.Force {
+				if stateToStatus(c.State) == cpb.ListContainerResponse_RUNNING && !optionz.Force {
 					return status.Errorf(codes.FailedPrecondition, "container %s is running", cnt)
 				}
 				if err := m.client.ContainerRemove(ctx, cnt, container.RemoveOptions{
@@ -34,6 +34,7 @@ func (m *Manager) ContainerRemove(ctx context.Context, cnt string, opts ...optio
 				}); err != nil {
 					return status.Errorf(codes.Internal, "unable to remove container: %v", err)
 				}
+				m.forget(cnt)
 				return nil
 			}
 		}
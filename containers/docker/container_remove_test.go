@@ -45,13 +45,25 @@ func TestContainerRemove(t *testing.T) {
 			},
 			inCnts: []types.Container{
 				types.Container{
-					Image:  "container-running",
-					Names:  []string{"container-running"},
-					Status: "Up",
+					Image: "container-running",
+					Names: []string{"container-running"},
+					State: container.StateRunning,
 				},
 			},
 			wantErr: status.Errorf(codes.FailedPrecondition, "container container-running is running"),
 		},
+		{
+			name:  "container-paused",
+			inCnt: "container-paused",
+			inCnts: []types.Container{
+				types.Container{
+					Image: "container-paused",
+					Names: []string{"container-paused"},
+					State: container.StatePaused,
+				},
+			},
+			wantErr: status.Errorf(codes.FailedPrecondition, "container container-paused is running"),
+		},
 		{
 			name:   "container-running-with-force",
 			inCnt:  "container-running",
@@ -63,9 +75,9 @@ func TestContainerRemove(t *testing.T) {
 			},
 			inCnts: []types.Container{
 				types.Container{
-					Image:  "container-running",
-					Names:  []string{"container-running"},
-					Status: "Up",
+					Image: "container-running",
+					Names: []string{"container-running"},
+					State: container.StateRunning,
 				},
 			},
 			wantState: &fakeRemovingDocker{
@@ -82,9 +94,9 @@ func TestContainerRemove(t *testing.T) {
 			},
 			inCnts: []types.Container{
 				types.Container{
-					Image:  "container-remove",
-					Names:  []string{"container-remove"},
-					Status: "Exited",
+					Image: "container-remove",
+					Names: []string{"container-remove"},
+					State: container.StateExited,
 				},
 			},
 			wantState: &fakeRemovingDocker{
@@ -0,0 +1,60 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"math"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ContainerRestart restarts a container in place, preserving its ID and logs. The container is
+// given the Timeout option to stop before it is killed; if unset, the container's StopTimeout
+// value is used, if set, otherwise the engine default. If the Force option is set, the container
+// is killed immediately. Stopped containers are simply started.
+func (m *Manager) ContainerRestart(ctx context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	cnt, err := findInstance(instance, cnts)
+	if err != nil {
+		return err
+	}
+
+	var timeout *int
+	switch {
+	case optionz.Force:
+		seconds := 0
+		timeout = &seconds
+	case optionz.Timeout > 0:
+		seconds := int(math.Ceil(optionz.Timeout.Seconds()))
+		timeout = &seconds
+	}
+
+	if err := m.client.ContainerRestart(ctx, cnt.ID, container.StopOptions{Timeout: timeout}); err != nil {
+		return status.Errorf(codes.Unknown, "failed to restart container %s with error %s", instance, err)
+	}
+	m.unmarkStopped(cnt.ID)
+
+	return nil
+}
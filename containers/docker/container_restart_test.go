@@ -0,0 +1,112 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRestartingDocker struct {
+	fakeDocker
+	cnts []types.Container
+
+	ID      string
+	Timeout *int
+}
+
+func (f *fakeRestartingDocker) ContainerRestart(_ context.Context, container string, options container.StopOptions) error {
+	f.ID = container
+	f.Timeout = options.Timeout
+	return nil
+}
+
+func (f fakeRestartingDocker) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
+	return f.cnts, nil
+}
+
+func TestContainerRestart(t *testing.T) {
+	timeout, immediate := 5, 0
+	cnts := []types.Container{
+		{
+			ID:    "some-id",
+			Names: []string{"/some-instance"},
+			State: container.StateRunning,
+		},
+	}
+	tests := []struct {
+		name       string
+		inOpts     []options.Option
+		inInstance string
+		wantState  *fakeRestartingDocker
+		wantErr    error
+	}{
+		{
+			name:       "no-such-instance",
+			inInstance: "no-such-instance",
+			wantErr:    status.Errorf(codes.NotFound, "container %s was not found", "no-such-instance"),
+		},
+		{
+			name:       "default-timeout",
+			inInstance: "some-instance",
+			wantState:  &fakeRestartingDocker{ID: "some-id"},
+		},
+		{
+			name:       "with-timeout",
+			inInstance: "some-instance",
+			inOpts:     []options.Option{options.WithTimeout(5 * time.Second)},
+			wantState:  &fakeRestartingDocker{ID: "some-id", Timeout: &timeout},
+		},
+		{
+			name:       "with-sub-second-timeout",
+			inInstance: "some-instance",
+			inOpts:     []options.Option{options.WithTimeout(4200 * time.Millisecond)},
+			wantState:  &fakeRestartingDocker{ID: "some-id", Timeout: &timeout},
+		},
+		{
+			name:       "with-force",
+			inInstance: "some-instance",
+			inOpts:     []options.Option{options.Force(), options.WithTimeout(5 * time.Second)},
+			wantState:  &fakeRestartingDocker{ID: "some-id", Timeout: &immediate},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frd := &fakeRestartingDocker{cnts: cnts}
+			mgr := New(frd)
+
+			err := mgr.ContainerRestart(context.Background(), tc.inInstance, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerRestart(%q, %+v) returned unexpected error (-want, +got):\n%s", tc.inInstance, tc.inOpts, diff)
+			}
+
+			if tc.wantState != nil {
+				if diff := cmp.Diff(tc.wantState, frd, cmpopts.IgnoreUnexported(fakeRestartingDocker{})); diff != "" {
+					t.Errorf("ContainerRestart(%q, %+v) returned diff(-want, +got):\n%s", tc.inInstance, tc.inOpts, diff)
+				}
+			}
+		})
+	}
+}
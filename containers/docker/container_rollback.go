@@ -0,0 +1,137 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// ContainerRollback restores an earlier version of an instance. By default, the previous version
+// kept by a blue/green update is restored, or, if none is kept, the version is recreated from the
+// revision preceding the current one. The Revision option selects the revision to recreate by
+// number or name. The version being replaced is in turn kept for the KeepPrevious option's
+// duration, so a rollback can itself be rolled back. The restored version is recorded as a new
+// revision of the instance.
+func (m *Manager) ContainerRollback(ctx context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+
+	// A rollback must not race with an update of the same instance.
+	if err := m.stageContainerUpdate(instance); err != nil {
+		return err
+	}
+	defer func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.updateInProgress, instance)
+	}()
+
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	current, err := findInstance(instance, cnts)
+	if err != nil {
+		return err
+	}
+
+	// Clean up after an earlier update that was interrupted.
+	next := nextName(instance)
+	if _, err := findInstance(next, cnts); err == nil {
+		m.discard(ctx, next)
+	}
+
+	_, err = findInstance(previousName(instance), cnts)
+	retained := err == nil
+
+	var target *revision
+	if optionz.Revision == "" && retained {
+		// The revision being restored is only known if the history goes back far enough.
+		target, _ = m.findRevision(instance, "")
+		err = m.swapPrevious(ctx, instance, current.ID, optionz.KeepPrevious)
+	} else {
+		target, err = m.findRevision(instance, optionz.Revision)
+		if err != nil {
+			if optionz.Revision == "" {
+				return status.Errorf(codes.FailedPrecondition, "no previous version of instance %s is retained", instance)
+			}
+			return err
+		}
+		err = m.recreate(ctx, instance, current.ID, target, optionz.KeepPrevious)
+	}
+	if err != nil {
+		return err
+	}
+
+	if target != nil {
+		rev := *target
+		rev.Created = time.Now()
+		m.record(instance, &rev)
+	}
+	return nil
+}
+
+// swapPrevious restores the previous version of the instance kept by a blue/green update.
+func (m *Manager) swapPrevious(ctx context.Context, instance, currentID string, keep time.Duration) error {
+	// Park the previous version under the temporary name so the current version can take its place.
+	kept := m.release(instance)
+	next := nextName(instance)
+	if err := m.client.ContainerRename(ctx, previousName(instance), next); err != nil {
+		m.restoreRetained(instance, kept)
+		return status.Errorf(codes.Internal, "failed to rename container %s to %s: %v", previousName(instance), next, err)
+	}
+
+	if err := m.promote(ctx, instance, currentID, next, true, keep); err != nil {
+		if err := m.client.ContainerRename(ctx, next, previousName(instance)); err != nil {
+			klog.Warningf("unable to rename container %s back to %s: %v", next, previousName(instance), err)
+		}
+		m.restoreRetained(instance, kept)
+		return err
+	}
+
+	return nil
+}
+
+// recreate replaces the current version of the instance with a container created from the
+// revision.
+func (m *Manager) recreate(ctx context.Context, instance, currentID string, target *revision, keep time.Duration) error {
+	next := nextName(instance)
+	if _, err := m.create(ctx, next, target); err != nil {
+		return status.Errorf(codes.Internal, "failed to recreate revision %d of instance %s: %v", target.Number, instance, err)
+	}
+
+	// The version retained by an earlier update is superseded by the current one, but only removed
+	// once the current one is retired in its place.
+	unpark, err := m.parkPrevious(ctx, instance)
+	if err != nil {
+		m.discard(ctx, next)
+		return status.Errorf(codes.Internal, "failed to recreate revision %d of instance %s: %v", target.Number, instance, err)
+	}
+	if err := m.promote(ctx, instance, currentID, next, true, keep); err != nil {
+		unpark(false)
+		m.discard(ctx, next)
+		return err
+	}
+	unpark(true)
+
+	return nil
+}
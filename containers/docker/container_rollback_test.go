@@ -0,0 +1,155 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestContainerRollback(t *testing.T) {
+	tests := []struct {
+		name        string
+		inOpts      []options.Option
+		inCnts      []fakeCnt
+		inHistory   []string
+		wantCnts    []fakeCnt
+		wantRetain  bool
+		wantHistory []string
+		wantErr     error
+	}{
+		{
+			name: "rollback",
+			inCnts: []fakeCnt{
+				{ID: "old-id", Name: "app-previous"},
+				{ID: "new-id", Name: "app", Running: true},
+			},
+			wantCnts: []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+		},
+		{
+			name:   "rollback-keep-replaced",
+			inOpts: []options.Option{options.WithKeepPrevious(time.Hour)},
+			inCnts: []fakeCnt{
+				{ID: "old-id", Name: "app-previous"},
+				{ID: "new-id", Name: "app", Running: true},
+			},
+			wantCnts: []fakeCnt{
+				{ID: "old-id", Name: "app", Running: true},
+				{ID: "new-id", Name: "app-previous"},
+			},
+			wantRetain: true,
+		},
+		{
+			name: "rollback-records-revision",
+			inCnts: []fakeCnt{
+				{ID: "old-id", Name: "app-previous"},
+				{ID: "new-id", Name: "app", Running: true},
+			},
+			inHistory:   []string{"v1", "v2"},
+			wantCnts:    []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			wantHistory: []string{"v1", "v2", "v1"},
+		},
+		{
+			name:        "recreate-previous-revision",
+			inCnts:      []fakeCnt{{ID: "new-id", Name: "app", Running: true}},
+			inHistory:   []string{"v1", "v2"},
+			wantCnts:    []fakeCnt{{ID: "app-next-id", Name: "app", Running: true}},
+			wantHistory: []string{"v1", "v2", "v1"},
+		},
+		{
+			name:   "recreate-named-revision",
+			inOpts: []options.Option{options.WithRevision("v1")},
+			inCnts: []fakeCnt{
+				{ID: "old-id", Name: "app-previous"},
+				{ID: "new-id", Name: "app", Running: true},
+			},
+			inHistory:   []string{"v1", "v2", "v3"},
+			wantCnts:    []fakeCnt{{ID: "app-next-id", Name: "app", Running: true}},
+			wantHistory: []string{"v1", "v2", "v3", "v1"},
+		},
+		{
+			name:   "recreate-numbered-revision-keep-replaced",
+			inOpts: []options.Option{options.WithRevision("2"), options.WithKeepPrevious(time.Hour)},
+			inCnts: []fakeCnt{
+				{ID: "new-id", Name: "app", Running: true},
+			},
+			inHistory: []string{"v1", "v2", "v3"},
+			wantCnts: []fakeCnt{
+				{ID: "new-id", Name: "app-previous"},
+				{ID: "app-next-id", Name: "app", Running: true},
+			},
+			wantRetain:  true,
+			wantHistory: []string{"v1", "v2", "v3", "v2"},
+		},
+		{
+			name:        "unknown-revision",
+			inOpts:      []options.Option{options.WithRevision("v4")},
+			inCnts:      []fakeCnt{{ID: "new-id", Name: "app", Running: true}},
+			inHistory:   []string{"v1", "v2", "v3"},
+			wantCnts:    []fakeCnt{{ID: "new-id", Name: "app", Running: true}},
+			wantHistory: []string{"v1", "v2", "v3"},
+			wantErr:     status.Errorf(codes.NotFound, "revision %s of instance %s not found", "v4", "app"),
+		},
+		{
+			name:     "no-previous-version",
+			inCnts:   []fakeCnt{{ID: "new-id", Name: "app", Running: true}},
+			wantCnts: []fakeCnt{{ID: "new-id", Name: "app", Running: true}},
+			wantErr:  status.Errorf(codes.FailedPrecondition, "no previous version of instance %s is retained", "app"),
+		},
+		{
+			name:    "no-such-instance",
+			wantErr: status.Errorf(codes.NotFound, "container %s was not found", "app"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts}
+			mgr := New(fbd)
+			for _, name := range tc.inHistory {
+				mgr.record("app", &revision{Revision: options.Revision{Name: name}})
+			}
+
+			err := mgr.ContainerRollback(context.Background(), "app", tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerRollback(%+v) returned unexpected error (-want, +got):\n%s", tc.inOpts, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantCnts, fbd.Cnts, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ContainerRollback(%+v) returned diff(-want, +got):\n%s", tc.inOpts, diff)
+			}
+
+			if _, ok := mgr.retained["app"]; ok != tc.wantRetain {
+				t.Errorf("ContainerRollback(%+v) retained replaced version: %t, want %t", tc.inOpts, ok, tc.wantRetain)
+			}
+			mgr.release("app")
+
+			var history []string
+			for _, rev := range mgr.history["app"] {
+				history = append(history, rev.Name)
+			}
+			if diff := cmp.Diff(tc.wantHistory, history, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ContainerRollback(%+v) returned history diff(-want, +got):\n%s", tc.inOpts, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// ContainerSetResources changes the CPU, memory, cpuset, block IO weight and pids limits and the
+// restart policy of an instance in place, without restarting it. Unset limits are left unchanged.
+// The new memory and pids limits of a running instance must not be below its current usage. The
+// change is recorded in the current revision of the instance, so that it is preserved if the
+// revision is recreated.
+func (m *Manager) ContainerSetResources(ctx context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+
+	switch res := optionz.Resources; {
+	case len(res.Ulimits) > 0:
+		return status.Errorf(codes.InvalidArgument, "ulimits of instance %s cannot be changed in place", instance)
+	case len(res.DeviceReadBPS) > 0 || len(res.DeviceWriteBPS) > 0 || len(res.DeviceReadIOPS) > 0 || len(res.DeviceWriteIOPS) > 0:
+		return status.Errorf(codes.InvalidArgument, "device limits of instance %s cannot be changed in place", instance)
+	case res.OOMScoreAdj != 0:
+		return status.Errorf(codes.InvalidArgument, "oom score adjustment of instance %s cannot be changed in place", instance)
+	}
+
+	// A change must not race with an update of the same instance.
+	if err := m.stageContainerUpdate(instance); err != nil {
+		return err
+	}
+	defer func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.updateInProgress, instance)
+	}()
+
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+	cntJSON, err := m.jsonState(ctx, instance, cnts)
+	if err != nil {
+		return err
+	}
+
+	// The swap limit is checked against the current memory limit unless that is changed too.
+	hardMemory := optionz.HardMemory
+	if hardMemory == 0 && cntJSON.HostConfig != nil {
+		hardMemory = cntJSON.HostConfig.Memory
+	}
+	resources, err := resourcesConfig(optionz.CPU, optionz.SoftMemory, hardMemory, optionz.Resources)
+	if err != nil {
+		return err
+	}
+	// Zero values leave the current limits unchanged.
+	resources.Memory = optionz.HardMemory
+
+	update := container.UpdateConfig{Resources: resources}
+	if optionz.RestartPolicy != nil {
+		// The restart policy of an instance with dependencies is held by a label (see
+		// RestartPolicyLabel), which cannot be changed on an existing container.
+		if cntJSON.Config != nil && cntJSON.Config.Labels[options.DependsOnLabel] != "" {
+			return status.Errorf(codes.FailedPrecondition, "restart policy of instance %s cannot be changed in place as it has dependencies", instance)
+		}
+		if update.RestartPolicy, err = restartPolicy(optionz.RestartPolicy); err != nil {
+			return err
+		}
+	}
+
+	if cntJSON.State != nil && cntJSON.State.Running {
+		if err := m.checkUsage(ctx, cntJSON.ID, instance, resources); err != nil {
+			return err
+		}
+	}
+
+	resp, err := m.client.ContainerUpdate(ctx, cntJSON.ID, update)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update resources of instance %s: %v", instance, err)
+	}
+	for _, warning := range resp.Warnings {
+		klog.Warningf("updating resources of instance %s: %s", instance, warning)
+	}
+
+	m.amend(instance, func(hostConfig *container.HostConfig) {
+		mergeResources(&hostConfig.Resources, resources)
+		if optionz.RestartPolicy != nil {
+			hostConfig.RestartPolicy = update.RestartPolicy
+		}
+	})
+	return nil
+}
+
+// checkUsage returns an error if the memory or pids limit is below the current usage of the
+// container.
+func (m *Manager) checkUsage(ctx context.Context, id, instance string, resources container.Resources) error {
+	if resources.Memory == 0 && (resources.PidsLimit == nil || *resources.PidsLimit <= 0) {
+		return nil
+	}
+
+	reader, err := m.client.ContainerStatsOneShot(ctx, id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get usage of instance %s: %v", instance, err)
+	}
+	defer reader.Body.Close()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(reader.Body).Decode(&stats); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode usage of instance %s: %v", instance, err)
+	}
+
+	if usage := memoryUsage(stats.MemoryStats); resources.Memory > 0 && uint64(resources.Memory) < usage {
+		return status.Errorf(codes.FailedPrecondition, "memory limit %d of instance %s is below its current usage %d", resources.Memory, instance, usage)
+	}
+	if limit := resources.PidsLimit; limit != nil && *limit > 0 && uint64(*limit) < stats.PidsStats.Current {
+		return status.Errorf(codes.FailedPrecondition, "pids limit %d of instance %s is below its current %d processes", *limit, instance, stats.PidsStats.Current)
+	}
+	return nil
+}
+
+// memoryUsage returns the memory used by a container, excluding the page cache that the kernel
+// reclaims before enforcing a lower limit.
+func memoryUsage(stats container.MemoryStats) uint64 {
+	// The cache is reported as inactive_file by cgroup v2 and total_inactive_file by cgroup v1.
+	for _, key := range []string{"inactive_file", "total_inactive_file"} {
+		if cache, ok := stats.Stats[key]; ok && cache < stats.Usage {
+			return stats.Usage - cache
+		}
+	}
+	return stats.Usage
+}
+
+// mergeResources sets the limits changed in place on the resources.
+func mergeResources(dst *container.Resources, src container.Resources) {
+	for _, field := range []struct {
+		dst *int64
+		src int64
+	}{
+		{&dst.NanoCPUs, src.NanoCPUs},
+		{&dst.Memory, src.Memory},
+		{&dst.MemoryReservation, src.MemoryReservation},
+		{&dst.MemorySwap, src.MemorySwap},
+		{&dst.CPUShares, src.CPUShares},
+	} {
+		if field.src != 0 {
+			*field.dst = field.src
+		}
+	}
+	if src.CpusetCpus != "" {
+		dst.CpusetCpus = src.CpusetCpus
+	}
+	if src.CpusetMems != "" {
+		dst.CpusetMems = src.CpusetMems
+	}
+	if src.BlkioWeight != 0 {
+		dst.BlkioWeight = src.BlkioWeight
+	}
+	if src.PidsLimit != nil {
+		dst.PidsLimit = src.PidsLimit
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeResourcesDocker struct {
+	fakeDocker
+	cnts    []types.Container
+	running bool
+	memory  int64
+	labels  map[string]string
+	stats   container.StatsResponse
+
+	Updated *container.UpdateConfig
+}
+
+func (f *fakeResourcesDocker) ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error) {
+	return f.cnts, nil
+}
+
+func (f *fakeResourcesDocker) ContainerInspect(ctx context.Context, cnt string) (container.InspectResponse, error) {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:         cnt,
+			State:      &container.State{Running: f.running},
+			HostConfig: &container.HostConfig{Resources: container.Resources{Memory: f.memory}},
+		},
+		Config: &container.Config{Labels: f.labels},
+	}, nil
+}
+
+func (f *fakeResourcesDocker) ContainerStatsOneShot(ctx context.Context, cnt string) (container.StatsResponseReader, error) {
+	body, err := json.Marshal(f.stats)
+	if err != nil {
+		return container.StatsResponseReader{}, err
+	}
+	return container.StatsResponseReader{Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func (f *fakeResourcesDocker) ContainerUpdate(ctx context.Context, cnt string, updateConfig container.UpdateConfig) (container.UpdateResponse, error) {
+	f.Updated = &updateConfig
+	return container.UpdateResponse{}, nil
+}
+
+func TestContainerSetResources(t *testing.T) {
+	pids := int64(64)
+	usage := container.StatsResponse{
+		MemoryStats: container.MemoryStats{Usage: 300 << 20, Stats: map[string]uint64{"inactive_file": 100 << 20}},
+		PidsStats:   container.PidsStats{Current: 32},
+	}
+
+	tests := []struct {
+		name       string
+		inOpts     []options.Option
+		inRunning  bool
+		inMemory   int64
+		inLabels   map[string]string
+		inInstance string
+		wantUpdate *container.UpdateConfig
+		wantErr    error
+	}{
+		{
+			name:      "limits-and-restart-policy",
+			inRunning: true,
+			inOpts: []options.Option{
+				options.WithCPUs(0.5),
+				options.WithHardLimit(256 << 20),
+				options.WithResources(options.Resources{PidsLimit: 64, CPUSetCPUs: "2-3"}),
+				options.WithRestartPolicy(&cpb.StartContainerRequest_Restart{Policy: cpb.StartContainerRequest_Restart_ALWAYS}),
+			},
+			wantUpdate: &container.UpdateConfig{
+				Resources: container.Resources{
+					NanoCPUs:   500000000,
+					Memory:     256 << 20,
+					PidsLimit:  &pids,
+					CpusetCpus: "2-3",
+				},
+				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyAlways},
+			},
+		},
+		{
+			name:     "restart-policy-with-dependencies",
+			inLabels: map[string]string{options.DependsOnLabel: "db", options.RestartPolicyLabel: "always"},
+			inOpts: []options.Option{
+				options.WithRestartPolicy(&cpb.StartContainerRequest_Restart{Policy: cpb.StartContainerRequest_Restart_NONE}),
+			},
+			wantErr: status.Errorf(codes.FailedPrecondition, "restart policy of instance %s cannot be changed in place as it has dependencies", "app"),
+		},
+		{
+			name:     "swap-checked-against-current-memory",
+			inMemory: 256 << 20,
+			inOpts: []options.Option{
+				options.WithResources(options.Resources{MemorySwap: 512 << 20}),
+			},
+			wantUpdate: &container.UpdateConfig{
+				Resources: container.Resources{MemorySwap: 512 << 20},
+			},
+		},
+		{
+			name:      "memory-below-usage",
+			inRunning: true,
+			inOpts:    []options.Option{options.WithHardLimit(128 << 20)},
+			wantErr:   status.Errorf(codes.FailedPrecondition, "memory limit %d of instance %s is below its current usage %d", 128<<20, "app", 200<<20),
+		},
+		{
+			name:   "memory-below-usage-of-stopped-container",
+			inOpts: []options.Option{options.WithHardLimit(128 << 20)},
+			wantUpdate: &container.UpdateConfig{
+				Resources: container.Resources{Memory: 128 << 20},
+			},
+		},
+		{
+			name:      "pids-below-usage",
+			inRunning: true,
+			inOpts:    []options.Option{options.WithResources(options.Resources{PidsLimit: 16})},
+			wantErr:   status.Errorf(codes.FailedPrecondition, "pids limit %d of instance %s is below its current %d processes", 16, "app", 32),
+		},
+		{
+			name:    "ulimits",
+			inOpts:  []options.Option{options.WithResources(options.Resources{Ulimits: []options.Ulimit{{Name: "nofile", Soft: 1, Hard: 1}}})},
+			wantErr: status.Errorf(codes.InvalidArgument, "ulimits of instance %s cannot be changed in place", "app"),
+		},
+		{
+			name:    "invalid-limit",
+			inOpts:  []options.Option{options.WithResources(options.Resources{CPUShares: 1})},
+			wantErr: status.Errorf(codes.InvalidArgument, "cpu shares must be at least 2, got %d", 1),
+		},
+		{
+			name:       "no-such-instance",
+			inInstance: "other",
+			inOpts:     []options.Option{options.WithHardLimit(128 << 20)},
+			wantErr:    status.Errorf(codes.NotFound, "instance name %s not found", "other"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frd := &fakeResourcesDocker{
+				cnts:    []types.Container{{ID: "app-id", Names: []string{"/app"}}},
+				running: tc.inRunning,
+				memory:  tc.inMemory,
+				labels:  tc.inLabels,
+				stats:   usage,
+			}
+			mgr := New(frd)
+
+			instance := tc.inInstance
+			if instance == "" {
+				instance = "app"
+			}
+			err := mgr.ContainerSetResources(context.Background(), instance, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerSetResources(%q, %+v) returned unexpected error (-want, +got):\n%s", instance, tc.inOpts, diff)
+			}
+			if diff := cmp.Diff(tc.wantUpdate, frd.Updated); diff != "" {
+				t.Errorf("ContainerSetResources(%q, %+v) returned diff in update (-want, +got):\n%s", instance, tc.inOpts, diff)
+			}
+		})
+	}
+}
+
+func TestContainerSetResourcesAmendsRevision(t *testing.T) {
+	frd := &fakeResourcesDocker{cnts: []types.Container{{ID: "app-id", Names: []string{"/app"}}}}
+	mgr := New(frd)
+
+	shared := &container.HostConfig{Resources: container.Resources{NanoCPUs: 1000000000, Memory: 64 << 20}}
+	mgr.record("app", &revision{hostConfig: shared})
+	mgr.record("app", &revision{hostConfig: shared})
+
+	if err := mgr.ContainerSetResources(context.Background(), "app", options.WithHardLimit(128<<20)); err != nil {
+		t.Fatalf("ContainerSetResources() returned error: %v", err)
+	}
+
+	revs := mgr.history["app"]
+	want := container.Resources{NanoCPUs: 1000000000, Memory: 128 << 20}
+	if diff := cmp.Diff(want, revs[1].hostConfig.Resources); diff != "" {
+		t.Errorf("ContainerSetResources() returned diff in current revision (-want, +got):\n%s", diff)
+	}
+	if revs[0].hostConfig.Memory != 64<<20 {
+		t.Errorf("ContainerSetResources() changed the memory of an earlier revision to %d", revs[0].hostConfig.Memory)
+	}
+}
+
+func TestContainerUpdateLive(t *testing.T) {
+	frd := &fakeResourcesDocker{cnts: []types.Container{{ID: "app-id", Names: []string{"/app"}}}}
+	mgr := New(frd)
+
+	opts := []options.Option{options.WithUpdateStrategy(options.LiveStrategy), options.WithCPUs(2)}
+	got, err := mgr.ContainerUpdate(context.Background(), "app", "", "", "", false, opts...)
+	if err != nil {
+		t.Fatalf("ContainerUpdate(%+v) returned error: %v", opts, err)
+	}
+	if got != "app" {
+		t.Errorf("ContainerUpdate(%+v) = %q, want %q", opts, got, "app")
+	}
+	want := &container.UpdateConfig{Resources: container.Resources{NanoCPUs: 2000000000}}
+	if diff := cmp.Diff(want, frd.Updated); diff != "" {
+		t.Errorf("ContainerUpdate(%+v) returned diff in update (-want, +got):\n%s", opts, diff)
+	}
+}
@@ -3,7 +3,10 @@ package docker
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -14,37 +17,50 @@ import (
 	options "github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
 
 	"github.com/google/shlex"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
 // ContainerStart starts a container provided the image exists and that the ports requested are not
-// currently in use.
+// currently in use. The started container is recorded as the first revision of the instance.
 func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string, opts ...options.Option) (string, error) {
+	name, rev, err := m.startContainer(ctx, imageName, tag, cmd, opts...)
+	if err != nil {
+		return "", err
+	}
+	m.record(name, rev)
+	return name, nil
+}
+
+// startContainer starts a container and returns its name along with the revision describing it.
+func (m *Manager) startContainer(ctx context.Context, imageName, tag, cmd string, opts ...options.Option) (string, *revision, error) {
 	optionz := options.ApplyOptions(opts...)
 
 	images, err := m.client.ImageList(ctx, image.ListOptions{
 		// TODO(alshabib): consider filtering for the image we care about
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	ref := fmt.Sprintf("%s:%s", imageName, tag)
 	if err := findImage(ref, images); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	cnts, err := m.client.ContainerList(ctx, container.ListOptions{
 		// TODO(alshabib): consider filtering for the image we care about
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	if err := checkExistingInstanceAndPorts(optionz.InstanceName, optionz.PortMapping, cnts); err != nil {
-		return "", err
+	bindings := portBindings(optionz.PortMapping, optionz.PortBindings)
+	if err := checkExistingInstanceAndPorts(optionz.InstanceName, bindings, cnts); err != nil {
+		return "", nil, err
 	}
 
 	mounts := make([]mount.Mount, 0, len(optionz.Volumes))
@@ -56,6 +72,11 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 			ReadOnly: vol.GetReadOnly(),
 		})
 	}
+	extra, err := mountsConfig(mounts, optionz.Mounts)
+	if err != nil {
+		return "", nil, err
+	}
+	mounts = append(mounts, extra...)
 
 	devices := make([]container.DeviceMapping, 0, len(optionz.Devices))
 	for _, dev := range optionz.Devices {
@@ -66,25 +87,22 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 		})
 	}
 
-	cpu, err := options.ParseCPUs(optionz.CPU)
+	resources, err := resourcesConfig(optionz.CPU, optionz.SoftMemory, optionz.HardMemory, optionz.Resources)
 	if err != nil {
-		return "", fmt.Errorf("unable to parse cpu limit %f: %v", optionz.CPU, err)
+		return "", nil, err
 	}
+	resources.Devices = devices
 
 	hostConfig := &container.HostConfig{
 		Mounts:      mounts,
 		NetworkMode: "host",
+		OomScoreAdj: optionz.Resources.OOMScoreAdj,
 
-		Resources: container.Resources{
-			NanoCPUs:          cpu,
-			Memory:            optionz.HardMemory, // hard
-			MemoryReservation: optionz.SoftMemory, // soft
-			Devices:           devices,
-		},
+		Resources: resources,
 	}
 	splitCmd, err := shlex.Split(cmd)
 	if err != nil {
-		return "", status.Errorf(codes.InvalidArgument,
+		return "", nil, status.Errorf(codes.InvalidArgument,
 			"failed to split command %q, got error %s", cmd, err)
 	}
 	if len(splitCmd) == 0 {
@@ -102,20 +120,26 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 		StdinOnce:    false,
 		Tty:          true,
 	}
-	if len(optionz.PortMapping) > 0 {
+	if len(bindings) > 0 {
 		portMap := nat.PortMap{}
 		portSet := nat.PortSet{}
-		for in, out := range optionz.PortMapping {
-			internal := fmt.Sprintf("%d", in)
-			external := fmt.Sprintf("%d", out)
-			in, err := nat.NewPort("tcp", internal)
+		for _, binding := range bindings {
+			in, err := nat.NewPort(binding.Protocol, fmt.Sprintf("%d", binding.Internal))
 			if err != nil {
-				return "", err
+				return "", nil, err
 			}
 
 			portSet[in] = struct{}{}
+			external := fmt.Sprintf("%d", binding.External)
+			if binding.HostIP != "" {
+				portMap[in] = append(portMap[in], nat.PortBinding{
+					HostIP:   binding.HostIP,
+					HostPort: external,
+				})
+				continue
+			}
 			bindingV4 := nat.PortBinding{
-				HostIP:   "0.0.0.0", // TODO(alshabib): do we want this to be configurable?
+				HostIP:   "0.0.0.0",
 				HostPort: external,
 			}
 			bindingV6 := nat.PortBinding{
@@ -123,7 +147,7 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 				HostPort: external,
 			}
 
-			portMap[in] = []nat.PortBinding{bindingV4, bindingV6}
+			portMap[in] = append(portMap[in], bindingV4, bindingV6)
 		}
 
 		hostConfig.PortBindings = portMap
@@ -141,6 +165,52 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 		hostConfig.NetworkMode = container.NetworkMode(optionz.Network)
 	}
 
+	// Handle hostname, DNS and /etc/hosts entries
+	if err := checkDNSServers(optionz.DNS); err != nil {
+		return "", nil, err
+	}
+	if err := checkExtraHosts(optionz.ExtraHosts); err != nil {
+		return "", nil, err
+	}
+	config.Hostname = optionz.Hostname
+	hostConfig.DNS = optionz.DNS
+	hostConfig.DNSSearch = optionz.DNSSearch
+	hostConfig.ExtraHosts = optionz.ExtraHosts
+
+	// Handle health check
+	if optionz.HealthCheck != nil {
+		healthcheck, err := healthConfig(*optionz.HealthCheck)
+		if err != nil {
+			return "", nil, err
+		}
+		config.Healthcheck = healthcheck
+	}
+
+	// Handle static IP addresses and network attachments
+	attachments := optionz.NetworkAttachments
+	switch {
+	case len(attachments) == 0 && (optionz.IPv4Address != "" || optionz.IPv6Address != ""):
+		attachments = []options.NetworkAttachment{{
+			Network:     string(hostConfig.NetworkMode),
+			IPv4Address: optionz.IPv4Address,
+			IPv6Address: optionz.IPv6Address,
+		}}
+	case len(attachments) != 0 && (optionz.Network != "" || optionz.IPv4Address != "" || optionz.IPv6Address != ""):
+		return "", nil, status.Errorf(codes.InvalidArgument, "network attachments cannot be combined with a network or static IP addresses")
+	}
+	endpoints, err := endpointsConfig(attachments)
+	if err != nil {
+		return "", nil, err
+	}
+	networkingConfig := &network.NetworkingConfig{}
+	if len(attachments) > 0 {
+		primary := attachments[0].Network
+		hostConfig.NetworkMode = container.NetworkMode(primary)
+		if endpoint := endpoints[primary]; endpoint.IPAMConfig != nil || len(endpoint.Aliases) > 0 {
+			networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{primary: endpoint}
+		}
+	}
+
 	// Handle Capabilities
 	if optionz.Capabilities != nil {
 		caps := optionz.Capabilities.(*cpb.StartContainerRequest_Capabilities)
@@ -148,28 +218,17 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 		hostConfig.CapDrop = caps.GetRemove()
 	}
 
+	if err := m.applySecurity(ctx, hostConfig, optionz.Security); err != nil {
+		return "", nil, err
+	}
+
 	// Handle RestartPolicy
 	if optionz.RestartPolicy != nil {
-		restartPolicy := optionz.RestartPolicy.(*cpb.StartContainerRequest_Restart)
-
-		var policy container.RestartPolicyMode
-		switch restartPolicy.GetPolicy() {
-		case cpb.StartContainerRequest_Restart_ALWAYS:
-			policy = container.RestartPolicyAlways
-		case cpb.StartContainerRequest_Restart_ON_FAILURE:
-			policy = container.RestartPolicyOnFailure
-		case cpb.StartContainerRequest_Restart_NONE:
-			policy = container.RestartPolicyDisabled
-		case cpb.StartContainerRequest_Restart_UNLESS_STOPPED:
-			policy = container.RestartPolicyUnlessStopped
-		default:
-			return "", status.Errorf(codes.FailedPrecondition, "unkown restart policy '%v'", restartPolicy.GetPolicy())
-		}
-
-		hostConfig.RestartPolicy = container.RestartPolicy{
-			Name:              policy,
-			MaximumRetryCount: int(restartPolicy.GetAttempts()),
+		policy, err := restartPolicy(optionz.RestartPolicy)
+		if err != nil {
+			return "", nil, err
 		}
+		hostConfig.RestartPolicy = policy
 	}
 
 	// Handle RunAs
@@ -177,7 +236,7 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 		runAs := optionz.RunAs.(*cpb.StartContainerRequest_RunAs)
 		user := runAs.GetUser()
 		if user == "" {
-			return "", status.Errorf(codes.FailedPrecondition, "user can not be empty in RunAs option")
+			return "", nil, status.Errorf(codes.FailedPrecondition, "user can not be empty in RunAs option")
 		}
 		if runAs.GetGroup() != "" {
 			user = fmt.Sprintf("%s:%s", user, runAs.GetGroup())
@@ -185,24 +244,102 @@ func (m *Manager) ContainerStart(ctx context.Context, imageName, tag, cmd string
 		config.User = user
 	}
 
-	resp, err := m.client.ContainerCreate(ctx, config, hostConfig, &network.NetworkingConfig{}, nil, optionz.InstanceName)
-	if err != nil {
-		return "", status.Errorf(codes.Internal, "unable to create container: %v", err)
+	// Record the dependencies on the container so that they are honoured when the device boots.
+	if err := checkDependencies(optionz.InstanceName, optionz.DependsOn); err != nil {
+		return "", nil, err
+	}
+	if len(optionz.DependsOn) > 0 {
+		labels := make(map[string]string, len(config.Labels)+2)
+		for key, value := range config.Labels {
+			labels[key] = value
+		}
+		labels[options.DependsOnLabel] = dependenciesLabel(optionz.DependsOn)
+		if optionz.DependencyTimeout > 0 {
+			labels[options.DependencyTimeoutLabel] = optionz.DependencyTimeout.String()
+		}
+		// Docker starts the containers whose restart policy asks for it before containerz runs and
+		// regardless of their dependencies, so the policy is left to the reconciliation at boot.
+		if !hostConfig.RestartPolicy.IsNone() {
+			labels[options.RestartPolicyLabel] = restartPolicyLabel(hostConfig.RestartPolicy)
+			hostConfig.RestartPolicy = container.RestartPolicy{Name: container.RestartPolicyDisabled}
+		}
+		config.Labels = labels
+	}
+	if err := m.waitDependencies(ctx, optionz.DependsOn, optionz.DependencyTimeout); err != nil {
+		return "", nil, err
 	}
 
-	if err := m.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
-		return "", status.Errorf(codes.Internal, "unable to start container: %v", err)
+	rev := &revision{
+		Revision: options.Revision{
+			Name:    optionz.RevisionName,
+			Image:   imageName,
+			Tag:     tag,
+			Created: time.Now(),
+		},
+		config:           config,
+		hostConfig:       hostConfig,
+		networkingConfig: networkingConfig,
+		connects:         make(map[string]*network.EndpointSettings),
+	}
+	// The container is created on the first network, and connected to the remaining ones.
+	for i := 1; i < len(attachments); i++ {
+		rev.connects[attachments[i].Network] = endpoints[attachments[i].Network]
 	}
 
-	name := resp.ID
+	id, err := m.createAndStart(ctx, optionz.InstanceName, rev)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name := id
 	if optionz.InstanceName != "" {
 		name = optionz.InstanceName
 	}
 
-	return name, nil
+	return name, rev, nil
+}
+
+// createAndStart creates a container from the revision and starts it. It returns the ID of the
+// container.
+func (m *Manager) createAndStart(ctx context.Context, name string, rev *revision) (string, error) {
+	id, err := m.create(ctx, name, rev)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.client.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
+		return "", status.Errorf(codes.Internal, "unable to start container: %v", err)
+	}
+
+	return id, nil
 }
 
-func checkExistingInstanceAndPorts(instance string, ports map[uint32]uint32, cnts []types.Container) error {
+// create creates a container from the revision and connects it to its additional networks. It
+// returns the ID of the container.
+func (m *Manager) create(ctx context.Context, name string, rev *revision) (string, error) {
+	resp, err := m.client.ContainerCreate(ctx, rev.config, rev.hostConfig, rev.networkingConfig, &m.platform, name)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "unable to create container: %v", err)
+	}
+
+	networks := make([]string, 0, len(rev.connects))
+	for network := range rev.connects {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		if err := m.client.NetworkConnect(ctx, network, resp.ID, rev.connects[network]); err != nil {
+			if err := m.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true}); err != nil {
+				klog.Warningf("unable to remove container %s: %v", resp.ID, err)
+			}
+			return "", status.Errorf(codes.Internal, "unable to connect container to network %s: %v", network, err)
+		}
+	}
+
+	return resp.ID, nil
+}
+
+func checkExistingInstanceAndPorts(instance string, ports []options.PortBinding, cnts []types.Container) error {
 	if instance == "" && len(ports) == 0 {
 		return nil
 	}
@@ -214,17 +351,191 @@ func checkExistingInstanceAndPorts(instance string, ports map[uint32]uint32, cnt
 				return status.Errorf(codes.AlreadyExists, "instance name %s already in use", instance)
 			}
 		}
-		for _, port := range cnt.Ports {
-			for _, ext := range ports {
-				if ext == uint32(port.PublicPort) {
-					return status.Errorf(codes.Unavailable, "port %d already in use", ext)
-				}
+		if err := checkPortConflicts(ports, cnt.Ports); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// portBindings merges the plain TCP port mapping with the explicit port bindings, defaulting the
+// protocol of every binding to tcp.
+func portBindings(mapping map[uint32]uint32, bindings []options.PortBinding) []options.PortBinding {
+	res := make([]options.PortBinding, 0, len(mapping)+len(bindings))
+	for in, out := range mapping {
+		res = append(res, options.PortBinding{Internal: in, External: out, Protocol: "tcp"})
+	}
+	for _, binding := range bindings {
+		if binding.Protocol == "" {
+			binding.Protocol = "tcp"
+		}
+		res = append(res, binding)
+	}
+	return res
+}
+
+// checkPortConflicts returns an error if any of the requested bindings uses a host port that is
+// already published with the same protocol on an overlapping host address.
+func checkPortConflicts(bindings []options.PortBinding, used []types.Port) error {
+	for _, port := range used {
+		proto := port.Type
+		if proto == "" {
+			proto = "tcp"
+		}
+		for _, binding := range bindings {
+			if binding.External != uint32(port.PublicPort) || binding.Protocol != proto {
+				continue
 			}
+			if !hostIPsOverlap(binding.HostIP, port.IP) {
+				continue
+			}
+			if binding.Protocol == "tcp" {
+				return status.Errorf(codes.Unavailable, "port %d already in use", binding.External)
+			}
+			return status.Errorf(codes.Unavailable, "port %d/%s already in use", binding.External, binding.Protocol)
+		}
+	}
+	return nil
+}
+
+// hostIPsOverlap reports whether two host addresses may collide, i.e. either address is
+// unspecified or both are the same.
+func hostIPsOverlap(a, b string) bool {
+	unspecified := func(ip string) bool {
+		return ip == "" || net.ParseIP(ip).IsUnspecified()
+	}
+	if unspecified(a) || unspecified(b) {
+		return true
+	}
+	return net.ParseIP(a).Equal(net.ParseIP(b))
+}
+
+// checkDNSServers ensures that every DNS server is an IP address.
+func checkDNSServers(servers []string) error {
+	for _, server := range servers {
+		if net.ParseIP(server) == nil {
+			return status.Errorf(codes.InvalidArgument, "dns server %q is not an IP address", server)
+		}
+	}
+	return nil
+}
+
+// checkExtraHosts ensures that every extra host is of the form <hostname>:<ip>. The special
+// address host-gateway resolves to the host's gateway address.
+func checkExtraHosts(hosts []string) error {
+	for _, host := range hosts {
+		name, ip, ok := strings.Cut(host, ":")
+		if !ok || name == "" {
+			return status.Errorf(codes.InvalidArgument, "extra host %q must be of the form <hostname>:<ip>", host)
+		}
+		if ip != "host-gateway" && net.ParseIP(ip) == nil {
+			return status.Errorf(codes.InvalidArgument, "extra host %q has an invalid IP address", host)
 		}
 	}
 	return nil
 }
 
+// restartPolicy returns the docker restart policy of the RestartPolicy option.
+func restartPolicy(opt proto.Message) (container.RestartPolicy, error) {
+	restartPolicy := opt.(*cpb.StartContainerRequest_Restart)
+
+	var policy container.RestartPolicyMode
+	switch restartPolicy.GetPolicy() {
+	case cpb.StartContainerRequest_Restart_ALWAYS:
+		policy = container.RestartPolicyAlways
+	case cpb.StartContainerRequest_Restart_ON_FAILURE:
+		policy = container.RestartPolicyOnFailure
+	case cpb.StartContainerRequest_Restart_NONE:
+		policy = container.RestartPolicyDisabled
+	case cpb.StartContainerRequest_Restart_UNLESS_STOPPED:
+		policy = container.RestartPolicyUnlessStopped
+	default:
+		return container.RestartPolicy{}, status.Errorf(codes.FailedPrecondition, "unkown restart policy '%v'", restartPolicy.GetPolicy())
+	}
+
+	return container.RestartPolicy{
+		Name:              policy,
+		MaximumRetryCount: int(restartPolicy.GetAttempts()),
+	}, nil
+}
+
+// healthConfig returns the docker health check configuration of the health check.
+func healthConfig(check options.HealthCheck) (*container.HealthConfig, error) {
+	if len(check.Test) > 0 {
+		switch check.Test[0] {
+		case "NONE":
+			if len(check.Test) != 1 {
+				return nil, status.Errorf(codes.InvalidArgument, "health check NONE takes no arguments")
+			}
+		case "CMD", "CMD-SHELL":
+			if len(check.Test) < 2 {
+				return nil, status.Errorf(codes.InvalidArgument, "health check %s requires a command", check.Test[0])
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "health check test must start with NONE, CMD or CMD-SHELL, got %q", check.Test[0])
+		}
+	}
+	for name, d := range map[string]time.Duration{
+		"interval":     check.Interval,
+		"timeout":      check.Timeout,
+		"start period": check.StartPeriod,
+	} {
+		// Docker rejects durations shorter than a millisecond, zero inheriting from the image.
+		if d != 0 && d < time.Millisecond {
+			return nil, status.Errorf(codes.InvalidArgument, "health check %s must be at least 1ms, got %v", name, d)
+		}
+	}
+	if check.Retries < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "health check retries must not be negative, got %d", check.Retries)
+	}
+
+	return &container.HealthConfig{
+		Test:        check.Test,
+		Interval:    check.Interval,
+		Timeout:     check.Timeout,
+		StartPeriod: check.StartPeriod,
+		Retries:     check.Retries,
+	}, nil
+}
+
+// endpointsConfig returns the endpoint settings for each of the network attachments. Aliases and
+// static addresses are only supported on user-defined networks, and the host, none and container
+// network modes cannot be combined with other networks.
+func endpointsConfig(attachments []options.NetworkAttachment) (map[string]*network.EndpointSettings, error) {
+	endpoints := make(map[string]*network.EndpointSettings, len(attachments))
+	for _, attachment := range attachments {
+		mode := container.NetworkMode(attachment.Network)
+		if _, ok := endpoints[attachment.Network]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "network %s is attached more than once", attachment.Network)
+		}
+		if len(attachments) > 1 && (mode.IsHost() || mode.IsNone() || mode.IsContainer()) {
+			return nil, status.Errorf(codes.InvalidArgument, "network %s cannot be combined with other networks", attachment.Network)
+		}
+		if len(attachment.Aliases) > 0 && !mode.IsUserDefined() {
+			return nil, status.Errorf(codes.InvalidArgument, "network aliases require a user-defined network, got %q", mode)
+		}
+
+		endpoint := &network.EndpointSettings{Aliases: attachment.Aliases}
+		if attachment.IPv4Address != "" || attachment.IPv6Address != "" {
+			if !mode.IsUserDefined() {
+				return nil, status.Errorf(codes.InvalidArgument, "static IP addresses require a user-defined network, got %q", mode)
+			}
+			if ip := net.ParseIP(attachment.IPv4Address); attachment.IPv4Address != "" && (ip == nil || ip.To4() == nil) {
+				return nil, status.Errorf(codes.InvalidArgument, "%q is not an IPv4 address", attachment.IPv4Address)
+			}
+			if ip := net.ParseIP(attachment.IPv6Address); attachment.IPv6Address != "" && (ip == nil || ip.To4() != nil) {
+				return nil, status.Errorf(codes.InvalidArgument, "%q is not an IPv6 address", attachment.IPv6Address)
+			}
+			endpoint.IPAMConfig = &network.EndpointIPAMConfig{
+				IPv4Address: attachment.IPv4Address,
+				IPv6Address: attachment.IPv6Address,
+			}
+		}
+		endpoints[attachment.Network] = endpoint
+	}
+	return endpoints, nil
+}
+
 // cgroupPermissions returns the cgroup permissions for the device in the order of rwm.
 func cgroupPermissions(perms []cpb.Device_Permission) string {
 	permMap := map[cpb.Device_Permission]bool{}
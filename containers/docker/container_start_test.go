@@ -2,7 +2,9 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -10,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	options "github.com/openconfig/containerz/containers"
@@ -26,6 +29,7 @@ type fakeStartingDocker struct {
 	cnts      []types.Container
 
 	Ports       nat.PortSet
+	Bindings    nat.PortMap
 	Env         []string
 	Volumes     []mount.Mount
 	ContainerID string
@@ -34,6 +38,14 @@ type fakeStartingDocker struct {
 	CapAdd      []string
 	CapDel      []string
 	Network     string
+	Hostname    string
+	DNS         []string
+	DNSSearch   []string
+	ExtraHosts  []string
+	Healthcheck *container.HealthConfig
+	Endpoints   map[string]*network.EndpointSettings
+	Connected   map[string]*network.EndpointSettings
+	Removed     string
 	Labels      map[string]string
 	Devices     []container.DeviceMapping
 	Cmd         []string
@@ -41,10 +53,16 @@ type fakeStartingDocker struct {
 	CPU        int64
 	HardMemory int64
 	SoftMemory int64
+
+	CpusetCpus  string
+	PidsLimit   *int64
+	Ulimits     []*units.Ulimit
+	OOMScoreAdj int
 }
 
 func (f *fakeStartingDocker) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error) {
 	f.Ports = config.ExposedPorts
+	f.Bindings = hostConfig.PortBindings
 	f.Cmd = config.Cmd
 	f.Env = config.Env
 	f.Volumes = hostConfig.Mounts
@@ -53,10 +71,20 @@ func (f *fakeStartingDocker) ContainerCreate(ctx context.Context, config *contai
 	f.CapAdd = hostConfig.CapAdd
 	f.CapDel = hostConfig.CapDrop
 	f.Labels = config.Labels
+	f.Hostname = config.Hostname
+	f.DNS = hostConfig.DNS
+	f.DNSSearch = hostConfig.DNSSearch
+	f.ExtraHosts = hostConfig.ExtraHosts
+	f.Healthcheck = config.Healthcheck
+	f.Endpoints = networkingConfig.EndpointsConfig
 	f.CPU = hostConfig.Resources.NanoCPUs
 	f.HardMemory = hostConfig.Resources.Memory
 	f.SoftMemory = hostConfig.Resources.MemoryReservation
 	f.Devices = hostConfig.Resources.Devices
+	f.CpusetCpus = hostConfig.Resources.CpusetCpus
+	f.PidsLimit = hostConfig.Resources.PidsLimit
+	f.Ulimits = hostConfig.Resources.Ulimits
+	f.OOMScoreAdj = hostConfig.OomScoreAdj
 	// If this is not out default, remember it.
 	if !hostConfig.NetworkMode.IsHost() {
 		f.Network = string(hostConfig.NetworkMode)
@@ -67,6 +95,22 @@ func (f *fakeStartingDocker) ContainerCreate(ctx context.Context, config *contai
 	}, nil
 }
 
+func (f *fakeStartingDocker) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	if networkID == "unreachable" {
+		return fmt.Errorf("network %s not found", networkID)
+	}
+	if f.Connected == nil {
+		f.Connected = map[string]*network.EndpointSettings{}
+	}
+	f.Connected[networkID] = config
+	return nil
+}
+
+func (f *fakeStartingDocker) ContainerRemove(ctx context.Context, container string, options container.RemoveOptions) error {
+	f.Removed = container
+	return nil
+}
+
 func (f *fakeStartingDocker) ContainerStart(ctx context.Context, container string, options container.StartOptions) error {
 	f.ContainerID = container
 	return nil
@@ -170,8 +214,12 @@ func TestContainerStart(t *testing.T) {
 			},
 			inOpts: []options.Option{options.WithInstanceName("my-container"), options.WithPorts(map[uint32]uint32{1: 1})},
 			wantState: &fakeStartingDocker{
-				Cmd:         []string{"my-cmd"},
-				Ports:       nat.PortSet{"1/tcp": struct{}{}},
+				Cmd:   []string{"my-cmd"},
+				Ports: nat.PortSet{"1/tcp": struct{}{}},
+				Bindings: nat.PortMap{"1/tcp": []nat.PortBinding{
+					{HostIP: "0.0.0.0", HostPort: "1"},
+					{HostIP: "::", HostPort: "1"},
+				}},
 				ContainerID: "my-container",
 				Volumes:     []mount.Mount{},
 			},
@@ -306,6 +354,329 @@ func TestContainerStart(t *testing.T) {
 				Network: "my-network",
 			},
 		},
+		{
+			name:    "container-with-hostname-dns-and-extra-hosts",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithHostname("router-app"),
+				options.WithDNS([]string{"192.0.2.53", "2001:db8::53"}, []string{"example.com"}),
+				options.WithExtraHosts([]string{"collector:192.0.2.10", "gateway:host-gateway"}),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd:        []string{"my-cmd"},
+				Hostname:   "router-app",
+				DNS:        []string{"192.0.2.53", "2001:db8::53"},
+				DNSSearch:  []string{"example.com"},
+				ExtraHosts: []string{"collector:192.0.2.10", "gateway:host-gateway"},
+			},
+		},
+		{
+			name:    "container-with-health-check",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithHealthCheck(options.HealthCheck{
+					Test:        []string{"CMD-SHELL", "curl -f http://localhost:8080/healthz"},
+					Interval:    10 * time.Second,
+					Timeout:     2 * time.Second,
+					Retries:     3,
+					StartPeriod: 30 * time.Second,
+				}),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd: []string{"my-cmd"},
+				Healthcheck: &container.HealthConfig{
+					Test:        []string{"CMD-SHELL", "curl -f http://localhost:8080/healthz"},
+					Interval:    10 * time.Second,
+					Timeout:     2 * time.Second,
+					Retries:     3,
+					StartPeriod: 30 * time.Second,
+				},
+			},
+		},
+		{
+			name:    "container-with-resources",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithResources(options.Resources{
+					CPUSetCPUs:  "2-3",
+					PidsLimit:   128,
+					Ulimits:     []options.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}},
+					OOMScoreAdj: 500,
+				}),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd:         []string{"my-cmd"},
+				CpusetCpus:  "2-3",
+				PidsLimit:   func() *int64 { limit := int64(128); return &limit }(),
+				Ulimits:     []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}},
+				OOMScoreAdj: 500,
+			},
+		},
+		{
+			name:    "container-with-invalid-resources",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithResources(options.Resources{PidsLimit: -5}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "pids limit must be positive or -1 for no limit, got %d", -5),
+		},
+		{
+			name:    "container-with-health-check-disabled",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithHealthCheck(options.HealthCheck{Test: []string{"NONE"}}),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd:         []string{"my-cmd"},
+				Healthcheck: &container.HealthConfig{Test: []string{"NONE"}},
+			},
+		},
+		{
+			name:    "container-with-invalid-health-check",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithHealthCheck(options.HealthCheck{Test: []string{"curl", "-f", "localhost"}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "health check test must start with NONE, CMD or CMD-SHELL, got %q", "curl"),
+		},
+		{
+			name:    "container-with-invalid-dns-server",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithDNS([]string{"resolver"}, nil),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "dns server %q is not an IP address", "resolver"),
+		},
+		{
+			name:    "container-with-invalid-extra-host",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithExtraHosts([]string{"collector"}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "extra host %q must be of the form <hostname>:<ip>", "collector"),
+		},
+		{
+			name:    "container-with-static-ips",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithNetwork("my-network"),
+				options.WithIPAddresses("192.0.2.2", "2001:db8::2"),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd:     []string{"my-cmd"},
+				Network: "my-network",
+				Endpoints: map[string]*network.EndpointSettings{
+					"my-network": {
+						IPAMConfig: &network.EndpointIPAMConfig{
+							IPv4Address: "192.0.2.2",
+							IPv6Address: "2001:db8::2",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "container-with-static-ip-on-host-network",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithIPAddresses("192.0.2.2", ""),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "static IP addresses require a user-defined network, got %q", "host"),
+		},
+		{
+			name:    "container-with-invalid-static-ipv4",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithNetwork("my-network"),
+				options.WithIPAddresses("2001:db8::2", ""),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q is not an IPv4 address", "2001:db8::2"),
+		},
+		{
+			name:    "container-with-network-attachments",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithInstanceName("my-container"),
+				options.WithNetworkAttachments([]options.NetworkAttachment{
+					{Network: "mgmt", IPv4Address: "192.0.2.2"},
+					{Network: "data", Aliases: []string{"speaker"}},
+					{Network: "bridge"},
+				}),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd:         []string{"my-cmd"},
+				ContainerID: "my-container",
+				Network:     "mgmt",
+				Endpoints: map[string]*network.EndpointSettings{
+					"mgmt": {IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "192.0.2.2"}},
+				},
+				Connected: map[string]*network.EndpointSettings{
+					"data":   {Aliases: []string{"speaker"}},
+					"bridge": {},
+				},
+			},
+		},
+		{
+			name:    "container-with-network-attachments-and-network",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithNetwork("mgmt"),
+				options.WithNetworkAttachments([]options.NetworkAttachment{{Network: "data"}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "network attachments cannot be combined with a network or static IP addresses"),
+		},
+		{
+			name:    "container-with-host-and-other-network-attachments",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithNetworkAttachments([]options.NetworkAttachment{{Network: "host"}, {Network: "data"}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "network %s cannot be combined with other networks", "host"),
+		},
+		{
+			name:    "container-with-duplicate-network-attachments",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithNetworkAttachments([]options.NetworkAttachment{{Network: "data"}, {Network: "data"}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "network %s is attached more than once", "data"),
+		},
+		{
+			name:    "container-with-alias-on-default-bridge",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithNetworkAttachments([]options.NetworkAttachment{{Network: "bridge", Aliases: []string{"speaker"}}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "network aliases require a user-defined network, got %q", "bridge"),
+		},
+		{
+			name:    "container-with-unreachable-network-attachment",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithInstanceName("my-container"),
+				options.WithNetworkAttachments([]options.NetworkAttachment{{Network: "mgmt"}, {Network: "unreachable"}}),
+			},
+			wantErr: status.Errorf(codes.Internal, "unable to connect container to network %s: %v", "unreachable", "network unreachable not found"),
+		},
 		{
 			name:    "container-with-labels",
 			inImage: "my-image",
@@ -341,13 +712,77 @@ func TestContainerStart(t *testing.T) {
 			},
 			inOpts: []options.Option{options.WithInstanceName("my-container"), options.WithPorts(map[uint32]uint32{1: 1}), options.WithEnv(map[string]string{"AA": "BB"})},
 			wantState: &fakeStartingDocker{
-				Cmd:         []string{"my-cmd"},
-				Ports:       nat.PortSet{"1/tcp": struct{}{}},
+				Cmd:   []string{"my-cmd"},
+				Ports: nat.PortSet{"1/tcp": struct{}{}},
+				Bindings: nat.PortMap{"1/tcp": []nat.PortBinding{
+					{HostIP: "0.0.0.0", HostPort: "1"},
+					{HostIP: "::", HostPort: "1"},
+				}},
 				Env:         []string{"AA=BB"},
 				ContainerID: "my-container",
 				Volumes:     []mount.Mount{},
 			},
 		},
+		{
+			name:    "container-with-udp-and-host-ip-ports",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inCnts: []types.Container{
+				{
+					Names: []string{"/other-container"},
+					Ports: []types.Port{{PublicPort: 514, Type: "tcp"}, {IP: "10.0.0.2", PublicPort: 179, Type: "tcp"}},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithInstanceName("my-container"),
+				options.WithPortBindings([]options.PortBinding{
+					{Internal: 514, External: 514, Protocol: "udp"},
+					{Internal: 179, External: 179, HostIP: "10.0.0.1"},
+				}),
+			},
+			wantState: &fakeStartingDocker{
+				Cmd:   []string{"my-cmd"},
+				Ports: nat.PortSet{"514/udp": struct{}{}, "179/tcp": struct{}{}},
+				Bindings: nat.PortMap{
+					"514/udp": []nat.PortBinding{
+						{HostIP: "0.0.0.0", HostPort: "514"},
+						{HostIP: "::", HostPort: "514"},
+					},
+					"179/tcp": []nat.PortBinding{{HostIP: "10.0.0.1", HostPort: "179"}},
+				},
+				ContainerID: "my-container",
+			},
+		},
+		{
+			name:    "container-with-udp-port-in-use",
+			inImage: "my-image",
+			inTag:   "my-tag",
+			inCmd:   "my-cmd",
+			inSummaries: []image.Summary{
+				{
+					RepoTags: []string{"my-image:my-tag"},
+				},
+			},
+			inCnts: []types.Container{
+				{
+					Names: []string{"/other-container"},
+					Ports: []types.Port{{IP: "0.0.0.0", PublicPort: 514, Type: "udp"}},
+				},
+			},
+			inOpts: []options.Option{
+				options.WithInstanceName("my-container"),
+				options.WithPortBindings([]options.PortBinding{
+					{Internal: 514, External: 514, Protocol: "udp", HostIP: "10.0.0.1"},
+				}),
+			},
+			wantErr: status.Errorf(codes.Unavailable, "port 514/udp already in use"),
+		},
 		{
 			name:    "container-with-env-and-port-and-volumes-and-devices",
 			inImage: "my-image",
@@ -377,8 +812,12 @@ func TestContainerStart(t *testing.T) {
 				}),
 			},
 			wantState: &fakeStartingDocker{
-				Cmd:         []string{"my-cmd"},
-				Ports:       nat.PortSet{"1/tcp": struct{}{}},
+				Cmd:   []string{"my-cmd"},
+				Ports: nat.PortSet{"1/tcp": struct{}{}},
+				Bindings: nat.PortMap{"1/tcp": []nat.PortBinding{
+					{HostIP: "0.0.0.0", HostPort: "1"},
+					{HostIP: "::", HostPort: "1"},
+				}},
 				Env:         []string{"AA=BB"},
 				ContainerID: "my-container",
 				Volumes: []mount.Mount{
@@ -494,3 +933,129 @@ func TestContainerStart(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerStartDependencies(t *testing.T) {
+	deps := []options.Dependency{{Instance: "db", Condition: options.ConditionRunning}}
+
+	tests := []struct {
+		name       string
+		inOpts     []options.Option
+		inCnts     []fakeCnt
+		wantLabels map[string]string
+		wantPolicy container.RestartPolicyMode
+		wantErr    error
+	}{
+		{
+			name:   "dependency-ready",
+			inOpts: []options.Option{options.WithDependsOn(deps), options.WithDependencyTimeout(time.Minute)},
+			inCnts: []fakeCnt{{ID: "db-id", Name: "db", Running: true}},
+			wantLabels: map[string]string{
+				options.DependsOnLabel:         "db",
+				options.DependencyTimeoutLabel: "1m0s",
+			},
+		},
+		{
+			name: "restart-policy-left-to-reconciliation",
+			inOpts: []options.Option{
+				options.WithDependsOn(deps),
+				options.WithRestartPolicy(&cpb.StartContainerRequest_Restart{Policy: cpb.StartContainerRequest_Restart_ON_FAILURE, Attempts: 3}),
+			},
+			inCnts: []fakeCnt{{ID: "db-id", Name: "db", Running: true}},
+			wantLabels: map[string]string{
+				options.DependsOnLabel:     "db",
+				options.RestartPolicyLabel: "on-failure:3",
+			},
+			wantPolicy: container.RestartPolicyDisabled,
+		},
+		{
+			name:    "dependency-not-ready",
+			inOpts:  []options.Option{options.WithDependsOn(deps)},
+			inCnts:  []fakeCnt{{ID: "db-id", Name: "db"}},
+			wantErr: status.Errorf(codes.FailedPrecondition, "dependency %s is not ready: %v", "db", fmt.Errorf("container db is not running")),
+		},
+		{
+			name:    "depends-on-itself",
+			inOpts:  []options.Option{options.WithDependsOn([]options.Dependency{{Instance: "collector"}})},
+			wantErr: status.Errorf(codes.InvalidArgument, "container %s cannot depend on itself", "collector"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts}
+			mgr := New(fbd)
+
+			opts := append([]options.Option{options.WithInstanceName("collector")}, tc.inOpts...)
+			_, err := mgr.ContainerStart(context.Background(), "my-image", "v2", "", opts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerStart(%+v) returned unexpected error (-want, +got):\n%s", tc.inOpts, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			cnt := fbd.find("collector")
+			if cnt == nil {
+				t.Fatalf("ContainerStart(%+v) did not create the container", tc.inOpts)
+			}
+			if diff := cmp.Diff(tc.wantLabels, cnt.Labels); diff != "" {
+				t.Errorf("ContainerStart(%+v) returned diff in labels (-want, +got):\n%s", tc.inOpts, diff)
+			}
+			if cnt.Policy != tc.wantPolicy {
+				t.Errorf("ContainerStart(%+v) created the container with restart policy %q, want %q", tc.inOpts, cnt.Policy, tc.wantPolicy)
+			}
+		})
+	}
+}
+
+func TestHealthConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      options.HealthCheck
+		want    *container.HealthConfig
+		wantErr error
+	}{
+		{
+			name: "inherit-test",
+			in:   options.HealthCheck{Interval: time.Second, Retries: 5},
+			want: &container.HealthConfig{Interval: time.Second, Retries: 5},
+		},
+		{
+			name: "exec-form",
+			in:   options.HealthCheck{Test: []string{"CMD", "/bin/check", "--quiet"}},
+			want: &container.HealthConfig{Test: []string{"CMD", "/bin/check", "--quiet"}},
+		},
+		{
+			name:    "missing-command",
+			in:      options.HealthCheck{Test: []string{"CMD-SHELL"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "health check %s requires a command", "CMD-SHELL"),
+		},
+		{
+			name:    "none-with-arguments",
+			in:      options.HealthCheck{Test: []string{"NONE", "true"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "health check NONE takes no arguments"),
+		},
+		{
+			name:    "interval-too-short",
+			in:      options.HealthCheck{Interval: time.Microsecond},
+			wantErr: status.Errorf(codes.InvalidArgument, "health check %s must be at least 1ms, got %v", "interval", time.Microsecond),
+		},
+		{
+			name:    "negative-retries",
+			in:      options.HealthCheck{Retries: -1},
+			wantErr: status.Errorf(codes.InvalidArgument, "health check retries must not be negative, got %d", -1),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := healthConfig(tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("healthConfig(%+v) returned unexpected error (-want, +got):\n%s", tc.in, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("healthConfig(%+v) returned diff (-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
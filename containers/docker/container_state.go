@@ -0,0 +1,61 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusStates maps each status reported by ListContainer to the runtime states it covers.
+var statusStates = map[cpb.ListContainerResponse_Status][]container.ContainerState{
+	cpb.ListContainerResponse_RUNNING: {container.StateRunning, container.StatePaused, container.StateRestarting},
+	cpb.ListContainerResponse_STOPPED: {container.StateExited, container.StateDead, container.StateRemoving},
+	cpb.ListContainerResponse_PRESENT: {container.StateCreated},
+}
+
+// stateToStatus maps the runtime state of a container to the status reported by ListContainer.
+// Paused and restarting containers still have a live process and are reported as running; a
+// container that was created but never started is reported as present.
+func stateToStatus(state container.ContainerState) cpb.ListContainerResponse_Status {
+	for st, states := range statusStates {
+		for _, s := range states {
+			if s == state {
+				return st
+			}
+		}
+	}
+	return cpb.ListContainerResponse_UNSPECIFIED
+}
+
+// stateFilter returns the runtime states matched by a state filter value, which is either a
+// ListContainer status or a runtime state.
+func stateFilter(value string) ([]container.ContainerState, error) {
+	if st, ok := cpb.ListContainerResponse_Status_value[strings.ToUpper(value)]; ok {
+		if states, ok := statusStates[cpb.ListContainerResponse_Status(st)]; ok {
+			return states, nil
+		}
+	}
+
+	state := container.ContainerState(strings.ToLower(value))
+	if err := container.ValidateContainerState(state); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid state filter %q", value)
+	}
+	return []container.ContainerState{state}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStateToStatus(t *testing.T) {
+	tests := []struct {
+		in   container.ContainerState
+		want cpb.ListContainerResponse_Status
+	}{
+		{in: container.StateCreated, want: cpb.ListContainerResponse_PRESENT},
+		{in: container.StateRunning, want: cpb.ListContainerResponse_RUNNING},
+		{in: container.StatePaused, want: cpb.ListContainerResponse_RUNNING},
+		{in: container.StateRestarting, want: cpb.ListContainerResponse_RUNNING},
+		{in: container.StateRemoving, want: cpb.ListContainerResponse_STOPPED},
+		{in: container.StateExited, want: cpb.ListContainerResponse_STOPPED},
+		{in: container.StateDead, want: cpb.ListContainerResponse_STOPPED},
+		{in: "", want: cpb.ListContainerResponse_UNSPECIFIED},
+		{in: "Up 3 hours", want: cpb.ListContainerResponse_UNSPECIFIED},
+	}
+
+	for _, tc := range tests {
+		if got := stateToStatus(tc.in); got != tc.want {
+			t.Errorf("stateToStatus(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestStateFilter(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []container.ContainerState
+		wantErr error
+	}{
+		{in: "RUNNING", want: []container.ContainerState{"running", "paused", "restarting"}},
+		{in: "stopped", want: []container.ContainerState{"exited", "dead", "removing"}},
+		{in: "PRESENT", want: []container.ContainerState{"created"}},
+		{in: "paused", want: []container.ContainerState{"paused"}},
+		{in: "Exited", want: []container.ContainerState{"exited"}},
+		{in: "NOT_FOUND", wantErr: status.Errorf(codes.InvalidArgument, "invalid state filter %q", "NOT_FOUND")},
+		{in: "sleeping", wantErr: status.Errorf(codes.InvalidArgument, "invalid state filter %q", "sleeping")},
+	}
+
+	for _, tc := range tests {
+		got, err := stateFilter(tc.in)
+		if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+			t.Errorf("stateFilter(%q) returned diff in error (-want, +got):\n%s", tc.in, diff)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("stateFilter(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+	}
+}
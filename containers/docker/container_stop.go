@@ -5,10 +5,11 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
-	"github.com/openconfig/containerz/containers"
 )
 
 // maximumStopTimeout sets a cap on how long the docker can wait before
@@ -21,19 +22,29 @@ const maximumStopTimeout = 10
 // If the Force option is set but no timeout is provided the container's StopTimeout
 // value is used, if set, otherwise the engine default.
 // If the Force option is not set, no forceful termination is performed.
+// Stopping a container that exists but is not running, i.e. that was created or has exited,
+// succeeds rather than returning NotFound, which is reserved to containers that do not exist. It
+// has no effect other than the container being recorded as stopped by the operator, so that it is
+// not started when the device boots.
 func (m *Manager) ContainerStop(ctx context.Context, instance string, opts ...options.Option) error {
 	optionz := options.ApplyOptions(opts...)
 
-	cnts, err := m.client.ContainerList(ctx, container.ListOptions{
-		// TODO(alshabib): consider filtering for the image we care about
-	})
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
 	if err != nil {
 		return err
 	}
 
 	// check if the container exists.
-	if err := checkExistingInstanceAndPorts(instance, nil, cnts); err == nil {
-		return status.Errorf(codes.NotFound, "container %s was not found", instance)
+	cnt, err := findInstance(instance, cnts)
+	if err != nil {
+		return err
+	}
+
+	// there is nothing to do if the container is not running.
+	switch stateToStatus(cnt.State) {
+	case cpb.ListContainerResponse_STOPPED, cpb.ListContainerResponse_PRESENT:
+		m.markStopped(cnt.ID)
+		return nil
 	}
 
 	// a negative timeout indicates to docker that no forceful termination should
@@ -62,6 +73,7 @@ func (m *Manager) ContainerStop(ctx context.Context, instance string, opts ...op
 		return status.Errorf(codes.Unknown, "failed to stop container %s with error %s",
 			instance, err)
 	}
+	m.markStopped(cnt.ID)
 
 	return nil
 }
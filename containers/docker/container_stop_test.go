@@ -5,13 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types"
+	"github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"github.com/openconfig/containerz/containers"
 )
 
 type fakeStoppingDocker struct {
@@ -43,19 +43,62 @@ func (f *fakeStoppingDocker) ContainerRemove(ctx context.Context, container stri
 
 func TestContainerStop(t *testing.T) {
 	tests := []struct {
-		name       string
-		inTimeout  time.Duration
-		inOpts     []options.Option
-		inInstance string
-		inCnts     []types.Container
-		wantState  *fakeStoppingDocker
-		wantErr    error
+		name        string
+		inTimeout   time.Duration
+		inOpts      []options.Option
+		inInstance  string
+		inCnts      []types.Container
+		wantState   *fakeStoppingDocker
+		wantStopped map[string]bool
+		wantErr     error
 	}{
 		{
 			name:       "no-such-instance",
 			inInstance: "no-such-instance",
 			wantErr:    status.Errorf(codes.NotFound, "container no-such-instance was not found"),
 		},
+		{
+			name:       "stop-exited",
+			inInstance: "stop-exited",
+			inCnts: []types.Container{
+				types.Container{
+					ID:    "exited-id",
+					Names: []string{"stop-exited"},
+					State: container.StateExited,
+				},
+			},
+			wantState:   &fakeStoppingDocker{},
+			wantStopped: map[string]bool{"exited-id": true},
+		},
+		{
+			name:       "stop-created",
+			inInstance: "stop-created",
+			inCnts: []types.Container{
+				types.Container{
+					ID:    "created-id",
+					Names: []string{"stop-created"},
+					State: container.StateCreated,
+				},
+			},
+			wantState:   &fakeStoppingDocker{},
+			wantStopped: map[string]bool{"created-id": true},
+		},
+		{
+			name:       "stop-paused",
+			inInstance: "stop-paused",
+			inCnts: []types.Container{
+				types.Container{
+					ID:    "paused-id",
+					Names: []string{"stop-paused"},
+					State: container.StatePaused,
+				},
+			},
+			wantState: &fakeStoppingDocker{
+				Instance: "stop-paused",
+				Duration: -1,
+			},
+			wantStopped: map[string]bool{"paused-id": true},
+		},
 		{
 			name:       "stop-no-force",
 			inInstance: "stop-no-force",
@@ -127,6 +170,11 @@ func TestContainerStop(t *testing.T) {
 					t.Errorf("ContainerStop(%q, %+v) returned diff(-want, +got):\n%s", tc.inInstance, tc.inOpts, diff)
 				}
 			}
+			if tc.wantStopped != nil {
+				if diff := cmp.Diff(tc.wantStopped, mgr.stopped); diff != "" {
+					t.Errorf("ContainerStop(%q, %+v) returned diff in stopped containers (-want, +got):\n%s", tc.inInstance, tc.inOpts, diff)
+				}
+			}
 		})
 	}
 }
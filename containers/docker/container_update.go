@@ -3,19 +3,29 @@ package docker
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
-	"github.com/docker/docker/api/types"
+	"github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
-	"github.com/openconfig/containerz/containers"
 )
 
+const (
+	// defaultHealthTimeout bounds how long a blue/green update waits for the new version to become
+	// healthy, unless overridden by the HealthTimeout option.
+	defaultHealthTimeout = time.Minute
+)
+
+// healthPollInterval is how often the health of the new version is checked.
+var healthPollInterval = time.Second
+
 type instanceConfig struct {
 	config     *container.Config
 	hostConfig *container.HostConfig
@@ -75,11 +85,23 @@ func (m *Manager) performContainerUpdate(ctx context.Context, instance, image, t
 	// There was some error, let's try to restore previous state.
 	errPfx := fmt.Sprintf("failed to update instance %s due to: %v", instance, err)
 
-	resp, err := m.client.ContainerCreate(ctx, oldCntJSON.Config, oldCntJSON.HostConfig, &network.NetworkingConfig{}, nil, instance)
+	networkingConfig, connects := restoreNetworks(oldCntJSON)
+	resp, err := m.client.ContainerCreate(ctx, oldCntJSON.Config, oldCntJSON.HostConfig, networkingConfig, &m.platform, instance)
 	if err != nil {
 		return "", status.Errorf(codes.Internal, "%s; restoration of previous state failed when creating container: %v", errPfx, err)
 	}
 
+	names := make([]string, 0, len(connects))
+	for name := range connects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := m.client.NetworkConnect(ctx, name, resp.ID, connects[name]); err != nil {
+			return "", status.Errorf(codes.Internal, "%s; restoration of previous state failed when connecting container to network %s: %v", errPfx, name, err)
+		}
+	}
+
 	if err := m.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
 		return "", status.Errorf(codes.Internal, "%s; restoration of previous state failed when starting container: %v", errPfx, err)
 	}
@@ -87,6 +109,218 @@ func (m *Manager) performContainerUpdate(ctx context.Context, instance, image, t
 	return instance, status.Errorf(codes.Internal, "%s; yet, restoration of previous state succeeded", errPfx)
 }
 
+// performBlueGreenUpdate starts the new version of the instance under a temporary name while the
+// current version keeps running. Once the new version is healthy, the current version is stopped
+// and the new version takes over the instance name. If the new version fails, it is discarded and
+// the current version is left untouched.
+//
+// The versions only run side by side if they do not compete for the ports of the host, i.e.
+// neither of them uses the network of the host and the new version publishes none of the ports of
+// the current one. Otherwise the current version hands its ports over to the new one: it is stopped
+// before the new version starts, and started again should the new version fail.
+func (m *Manager) performBlueGreenUpdate(ctx context.Context, instance, image, tag, cmd string, cnts []types.Container, opts ...options.Option) (string, error) {
+	// Don't forget to notify the manager that update for this instance has finished.
+	defer func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.updateInProgress, instance)
+	}()
+
+	optionz := options.ApplyOptions(opts...)
+
+	current, err := findInstance(instance, cnts)
+	if err != nil {
+		return "", err
+	}
+
+	// Clean up after an earlier update that was interrupted.
+	next := nextName(instance)
+	if _, err := findInstance(next, cnts); err == nil {
+		m.discard(ctx, next)
+	}
+
+	mode := optionz.Network
+	if len(optionz.NetworkAttachments) > 0 {
+		mode = optionz.NetworkAttachments[0].Network
+	}
+	bindings := portBindings(optionz.PortMapping, optionz.PortBindings)
+	handover := mode == "" || mode == "host" || current.HostConfig.NetworkMode == "host" ||
+		checkPortConflicts(bindings, current.Ports) != nil
+	restart := handover && current.State == container.StateRunning
+	if handover {
+		if err := m.client.ContainerStop(ctx, current.ID, container.StopOptions{}); err != nil {
+			return "", status.Errorf(codes.Internal, "failed update of instance %s due to: failed to stop instance: %v", instance, err)
+		}
+	}
+
+	// fail discards the new version and restores the current one.
+	fail := func(cause error) (string, error) {
+		m.discard(ctx, next)
+		if !restart {
+			return "", status.Errorf(codes.Internal, "failed update of instance %s due to: %v; previous version left running", instance, cause)
+		}
+		if err := m.client.ContainerStart(ctx, current.ID, container.StartOptions{}); err != nil {
+			return "", status.Errorf(codes.Internal, "failed update of instance %s due to: %v; restart of previous version failed: %v", instance, cause, err)
+		}
+		return "", status.Errorf(codes.Internal, "failed update of instance %s due to: %v; previous version restarted", instance, cause)
+	}
+
+	opts = append(opts, options.WithInstanceName(next))
+	_, rev, err := m.startContainer(ctx, image, tag, cmd, opts...)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := m.waitHealthy(ctx, next, optionz.HealthTimeout); err != nil {
+		return fail(err)
+	}
+
+	// The version retained by an earlier update is superseded by the current one, but only removed
+	// once the current one is retired in its place.
+	unpark, err := m.parkPrevious(ctx, instance)
+	if err != nil {
+		return fail(err)
+	}
+	if err := m.promote(ctx, instance, current.ID, next, false, optionz.KeepPrevious); err != nil {
+		unpark(false)
+		m.discard(ctx, next)
+		return "", err
+	}
+	unpark(true)
+	m.record(instance, rev)
+
+	return instance, nil
+}
+
+// promote stops the current version of the instance and renames the replacement container to the
+// instance name, starting it if needed. The current version is renamed to the previous name and
+// kept, stopped, for the keep duration. If any step fails, the current version is restored. The
+// previous name must be free.
+func (m *Manager) promote(ctx context.Context, instance, currentID, replacement string, start bool, keep time.Duration) error {
+	previous := previousName(instance)
+
+	if err := m.client.ContainerStop(ctx, currentID, container.StopOptions{}); err != nil {
+		return status.Errorf(codes.Internal, "failed to stop instance %s: %v", instance, err)
+	}
+
+	restore := func(stage string, cause error) error {
+		if err := m.client.ContainerStart(ctx, currentID, container.StartOptions{}); err != nil {
+			return status.Errorf(codes.Internal, "failed to %s: %v; restoration of instance %s failed: %v", stage, cause, instance, err)
+		}
+		return status.Errorf(codes.Internal, "failed to %s: %v; instance %s was restored", stage, cause, instance)
+	}
+
+	if err := m.client.ContainerRename(ctx, currentID, previous); err != nil {
+		return restore("retire instance "+instance, err)
+	}
+
+	if err := m.client.ContainerRename(ctx, replacement, instance); err != nil {
+		if err := m.client.ContainerRename(ctx, currentID, instance); err != nil {
+			klog.Warningf("unable to rename container %s back to %s: %v", currentID, instance, err)
+		}
+		return restore("rename "+replacement+" to "+instance, err)
+	}
+
+	if start {
+		if err := m.client.ContainerStart(ctx, instance, container.StartOptions{}); err != nil {
+			if err := m.client.ContainerRename(ctx, instance, replacement); err != nil {
+				klog.Warningf("unable to rename container %s back to %s: %v", instance, replacement, err)
+			}
+			if err := m.client.ContainerRename(ctx, currentID, instance); err != nil {
+				klog.Warningf("unable to rename container %s back to %s: %v", currentID, instance, err)
+			}
+			return restore("start "+instance, err)
+		}
+	}
+
+	m.retain(instance, currentID, keep)
+	return nil
+}
+
+// parkPrevious moves the previous version of the instance kept by an earlier update, if any, out of
+// the way of the current version about to be retired. The returned function removes the parked
+// version once the current one is retired, or moves it back and keeps it as before otherwise.
+func (m *Manager) parkPrevious(ctx context.Context, instance string) (func(retired bool), error) {
+	previous := previousName(instance)
+	cnt, err := m.client.ContainerInspect(ctx, previous)
+	if err != nil || cnt.ContainerJSONBase == nil {
+		return func(bool) {}, nil
+	}
+
+	kept := m.release(instance)
+	parked := supersededName(instance)
+	if err := m.client.ContainerRename(ctx, cnt.ID, parked); err != nil {
+		m.restoreRetained(instance, kept)
+		return nil, fmt.Errorf("unable to rename container %s to %s: %v", previous, parked, err)
+	}
+
+	return func(retired bool) {
+		if retired {
+			m.discard(ctx, cnt.ID)
+			return
+		}
+		if err := m.client.ContainerRename(ctx, cnt.ID, previous); err != nil {
+			klog.Warningf("unable to rename container %s back to %s: %v", parked, previous, err)
+		}
+		m.restoreRetained(instance, kept)
+	}, nil
+}
+
+// discard forcibly removes a container, logging any failure.
+func (m *Manager) discard(ctx context.Context, name string) {
+	if err := m.client.ContainerRemove(ctx, name, container.RemoveOptions{Force: true}); err != nil {
+		klog.Warningf("unable to remove container %s: %v", name, err)
+	}
+}
+
+// waitHealthy waits until the container is running and, if it has a health check, healthy.
+func (m *Manager) waitHealthy(ctx context.Context, name string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		cnt, err := m.client.ContainerInspect(ctx, name)
+		if err != nil {
+			return fmt.Errorf("unable to inspect container %s: %v", name, err)
+		}
+		if state := cnt.State; state != nil {
+			switch {
+			case !state.Running:
+				return fmt.Errorf("container %s is %s", name, state.Status)
+			case state.Health == nil || state.Health.Status == container.Healthy:
+				return nil
+			case state.Health.Status == container.Unhealthy:
+				return fmt.Errorf("container %s is unhealthy", name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("container %s did not become healthy within %v", name, timeout)
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// nextName is the temporary name of the new version of an instance during a blue/green update.
+func nextName(instance string) string {
+	return instance + "-next"
+}
+
+// previousName is the name of the previous version of an instance kept for rollback.
+func previousName(instance string) string {
+	return instance + "-previous"
+}
+
+// supersededName is the temporary name of the previous version of an instance while it is
+// superseded by the current version.
+func supersededName(instance string) string {
+	return instance + "-superseded"
+}
+
 func (m *Manager) performContainerUpdatePrechecks(ctx context.Context, instance, imageName, tag, cmd string, async bool, opts ...options.Option) ([]types.Container, error) {
 	optionz := options.ApplyOptions(opts...)
 
@@ -112,10 +346,17 @@ func (m *Manager) performContainerUpdatePrechecks(ctx context.Context, instance,
 	}
 
 	// Ensure that the provided port mapping is feasible.
-	if err := checkPortAvailability(optionz.PortMapping, cnts, instance); err != nil {
+	bindings := portBindings(optionz.PortMapping, optionz.PortBindings)
+	if err := checkPortAvailability(bindings, cnts, instance); err != nil {
 		return nil, err
 	}
 
+	switch optionz.UpdateStrategy {
+	case "", options.RecreateStrategy, options.BlueGreenStrategy:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown update strategy %q", optionz.UpdateStrategy)
+	}
+
 	return cnts, nil
 }
 
@@ -155,7 +396,16 @@ func (m *Manager) stageContainerUpdate(instance string) error {
 // state (date written to memory or the filesystem) cannot be depended upon.
 // In particular, the contents of the filesystem are not guaranteed during a
 // rollback.
+// With the live strategy, only the resource limits and restart policy are
+// changed, in place; see ContainerSetResources.
 func (m *Manager) ContainerUpdate(ctx context.Context, instance, image, tag, cmd string, async bool, opts ...options.Option) (string, error) {
+	// Resource limits are changed in place, leaving the image and command as they are.
+	if options.ApplyOptions(opts...).UpdateStrategy == options.LiveStrategy {
+		if err := m.ContainerSetResources(ctx, instance, opts...); err != nil {
+			return "", err
+		}
+		return instance, nil
+	}
 
 	// Perform all pre-update checks.
 	cnts, err := m.performContainerUpdatePrechecks(ctx, instance, image, tag, cmd, async, opts...)
@@ -169,6 +419,10 @@ func (m *Manager) ContainerUpdate(ctx context.Context, instance, image, tag, cmd
 	}
 
 	// All checks passed, proceed to the actual (synchronous or asynchronous) update.
+	update := m.performContainerUpdate
+	if options.ApplyOptions(opts...).UpdateStrategy == options.BlueGreenStrategy {
+		update = m.performBlueGreenUpdate
+	}
 	if async {
 		klog.Infof("Starting asynchronous update of instance %s to image %s:%s with cmd %s and options %+v", instance, image, tag, cmd, opts)
 		deadline, ok := ctx.Deadline()
@@ -188,7 +442,7 @@ func (m *Manager) ContainerUpdate(ctx context.Context, instance, image, tag, cmd
 		go func() {
 			defer cancel()
 			// There can only be one go routine per instance name due to the mutex handling.
-			updatedInstance, err := m.performContainerUpdate(
+			updatedInstance, err := update(
 				ctx, instance, image, tag, cmd, cnts, opts...)
 			if err != nil {
 				klog.Infof("Async container update failed. Error is %s", err)
@@ -199,7 +453,37 @@ func (m *Manager) ContainerUpdate(ctx context.Context, instance, image, tag, cmd
 		}()
 		return instance, nil
 	}
-	return m.performContainerUpdate(ctx, instance, image, tag, cmd, cnts, opts...)
+	return update(ctx, instance, image, tag, cmd, cnts, opts...)
+}
+
+// restoreNetworks returns the networking config needed to recreate the container on its primary
+// network, along with the endpoint settings of every other network it was connected to. Only the
+// user supplied aliases and static addresses are restored.
+func restoreNetworks(cntJSON types.ContainerJSON) (*network.NetworkingConfig, map[string]*network.EndpointSettings) {
+	cfg := &network.NetworkingConfig{}
+	if cntJSON.ContainerJSONBase == nil || cntJSON.HostConfig == nil || cntJSON.NetworkSettings == nil {
+		return cfg, nil
+	}
+
+	primary := string(cntJSON.HostConfig.NetworkMode)
+	connects := map[string]*network.EndpointSettings{}
+	for name, endpoint := range cntJSON.NetworkSettings.Networks {
+		if endpoint == nil {
+			continue
+		}
+		settings := &network.EndpointSettings{
+			Aliases:    endpoint.Aliases,
+			IPAMConfig: endpoint.IPAMConfig,
+		}
+		if name != primary {
+			connects[name] = settings
+			continue
+		}
+		if settings.IPAMConfig != nil || len(settings.Aliases) > 0 {
+			cfg.EndpointsConfig = map[string]*network.EndpointSettings{name: settings}
+		}
+	}
+	return cfg, connects
 }
 
 // checkInstanceExists checks whether a container with the given instance name exists.
@@ -217,19 +501,15 @@ func checkInstanceExists(instance string, cnts []types.Container) error {
 
 // checkPortAvailability checks whether the provided port map relies on in-use ports.
 // Notably, this check ignores ports on containers matching the provided ignoreInstance name.
-func checkPortAvailability(ports map[uint32]uint32, cnts []types.Container, ignoreInstance string) error {
+func checkPortAvailability(ports []options.PortBinding, cnts []types.Container, ignoreInstance string) error {
 	for _, cnt := range cnts {
 		// Shall we ignore this container's ports?
 		if containerMatchesInstance(cnt, ignoreInstance) {
 			continue
 		}
 
-		for _, port := range cnt.Ports {
-			for _, ext := range ports {
-				if ext == uint32(port.PublicPort) {
-					return status.Errorf(codes.Unavailable, "port %d already in use", ext)
-				}
-			}
+		if err := checkPortConflicts(ports, cnt.Ports); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -245,3 +525,13 @@ func containerMatchesInstance(cnt types.Container, instance string) bool {
 	}
 	return false
 }
+
+// findInstance returns the container matching the instance name.
+func findInstance(instance string, cnts []types.Container) (types.Container, error) {
+	for _, cnt := range cnts {
+		if containerMatchesInstance(cnt, instance) {
+			return cnt, nil
+		}
+	}
+	return types.Container{}, status.Errorf(codes.NotFound, "container %s was not found", instance)
+}
@@ -468,3 +468,368 @@ func TestContainerUpdateAsync(t *testing.T) {
 		t.Fatalf("ContainerUpdate(context.Background(), block-till-released, image-A2, tag-A2, , true) returned unexpected error: %v", err)
 	}
 }
+
+func TestRestoreNetworks(t *testing.T) {
+	tests := []struct {
+		name         string
+		inCntJSON    types.ContainerJSON
+		wantConfig   *network.NetworkingConfig
+		wantConnects map[string]*network.EndpointSettings
+	}{
+		{
+			name: "host-network",
+			inCntJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					HostConfig: &container.HostConfig{NetworkMode: "host"},
+				},
+				NetworkSettings: &types.NetworkSettings{
+					Networks: map[string]*network.EndpointSettings{"host": {}},
+				},
+			},
+			wantConfig: &network.NetworkingConfig{},
+		},
+		{
+			name: "multiple-networks",
+			inCntJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					HostConfig: &container.HostConfig{NetworkMode: "mgmt"},
+				},
+				NetworkSettings: &types.NetworkSettings{
+					Networks: map[string]*network.EndpointSettings{
+						"mgmt": {
+							IPAddress:  "192.0.2.2",
+							IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "192.0.2.2"},
+						},
+						"data": {
+							IPAddress: "198.51.100.7",
+							Aliases:   []string{"speaker"},
+						},
+					},
+				},
+			},
+			wantConfig: &network.NetworkingConfig{
+				EndpointsConfig: map[string]*network.EndpointSettings{
+					"mgmt": {IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "192.0.2.2"}},
+				},
+			},
+			wantConnects: map[string]*network.EndpointSettings{
+				"data": {Aliases: []string{"speaker"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotConfig, gotConnects := restoreNetworks(tc.inCntJSON)
+			if diff := cmp.Diff(tc.wantConfig, gotConfig); diff != "" {
+				t.Errorf("restoreNetworks(%+v) returned diff in config (-want, +got):\n%s", tc.inCntJSON, diff)
+			}
+			if diff := cmp.Diff(tc.wantConnects, gotConnects, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("restoreNetworks(%+v) returned diff in connects (-want, +got):\n%s", tc.inCntJSON, diff)
+			}
+		})
+	}
+}
+
+type fakeCnt struct {
+	ID      string
+	Name    string
+	Running bool
+	Health  container.HealthStatus
+	Ports   []types.Port
+	Labels  map[string]string
+	Policy  container.RestartPolicyMode
+	Exit    int
+}
+
+// fakeBlueGreenDocker keeps track of containers by name so that renames can be observed.
+type fakeBlueGreenDocker struct {
+	fakeDocker
+	health    container.HealthStatus
+	createErr map[string]error // errors returned when creating the named containers
+	renameErr map[string]error // errors returned when renaming the named containers
+	removeErr map[string]error // errors returned when removing the named containers
+	started   []string         // containers started, in order
+	mu        sync.Mutex
+
+	Cnts []fakeCnt
+}
+
+func (f *fakeBlueGreenDocker) find(ref string) *fakeCnt {
+	for i := range f.Cnts {
+		if f.Cnts[i].Name == ref || f.Cnts[i].ID == ref {
+			return &f.Cnts[i]
+		}
+	}
+	return nil
+}
+
+func (f *fakeBlueGreenDocker) ImageList(_ context.Context, _ image.ListOptions) ([]image.Summary, error) {
+	return []image.Summary{{RepoTags: []string{"my-image:v2"}}}, nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerList(_ context.Context, opts container.ListOptions) ([]types.Container, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var cnts []types.Container
+	for _, cnt := range f.Cnts {
+		if opts.Filters.Len() > 0 && !opts.Filters.MatchKVList("label", cnt.Labels) {
+			continue
+		}
+		// Like docker, only running containers publish their ports.
+		state, ports := container.StateExited, []types.Port(nil)
+		if cnt.Running {
+			state, ports = container.StateRunning, cnt.Ports
+		}
+		cnts = append(cnts, types.Container{ID: cnt.ID, Names: []string{"/" + cnt.Name}, State: state, Ports: ports, Labels: cnt.Labels})
+	}
+	return cnts, nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerCreate(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *ocispec.Platform, name string) (container.CreateResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.createErr != nil && f.createErr[name] != nil {
+		return container.CreateResponse{}, f.createErr[name]
+	}
+	var labels map[string]string
+	if config != nil {
+		labels = config.Labels
+	}
+	var policy container.RestartPolicyMode
+	if hostConfig != nil {
+		policy = hostConfig.RestartPolicy.Name
+	}
+	f.Cnts = append(f.Cnts, fakeCnt{ID: name + "-id", Name: name, Health: f.health, Labels: labels, Policy: policy})
+	return container.CreateResponse{ID: name + "-id"}, nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerStart(_ context.Context, ref string, _ container.StartOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cnt := f.find(ref)
+	if cnt == nil {
+		return fmt.Errorf("no such container %s", ref)
+	}
+	cnt.Running = true
+	f.started = append(f.started, cnt.Name)
+	return nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerStop(_ context.Context, ref string, _ container.StopOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cnt := f.find(ref)
+	if cnt == nil {
+		return fmt.Errorf("no such container %s", ref)
+	}
+	cnt.Running = false
+	return nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerRestart(_ context.Context, ref string, _ container.StopOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cnt := f.find(ref)
+	if cnt == nil {
+		return fmt.Errorf("no such container %s", ref)
+	}
+	cnt.Running = true
+	return nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerRename(_ context.Context, ref, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.renameErr[ref]; err != nil {
+		return err
+	}
+	if f.find(name) != nil {
+		return fmt.Errorf("name %s already in use", name)
+	}
+	cnt := f.find(ref)
+	if cnt == nil {
+		return fmt.Errorf("no such container %s", ref)
+	}
+	cnt.Name = name
+	return nil
+}
+
+func (f *fakeBlueGreenDocker) ContainerRemove(_ context.Context, ref string, _ container.RemoveOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.removeErr[ref]; err != nil {
+		return err
+	}
+	for i, cnt := range f.Cnts {
+		if cnt.Name == ref || cnt.ID == ref {
+			f.Cnts = append(f.Cnts[:i], f.Cnts[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no such container %s", ref)
+}
+
+func (f *fakeBlueGreenDocker) ContainerInspect(_ context.Context, ref string) (types.ContainerJSON, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cnt := f.find(ref)
+	if cnt == nil {
+		return types.ContainerJSON{}, fmt.Errorf("no such container %s", ref)
+	}
+	state := &container.State{Running: cnt.Running, Status: container.StateExited, ExitCode: cnt.Exit}
+	if cnt.Running {
+		state.Status = container.StateRunning
+	}
+	if cnt.Health != "" {
+		state.Health = &container.Health{Status: cnt.Health}
+	}
+	base := &types.ContainerJSONBase{ID: cnt.ID, State: state}
+	if cnt.Policy != "" {
+		base.HostConfig = &container.HostConfig{RestartPolicy: container.RestartPolicy{Name: cnt.Policy}}
+	}
+	return types.ContainerJSON{ContainerJSONBase: base, Config: &container.Config{Labels: cnt.Labels}}, nil
+}
+
+func TestContainerUpdateBlueGreen(t *testing.T) {
+	healthPollInterval = time.Millisecond
+
+	tests := []struct {
+		name        string
+		inOpts      []options.Option
+		inCnts      []fakeCnt
+		inHealth    container.HealthStatus
+		inRetained  string
+		inRenameErr map[string]error
+		wantCnts    []fakeCnt
+		wantRetain  bool
+		wantErr     error
+	}{
+		{
+			name:     "healthy-no-keep",
+			inOpts:   []options.Option{options.WithUpdateStrategy(options.BlueGreenStrategy)},
+			inCnts:   []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			inHealth: container.Healthy,
+			wantCnts: []fakeCnt{{ID: "app-next-id", Name: "app", Running: true, Health: container.Healthy}},
+		},
+		{
+			name: "healthy-keep-previous",
+			inOpts: []options.Option{
+				options.WithUpdateStrategy(options.BlueGreenStrategy),
+				options.WithKeepPrevious(time.Hour),
+			},
+			inCnts: []fakeCnt{
+				{ID: "old-id", Name: "app", Running: true},
+				{ID: "older-id", Name: "app-previous"},
+			},
+			wantCnts: []fakeCnt{
+				{ID: "old-id", Name: "app-previous"},
+				{ID: "app-next-id", Name: "app", Running: true},
+			},
+			wantRetain: true,
+		},
+		{
+			name: "promote-fails-keeps-previous",
+			inOpts: []options.Option{
+				options.WithUpdateStrategy(options.BlueGreenStrategy),
+				options.WithKeepPrevious(time.Hour),
+			},
+			inCnts: []fakeCnt{
+				{ID: "old-id", Name: "app", Running: true},
+				{ID: "older-id", Name: "app-previous"},
+			},
+			inRetained: "older-id",
+			inRenameErr: map[string]error{
+				"app-next": fmt.Errorf("rename failed"),
+			},
+			wantCnts: []fakeCnt{
+				{ID: "old-id", Name: "app", Running: true},
+				{ID: "older-id", Name: "app-previous"},
+			},
+			wantRetain: true,
+			wantErr:    status.Errorf(codes.Internal, "failed to rename app-next to app: rename failed; instance app was restored"),
+		},
+		{
+			name: "unhealthy",
+			inOpts: []options.Option{
+				options.WithUpdateStrategy(options.BlueGreenStrategy),
+				options.WithKeepPrevious(time.Hour),
+			},
+			inCnts:   []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			inHealth: container.Unhealthy,
+			wantCnts: []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			wantErr:  status.Errorf(codes.Internal, "failed update of instance app due to: container app-next is unhealthy; previous version restarted"),
+		},
+		{
+			name: "unhealthy-side-by-side",
+			inOpts: []options.Option{
+				options.WithUpdateStrategy(options.BlueGreenStrategy),
+				options.WithNetwork("bridge"),
+			},
+			inCnts:   []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			inHealth: container.Unhealthy,
+			wantCnts: []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			wantErr:  status.Errorf(codes.Internal, "failed update of instance app due to: container app-next is unhealthy; previous version left running"),
+		},
+		{
+			name: "health-timeout",
+			inOpts: []options.Option{
+				options.WithUpdateStrategy(options.BlueGreenStrategy),
+				options.WithHealthTimeout(10 * time.Millisecond),
+			},
+			inCnts:   []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			inHealth: container.Starting,
+			wantCnts: []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			wantErr:  status.Errorf(codes.Internal, "failed update of instance app due to: container app-next did not become healthy within 10ms; previous version restarted"),
+		},
+		{
+			name: "published-ports-handed-over",
+			inOpts: []options.Option{
+				options.WithUpdateStrategy(options.BlueGreenStrategy),
+				options.WithNetwork("bridge"),
+				options.WithPorts(map[uint32]uint32{80: 8080}),
+			},
+			inCnts:   []fakeCnt{{ID: "old-id", Name: "app", Running: true, Ports: []types.Port{{PublicPort: 8080}}}},
+			wantCnts: []fakeCnt{{ID: "app-next-id", Name: "app", Running: true}},
+		},
+		{
+			name:     "unknown-strategy",
+			inOpts:   []options.Option{options.WithUpdateStrategy("canary")},
+			inCnts:   []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			wantCnts: []fakeCnt{{ID: "old-id", Name: "app", Running: true}},
+			wantErr:  status.Errorf(codes.InvalidArgument, "unknown update strategy %q", "canary"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts, health: tc.inHealth, renameErr: tc.inRenameErr}
+			mgr := New(fbd)
+			if tc.inRetained != "" {
+				mgr.retain("app", tc.inRetained, time.Hour)
+			}
+
+			_, err := mgr.ContainerUpdate(context.Background(), "app", "my-image", "v2", "", false, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("ContainerUpdate(%+v) returned unexpected error (-want, +got):\n%s", tc.inOpts, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantCnts, fbd.Cnts, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ContainerUpdate(%+v) returned diff(-want, +got):\n%s", tc.inOpts, diff)
+			}
+
+			if _, ok := mgr.retained["app"]; ok != tc.wantRetain {
+				t.Errorf("ContainerUpdate(%+v) retained previous version: %t, want %t", tc.inOpts, ok, tc.wantRetain)
+			}
+			mgr.release("app")
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dialContext connects to the TCP port of a dependency. It is a variable so that tests can
+// override it.
+var dialContext = (&net.Dialer{Timeout: time.Second}).DialContext
+
+// checkDependencies validates the dependencies of the instance.
+func checkDependencies(instance string, deps []options.Dependency) error {
+	for _, dep := range deps {
+		if dep.Instance == "" {
+			return status.Errorf(codes.InvalidArgument, "dependency %s has no instance", dep)
+		}
+		if instance != "" && dep.Instance == instance {
+			return status.Errorf(codes.InvalidArgument, "container %s cannot depend on itself", instance)
+		}
+		switch dep.Condition {
+		case "", options.ConditionRunning, options.ConditionHealthy:
+		case options.ConditionTCP:
+			if dep.Port == 0 {
+				return status.Errorf(codes.InvalidArgument, "dependency %s has no port", dep)
+			}
+		default:
+			return status.Errorf(codes.InvalidArgument, "dependency %s has unknown condition %q", dep, dep.Condition)
+		}
+	}
+	return nil
+}
+
+// waitDependencies waits for the dependencies to be ready. If the timeout is zero, it fails as soon
+// as a dependency is not ready.
+func (m *Manager) waitDependencies(ctx context.Context, deps []options.Dependency, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		dep, err := m.firstNotReady(ctx, deps)
+		if err == nil {
+			return nil
+		}
+		if timeout <= 0 {
+			return status.Errorf(codes.FailedPrecondition, "dependency %s is not ready: %v", dep, err)
+		}
+		if !time.Now().Before(deadline) {
+			return status.Errorf(codes.DeadlineExceeded, "dependency %s did not become ready within %v: %v", dep, timeout, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// firstNotReady returns the first dependency that is not ready along with the reason why.
+func (m *Manager) firstNotReady(ctx context.Context, deps []options.Dependency) (options.Dependency, error) {
+	for _, dep := range deps {
+		if err := m.ready(ctx, dep); err != nil {
+			return dep, err
+		}
+	}
+	return options.Dependency{}, nil
+}
+
+// ready returns an error if the dependency does not meet its readiness condition.
+func (m *Manager) ready(ctx context.Context, dep options.Dependency) error {
+	cnt, err := m.client.ContainerInspect(ctx, dep.Instance)
+	if err != nil {
+		return fmt.Errorf("unable to inspect container %s: %v", dep.Instance, err)
+	}
+	if cnt.ContainerJSONBase == nil || cnt.State == nil || !cnt.State.Running {
+		return fmt.Errorf("container %s is not running", dep.Instance)
+	}
+
+	switch dep.Condition {
+	case options.ConditionHealthy:
+		if cnt.State.Health == nil {
+			return fmt.Errorf("container %s has no health check", dep.Instance)
+		}
+		if cnt.State.Health.Status != container.Healthy {
+			return fmt.Errorf("container %s is %s", dep.Instance, cnt.State.Health.Status)
+		}
+	case options.ConditionTCP:
+		addr := net.JoinHostPort(containerAddress(cnt), strconv.Itoa(int(dep.Port)))
+		conn, err := dialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("container %s does not accept connections on %s: %v", dep.Instance, addr, err)
+		}
+		conn.Close()
+	}
+	return nil
+}
+
+// containerAddress returns the address at which the ports of the container can be reached. It is
+// the address of the container on its first network, by name, or the loopback address if the
+// container shares the network of the host.
+func containerAddress(cnt types.ContainerJSON) string {
+	if cnt.NetworkSettings != nil {
+		names := make([]string, 0, len(cnt.NetworkSettings.Networks))
+		for name := range cnt.NetworkSettings.Networks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if endpoint := cnt.NetworkSettings.Networks[name]; endpoint != nil && endpoint.IPAddress != "" {
+				return endpoint.IPAddress
+			}
+		}
+	}
+	return "127.0.0.1"
+}
+
+// dependenciesLabel returns the value of the depends-on label for the dependencies.
+func dependenciesLabel(deps []options.Dependency) string {
+	specs := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		specs = append(specs, dep.String())
+	}
+	return strings.Join(specs, ",")
+}
+
+// parseDependencies parses the value of the depends-on label.
+func parseDependencies(label string) ([]options.Dependency, error) {
+	var deps []options.Dependency
+	for _, spec := range splitList(label) {
+		dep, err := options.ParseDependency(spec)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
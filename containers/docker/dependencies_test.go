@@ -0,0 +1,199 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		inDeps  []options.Dependency
+		wantErr error
+	}{
+		{
+			name: "valid",
+			inDeps: []options.Dependency{
+				{Instance: "db"},
+				{Instance: "cache", Condition: options.ConditionHealthy},
+				{Instance: "broker", Condition: options.ConditionTCP, Port: 9092},
+			},
+		},
+		{
+			name:    "no-instance",
+			inDeps:  []options.Dependency{{Condition: options.ConditionHealthy}},
+			wantErr: status.Errorf(codes.InvalidArgument, "dependency %s has no instance", ":healthy"),
+		},
+		{
+			name:    "self",
+			inDeps:  []options.Dependency{{Instance: "collector"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "container %s cannot depend on itself", "collector"),
+		},
+		{
+			name:    "no-port",
+			inDeps:  []options.Dependency{{Instance: "db", Condition: options.ConditionTCP}},
+			wantErr: status.Errorf(codes.InvalidArgument, "dependency %s has no port", "db:tcp/0"),
+		},
+		{
+			name:    "unknown-condition",
+			inDeps:  []options.Dependency{{Instance: "db", Condition: "ready"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "dependency %s has unknown condition %q", "db:ready", "ready"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDependencies("collector", tc.inDeps)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("checkDependencies(%v) returned unexpected error (-want, +got):\n%s", tc.inDeps, diff)
+			}
+		})
+	}
+}
+
+func TestWaitDependencies(t *testing.T) {
+	healthPollInterval = time.Millisecond
+	defer func(dial func(context.Context, string, string) (net.Conn, error)) { dialContext = dial }(dialContext)
+
+	var dialed []string
+	dialContext = func(_ context.Context, _, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		if addr != "127.0.0.1:5432" {
+			return nil, fmt.Errorf("connection refused")
+		}
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}
+
+	tests := []struct {
+		name       string
+		inDeps     []options.Dependency
+		inCnts     []fakeCnt
+		inTimeout  time.Duration
+		wantDialed []string
+		wantErr    error
+	}{
+		{
+			name:   "running",
+			inDeps: []options.Dependency{{Instance: "db", Condition: options.ConditionRunning}},
+			inCnts: []fakeCnt{{ID: "db-id", Name: "db", Running: true}},
+		},
+		{
+			name:   "healthy",
+			inDeps: []options.Dependency{{Instance: "db", Condition: options.ConditionHealthy}},
+			inCnts: []fakeCnt{{ID: "db-id", Name: "db", Running: true, Health: "healthy"}},
+		},
+		{
+			name:       "tcp",
+			inDeps:     []options.Dependency{{Instance: "db", Condition: options.ConditionTCP, Port: 5432}},
+			inCnts:     []fakeCnt{{ID: "db-id", Name: "db", Running: true}},
+			wantDialed: []string{"127.0.0.1:5432"},
+		},
+		{
+			name:    "fail-fast",
+			inDeps:  []options.Dependency{{Instance: "db"}},
+			inCnts:  []fakeCnt{{ID: "db-id", Name: "db"}},
+			wantErr: status.Errorf(codes.FailedPrecondition, "dependency %s is not ready: %v", "db", fmt.Errorf("container db is not running")),
+		},
+		{
+			name:    "no-health-check",
+			inDeps:  []options.Dependency{{Instance: "db", Condition: options.ConditionHealthy}},
+			inCnts:  []fakeCnt{{ID: "db-id", Name: "db", Running: true}},
+			wantErr: status.Errorf(codes.FailedPrecondition, "dependency %s is not ready: %v", "db:healthy", fmt.Errorf("container db has no health check")),
+		},
+		{
+			name:      "timeout",
+			inDeps:    []options.Dependency{{Instance: "db", Condition: options.ConditionHealthy}},
+			inCnts:    []fakeCnt{{ID: "db-id", Name: "db", Running: true, Health: "starting"}},
+			inTimeout: 5 * time.Millisecond,
+			wantErr: status.Errorf(codes.DeadlineExceeded, "dependency %s did not become ready within %v: %v", "db:healthy", 5*time.Millisecond,
+				fmt.Errorf("container db is starting")),
+		},
+		{
+			name:       "port-closed",
+			inDeps:     []options.Dependency{{Instance: "db", Condition: options.ConditionTCP, Port: 6379}},
+			inCnts:     []fakeCnt{{ID: "db-id", Name: "db", Running: true}},
+			wantDialed: []string{"127.0.0.1:6379"},
+			wantErr: status.Errorf(codes.FailedPrecondition, "dependency %s is not ready: %v", "db:tcp/6379",
+				fmt.Errorf("container db does not accept connections on 127.0.0.1:6379: connection refused")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dialed = nil
+			mgr := New(&fakeBlueGreenDocker{Cnts: tc.inCnts})
+
+			err := mgr.waitDependencies(context.Background(), tc.inDeps, tc.inTimeout)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("waitDependencies(%v, %v) returned unexpected error (-want, +got):\n%s", tc.inDeps, tc.inTimeout, diff)
+			}
+
+			if tc.inTimeout == 0 {
+				if diff := cmp.Diff(tc.wantDialed, dialed); diff != "" {
+					t.Errorf("waitDependencies(%v, %v) dialed diff(-want, +got):\n%s", tc.inDeps, tc.inTimeout, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestContainerAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		in   types.ContainerJSON
+		want string
+	}{
+		{
+			name: "host-network",
+			in:   types.ContainerJSON{NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{"host": {}}}},
+			want: "127.0.0.1",
+		},
+		{
+			name: "first-network",
+			in: types.ContainerJSON{NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+				"mgmt": {IPAddress: "192.0.2.3"},
+				"data": {IPAddress: "198.51.100.7"},
+			}}},
+			want: "198.51.100.7",
+		},
+		{
+			name: "no-settings",
+			want: "127.0.0.1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := containerAddress(tc.in); got != tc.want {
+				t.Errorf("containerAddress() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// groupMember is a container of an application group.
+type groupMember struct {
+	cnt  types.Container
+	name string
+}
+
+// groupMembers returns the containers of the group, ordered so that each member comes after the
+// members it depends on.
+func (m *Manager) groupMembers(ctx context.Context, group string) ([]groupMember, error) {
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", options.GroupLabel+"="+group)),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list containers: %v", err)
+	}
+
+	var members []options.GroupMember
+	byName := make(map[string]types.Container, len(cnts))
+	for _, cnt := range cnts {
+		if cnt.Labels[options.GroupLabel] != group || len(cnt.Names) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(cnt.Names[0], "/")
+		deps, err := parseDependencies(cnt.Labels[options.DependsOnLabel])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "member %s of group %s has invalid dependencies: %v", name, group, err)
+		}
+		byName[name] = cnt
+		members = append(members, options.GroupMember{Name: name, DependsOn: deps})
+	}
+	if len(members) == 0 {
+		return nil, status.Errorf(codes.NotFound, "group %s not found", group)
+	}
+
+	// Members may have been removed individually, so only the dependencies still present matter.
+	for i := range members {
+		var deps []options.Dependency
+		for _, dep := range members[i].DependsOn {
+			if _, ok := byName[dep.Instance]; ok {
+				deps = append(deps, dep)
+			}
+		}
+		members[i].DependsOn = deps
+	}
+
+	ordered, err := startOrder(members)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]groupMember, 0, len(ordered))
+	for _, member := range ordered {
+		res = append(res, groupMember{cnt: byName[member.Name], name: member.Name})
+	}
+	return res, nil
+}
+
+// startOrder returns the members ordered so that each member comes after the members it depends
+// on. Members keep their relative order otherwise. If the dependencies have a cycle, the members
+// that can be ordered are returned along with the error.
+func startOrder(members []options.GroupMember) ([]options.GroupMember, error) {
+	index := make(map[string]int, len(members))
+	for i, member := range members {
+		if member.Name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "group members must be named")
+		}
+		if _, ok := index[member.Name]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "group member %s is defined more than once", member.Name)
+		}
+		index[member.Name] = i
+	}
+
+	pending := make([]int, len(members))
+	dependents := make(map[string][]int, len(members))
+	for i, member := range members {
+		for _, dep := range member.DependsOn {
+			if _, ok := index[dep.Instance]; !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "group member %s depends on unknown member %s", member.Name, dep.Instance)
+			}
+			if dep.Instance == member.Name {
+				return nil, status.Errorf(codes.InvalidArgument, "group member %s depends on itself", member.Name)
+			}
+			pending[i]++
+			dependents[dep.Instance] = append(dependents[dep.Instance], i)
+		}
+	}
+
+	ordered := make([]options.GroupMember, 0, len(members))
+	done := make([]bool, len(members))
+	for len(ordered) < len(members) {
+		progress := false
+		for i, member := range members {
+			if done[i] || pending[i] > 0 {
+				continue
+			}
+			done[i] = true
+			progress = true
+			ordered = append(ordered, member)
+			for _, dependent := range dependents[member.Name] {
+				pending[dependent]--
+			}
+			// Restart from the first member so that the original order is kept where possible.
+			break
+		}
+		if !progress {
+			var cycle []string
+			for i, member := range members {
+				if !done[i] {
+					cycle = append(cycle, member.Name)
+				}
+			}
+			return ordered, status.Errorf(codes.InvalidArgument, "group members %s have a dependency cycle", strings.Join(cycle, ", "))
+		}
+	}
+
+	return ordered, nil
+}
+
+// memberOptions returns the options to start the member with. The group wide options come first
+// so that the member's own options take precedence, except for labels which are merged.
+func memberOptions(group string, member options.GroupMember, opts []options.Option) []options.Option {
+	labels := map[string]string{}
+	for key, value := range options.ApplyOptions(opts...).Labels {
+		labels[key] = value
+	}
+	for key, value := range options.ApplyOptions(member.Options...).Labels {
+		labels[key] = value
+	}
+	labels[options.GroupLabel] = group
+	// The dependencies are recorded from the member's definition when it is started.
+	delete(labels, options.DependsOnLabel)
+	delete(labels, options.DependencyTimeoutLabel)
+
+	res := make([]options.Option, 0, len(opts)+len(member.Options)+3)
+	res = append(res, opts...)
+	res = append(res, member.Options...)
+	return append(res, options.WithLabels(labels), options.WithDependsOn(member.DependsOn), options.WithInstanceName(member.Name))
+}
+
+// splitList splits a comma separated list, dropping empty elements.
+func splitList(list string) []string {
+	var res []string
+	for _, elem := range strings.Split(list, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			res = append(res, elem)
+		}
+	}
+	return res
+}
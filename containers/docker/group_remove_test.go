@@ -0,0 +1,88 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGroupRemove(t *testing.T) {
+	group := map[string]string{options.GroupLabel: "bgp"}
+
+	tests := []struct {
+		name     string
+		inOpts   []options.Option
+		inCnts   []fakeCnt
+		wantCnts []fakeCnt
+		wantErr  error
+	}{
+		{
+			name: "remove",
+			inCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Labels: group},
+				{ID: "shipper-id", Name: "shipper", Labels: group},
+				{ID: "other-id", Name: "other", Running: true},
+			},
+			wantCnts: []fakeCnt{{ID: "other-id", Name: "other", Running: true}},
+		},
+		{
+			name: "running-member",
+			inCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Labels: group},
+				{ID: "shipper-id", Name: "shipper", Running: true, Labels: group},
+			},
+			wantCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Labels: group},
+				{ID: "shipper-id", Name: "shipper", Running: true, Labels: group},
+			},
+			wantErr: status.Errorf(codes.FailedPrecondition, "member %s of group %s is running", "shipper", "bgp"),
+		},
+		{
+			name:   "running-member-forced",
+			inOpts: []options.Option{options.Force()},
+			inCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Running: true, Labels: group},
+				{ID: "shipper-id", Name: "shipper", Running: true, Labels: group},
+			},
+		},
+		{
+			name:    "no-such-group",
+			wantErr: status.Errorf(codes.NotFound, "group %s not found", "bgp"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts}
+			mgr := New(fbd)
+
+			err := mgr.GroupRemove(context.Background(), "bgp", tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("GroupRemove(%+v) returned unexpected error (-want, +got):\n%s", tc.inOpts, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantCnts, fbd.Cnts, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GroupRemove(%+v) returned diff(-want, +got):\n%s", tc.inOpts, diff)
+			}
+		})
+	}
+}
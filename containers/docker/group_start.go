@@ -0,0 +1,61 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GroupStart starts the members of an application group, each one after the members it depends
+// on. The options are applied to every member, before the member's own options, and the labels of
+// both are merged. If a member fails to start, the members already started are removed. It returns
+// the names of the members in the order they were started.
+func (m *Manager) GroupStart(ctx context.Context, group string, members []options.GroupMember, opts ...options.Option) ([]string, error) {
+	if group == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group name must be provided")
+	}
+	if len(members) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "group %s has no members", group)
+	}
+
+	ordered, err := startOrder(members)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := m.groupMembers(ctx, group); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "group %s already exists", group)
+	} else if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	started := make([]string, 0, len(ordered))
+	for _, member := range ordered {
+		if _, err := m.ContainerStart(ctx, member.Image, member.Tag, member.Cmd, memberOptions(group, member, opts)...); err != nil {
+			for i := len(started) - 1; i >= 0; i-- {
+				m.discard(ctx, started[i])
+				m.forget(started[i])
+			}
+			return nil, status.Errorf(codes.Internal, "unable to start member %s of group %s: %v", member.Name, group, err)
+		}
+		started = append(started, member.Name)
+	}
+
+	return started, nil
+}
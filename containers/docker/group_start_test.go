@@ -0,0 +1,100 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGroupStart(t *testing.T) {
+	members := []options.GroupMember{
+		{Name: "shipper", Image: "my-image", Tag: "v2", DependsOn: []options.Dependency{{Instance: "app"}}},
+		{Name: "app", Image: "my-image", Tag: "v2"},
+	}
+
+	tests := []struct {
+		name      string
+		inMembers []options.GroupMember
+		inCnts    []fakeCnt
+		inErr     map[string]error
+		want      []string
+		wantCnts  []fakeCnt
+		wantErr   error
+	}{
+		{
+			name:      "start",
+			inMembers: members,
+			want:      []string{"app", "shipper"},
+			wantCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Running: true, Labels: map[string]string{options.GroupLabel: "bgp", "team": "routing"}},
+				{ID: "shipper-id", Name: "shipper", Running: true, Labels: map[string]string{options.GroupLabel: "bgp", options.DependsOnLabel: "app", "team": "routing"}},
+			},
+		},
+		{
+			name:      "member-fails-to-start",
+			inMembers: members,
+			inErr:     map[string]error{"shipper": fmt.Errorf("boom")},
+			wantErr: status.Errorf(codes.Internal, "unable to start member %s of group %s: %v", "shipper", "bgp",
+				status.Errorf(codes.Internal, "unable to create container: %v", fmt.Errorf("boom"))),
+		},
+		{
+			name:      "already-exists",
+			inMembers: members,
+			inCnts:    []fakeCnt{{ID: "app-id", Name: "app", Running: true, Labels: map[string]string{options.GroupLabel: "bgp"}}},
+			wantCnts:  []fakeCnt{{ID: "app-id", Name: "app", Running: true, Labels: map[string]string{options.GroupLabel: "bgp"}}},
+			wantErr:   status.Errorf(codes.AlreadyExists, "group %s already exists", "bgp"),
+		},
+		{
+			name:    "no-members",
+			wantErr: status.Errorf(codes.InvalidArgument, "group %s has no members", "bgp"),
+		},
+		{
+			name: "dependency-cycle",
+			inMembers: []options.GroupMember{
+				{Name: "shipper", DependsOn: []options.Dependency{{Instance: "app"}}},
+				{Name: "app", DependsOn: []options.Dependency{{Instance: "shipper"}}},
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "group members %s have a dependency cycle", "shipper, app"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts, createErr: tc.inErr}
+			mgr := New(fbd)
+
+			got, err := mgr.GroupStart(context.Background(), "bgp", tc.inMembers, options.WithLabels(map[string]string{"team": "routing"}))
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("GroupStart(%+v) returned unexpected error (-want, +got):\n%s", tc.inMembers, diff)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GroupStart(%+v) returned diff(-want, +got):\n%s", tc.inMembers, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantCnts, fbd.Cnts, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GroupStart(%+v) returned diff in containers (-want, +got):\n%s", tc.inMembers, diff)
+			}
+		})
+	}
+}
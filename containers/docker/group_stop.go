@@ -0,0 +1,44 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GroupStop stops the running members of an application group, each one before the members it
+// depends on. The options are passed to ContainerStop.
+func (m *Manager) GroupStop(ctx context.Context, group string, opts ...options.Option) error {
+	members, err := m.groupMembers(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	for i := len(members) - 1; i >= 0; i-- {
+		if members[i].cnt.State != container.StateRunning {
+			continue
+		}
+		if err := m.ContainerStop(ctx, members[i].name, opts...); err != nil {
+			return status.Errorf(codes.Internal, "unable to stop member %s of group %s: %v", members[i].name, group, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGroupStop(t *testing.T) {
+	group := map[string]string{options.GroupLabel: "bgp"}
+
+	tests := []struct {
+		name     string
+		inCnts   []fakeCnt
+		wantCnts []fakeCnt
+		wantErr  error
+	}{
+		{
+			name: "stop",
+			inCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Running: true, Labels: group},
+				{ID: "shipper-id", Name: "shipper", Labels: group},
+				{ID: "other-id", Name: "other", Running: true},
+			},
+			wantCnts: []fakeCnt{
+				{ID: "app-id", Name: "app", Labels: group},
+				{ID: "shipper-id", Name: "shipper", Labels: group},
+				{ID: "other-id", Name: "other", Running: true},
+			},
+		},
+		{
+			name:     "no-such-group",
+			inCnts:   []fakeCnt{{ID: "other-id", Name: "other", Running: true}},
+			wantCnts: []fakeCnt{{ID: "other-id", Name: "other", Running: true}},
+			wantErr:  status.Errorf(codes.NotFound, "group %s not found", "bgp"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts}
+			mgr := New(fbd)
+
+			err := mgr.GroupStop(context.Background(), "bgp")
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("GroupStop() returned unexpected error (-want, +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantCnts, fbd.Cnts, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GroupStop() returned diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
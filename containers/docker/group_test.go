@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStartOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []options.GroupMember
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "no-dependencies",
+			in:   []options.GroupMember{{Name: "app"}, {Name: "shipper"}},
+			want: []string{"app", "shipper"},
+		},
+		{
+			name: "dependencies",
+			in: []options.GroupMember{
+				{Name: "shipper", DependsOn: []options.Dependency{{Instance: "app"}}},
+				{Name: "exporter", DependsOn: []options.Dependency{{Instance: "app"}, {Instance: "shipper"}}},
+				{Name: "app"},
+			},
+			want: []string{"app", "shipper", "exporter"},
+		},
+		{
+			name: "keeps-order-of-independent-members",
+			in: []options.GroupMember{
+				{Name: "shipper", DependsOn: []options.Dependency{{Instance: "app"}}},
+				{Name: "exporter"},
+				{Name: "app"},
+			},
+			want: []string{"exporter", "app", "shipper"},
+		},
+		{
+			name:    "unnamed-member",
+			in:      []options.GroupMember{{Name: "app"}, {}},
+			wantErr: status.Errorf(codes.InvalidArgument, "group members must be named"),
+		},
+		{
+			name:    "duplicate-member",
+			in:      []options.GroupMember{{Name: "app"}, {Name: "app"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "group member %s is defined more than once", "app"),
+		},
+		{
+			name:    "unknown-dependency",
+			in:      []options.GroupMember{{Name: "app", DependsOn: []options.Dependency{{Instance: "db"}}}},
+			wantErr: status.Errorf(codes.InvalidArgument, "group member %s depends on unknown member %s", "app", "db"),
+		},
+		{
+			name:    "self-dependency",
+			in:      []options.GroupMember{{Name: "app", DependsOn: []options.Dependency{{Instance: "app"}}}},
+			wantErr: status.Errorf(codes.InvalidArgument, "group member %s depends on itself", "app"),
+		},
+		{
+			name: "cycle",
+			in: []options.GroupMember{
+				{Name: "app"},
+				{Name: "shipper", DependsOn: []options.Dependency{{Instance: "exporter"}}},
+				{Name: "exporter", DependsOn: []options.Dependency{{Instance: "shipper"}}},
+			},
+			want:    []string{"app"},
+			wantErr: status.Errorf(codes.InvalidArgument, "group members %s have a dependency cycle", "shipper, exporter"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := startOrder(tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("startOrder(%+v) returned unexpected error (-want, +got):\n%s", tc.in, diff)
+			}
+
+			var names []string
+			for _, member := range got {
+				names = append(names, member.Name)
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Errorf("startOrder(%+v) returned diff(-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
+
+func TestMemberOptions(t *testing.T) {
+	member := options.GroupMember{
+		Name:      "shipper",
+		DependsOn: []options.Dependency{{Instance: "app"}, {Instance: "exporter"}},
+		Options: []options.Option{
+			options.WithLabels(map[string]string{"role": "sidecar"}),
+			options.WithEnv(map[string]string{"LEVEL": "debug"}),
+		},
+	}
+	shared := []options.Option{
+		options.WithLabels(map[string]string{"team": "routing", "role": "main"}),
+		options.WithEnv(map[string]string{"LEVEL": "info"}),
+	}
+
+	got := options.ApplyOptions(memberOptions("bgp", member, shared)...)
+
+	wantLabels := map[string]string{
+		"team":             "routing",
+		"role":             "sidecar",
+		options.GroupLabel: "bgp",
+	}
+	if diff := cmp.Diff(wantLabels, got.Labels); diff != "" {
+		t.Errorf("memberOptions() returned diff in labels (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(member.DependsOn, got.DependsOn); diff != "" {
+		t.Errorf("memberOptions() returned diff in dependencies (-want, +got):\n%s", diff)
+	}
+	if got.InstanceName != "shipper" {
+		t.Errorf("memberOptions() returned instance %q, want %q", got.InstanceName, "shipper")
+	}
+	if diff := cmp.Diff(map[string]string{"LEVEL": "debug"}, got.EnvMapping); diff != "" {
+		t.Errorf("memberOptions() returned diff in env (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// GroupUpdate updates an application group to the given members, each one after the members it
+// depends on. Existing members are updated with ContainerUpdate, new ones are started and members
+// no longer part of the group are removed once all the others are updated. If any member fails to
+// update or be removed, the members already updated are rolled back to the revision they were at,
+// the new ones are removed and the ones removed are restored, so that the group is updated as a
+// whole or not at all. The group is left untouched unless the revision of every existing member is
+// known.
+func (m *Manager) GroupUpdate(ctx context.Context, group string, members []options.GroupMember, opts ...options.Option) error {
+	if len(members) == 0 {
+		return status.Errorf(codes.InvalidArgument, "group %s has no members", group)
+	}
+
+	ordered, err := startOrder(members)
+	if err != nil {
+		return err
+	}
+
+	current, err := m.groupMembers(ctx, group)
+	if err != nil {
+		return err
+	}
+	changes := &groupChanges{revisions: make(map[string]int, len(current))}
+	existing := make(map[string]bool, len(current))
+	for _, member := range current {
+		rev, ok := m.currentRevision(member.name)
+		if !ok {
+			return status.Errorf(codes.FailedPrecondition, "member %s of group %s cannot be rolled back as none of its revisions is known", member.name, group)
+		}
+		changes.revisions[member.name] = rev.Number
+		existing[member.name] = true
+	}
+
+	for _, member := range ordered {
+		memberOpts := memberOptions(group, member, opts)
+		if existing[member.Name] {
+			_, err = m.ContainerUpdate(ctx, member.Name, member.Image, member.Tag, member.Cmd, false, memberOpts...)
+		} else {
+			_, err = m.ContainerStart(ctx, member.Image, member.Tag, member.Cmd, memberOpts...)
+		}
+		if err != nil {
+			return m.failGroup(ctx, fmt.Sprintf("failed update of member %s of group %s due to: %v", member.Name, group, err), changes)
+		}
+		if existing[member.Name] {
+			changes.updated = append(changes.updated, member.Name)
+		} else {
+			changes.started = append(changes.started, member.Name)
+		}
+		delete(existing, member.Name)
+	}
+
+	// Whatever is left is no longer part of the group. These members are all stopped before any of
+	// them is removed, and their history is only dropped once all of them are, so that they can be
+	// restored should one of them fail to be removed.
+	for i := len(current) - 1; i >= 0; i-- {
+		if !existing[current[i].name] {
+			continue
+		}
+		if err := m.client.ContainerStop(ctx, current[i].name, container.StopOptions{}); err != nil {
+			return m.failGroup(ctx, fmt.Sprintf("unable to stop member %s of group %s: %v", current[i].name, group, err), changes)
+		}
+		changes.stopped = append(changes.stopped, current[i])
+	}
+	for _, member := range changes.stopped {
+		if err := m.client.ContainerRemove(ctx, member.name, container.RemoveOptions{}); err != nil {
+			return m.failGroup(ctx, fmt.Sprintf("unable to remove member %s of group %s: %v", member.name, group, err), changes)
+		}
+		changes.removed++
+	}
+	for _, member := range changes.stopped {
+		m.forget(member.name)
+	}
+
+	return nil
+}
+
+// groupChanges records the changes made to a group by an update, so that they can be rolled back.
+type groupChanges struct {
+	revisions map[string]int // revision of each existing member before the update
+	updated   []string       // existing members updated, in order
+	started   []string       // new members started, in order
+	stopped   []groupMember  // members no longer part of the group that were stopped, in order
+	removed   int            // number of stopped members that were removed
+}
+
+// failGroup rolls back the changes made to the group and returns an error reporting the cause of
+// the failure and the outcome of the rollback.
+func (m *Manager) failGroup(ctx context.Context, cause string, changes *groupChanges) error {
+	if err := m.rollbackGroup(ctx, changes); err != nil {
+		return status.Errorf(codes.Internal, "%s; rollback of group failed: %v", cause, err)
+	}
+	return status.Errorf(codes.Internal, "%s; group was rolled back", cause)
+}
+
+// rollbackGroup restores the members removed or stopped, removes the started members and rolls the
+// updated members back to the revision they were at, in the reverse order they were changed in.
+// It returns the first error encountered but attempts to roll back every member regardless.
+func (m *Manager) rollbackGroup(ctx context.Context, changes *groupChanges) error {
+	var res error
+	failed := func(name string, err error) {
+		klog.Warningf("unable to roll back container %s: %v", name, err)
+		if res == nil {
+			res = fmt.Errorf("member %s: %v", name, err)
+		}
+	}
+
+	for i := len(changes.stopped) - 1; i >= 0; i-- {
+		member := changes.stopped[i]
+		running := member.cnt.State == container.StateRunning
+		if i >= changes.removed {
+			if running {
+				if err := m.client.ContainerStart(ctx, member.name, container.StartOptions{}); err != nil {
+					failed(member.name, err)
+				}
+			}
+			continue
+		}
+		rev, err := m.findRevision(member.name, strconv.Itoa(changes.revisions[member.name]))
+		if err == nil {
+			if running {
+				_, err = m.createAndStart(ctx, member.name, rev)
+			} else {
+				_, err = m.create(ctx, member.name, rev)
+			}
+		}
+		if err != nil {
+			failed(member.name, err)
+		}
+	}
+
+	for i := len(changes.started) - 1; i >= 0; i-- {
+		m.discard(ctx, changes.started[i])
+		m.forget(changes.started[i])
+	}
+
+	for i := len(changes.updated) - 1; i >= 0; i-- {
+		name := changes.updated[i]
+		if err := m.ContainerRollback(ctx, name, options.WithRevision(strconv.Itoa(changes.revisions[name]))); err != nil {
+			failed(name, err)
+		}
+	}
+	return res
+}
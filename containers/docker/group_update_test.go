@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGroupUpdate(t *testing.T) {
+	app := options.GroupMember{Name: "app", Image: "my-image", Tag: "v2"}
+	shipper := options.GroupMember{Name: "shipper", Image: "my-image", Tag: "v2", DependsOn: []options.Dependency{{Instance: "app"}}}
+	exporter := options.GroupMember{Name: "exporter", Image: "my-image", Tag: "v2", DependsOn: []options.Dependency{{Instance: "app"}}}
+	broken := func(member options.GroupMember) options.GroupMember {
+		member.Tag = "v3"
+		return member
+	}
+
+	tests := []struct {
+		name        string
+		inMembers   []options.GroupMember
+		inForget    string
+		inRemoveErr map[string]error
+		wantNames   []string
+		wantHistory int
+		wantCode    codes.Code
+	}{
+		{
+			name:        "update",
+			inMembers:   []options.GroupMember{app, shipper},
+			wantNames:   []string{"app", "shipper"},
+			wantHistory: 2,
+		},
+		{
+			name:        "add-member",
+			inMembers:   []options.GroupMember{app, shipper, exporter},
+			wantNames:   []string{"app", "shipper", "exporter"},
+			wantHistory: 2,
+		},
+		{
+			name:        "drop-member",
+			inMembers:   []options.GroupMember{app},
+			wantNames:   []string{"app"},
+			wantHistory: 2,
+		},
+		{
+			name:        "member-fails",
+			inMembers:   []options.GroupMember{app, broken(shipper)},
+			wantNames:   []string{"shipper", "app"},
+			wantHistory: 3,
+			wantCode:    codes.Internal,
+		},
+		{
+			name:        "new-member-fails",
+			inMembers:   []options.GroupMember{app, shipper, broken(exporter)},
+			wantNames:   []string{"shipper", "app"},
+			wantHistory: 3,
+			wantCode:    codes.Internal,
+		},
+		{
+			name:        "member-without-history",
+			inMembers:   []options.GroupMember{app, shipper},
+			inForget:    "shipper",
+			wantNames:   []string{"app", "shipper"},
+			wantHistory: 1,
+			wantCode:    codes.FailedPrecondition,
+		},
+		{
+			name:        "drop-member-fails",
+			inMembers:   []options.GroupMember{app},
+			inRemoveErr: map[string]error{"shipper": errors.New("device busy")},
+			wantNames:   []string{"shipper", "app"},
+			wantHistory: 3,
+			wantCode:    codes.Internal,
+		},
+		{
+			name:        "removed-member-restored",
+			inMembers:   []options.GroupMember{{Name: "exporter", Image: "my-image", Tag: "v2"}},
+			inRemoveErr: map[string]error{"app": errors.New("device busy")},
+			wantNames:   []string{"app", "shipper"},
+			wantHistory: 1,
+			wantCode:    codes.Internal,
+		},
+		{
+			name:        "dependency-cycle",
+			inMembers:   []options.GroupMember{{Name: "app", DependsOn: []options.Dependency{{Instance: "app"}}}},
+			wantNames:   []string{"app", "shipper"},
+			wantHistory: 1,
+			wantCode:    codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{}
+			mgr := New(fbd)
+			if _, err := mgr.GroupStart(context.Background(), "bgp", []options.GroupMember{app, shipper}); err != nil {
+				t.Fatalf("GroupStart() returned error: %v", err)
+			}
+			if tc.inForget != "" {
+				mgr.forget(tc.inForget)
+			}
+			fbd.removeErr = tc.inRemoveErr
+
+			err := mgr.GroupUpdate(context.Background(), "bgp", tc.inMembers)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("GroupUpdate(%+v) returned error %v, want code %v", tc.inMembers, err, tc.wantCode)
+			}
+
+			var names []string
+			for _, cnt := range fbd.Cnts {
+				if !cnt.Running || cnt.Labels[options.GroupLabel] != "bgp" {
+					t.Errorf("GroupUpdate(%+v) left container %+v, want running group member", tc.inMembers, cnt)
+				}
+				names = append(names, cnt.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, names, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GroupUpdate(%+v) returned diff in containers (-want, +got):\n%s", tc.inMembers, diff)
+			}
+
+			if got := len(mgr.history["app"]); got != tc.wantHistory {
+				t.Errorf("GroupUpdate(%+v) recorded %d revisions of app, want %d", tc.inMembers, got, tc.wantHistory)
+			}
+		})
+	}
+}
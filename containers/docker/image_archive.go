@@ -0,0 +1,515 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moby/go-archive/compression"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// archiveManifestFile lists the images of an archive written by docker save.
+	archiveManifestFile = "manifest.json"
+
+	// archiveIndexFile is the image index of an archive of an OCI image layout.
+	archiveIndexFile = "index.json"
+
+	// archiveRepositoriesFile lists the images of an archive written by docker before 1.10.
+	archiveRepositoriesFile = "repositories"
+
+	// archiveMetadataLimit is the limit on the size of the metadata files, i.e. the manifests,
+	// indexes and configs, read from an image archive.
+	archiveMetadataLimit = 4 << 20
+
+	// maxIndexDepth is the limit on the nesting of the image indexes of an image archive.
+	maxIndexDepth = 4
+
+	// containerdImageNameAnnotation holds the full reference of the images saved by docker.
+	containerdImageNameAnnotation = "io.containerd.image.name"
+
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// archiveManifest is an entry of the manifest.json file of an image archive, which docker loads
+// the images of the archive from.
+type archiveManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// archiveImage is an image manifest of an OCI image archive.
+type archiveImage struct {
+	desc     ocispec.Descriptor // descriptor of the manifest
+	top      ocispec.Descriptor // descriptor of index.json the manifest was found under
+	manifest ocispec.Manifest
+	platform ocispec.Platform
+}
+
+// prepareImageArchive normalizes the image archive into one docker is able to load, and checks
+// that its images are built for the platform of the manager. The archive may be compressed with
+// gzip or zstd, and be written by docker save or hold an OCI image layout. The image of an OCI
+// image layout is selected by platform and named after the reference it was saved under, or ref
+// if it has none. The archive returned in place of f, if any, must be removed by the caller.
+func (m *Manager) prepareImageArchive(f *os.File, ref string) (*os.File, error) {
+	available, err := diskSpace(filepath.Dir(f.Name()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to determine available disk space: %v", err)
+	}
+	uncompressed, err := decompressImageArchive(f, available)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := m.normalizeImageArchive(uncompressed, ref)
+	if uncompressed != f && out != uncompressed {
+		uncompressed.Close()
+		os.Remove(uncompressed.Name())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Docker loads the archive from the current offset.
+	if _, err := out.Seek(0, io.SeekStart); err != nil {
+		if out != f {
+			out.Close()
+			os.Remove(out.Name())
+		}
+		return nil, status.Errorf(codes.Internal, "unable to read image archive: %v", err)
+	}
+	return out, nil
+}
+
+// decompressImageArchive returns the image archive decompressed into a file alongside it, or the
+// archive itself if it is not compressed. At most limit bytes are decompressed, so that an archive
+// which expands beyond the free space next to it is rejected rather than filling the disk.
+func decompressImageArchive(f *os.File, limit uint64) (*os.File, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read image archive: %v", err)
+	}
+	header := make([]byte, 10)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, status.Errorf(codes.Internal, "unable to read image archive: %v", err)
+	}
+	switch c := compression.Detect(header[:n]); c {
+	case compression.None:
+		return f, nil
+	case compression.Gzip, compression.Zstd:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "image archive is compressed with %s, only gzip and zstd are supported", c.Extension())
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read image archive: %v", err)
+	}
+	r, err := compression.DecompressStream(f)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decompress image archive: %v", err)
+	}
+	defer r.Close()
+
+	if limit == 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "not enough space to decompress image archive")
+	}
+	out, err := os.CreateTemp(filepath.Dir(f.Name()), ".image-*.tar")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create image archive: %v", err)
+	}
+	written, err := io.Copy(out, io.LimitReader(r, int64(min(limit, math.MaxInt64-1))+1))
+	if err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decompress image archive: %v", err)
+	}
+	if uint64(written) > limit {
+		out.Close()
+		os.Remove(out.Name())
+		return nil, status.Errorf(codes.ResourceExhausted, "image archive decompresses to more than the %d bytes of space available", limit)
+	}
+	return out, nil
+}
+
+// diskSpace returns the space available to unprivileged users on the filesystem of loc.
+func diskSpace(loc string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(loc, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
+
+// normalizeImageArchive performs the steps of prepareImageArchive on the uncompressed archive.
+func (m *Manager) normalizeImageArchive(f *os.File, ref string) (*os.File, error) {
+	files, err := readArchiveFiles(f, archiveManifestFile, archiveIndexFile, archiveRepositoriesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []archiveManifest
+	if buf, ok := files[archiveManifestFile]; ok {
+		if err := json.Unmarshal(buf, &entries); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid image archive %s: %v", archiveManifestFile, err)
+		}
+	}
+
+	switch {
+	case files[archiveIndexFile] != nil:
+		return m.selectArchiveImage(f, files[archiveIndexFile], entries, ref)
+	case files[archiveManifestFile] != nil:
+		if err := m.checkArchivePlatform(f, entries); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case files[archiveRepositoriesFile] != nil:
+		return f, nil // written by docker before 1.10, which did not record platforms
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "image archive is neither written by docker save nor an OCI image layout")
+	}
+}
+
+// checkArchivePlatform checks that the images of an archive written by docker save are built for
+// the platform of the manager.
+func (m *Manager) checkArchivePlatform(f *os.File, entries []archiveManifest) error {
+	var configs []string
+	for _, e := range entries {
+		configs = append(configs, e.Config)
+	}
+	files, err := readArchiveFiles(f, configs...)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		name := e.Config
+		if len(e.RepoTags) > 0 {
+			name = e.RepoTags[0]
+		}
+		buf, ok := files[path.Clean(e.Config)]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "image archive is missing the config %s of image %s", e.Config, name)
+		}
+		var img ocispec.Image
+		if err := json.Unmarshal(buf, &img); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid config of image %s: %v", name, err)
+		}
+		if !matchPlatform(m.platform, img.Platform) {
+			return status.Errorf(codes.FailedPrecondition, "image %s is built for platform %s, which does not match the platform %s of the target", name, formatPlatform(img.Platform), formatPlatform(m.platform))
+		}
+	}
+	return nil
+}
+
+// selectArchiveImage selects the image of an OCI image archive, holding the given index.json and
+// manifest.json entries, built for the platform of the manager. The image is named after ref if
+// the archive does not name it.
+func (m *Manager) selectArchiveImage(f *os.File, index []byte, entries []archiveManifest, ref string) (*os.File, error) {
+	images, err := archiveImages(f, index)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *archiveImage
+	var available []string
+	for _, img := range images {
+		if matchPlatform(m.platform, img.platform) {
+			selected = img
+			break
+		}
+		if img.platform.OS != "unknown" { // e.g. attestations
+			available = append(available, formatPlatform(img.platform))
+		}
+	}
+	if selected == nil {
+		sort.Strings(available)
+		return nil, status.Errorf(codes.FailedPrecondition, "image archive has no image for platform %s, it provides %s", formatPlatform(m.platform), strings.Join(dedup(available), ", "))
+	}
+
+	entry := archiveManifest{
+		Config: blobPath(selected.manifest.Config),
+	}
+	for _, l := range selected.manifest.Layers {
+		entry.Layers = append(entry.Layers, blobPath(l))
+	}
+	for _, e := range entries {
+		if path.Clean(e.Config) == entry.Config {
+			entry.RepoTags = e.RepoTags
+		}
+	}
+	if entry.RepoTags == nil {
+		if saved := imageReference(selected.top); saved != "" {
+			entry.RepoTags = []string{saved}
+		} else if ref != "" {
+			entry.RepoTags = []string{ref}
+		}
+	}
+	if len(images) == 1 && len(entries) == 1 && path.Clean(entries[0].Config) == entry.Config {
+		return f, nil // docker loads the archive as is
+	}
+
+	desc := selected.desc
+	desc.Annotations = selected.top.Annotations
+	return rewriteImageArchive(f, entry, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{desc},
+	})
+}
+
+// archiveImages returns the image manifests of an OCI image archive, found by walking its
+// index.json and the image indexes it refers to, along with the platform of each.
+func archiveImages(f *os.File, index []byte) ([]*archiveImage, error) {
+	var idx ocispec.Index
+	if err := json.Unmarshal(index, &idx); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid image archive %s: %v", archiveIndexFile, err)
+	}
+
+	var images []*archiveImage
+	var level []*archiveImage
+	for _, d := range idx.Manifests {
+		level = append(level, &archiveImage{desc: d, top: d})
+	}
+	for depth := 0; len(level) > 0; depth++ {
+		if depth > maxIndexDepth {
+			return nil, status.Errorf(codes.InvalidArgument, "image archive indexes are nested more than %d levels deep", maxIndexDepth)
+		}
+		blobs, err := readArchiveBlobs(f, level)
+		if err != nil {
+			return nil, err
+		}
+
+		var nested []*archiveImage
+		for _, img := range level {
+			switch img.desc.MediaType {
+			case ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList:
+				var child ocispec.Index
+				if err := json.Unmarshal(blobs[blobPath(img.desc)], &child); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid image index %s: %v", img.desc.Digest, err)
+				}
+				for _, d := range child.Manifests {
+					nested = append(nested, &archiveImage{desc: d, top: img.top})
+				}
+			case ocispec.MediaTypeImageManifest, mediaTypeDockerManifest:
+				if err := json.Unmarshal(blobs[blobPath(img.desc)], &img.manifest); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid image manifest %s: %v", img.desc.Digest, err)
+				}
+				images = append(images, img)
+			}
+		}
+		level = nested
+	}
+
+	// Images built for a single platform may only record it in their config.
+	var unknown []ocispec.Descriptor
+	for _, img := range images {
+		if img.desc.Platform != nil {
+			img.platform = *img.desc.Platform
+		} else {
+			unknown = append(unknown, img.manifest.Config)
+		}
+	}
+	if len(unknown) > 0 {
+		var paths []string
+		for _, d := range unknown {
+			paths = append(paths, blobPath(d))
+		}
+		configs, err := readArchiveFiles(f, paths...)
+		if err != nil {
+			return nil, err
+		}
+		for _, img := range images {
+			if img.desc.Platform != nil {
+				continue
+			}
+			var config ocispec.Image
+			if err := json.Unmarshal(configs[blobPath(img.manifest.Config)], &config); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid image config %s: %v", img.manifest.Config.Digest, err)
+			}
+			img.platform = config.Platform
+		}
+	}
+	return images, nil
+}
+
+// readArchiveBlobs returns the content of the blobs of the descriptors of the images, by path.
+func readArchiveBlobs(f *os.File, images []*archiveImage) (map[string][]byte, error) {
+	var paths []string
+	for _, img := range images {
+		if err := img.desc.Digest.Validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid digest %q in image archive: %v", img.desc.Digest, err)
+		}
+		paths = append(paths, blobPath(img.desc))
+	}
+	return readArchiveFiles(f, paths...)
+}
+
+// readArchiveFiles returns the content of the named regular files of the tar archive, by name.
+// Files missing from the archive are absent from the result.
+func readArchiveFiles(f *os.File, names ...string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if len(names) == 0 {
+		return files, nil
+	}
+	want := map[string]bool{}
+	for _, name := range names {
+		want[path.Clean(name)] = true
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read image archive: %v", err)
+	}
+	tr := tar.NewReader(f)
+	for len(files) < len(want) {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid image archive: %v", err)
+		}
+		name := path.Clean(hdr.Name)
+		if !want[name] || !hdr.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		if hdr.Size > archiveMetadataLimit {
+			return nil, status.Errorf(codes.InvalidArgument, "image archive file %s exceeds the size limit of %d bytes", name, archiveMetadataLimit)
+		}
+		buf, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid image archive: %v", err)
+		}
+		files[name] = buf
+	}
+	return files, nil
+}
+
+// rewriteImageArchive writes a copy of the image archive, alongside it, in which manifest.json
+// only holds entry and index.json is replaced by index.
+func rewriteImageArchive(f *os.File, entry archiveManifest, index ocispec.Index) (*os.File, error) {
+	manifest, err := json.Marshal([]archiveManifest{entry})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal image archive manifest: %v", err)
+	}
+	idx, err := json.Marshal(index)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal image archive index: %v", err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read image archive: %v", err)
+	}
+	out, err := os.CreateTemp(filepath.Dir(f.Name()), ".image-*.tar")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create image archive: %v", err)
+	}
+	if err := copyImageArchive(out, f, map[string][]byte{
+		archiveManifestFile: manifest,
+		archiveIndexFile:    idx,
+	}); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return nil, err
+	}
+	return out, nil
+}
+
+// copyImageArchive copies the tar archive read from r to w, replacing the named files.
+func copyImageArchive(w io.Writer, r io.Reader, replaced map[string][]byte) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid image archive: %v", err)
+		}
+		if _, ok := replaced[path.Clean(hdr.Name)]; ok {
+			continue
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return status.Errorf(codes.Internal, "unable to write image archive: %v", err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return status.Errorf(codes.Internal, "unable to write image archive: %v", err)
+		}
+	}
+
+	names := make([]string, 0, len(replaced))
+	for name := range replaced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(replaced[name]))}); err != nil {
+			return status.Errorf(codes.Internal, "unable to write image archive: %v", err)
+		}
+		if _, err := tw.Write(replaced[name]); err != nil {
+			return status.Errorf(codes.Internal, "unable to write image archive: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return status.Errorf(codes.Internal, "unable to write image archive: %v", err)
+	}
+	return nil
+}
+
+// blobPath returns the path of the blob of the descriptor in an OCI image layout.
+func blobPath(d ocispec.Descriptor) string {
+	return fmt.Sprintf("blobs/%s/%s", d.Digest.Algorithm(), d.Digest.Encoded())
+}
+
+// imageReference returns the reference, of the format <name>:<tag>, the image of the descriptor
+// of index.json was saved under, if any.
+func imageReference(d ocispec.Descriptor) string {
+	if ref := d.Annotations[containerdImageNameAnnotation]; ref != "" {
+		return ref
+	}
+	// The reference name may only hold the tag of the image, which docker is unable to load.
+	if ref := d.Annotations[ocispec.AnnotationRefName]; strings.Contains(ref, ":") {
+		return ref
+	}
+	return ""
+}
+
+// dedup returns the sorted list without its duplicates.
+func dedup(sorted []string) []string {
+	var out []string
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
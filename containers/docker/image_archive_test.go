@@ -0,0 +1,510 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var (
+	amd64 = ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	arm64 = ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}
+)
+
+// imageArchive holds the files of an image archive written by writeImageArchive.
+type imageArchive map[string][]byte
+
+// addJSON adds v, encoded as JSON, to the blobs of the archive and returns its descriptor.
+func (a imageArchive) addJSON(t *testing.T, mediaType string, v any) ocispec.Descriptor {
+	t.Helper()
+	return a.addBlob(mediaType, mustJSON(t, v))
+}
+
+func (a imageArchive) addBlob(mediaType string, content []byte) ocispec.Descriptor {
+	d := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(content), Size: int64(len(content))}
+	a[blobPath(d)] = content
+	return d
+}
+
+// addImage adds the config, a single layer and the manifest of an image built for the platform to
+// the blobs of the archive, and returns the descriptor of the manifest.
+func (a imageArchive) addImage(t *testing.T, platform ocispec.Platform) ocispec.Descriptor {
+	t.Helper()
+	config := a.addJSON(t, ocispec.MediaTypeImageConfig, ocispec.Image{Platform: platform})
+	layer := a.addBlob(ocispec.MediaTypeImageLayer, []byte(platform.Architecture+" layer"))
+	return a.addJSON(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+}
+
+func (a imageArchive) manifest(t *testing.T, d ocispec.Descriptor) ocispec.Manifest {
+	t.Helper()
+	var m ocispec.Manifest
+	if err := json.Unmarshal(a[blobPath(d)], &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func writeImageArchive(t *testing.T, files imageArchive) *os.File {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), "image.tar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tar.NewWriter(f)
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(files[name]))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// readImageArchive returns the manifest.json and index.json files of the archive.
+func readImageArchive(t *testing.T, f *os.File) imageArchive {
+	t.Helper()
+	files, err := readArchiveFiles(f, archiveManifestFile, archiveIndexFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestPrepareImageArchiveDocker(t *testing.T) {
+	tests := []struct {
+		name       string
+		inPlatform ocispec.Platform
+		inConfig   string
+		wantErr    error
+	}{
+		{
+			name:       "matching",
+			inPlatform: arm64,
+			inConfig:   `{"architecture": "arm64", "os": "linux"}`,
+		},
+		{
+			name:       "other-arch",
+			inPlatform: arm64,
+			inConfig:   `{"architecture": "amd64", "os": "linux"}`,
+			wantErr:    status.Errorf(codes.FailedPrecondition, "image %s is built for platform %s, which does not match the platform %s of the target", "server:v1", "linux/amd64", "linux/arm64/v8"),
+		},
+		{
+			name:       "no-config",
+			inPlatform: arm64,
+			wantErr:    status.Errorf(codes.InvalidArgument, "image archive is missing the config %s of image %s", "config.json", "server:v1"),
+		},
+		{
+			name:       "bad-config",
+			inPlatform: arm64,
+			inConfig:   "not-json",
+			wantErr:    status.Errorf(codes.InvalidArgument, "invalid config of image %s: %v", "server:v1", "invalid character 'o' in literal null (expecting 'u')"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files := imageArchive{
+				archiveManifestFile: []byte(`[{"Config": "config.json", "RepoTags": ["server:v1"], "Layers": ["layer.tar"]}]`),
+				"layer.tar":         []byte("layer"),
+			}
+			if tc.inConfig != "" {
+				files["config.json"] = []byte(tc.inConfig)
+			}
+			f := writeImageArchive(t, files)
+
+			got, err := New(&fakeDocker{}, WithPlatform(tc.inPlatform)).prepareImageArchive(f, "")
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("prepareImageArchive() returned unexpected error (-want +got):\n%s", diff)
+			}
+			if err == nil && got != f {
+				t.Errorf("prepareImageArchive() returned %s, want the archive itself", got.Name())
+			}
+		})
+	}
+}
+
+func TestPrepareImageArchiveOCI(t *testing.T) {
+	files := imageArchive{"oci-layout": []byte(`{"imageLayoutVersion": "1.0.0"}`)}
+	amd64Manifest := files.addImage(t, amd64)
+	amd64Manifest.Platform = &amd64
+	arm64Manifest := files.addImage(t, arm64)
+	arm64Manifest.Platform = &arm64
+	attestation := files.addJSON(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{})
+	attestation.Platform = &ocispec.Platform{OS: "unknown", Architecture: "unknown"}
+	index := files.addJSON(t, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64Manifest, arm64Manifest, attestation},
+	})
+	index.Annotations = map[string]string{
+		containerdImageNameAnnotation: "registry/server:v1",
+		ocispec.AnnotationRefName:     "v1",
+	}
+	files[archiveIndexFile] = mustJSON(t, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{index},
+	})
+
+	t.Run("selected", func(t *testing.T) {
+		f := writeImageArchive(t, files)
+		got, err := New(&fakeDocker{}, WithPlatform(arm64)).prepareImageArchive(f, "")
+		if err != nil {
+			t.Fatalf("prepareImageArchive() returned error: %v", err)
+		}
+		if got == f {
+			t.Fatalf("prepareImageArchive() returned the archive itself, want a rewritten archive")
+		}
+		defer os.Remove(got.Name())
+		defer got.Close()
+
+		manifest := files.manifest(t, arm64Manifest)
+		selected := arm64Manifest
+		selected.Annotations = index.Annotations
+		want := imageArchive{
+			archiveManifestFile: mustJSON(t, []archiveManifest{{
+				Config:   blobPath(manifest.Config),
+				RepoTags: []string{"registry/server:v1"},
+				Layers:   []string{blobPath(manifest.Layers[0])},
+			}}),
+			archiveIndexFile: mustJSON(t, ocispec.Index{
+				Versioned: specs.Versioned{SchemaVersion: 2},
+				MediaType: ocispec.MediaTypeImageIndex,
+				Manifests: []ocispec.Descriptor{selected},
+			}),
+		}
+		if diff := cmp.Diff(want, readImageArchive(t, got)); diff != "" {
+			t.Errorf("prepareImageArchive() returned diff (-want +got):\n%s", diff)
+		}
+
+		// The blobs are copied over.
+		blobs, err := readArchiveFiles(got, blobPath(manifest.Config), blobPath(manifest.Layers[0]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(blobs) != 2 {
+			t.Errorf("prepareImageArchive() returned an archive with %d of the 2 blobs of the image", len(blobs))
+		}
+	})
+
+	t.Run("no-matching-platform", func(t *testing.T) {
+		f := writeImageArchive(t, files)
+		_, err := New(&fakeDocker{}, WithPlatform(ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"})).prepareImageArchive(f, "")
+		want := status.Errorf(codes.FailedPrecondition, "image archive has no image for platform %s, it provides %s", "linux/arm/v7", "linux/amd64, linux/arm64/v8")
+		if diff := cmp.Diff(want, err, cmpopts.EquateErrors()); diff != "" {
+			t.Errorf("prepareImageArchive() returned unexpected error (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPrepareImageArchiveSinglePlatform(t *testing.T) {
+	files := imageArchive{}
+	manifest := files.addImage(t, amd64)
+	manifest.Annotations = map[string]string{ocispec.AnnotationRefName: "server:v1"}
+	files[archiveIndexFile] = mustJSON(t, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []ocispec.Descriptor{manifest},
+	})
+	config := files.manifest(t, manifest).Config
+	files[archiveManifestFile] = mustJSON(t, []archiveManifest{{Config: blobPath(config), RepoTags: []string{"server:v1"}}})
+
+	t.Run("matching", func(t *testing.T) {
+		f := writeImageArchive(t, files)
+		got, err := New(&fakeDocker{}, WithPlatform(amd64)).prepareImageArchive(f, "")
+		if err != nil {
+			t.Fatalf("prepareImageArchive() returned error: %v", err)
+		}
+		if got != f {
+			t.Errorf("prepareImageArchive() returned %s, want the archive itself", got.Name())
+		}
+	})
+
+	t.Run("other-arch", func(t *testing.T) {
+		f := writeImageArchive(t, files)
+		_, err := New(&fakeDocker{}, WithPlatform(arm64)).prepareImageArchive(f, "")
+		want := status.Errorf(codes.FailedPrecondition, "image archive has no image for platform %s, it provides %s", "linux/arm64/v8", "linux/amd64")
+		if diff := cmp.Diff(want, err, cmpopts.EquateErrors()); diff != "" {
+			t.Errorf("prepareImageArchive() returned unexpected error (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPrepareImageArchiveLayout(t *testing.T) {
+	tests := []struct {
+		name         string
+		inRefName    string
+		inRef        string
+		wantRepoTags []string
+	}{
+		{
+			name:         "full-reference",
+			inRefName:    "registry:5000/server:v1",
+			inRef:        "other:v2",
+			wantRepoTags: []string{"registry:5000/server:v1"},
+		},
+		{
+			name:         "tag-only",
+			inRefName:    "v1",
+			inRef:        "server:v1",
+			wantRepoTags: []string{"server:v1"},
+		},
+		{
+			name:      "unnamed",
+			inRefName: "v1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files := imageArchive{"oci-layout": []byte(`{"imageLayoutVersion": "1.0.0"}`)}
+			manifest := files.addImage(t, amd64)
+			manifest.Annotations = map[string]string{ocispec.AnnotationRefName: tc.inRefName}
+			files[archiveIndexFile] = mustJSON(t, ocispec.Index{
+				Versioned: specs.Versioned{SchemaVersion: 2},
+				Manifests: []ocispec.Descriptor{manifest},
+			})
+			f := writeImageArchive(t, files)
+
+			got, err := New(&fakeDocker{}, WithPlatform(amd64)).prepareImageArchive(f, tc.inRef)
+			if err != nil {
+				t.Fatalf("prepareImageArchive(%q) returned error: %v", tc.inRef, err)
+			}
+			defer os.Remove(got.Name())
+			defer got.Close()
+
+			var entries []archiveManifest
+			if err := json.Unmarshal(readImageArchive(t, got)[archiveManifestFile], &entries); err != nil {
+				t.Fatalf("prepareImageArchive(%q) returned an archive without a valid %s: %v", tc.inRef, archiveManifestFile, err)
+			}
+			m := files.manifest(t, manifest)
+			want := []archiveManifest{{
+				Config:   blobPath(m.Config),
+				RepoTags: tc.wantRepoTags,
+				Layers:   []string{blobPath(m.Layers[0])},
+			}}
+			if diff := cmp.Diff(want, entries); diff != "" {
+				t.Errorf("prepareImageArchive(%q) returned diff (-want +got):\n%s", tc.inRef, diff)
+			}
+		})
+	}
+}
+
+func TestPrepareImageArchiveCompressed(t *testing.T) {
+	files := imageArchive{
+		archiveManifestFile: []byte(`[{"Config": "config.json", "RepoTags": ["server:v1"]}]`),
+		"config.json":       []byte(`{"architecture": "amd64", "os": "linux"}`),
+	}
+
+	tests := []struct {
+		name       string
+		inCompress func(io.Writer) io.WriteCloser
+	}{
+		{
+			name:       "gzip",
+			inCompress: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		},
+		{
+			name: "zstd",
+			inCompress: func(w io.Writer) io.WriteCloser {
+				zw, err := zstd.NewWriter(w)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return zw
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			uncompressed := writeImageArchive(t, files)
+			if _, err := uncompressed.Seek(0, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Create(uncompressed.Name() + "." + tc.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			w := tc.inCompress(f)
+			if _, err := io.Copy(w, uncompressed); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := New(&fakeDocker{}, WithPlatform(amd64)).prepareImageArchive(f, "")
+			if err != nil {
+				t.Fatalf("prepareImageArchive() returned error: %v", err)
+			}
+			if got == f {
+				t.Fatalf("prepareImageArchive() returned the compressed archive")
+			}
+			defer os.Remove(got.Name())
+			defer got.Close()
+
+			want := imageArchive{archiveManifestFile: files[archiveManifestFile]}
+			if diff := cmp.Diff(want, readImageArchive(t, got)); diff != "" {
+				t.Errorf("prepareImageArchive() returned diff (-want +got):\n%s", diff)
+			}
+			if left, err := filepath.Glob(filepath.Join(filepath.Dir(f.Name()), ".image-*")); err != nil || len(left) != 1 {
+				t.Errorf("prepareImageArchive() left %v alongside the archive, want only the decompressed archive", left)
+			}
+		})
+	}
+}
+
+func TestDecompressImageArchiveLimit(t *testing.T) {
+	const size = 1 << 20
+
+	tests := []struct {
+		name    string
+		inLimit uint64
+		wantErr error
+	}{
+		{
+			name:    "within-limit",
+			inLimit: size,
+		},
+		{
+			name:    "exceeds-limit",
+			inLimit: size - 1,
+			wantErr: status.Errorf(codes.ResourceExhausted, "image archive decompresses to more than the %d bytes of space available", size-1),
+		},
+		{
+			name:    "no-space",
+			wantErr: status.Errorf(codes.ResourceExhausted, "not enough space to decompress image archive"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			f, err := os.Create(filepath.Join(dir, "image.tar.gz"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			w := gzip.NewWriter(f)
+			if _, err := w.Write(make([]byte, size)); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := decompressImageArchive(f, tc.inLimit)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("decompressImageArchive() returned unexpected error (-want +got):\n%s", diff)
+			}
+			if got != nil {
+				defer got.Close()
+			}
+			left, err := filepath.Glob(filepath.Join(dir, ".image-*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if wantLeft := tc.wantErr == nil; (len(left) == 1) != wantLeft || len(left) > 1 {
+				t.Errorf("decompressImageArchive() left %v alongside the archive, want decompressed archive: %t", left, wantLeft)
+			}
+		})
+	}
+}
+
+func TestPrepareImageArchiveInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		inContent []byte
+		wantErr   error
+	}{
+		{
+			name:      "not-tar",
+			inContent: append([]byte("this is not a tar archive"), make([]byte, 1024)...),
+			wantErr:   status.Errorf(codes.InvalidArgument, "invalid image archive: %v", "archive/tar: invalid tar header"),
+		},
+		{
+			name:    "empty",
+			wantErr: status.Errorf(codes.InvalidArgument, "image archive is neither written by docker save nor an OCI image layout"),
+		},
+		{
+			name:      "bzip2",
+			inContent: []byte("BZh91AY&SY"),
+			wantErr:   status.Errorf(codes.InvalidArgument, "image archive is compressed with %s, only gzip and zstd are supported", "tar.bz2"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "image.tar")
+			if err := os.WriteFile(path, tc.inContent, 0644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			_, err = New(&fakeDocker{}).prepareImageArchive(f, "")
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("prepareImageArchive() returned unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	buf, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImageInspect returns the details of the image, referenced by name, name:tag or ID: its size,
+// platform, default command, exposed ports, volumes, labels and layers.
+func (m *Manager) ImageInspect(ctx context.Context, image string) (*options.ImageDetails, error) {
+	img, err := m.client.ImageInspect(ctx, image)
+	if err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "image %s not found", image)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to inspect image %s: %v", image, err)
+	}
+
+	details := &options.ImageDetails{
+		ID:           img.ID,
+		RepoTags:     nonNil(img.RepoTags),
+		RepoDigests:  nonNil(img.RepoDigests),
+		Size:         img.Size,
+		Architecture: img.Architecture,
+		Variant:      img.Variant,
+		OS:           img.Os,
+		Entrypoint:   []string{},
+		Cmd:          []string{},
+		ExposedPorts: []string{},
+		Volumes:      []string{},
+		Labels:       map[string]string{},
+		Layers:       nonNil(img.RootFS.Layers),
+	}
+	// Images built by older tools may lack a creation time.
+	if created, err := time.Parse(time.RFC3339Nano, img.Created); err == nil {
+		details.Created = created
+	}
+
+	if cfg := img.Config; cfg != nil {
+		details.Entrypoint = nonNil(cfg.Entrypoint)
+		details.Cmd = nonNil(cfg.Cmd)
+		for port := range cfg.ExposedPorts {
+			details.ExposedPorts = append(details.ExposedPorts, port)
+		}
+		sort.Strings(details.ExposedPorts)
+		for path := range cfg.Volumes {
+			details.Volumes = append(details.Volumes, path)
+		}
+		sort.Strings(details.Volumes)
+		for k, v := range cfg.Labels {
+			details.Labels[k] = v
+		}
+	}
+
+	return details, nil
+}
+
+// nonNil returns s, or an empty slice if s is nil, so that it is encoded as an empty list.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
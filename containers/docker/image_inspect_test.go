@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type fakeImageInspectingDocker struct {
+	fakeDocker
+	imgs map[string]image.InspectResponse
+}
+
+func (f *fakeImageInspectingDocker) ImageInspect(ctx context.Context, img string, opts ...client.ImageInspectOption) (image.InspectResponse, error) {
+	resp, ok := f.imgs[img]
+	if !ok {
+		return image.InspectResponse{}, cerrdefs.ErrNotFound
+	}
+	return resp, nil
+}
+
+func TestImageInspect(t *testing.T) {
+	created := time.Date(2025, 2, 3, 10, 30, 0, 0, time.UTC)
+	fake := &fakeImageInspectingDocker{
+		imgs: map[string]image.InspectResponse{
+			"server:v1": {
+				ID:           "sha256:server",
+				RepoTags:     []string{"server:v1", "server:latest"},
+				RepoDigests:  []string{"registry/server@sha256:digest"},
+				Created:      created.Format(time.RFC3339Nano),
+				Size:         1024,
+				Architecture: "arm64",
+				Variant:      "v8",
+				Os:           "linux",
+				Config: &dockerspec.DockerOCIImageConfig{
+					ImageConfig: ocispec.ImageConfig{
+						Entrypoint:   []string{"/server"},
+						Cmd:          []string{"--port", "8080"},
+						ExposedPorts: map[string]struct{}{"8080/tcp": {}, "53/udp": {}},
+						Volumes:      map[string]struct{}{"/data": {}, "/cache": {}},
+						Labels:       map[string]string{"version": "1"},
+					},
+				},
+				RootFS: image.RootFS{Type: "layers", Layers: []string{"sha256:base", "sha256:app"}},
+			},
+			"scratch": {
+				ID:      "sha256:scratch",
+				Created: "",
+				Os:      "linux",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		inImage     string
+		wantDetails *options.ImageDetails
+		wantErr     error
+	}{
+		{
+			name:    "image",
+			inImage: "server:v1",
+			wantDetails: &options.ImageDetails{
+				ID:           "sha256:server",
+				RepoTags:     []string{"server:v1", "server:latest"},
+				RepoDigests:  []string{"registry/server@sha256:digest"},
+				Created:      created,
+				Size:         1024,
+				Architecture: "arm64",
+				Variant:      "v8",
+				OS:           "linux",
+				Entrypoint:   []string{"/server"},
+				Cmd:          []string{"--port", "8080"},
+				ExposedPorts: []string{"53/udp", "8080/tcp"},
+				Volumes:      []string{"/cache", "/data"},
+				Labels:       map[string]string{"version": "1"},
+				Layers:       []string{"sha256:base", "sha256:app"},
+			},
+		},
+		{
+			name:    "no-config",
+			inImage: "scratch",
+			wantDetails: &options.ImageDetails{
+				ID:           "sha256:scratch",
+				RepoTags:     []string{},
+				RepoDigests:  []string{},
+				OS:           "linux",
+				Entrypoint:   []string{},
+				Cmd:          []string{},
+				ExposedPorts: []string{},
+				Volumes:      []string{},
+				Labels:       map[string]string{},
+				Layers:       []string{},
+			},
+		},
+		{
+			name:    "not-found",
+			inImage: "missing",
+			wantErr: status.Errorf(codes.NotFound, "image %s not found", "missing"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			details, err := New(fake).ImageInspect(context.Background(), tc.inImage)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("ImageInspect(%q) returned unexpected error (-want +got):\n%s", tc.inImage, diff)
+			}
+			if diff := cmp.Diff(tc.wantDetails, details); diff != "" {
+				t.Errorf("ImageInspect(%q) returned unexpected details (-want +got):\n%s", tc.inImage, diff)
+			}
+		})
+	}
+}
@@ -5,15 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/moby/moby/pkg/jsonmessage"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 	tpb "github.com/openconfig/gnoi/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ImagePull pull a container from a registry to this containerz server. Based on the options
@@ -36,8 +37,12 @@ func (m *Manager) ImagePull(ctx context.Context, imageName, tag string, opts ...
 
 	resp, err := m.client.ImagePull(ctx, fmt.Sprintf("%s:%s", imageName, tag), image.PullOptions{
 		RegistryAuth: auth.IdentityToken,
+		Platform:     formatPlatform(m.platform),
 	})
 	if err != nil {
+		if strings.Contains(err.Error(), "no matching manifest") {
+			return status.Errorf(codes.FailedPrecondition, "image %s:%s is not available for platform %s: %v", imageName, tag, formatPlatform(m.platform), err)
+		}
 		return status.Errorf(codes.Internal, "unable to pull container: %v", err)
 	}
 	defer resp.Close()
@@ -4,19 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"testing"
 
+	"github.com/docker/docker/api/types/image"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/docker/docker/api/types/image"
 	"github.com/moby/moby/pkg/jsonmessage"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 	tpb "github.com/openconfig/gnoi/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type fakePullingDocker struct {
@@ -24,10 +27,15 @@ type fakePullingDocker struct {
 	ImageRef  string
 	SourceRef string
 	TargetRef string
+	Platform  string
 }
 
 func (f *fakePullingDocker) ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error) {
 	f.ImageRef = ref
+	f.Platform = options.Platform
+	if ref == "amd64-only:latest" {
+		return nil, fmt.Errorf("no matching manifest for %s in the manifest list entries", options.Platform)
+	}
 	jm := &jsonmessage.JSONMessage{
 		Progress: &jsonmessage.JSONProgress{
 			Current: 10,
@@ -78,6 +86,7 @@ func TestImagePull(t *testing.T) {
 			inImage: "some-image",
 			wantState: &fakePullingDocker{
 				ImageRef: "some-image:latest",
+				Platform: "linux/arm64/v8",
 			},
 		},
 		{
@@ -86,6 +95,11 @@ func TestImagePull(t *testing.T) {
 			inOpts:  []options.Option{options.WithRegistryAuth(&tpb.Credentials{})},
 			wantErr: status.Error(codes.Unimplemented, "registry auth not yet implemented"),
 		},
+		{
+			name:    "no-matching-platform",
+			inImage: "amd64-only",
+			wantErr: status.Errorf(codes.FailedPrecondition, "image %s:%s is not available for platform %s: %v", "amd64-only", "latest", "linux/arm64/v8", "no matching manifest for linux/arm64/v8 in the manifest list entries"),
+		},
 		{
 			name: "pull-with-tag",
 			inOpts: []options.Option{
@@ -96,6 +110,7 @@ func TestImagePull(t *testing.T) {
 				ImageRef:  "some-image:latest",
 				SourceRef: "some-image:latest",
 				TargetRef: "another-name:another-tag",
+				Platform:  "linux/arm64/v8",
 			},
 		},
 		{
@@ -106,6 +121,7 @@ func TestImagePull(t *testing.T) {
 			inImage: "some-image",
 			wantState: &fakePullingDocker{
 				ImageRef: "some-image:latest",
+				Platform: "linux/arm64/v8",
 			},
 			wantResp: []*cpb.DeployResponse{
 				&cpb.DeployResponse{
@@ -128,6 +144,7 @@ func TestImagePull(t *testing.T) {
 				ImageRef:  "some-image:latest",
 				SourceRef: "some-image:latest",
 				TargetRef: "another-name:another-tag",
+				Platform:  "linux/arm64/v8",
 			},
 			wantResp: []*cpb.DeployResponse{
 				&cpb.DeployResponse{
@@ -145,7 +162,7 @@ func TestImagePull(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeStream.resps = nil
 			fd := &fakePullingDocker{}
-			mgr := New(fd)
+			mgr := New(fd, WithPlatform(ocispec.Platform{OS: "linux", Architecture: "arm64"}))
 
 			if err := mgr.ImagePull(context.Background(), tc.inImage, tc.inTag, tc.inOpts...); err != nil {
 				if tc.wantErr != nil {
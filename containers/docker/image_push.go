@@ -4,28 +4,47 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"github.com/docker/docker/client"
+	"os"
 	"strings"
 
 	"github.com/moby/moby/pkg/jsonmessage"
+	"github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"github.com/openconfig/containerz/containers"
+	"k8s.io/klog/v2"
 )
 
 // ImagePush pushes the container file to the containerz server. It can optionally tag the
-// container, otherwise it will use the name and tag provided in the file.
-func (m *Manager) ImagePush(ctx context.Context, file *os.File, opts ...options.Option) (string, string, error) {
+// container, otherwise it will use the name and tag provided in the file. The file may be
+// compressed with gzip or zstd, and be written by docker save or hold an OCI image layout.
+// It returns the name, tag and ID, i.e. the digest of the configuration, of the loaded image.
+func (m *Manager) ImagePush(ctx context.Context, file *os.File, opts ...options.Option) (string, string, string, error) {
 	if file == nil {
-		return "", "", status.Error(codes.InvalidArgument, "file must be supplied")
+		return "", "", "", status.Error(codes.InvalidArgument, "file must be supplied")
 	}
 
 	options := options.ApplyOptions(opts...)
+	var target string
+	if options.TargetName != "" {
+		if options.TargetTag == "" {
+			options.TargetTag = "latest"
+		}
+		target = fmt.Sprintf("%s:%s", options.TargetName, options.TargetTag)
+	}
 
-	resp, err := m.client.ImageLoad(ctx, file, client.ImageLoadWithQuiet(true))
+	archive, err := m.prepareImageArchive(file, target)
 	if err != nil {
-		return "", "", status.Errorf(codes.Internal, "unable to load image: %v", err)
+		return "", "", "", err
+	}
+	if archive != file {
+		defer os.Remove(archive.Name())
+		defer archive.Close()
+	}
+
+	resp, err := m.client.ImageLoad(ctx, archive, client.ImageLoadWithQuiet(true))
+	if err != nil {
+		return "", "", "", status.Errorf(codes.Internal, "unable to load image: %v", err)
 	}
 	defer resp.Body.Close()
 
@@ -33,28 +52,61 @@ func (m *Manager) ImagePush(ctx context.Context, file *os.File, opts ...options.
 		var jm jsonmessage.JSONMessage
 		dec := json.NewDecoder(resp.Body)
 		if err := dec.Decode(&jm); err != nil {
-			return "", "", status.Convert(err).Err()
+			return "", "", "", status.Convert(err).Err()
+		}
+		if jm.Error != nil {
+			return "", "", "", status.Errorf(codes.Internal, "unable to load image: %v", jm.Error)
 		}
 
-		imageNameAndTag := extractImageNameFromStream(jm.Stream)
-		if options.TargetName != "" && imageNameAndTag != "" {
-			if err := m.client.ImageTag(ctx, imageNameAndTag, fmt.Sprintf("%s:%s", options.TargetName, options.TargetTag)); err != nil {
-				return "", "", status.Convert(err).Err()
+		loaded := extractImageNameFromStream(jm.Stream)
+		if target != "" && loaded != "" {
+			if err := m.client.ImageTag(ctx, loaded, target); err != nil {
+				return "", "", "", status.Convert(err).Err()
 			}
-			return options.TargetName, options.TargetTag, nil
+			return options.TargetName, options.TargetTag, m.imageID(ctx, target), nil
+		}
+		if loaded == "" || strings.HasPrefix(loaded, "sha256:") {
+			return "", "", "", status.Error(codes.InvalidArgument, "the image archive does not name its image, a name must be supplied")
 		}
 
-		parts := strings.SplitN(imageNameAndTag, ":", 2)
-		return parts[0], parts[1], nil
+		name, tag := splitImageReference(loaded)
+		return name, tag, m.imageID(ctx, loaded), nil
 	}
 
-	return options.TargetName, options.TargetTag, nil
+	var id string
+	if target != "" {
+		id = m.imageID(ctx, target)
+	}
+	return options.TargetName, options.TargetTag, id, nil
 }
 
-func extractImageNameFromStream(stream string) string {
-	if len(stream) == 0 {
+// imageID returns the ID of the referenced image, or an empty string if it cannot be inspected.
+func (m *Manager) imageID(ctx context.Context, ref string) string {
+	img, err := m.client.ImageInspect(ctx, ref)
+	if err != nil {
+		klog.Warningf("unable to resolve the ID of image %s: %v", ref, err)
 		return ""
 	}
+	return img.ID
+}
 
-	return strings.Trim(strings.Replace(stream, "Loaded image: ", "", 1), "\n")
+// extractImageNameFromStream returns the reference of the first image loaded, or its ID if it was
+// loaded untagged.
+func extractImageNameFromStream(stream string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(stream), "\n")
+	for _, prefix := range []string{"Loaded image: ", "Loaded image ID: "} {
+		if ref, ok := strings.CutPrefix(line, prefix); ok {
+			return ref
+		}
+	}
+	return ""
+}
+
+// splitImageReference splits an image reference into its name and tag, which defaults to latest.
+// The name may hold the port of a registry, e.g. registry:5000/image:tag.
+func splitImageReference(ref string) (string, string) {
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, "latest"
 }
@@ -9,20 +9,22 @@ import (
 	"os"
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/docker/docker/api/types/image"
 	"github.com/moby/moby/pkg/jsonmessage"
+	"github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"github.com/openconfig/containerz/containers"
 )
 
 type fakePushingDocker struct {
 	fakeDocker
 	image, tag string
+	stream     string
 	isJSON     bool
+	ids        map[string]string
 
 	Source, Target string
 }
@@ -31,6 +33,9 @@ func (f fakePushingDocker) ImageLoad(ctx context.Context, input io.Reader, optio
 	jm := &jsonmessage.JSONMessage{
 		Stream: fmt.Sprintf("Loaded image: %s\n", f.image+":"+f.tag),
 	}
+	if f.stream != "" {
+		jm.Stream = f.stream
+	}
 
 	data, err := json.Marshal(jm)
 	if err != nil {
@@ -43,6 +48,14 @@ func (f fakePushingDocker) ImageLoad(ctx context.Context, input io.Reader, optio
 	}, nil
 }
 
+func (f *fakePushingDocker) ImageInspect(ctx context.Context, ref string, options ...client.ImageInspectOption) (image.InspectResponse, error) {
+	id, ok := f.ids[ref]
+	if !ok {
+		return image.InspectResponse{}, fmt.Errorf("no such image: %s", ref)
+	}
+	return image.InspectResponse{ID: id}, nil
+}
+
 func (f *fakePushingDocker) ImageTag(ctx context.Context, source, target string) error {
 	f.Source = source
 	f.Target = target
@@ -50,14 +63,21 @@ func (f *fakePushingDocker) ImageTag(ctx context.Context, source, target string)
 }
 
 func TestImagePush(t *testing.T) {
+	amd64Archive := imageArchive{
+		archiveManifestFile: []byte(`[{"Config": "config.json", "RepoTags": ["some-image:some-tag"]}]`),
+		"config.json":       []byte(`{"architecture": "amd64", "os": "linux"}`),
+	}
+
 	tests := []struct {
 		name               string
 		inOpts             []options.Option
-		inFile             *os.File
+		inArchive          imageArchive
 		inImage, inTag     string
+		inStream           string
 		isJSON             bool
 		wantState          *fakePushingDocker
 		wantImage, wantTag string
+		wantID             string
 		wantErr            error
 	}{
 		{
@@ -67,25 +87,65 @@ func TestImagePush(t *testing.T) {
 		{
 			name:      "plain-load",
 			isJSON:    true,
-			inFile:    os.Stdin, // using os.Stdin as a stand in for a file. We don't read from it.
+			inArchive: amd64Archive,
 			inImage:   "some-image",
 			inTag:     "some-tag",
 			wantImage: "some-image",
 			wantTag:   "some-tag",
+			wantID:    "sha256:abc",
 		},
 		{
-			name:    "plain-load-with-tagging",
-			isJSON:  true,
-			inFile:  os.Stdin,
-			inImage: "some-image",
-			inTag:   "some-tag",
-			inOpts:  []options.Option{options.WithTarget("another-image", "another-tag")},
+			name:      "plain-load-with-tagging",
+			isJSON:    true,
+			inArchive: amd64Archive,
+			inImage:   "some-image",
+			inTag:     "some-tag",
+			inOpts:    []options.Option{options.WithTarget("another-image", "another-tag")},
 			wantState: &fakePushingDocker{
 				Source: "some-image:some-tag",
 				Target: "another-image:another-tag",
 			},
 			wantImage: "another-image",
 			wantTag:   "another-tag",
+			wantID:    "sha256:def",
+		},
+		{
+			name:      "registry-port",
+			isJSON:    true,
+			inArchive: amd64Archive,
+			inImage:   "registry:5000/some-image",
+			inTag:     "some-tag",
+			wantImage: "registry:5000/some-image",
+			wantTag:   "some-tag",
+		},
+		{
+			name:      "untagged-with-tagging",
+			isJSON:    true,
+			inArchive: amd64Archive,
+			inStream:  "Loaded image ID: sha256:abc\n",
+			inOpts:    []options.Option{options.WithTarget("another-image", "")},
+			wantState: &fakePushingDocker{
+				Source: "sha256:abc",
+				Target: "another-image:latest",
+			},
+			wantImage: "another-image",
+			wantTag:   "latest",
+			wantID:    "sha256:abc",
+		},
+		{
+			name:      "untagged",
+			isJSON:    true,
+			inArchive: amd64Archive,
+			inStream:  "Loaded image ID: sha256:abc\n",
+			wantErr:   status.Error(codes.InvalidArgument, "the image archive does not name its image, a name must be supplied"),
+		},
+		{
+			name: "other-platform",
+			inArchive: imageArchive{
+				archiveManifestFile: []byte(`[{"Config": "config.json", "RepoTags": ["some-image:some-tag"]}]`),
+				"config.json":       []byte(`{"architecture": "arm64", "os": "linux"}`),
+			},
+			wantErr: status.Errorf(codes.FailedPrecondition, "image %s is built for platform %s, which does not match the platform %s of the target", "some-image:some-tag", "linux/arm64", "linux/amd64"),
 		},
 	}
 
@@ -95,10 +155,20 @@ func TestImagePush(t *testing.T) {
 				isJSON: tc.isJSON,
 				tag:    tc.inTag,
 				image:  tc.inImage,
+				stream: tc.inStream,
+				ids: map[string]string{
+					"some-image:some-tag":       "sha256:abc",
+					"another-image:another-tag": "sha256:def",
+					"another-image:latest":      "sha256:abc",
+				},
 			}
-			mgr := New(fd)
+			mgr := New(fd, WithPlatform(amd64))
 
-			gotImage, gotTag, err := mgr.ImagePush(context.Background(), tc.inFile, tc.inOpts...)
+			var file *os.File
+			if tc.inArchive != nil {
+				file = writeImageArchive(t, tc.inArchive)
+			}
+			gotImage, gotTag, gotID, err := mgr.ImagePush(context.Background(), file, tc.inOpts...)
 			if err != nil {
 				if tc.wantErr != nil {
 					if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
@@ -112,6 +182,9 @@ func TestImagePush(t *testing.T) {
 			if gotImage != tc.wantImage || gotTag != tc.wantTag {
 				t.Errorf("ImagePush(file) returned wrong info; want %s/%s, got %s/%s", tc.wantImage, tc.wantTag, gotImage, gotTag)
 			}
+			if gotID != tc.wantID {
+				t.Errorf("ImagePush(file) returned ID %q, want %q", gotID, tc.wantID)
+			}
 
 			if tc.wantState != nil {
 				if diff := cmp.Diff(tc.wantState, fd, cmpopts.IgnoreUnexported(fakePushingDocker{})); diff != "" {
@@ -5,7 +5,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/openconfig/containerz/containers"
 	"k8s.io/klog/v2"
 )
 
@@ -18,6 +20,13 @@ type Vacuum struct {
 	cli  docker
 	quit chan struct{}
 	wg   sync.WaitGroup
+
+	// retained returns the IDs of the stopped containers kept for rollback, which are not removed,
+	// if set.
+	retained func() map[string]bool
+
+	// pruneArtifacts removes the expired plugin tarballs, if set.
+	pruneArtifacts func(context.Context) ([]*options.PluginArtifact, error)
 }
 
 // NewJanitor creates a new docker janitor.
@@ -46,8 +55,9 @@ func (j *Vacuum) Stop(ctx context.Context) {
 }
 
 // vacuum removes any dangling containers and images. Dangling containers are containers that
-// have been stopped but not removed. Dangling images are intermediate images that were either
-// used as part of a build or run that have no name, i.e. images with name '<none>'.
+// have been stopped but not removed, except those kept for rollback. Dangling images are intermediate images that were either
+// used as part of a build or run that have no name, i.e. images with name '<none>'. The expired
+// plugin tarballs are removed too.
 func (j *Vacuum) vacuum(ctx context.Context) {
 	tick := time.NewTicker(cleaningInterval)
 	defer tick.Stop()
@@ -58,7 +68,7 @@ func (j *Vacuum) vacuum(ctx context.Context) {
 			klog.Info("janitor was told to quit so it is")
 			return
 		case <-tick.C:
-			cntReport, err := j.cli.ContainersPrune(ctx, filters.NewArgs())
+			cntReport, err := j.pruneContainers(ctx)
 			if err != nil {
 				klog.Errorf("unable to vacuum containers %v", err)
 			}
@@ -70,6 +80,51 @@ func (j *Vacuum) vacuum(ctx context.Context) {
 
 			klog.Infof("Removed %d containers reclaiming %d bytes", len(cntReport.ContainersDeleted), cntReport.SpaceReclaimed)
 			klog.Infof("Removed %d images reclaiming %d bytes", len(imgReport.ImagesDeleted), imgReport.SpaceReclaimed)
+
+			if j.pruneArtifacts != nil {
+				artifacts, err := j.pruneArtifacts(ctx)
+				if err != nil {
+					klog.Errorf("unable to vacuum plugin artifacts %v", err)
+				}
+				var reclaimed int64
+				for _, a := range artifacts {
+					reclaimed += a.Size
+				}
+				klog.Infof("Removed %d plugin artifacts reclaiming %d bytes", len(artifacts), reclaimed)
+			}
+		}
+	}
+}
+
+// pruneContainers removes the stopped containers, except those kept for rollback and the volume
+// helpers, which are never started. As the prune operation of docker cannot exclude containers by
+// ID, the stopped containers are removed one by one while any is kept.
+func (j *Vacuum) pruneContainers(ctx context.Context) (container.PruneReport, error) {
+	var retained map[string]bool
+	if j.retained != nil {
+		retained = j.retained()
+	}
+	if len(retained) == 0 {
+		return j.cli.ContainersPrune(ctx, filters.NewArgs(filters.Arg("label!", options.VolumeHelperLabel)))
+	}
+
+	cnts, err := j.cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("status", "created"), filters.Arg("status", "exited"), filters.Arg("status", "dead")),
+	})
+	if err != nil {
+		return container.PruneReport{}, err
+	}
+	report := container.PruneReport{}
+	for _, cnt := range cnts {
+		if _, ok := cnt.Labels[options.VolumeHelperLabel]; ok || retained[cnt.ID] {
+			continue
+		}
+		if err := j.cli.ContainerRemove(ctx, cnt.ID, container.RemoveOptions{}); err != nil {
+			klog.Warningf("unable to remove container %s: %v", cnt.ID, err)
+			continue
 		}
+		report.ContainersDeleted = append(report.ContainersDeleted, cnt.ID)
 	}
+	return report, nil
 }
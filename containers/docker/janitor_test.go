@@ -2,25 +2,36 @@ package docker
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/containerz/containers"
 )
 
 type fakeVacuumingDocker struct {
 	fakeDocker
 	cntCalled bool
+	cntArgs   filters.Args
 	imgCalled bool
 }
 
-func (f *fakeVacuumingDocker) ContainersPrune(_ context.Context, _ filters.Args) (container.PruneReport, error) {
+func (f *fakeVacuumingDocker) ContainersPrune(_ context.Context, args filters.Args) (container.PruneReport, error) {
 	f.cntCalled = true
+	f.cntArgs = args
 	return container.PruneReport{}, nil
 }
 
+func (f *fakeVacuumingDocker) PluginList(context.Context, filters.Args) (types.PluginsListResponse, error) {
+	return nil, nil
+}
+
 func (f *fakeVacuumingDocker) ImagesPrune(_ context.Context, _ filters.Args) (image.PruneReport, error) {
 	f.imgCalled = true
 	return image.PruneReport{}, nil
@@ -42,3 +53,68 @@ func TestVacuum(t *testing.T) {
 		t.Errorf("Vacuum did not call the correct api: containers: %t, images: %t", fvd.cntCalled, fvd.imgCalled)
 	}
 }
+
+func TestPruneContainersKeepsVolumeHelpers(t *testing.T) {
+	fvd := &fakeVacuumingDocker{}
+	if _, err := NewJanitor(fvd).pruneContainers(context.Background()); err != nil {
+		t.Fatalf("pruneContainers() returned error: %v", err)
+	}
+	if got := fvd.cntArgs.Get("label!"); !cmp.Equal(got, []string{options.VolumeHelperLabel}) {
+		t.Errorf("pruneContainers() pruned with label! filters %v, want %v", got, []string{options.VolumeHelperLabel})
+	}
+
+	// The stopped containers are removed one by one while any is kept for rollback.
+	fbd := &fakeBlueGreenDocker{
+		Cnts: []fakeCnt{
+			{ID: "old-id", Name: "app-previous"},
+			{ID: "helper-id", Name: "helper", Labels: map[string]string{options.VolumeHelperLabel: "data"}},
+			{ID: "stopped-id", Name: "stopped"},
+		},
+	}
+	mgr := New(fbd)
+	mgr.retain("app", "old-id", time.Hour)
+	defer mgr.release("app")
+
+	report, err := mgr.janitor.pruneContainers(context.Background())
+	if err != nil {
+		t.Fatalf("pruneContainers() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"stopped-id"}, report.ContainersDeleted); diff != "" {
+		t.Errorf("pruneContainers() removed diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestVacuumPluginArtifacts(t *testing.T) {
+	cleaningInterval = time.Second
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "expired.tar"), []byte("plugin"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	uploaded := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "expired.tar"), uploaded, uploaded); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "recent.tar"), []byte("plugin"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	records := `{"expired:latest": "expired", "recent:latest": "recent"}`
+	if err := os.WriteFile(filepath.Join(dir, pluginRecordsFile), []byte(records), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := New(&fakeVacuumingDocker{}, WithPluginLocation(dir), WithPluginRetention(time.Minute))
+	mgr.janitor.Start(ctx)
+
+	time.Sleep(time.Second * 2)
+
+	mgr.janitor.Stop(ctx)
+
+	if _, err := os.Stat(filepath.Join(dir, "expired.tar")); !os.IsNotExist(err) {
+		t.Errorf("Vacuum did not remove the expired plugin artifact: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "recent.tar")); err != nil {
+		t.Errorf("Vacuum removed the recent plugin artifact: %v", err)
+	}
+}
@@ -5,28 +5,44 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
-	"github.com/docker/docker/client"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/registry"
-	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/openconfig/containerz/containers"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"k8s.io/klog/v2"
 )
 
 type docker interface {
 	Close() error
+	CopyFromContainer(ctx context.Context, cnt, srcPath string) (io.ReadCloser, container.PathStat, error)
+	CopyToContainer(ctx context.Context, cnt, path string, content io.Reader, options container.CopyToContainerOptions) error
 	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error)
 	ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error)
+	ContainerKill(ctx context.Context, container, signal string) error
 	ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error)
 	ContainerLogs(ctx context.Context, container string, options container.LogsOptions) (io.ReadCloser, error)
+	ContainerPause(ctx context.Context, container string) error
 	ContainerRemove(ctx context.Context, container string, options container.RemoveOptions) error
+	ContainerRename(ctx context.Context, container, newContainerName string) error
+	ContainerRestart(ctx context.Context, container string, options container.StopOptions) error
 	ContainerStart(ctx context.Context, container string, options container.StartOptions) error
+	ContainerStatsOneShot(ctx context.Context, container string) (container.StatsResponseReader, error)
 	ContainerStop(ctx context.Context, container string, options container.StopOptions) error
+	ContainerUnpause(ctx context.Context, container string) error
+	ContainerUpdate(ctx context.Context, container string, updateConfig container.UpdateConfig) (container.UpdateResponse, error)
+	DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error)
+	Info(ctx context.Context) (system.Info, error)
+	ImageInspect(ctx context.Context, imageID string, options ...client.ImageInspectOption) (image.InspectResponse, error)
 	ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error)
 	ImageLoad(ctx context.Context, input io.Reader, options ...client.ImageLoadOption) (image.LoadResponse, error)
 	ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error)
@@ -37,8 +53,17 @@ type docker interface {
 	PluginDisable(ctx context.Context, name string, options types.PluginDisableOptions) error
 	PluginRemove(ctx context.Context, name string, options types.PluginRemoveOptions) error
 	PluginList(ctx context.Context, filter filters.Args) (types.PluginsListResponse, error)
+	PluginInspectWithRaw(ctx context.Context, name string) (*types.Plugin, []byte, error)
+	PluginSet(ctx context.Context, name string, args []string) error
+	NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error
+	NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error)
+	NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error
+	NetworkInspect(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, error)
+	NetworkList(ctx context.Context, options network.ListOptions) ([]network.Summary, error)
+	NetworkRemove(ctx context.Context, networkID string) error
 	RegistryLogin(ctx context.Context, auth registry.AuthConfig) (registry.AuthenticateOKBody, error)
 	VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error)
+	VolumeInspect(ctx context.Context, volumeID string) (volume.Volume, error)
 	VolumeList(ctx context.Context, options volume.ListOptions) (volume.ListResponse, error)
 	VolumeRemove(ctx context.Context, volumeID string, force bool) error
 
@@ -51,21 +76,131 @@ type Manager struct {
 	client           docker
 	janitor          *Vacuum
 	updateInProgress map[string]struct{}
+	retained         map[string]*retainedVersion // previous versions of the instances kept for rollback
+	stopped          map[string]bool             // IDs of the containers stopped by the operator
+	history          map[string][]*revision
 	mu               sync.Mutex
+
+	seccompProfileDir string           // directory of the named seccomp profiles
+	helperImage       string           // image of the containers used to access the contents of volumes
+	historyLocation   string           // directory the revision histories are persisted to
+	pluginLocation    string           // directory the plugin tarballs are deployed to
+	stagingLocation   string           // directory plugins are extracted to before being created
+	pluginSizeLimit   int64            // limit on the size of the extracted rootfs of a plugin
+	pluginRetention   time.Duration    // time unused plugin tarballs are kept for
+	platform          ocispec.Platform // platform of the images pulled, loaded and run
+}
+
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithSeccompProfileDir sets the directory holding the seccomp profiles containers may request by
+// name. A profile named strict is read from <dir>/strict.json.
+func WithSeccompProfileDir(dir string) Option {
+	return func(m *Manager) {
+		m.seccompProfileDir = dir
+	}
+}
+
+// WithHelperImage sets the image of the short lived containers through which the contents of
+// volumes are exported and imported. The containers are never started, so any image will do, but
+// volumes can be neither exported nor imported without one.
+func WithHelperImage(image string) Option {
+	return func(m *Manager) {
+		m.helperImage = image
+	}
+}
+
+// WithHistoryLocation sets the directory the revision history of each instance is persisted to,
+// as <instance>.json, along with the previous versions kept for rollback and the containers
+// stopped by the operator, so that they survive restarts of containerz. If unset, the history is
+// only kept in memory, the previous versions are removed when containerz restarts and the
+// containers stopped by the operator may be started again when the device boots.
+func WithHistoryLocation(dir string) Option {
+	return func(m *Manager) {
+		m.historyLocation = dir
+	}
+}
+
+// WithPluginLocation sets the directory plugin tarballs are deployed to. It defaults to /plugins.
+func WithPluginLocation(dir string) Option {
+	return func(m *Manager) {
+		m.pluginLocation = dir
+	}
+}
+
+// WithStagingLocation sets the directory plugins are extracted to, each in a directory of its
+// own, before being created. It defaults to /staging.
+func WithStagingLocation(dir string) Option {
+	return func(m *Manager) {
+		m.stagingLocation = dir
+	}
+}
+
+// WithPluginSizeLimit sets the limit, in bytes, on the size of the files of the rootfs of a
+// plugin. It defaults to 4GiB.
+func WithPluginSizeLimit(limit int64) Option {
+	return func(m *Manager) {
+		m.pluginSizeLimit = limit
+	}
+}
+
+// WithPluginRetention enables the removal by the janitor of the plugin tarballs no plugin uses any
+// more, once they were uploaded for longer than retention. It is disabled by default.
+func WithPluginRetention(retention time.Duration) Option {
+	return func(m *Manager) {
+		m.pluginRetention = retention
+	}
+}
+
+// WithPlatform sets the platform images are pulled for, and that the images pushed and the
+// containers started must be built for. It defaults to the platform containerz runs on.
+func WithPlatform(platform ocispec.Platform) Option {
+	return func(m *Manager) {
+		m.platform = normalizePlatform(platform)
+	}
 }
 
 // New builds a new docker manager given a docker client.
-func New(cli docker) *Manager {
-	return &Manager{
+func New(cli docker, opts ...Option) *Manager {
+	m := &Manager{
 		client:           cli,
 		janitor:          NewJanitor(cli),
 		updateInProgress: make(map[string]struct{}),
+		retained:         make(map[string]*retainedVersion),
+		stopped:          make(map[string]bool),
+		history:          make(map[string][]*revision),
+		pluginLocation:   defaultPluginLocation,
+		stagingLocation:  defaultStagingLocation,
+		pluginSizeLimit:  defaultPluginSizeLimit,
+		platform:         hostPlatform(),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	m.janitor.retained = m.retainedIDs
+	if m.pluginRetention > 0 {
+		m.janitor.pruneArtifacts = func(ctx context.Context) ([]*options.PluginArtifact, error) {
+			return m.PluginArtifactPrune(ctx, m.pluginRetention)
+		}
+	}
+	return m
 }
 
-// Start starts a docker session to the host
+// Start starts a docker session to the host. The removal of the previous versions kept for rollback
+// is scheduled again, and containers that declare dependencies are started in dependency order in
+// the background.
 func (m *Manager) Start(ctx context.Context) error {
+	m.loadStopped()
+	if err := m.resumeRetention(ctx); err != nil {
+		klog.Errorf("unable to resume the retention of previous versions: %v", err)
+	}
 	m.janitor.Start(ctx)
+	go func() {
+		if err := m.reconcile(ctx); err != nil {
+			klog.Errorf("unable to reconcile containers: %v", err)
+		}
+	}()
 	return nil
 }
 
@@ -4,18 +4,20 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
-	"github.com/docker/docker/client"
-	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/registry"
-	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -29,6 +31,14 @@ func (f *fakeDocker) Close() error {
 	return nil
 }
 
+func (fakeDocker) CopyFromContainer(ctx context.Context, cnt, srcPath string) (io.ReadCloser, container.PathStat, error) {
+	return nil, container.PathStat{}, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) CopyToContainer(ctx context.Context, cnt, path string, content io.Reader, options container.CopyToContainerOptions) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error) {
 	return container.CreateResponse{}, fmt.Errorf("not implemented")
 }
@@ -41,14 +51,30 @@ func (fakeDocker) ContainerLogs(ctx context.Context, container string, options c
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) ContainerKill(ctx context.Context, container, signal string) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) ContainerPause(ctx context.Context, container string) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) ContainerRemove(ctx context.Context, container string, options container.RemoveOptions) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) ContainerRename(ctx context.Context, container, newContainerName string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) ContainerRestart(ctx context.Context, container string, options container.StopOptions) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) ContainerStart(ctx context.Context, container string, options container.StartOptions) error {
 	return fmt.Errorf("not implemented")
 }
@@ -57,6 +83,22 @@ func (fakeDocker) ContainerStop(ctx context.Context, container string, _ contain
 	return fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) ContainerStatsOneShot(ctx context.Context, cnt string) (container.StatsResponseReader, error) {
+	return container.StatsResponseReader{}, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) ContainerUnpause(ctx context.Context, container string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) ContainerUpdate(ctx context.Context, cnt string, updateConfig container.UpdateConfig) (container.UpdateResponse, error) {
+	return container.UpdateResponse{}, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) ImageInspect(ctx context.Context, imageID string, options ...client.ImageInspectOption) (image.InspectResponse, error) {
+	return image.InspectResponse{}, fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -77,14 +119,50 @@ func (fakeDocker) ImageTag(ctx context.Context, source, target string) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
+	return network.CreateResponse{}, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) NetworkInspect(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, error) {
+	return network.Inspect{}, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) NetworkList(ctx context.Context, options network.ListOptions) ([]network.Summary, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) NetworkRemove(ctx context.Context, networkID string) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) RegistryLogin(ctx context.Context, auth registry.AuthConfig) (registry.AuthenticateOKBody, error) {
 	return registry.AuthenticateOKBody{}, fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	return types.DiskUsage{}, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) Info(ctx context.Context) (system.Info, error) {
+	return system.Info{}, fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error) {
 	return volume.Volume{}, fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) VolumeInspect(ctx context.Context, volumeID string) (volume.Volume, error) {
+	return volume.Volume{}, fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) VolumeList(ctx context.Context, options volume.ListOptions) (volume.ListResponse, error) {
 	return volume.ListResponse{}, fmt.Errorf("not implemented")
 }
@@ -117,13 +195,28 @@ func (fakeDocker) PluginRemove(ctx context.Context, name string, options types.P
 	return fmt.Errorf("not implemented")
 }
 
+func (fakeDocker) PluginInspectWithRaw(ctx context.Context, name string) (*types.Plugin, []byte, error) {
+	return nil, nil, fmt.Errorf("not implemented")
+}
+
+func (fakeDocker) PluginSet(ctx context.Context, name string, args []string) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (fakeDocker) PluginList(ctx context.Context, filter filters.Args) (types.PluginsListResponse, error) {
 	return types.PluginsListResponse{}, fmt.Errorf("not implemented")
 }
 
 func TestNew(t *testing.T) {
 	want := &Manager{
-		client: &fakeDocker{},
+		client:          &fakeDocker{},
+		pluginLocation:  "/plugins",
+		stagingLocation: "/staging",
+		pluginSizeLimit: 4 << 30,
+		platform:        ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH},
+	}
+	if runtime.GOARCH == "arm64" {
+		want.platform.Variant = "v8"
 	}
 
 	got := New(&fakeDocker{})
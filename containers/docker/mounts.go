@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mountsConfig returns the docker mounts of the volume, bind and tmpfs mounts. Their targets must
+// not clash with each other or with those of the existing mounts.
+func mountsConfig(existing []mount.Mount, mounts []options.Mount) ([]mount.Mount, error) {
+	targets := map[string]bool{}
+	for _, m := range existing {
+		targets[filepath.Clean(m.Target)] = true
+	}
+
+	res := make([]mount.Mount, 0, len(mounts))
+	for _, m := range mounts {
+		if !filepath.IsAbs(m.Target) {
+			return nil, status.Errorf(codes.InvalidArgument, "mount target %q must be absolute", m.Target)
+		}
+		target := filepath.Clean(m.Target)
+		if targets[target] {
+			return nil, status.Errorf(codes.InvalidArgument, "mount target %s is used more than once", target)
+		}
+		targets[target] = true
+
+		mnt := mount.Mount{
+			Type:     mount.Type(m.Type),
+			Source:   m.Source,
+			Target:   target,
+			ReadOnly: m.ReadOnly,
+		}
+		switch m.Type {
+		case options.VolumeMount:
+		case options.BindMount:
+			if !filepath.IsAbs(m.Source) {
+				return nil, status.Errorf(codes.InvalidArgument, "bind mount source %q must be absolute", m.Source)
+			}
+			mnt.Source = filepath.Clean(m.Source)
+			if m.Propagation != "" {
+				mnt.BindOptions = &mount.BindOptions{Propagation: mount.Propagation(m.Propagation)}
+			}
+		case options.TmpfsMount:
+			if m.TmpfsSize != 0 || m.TmpfsMode != 0 {
+				mnt.TmpfsOptions = &mount.TmpfsOptions{SizeBytes: m.TmpfsSize, Mode: m.TmpfsMode}
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "mount of %s has unknown type %q", target, m.Type)
+		}
+		res = append(res, mnt)
+	}
+	return res, nil
+}
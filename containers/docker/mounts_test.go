@@ -0,0 +1,84 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMountsConfig(t *testing.T) {
+	existing := []mount.Mount{{Type: "volume", Source: "data", Target: "/data"}}
+
+	tests := []struct {
+		name    string
+		in      []options.Mount
+		want    []mount.Mount
+		wantErr error
+	}{
+		{
+			name: "no-mounts",
+			want: []mount.Mount{},
+		},
+		{
+			name: "bind-and-tmpfs",
+			in: []options.Mount{
+				{Type: options.BindMount, Source: "/var/log/app/", Target: "/logs", ReadOnly: true, Propagation: "rslave"},
+				{Type: options.BindMount, Source: "/run/nos.sock", Target: "/run/nos.sock"},
+				{Type: options.TmpfsMount, Target: "/cache", TmpfsSize: 64 << 20, TmpfsMode: 0o1770},
+				{Type: options.TmpfsMount, Target: "/scratch"},
+			},
+			want: []mount.Mount{
+				{Type: mount.TypeBind, Source: "/var/log/app", Target: "/logs", ReadOnly: true, BindOptions: &mount.BindOptions{Propagation: mount.PropagationRSlave}},
+				{Type: mount.TypeBind, Source: "/run/nos.sock", Target: "/run/nos.sock"},
+				{Type: mount.TypeTmpfs, Target: "/cache", TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 64 << 20, Mode: 0o1770}},
+				{Type: mount.TypeTmpfs, Target: "/scratch"},
+			},
+		},
+		{
+			name:    "relative-target",
+			in:      []options.Mount{{Type: options.TmpfsMount, Target: "cache"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "mount target %q must be absolute", "cache"),
+		},
+		{
+			name:    "relative-source",
+			in:      []options.Mount{{Type: options.BindMount, Source: "log", Target: "/logs"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "bind mount source %q must be absolute", "log"),
+		},
+		{
+			name:    "target-of-volume",
+			in:      []options.Mount{{Type: options.TmpfsMount, Target: "/data/"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "mount target %s is used more than once", "/data"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := mountsConfig(existing, tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("mountsConfig(%+v) returned unexpected error (-want, +got):\n%s", tc.in, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mountsConfig(%+v) returned diff (-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// supportedNetworkDrivers is the set of network drivers that networks can be created with.
+var supportedNetworkDrivers = map[string]bool{
+	"bridge":  true,
+	"macvlan": true,
+	"ipvlan":  true,
+}
+
+// NetworkCreate creates a network with the provided name using the driver specified. The driver
+// defaults to bridge if it is not specified. Driver options (e.g. the parent interface of a
+// macvlan network), IPAM subnets and labels can optionally be provided.
+func (m *Manager) NetworkCreate(ctx context.Context, name, driver string, opts ...options.Option) (string, error) {
+	optionz := options.ApplyOptions(opts...)
+
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "a network name must be supplied")
+	}
+	if driver == "" {
+		driver = "bridge"
+	}
+	if !supportedNetworkDrivers[driver] {
+		return "", status.Errorf(codes.InvalidArgument, "network driver %q is not supported", driver)
+	}
+
+	ipam, ipv6, err := ipamConfig(optionz.NetworkSubnets)
+	if err != nil {
+		return "", err
+	}
+
+	nets, err := m.client.NetworkList(ctx, network.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("name", name)),
+	})
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "unable to list networks: %v", err)
+	}
+	for _, n := range nets {
+		if n.Name == name {
+			return "", status.Errorf(codes.AlreadyExists, "network %s already exists", name)
+		}
+	}
+
+	create := network.CreateOptions{
+		Driver:  driver,
+		Options: optionz.NetworkDriverOptions,
+		Labels:  optionz.NetworkLabels,
+	}
+	if len(ipam) > 0 {
+		create.IPAM = &network.IPAM{Config: ipam}
+	}
+	if ipv6 {
+		create.EnableIPv6 = &ipv6
+	}
+
+	if _, err := m.client.NetworkCreate(ctx, name, create); err != nil {
+		return "", status.Errorf(codes.Internal, "unable to create network: %v", err)
+	}
+	return name, nil
+}
+
+// ipamConfig validates the requested subnets and converts them to their docker representation.
+// It also reports whether any of the subnets is an IPv6 subnet.
+func ipamConfig(subnets []options.Subnet) ([]network.IPAMConfig, bool, error) {
+	var cfgs []network.IPAMConfig
+	var ipv6 bool
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet.Subnet)
+		if err != nil {
+			return nil, false, status.Errorf(codes.InvalidArgument, "invalid subnet %q: %v", subnet.Subnet, err)
+		}
+		if ipNet.IP.To4() == nil {
+			ipv6 = true
+		}
+
+		if subnet.Gateway != "" {
+			if gw := net.ParseIP(subnet.Gateway); gw == nil || !ipNet.Contains(gw) {
+				return nil, false, status.Errorf(codes.InvalidArgument, "gateway %q is not an address in subnet %s", subnet.Gateway, subnet.Subnet)
+			}
+		}
+		if subnet.IPRange != "" {
+			if err := checkIPRange(ipNet, subnet.IPRange); err != nil {
+				return nil, false, status.Errorf(codes.InvalidArgument, "invalid ip range for subnet %s: %v", subnet.Subnet, err)
+			}
+		}
+
+		cfgs = append(cfgs, network.IPAMConfig{
+			Subnet:  subnet.Subnet,
+			Gateway: subnet.Gateway,
+			IPRange: subnet.IPRange,
+		})
+	}
+	return cfgs, ipv6, nil
+}
+
+// checkIPRange ensures that ipRange is a CIDR contained within subnet.
+func checkIPRange(subnet *net.IPNet, ipRange string) error {
+	_, rangeNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return err
+	}
+	subnetOnes, _ := subnet.Mask.Size()
+	rangeOnes, _ := rangeNet.Mask.Size()
+	if !subnet.Contains(rangeNet.IP) || rangeOnes < subnetOnes {
+		return fmt.Errorf("%s is not contained in %s", ipRange, subnet)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeNetworkCreatingDocker struct {
+	fakeDocker
+	nets []network.Summary
+
+	Name string
+	Opts network.CreateOptions
+}
+
+func (f *fakeNetworkCreatingDocker) NetworkList(_ context.Context, _ network.ListOptions) ([]network.Summary, error) {
+	return f.nets, nil
+}
+
+func (f *fakeNetworkCreatingDocker) NetworkCreate(_ context.Context, name string, opts network.CreateOptions) (network.CreateResponse, error) {
+	f.Name = name
+	f.Opts = opts
+	return network.CreateResponse{ID: "some-id"}, nil
+}
+
+func TestNetworkCreate(t *testing.T) {
+	ipv6 := true
+	tests := []struct {
+		name      string
+		inName    string
+		inDriver  string
+		inOpts    []options.Option
+		inNets    []network.Summary
+		wantState *fakeNetworkCreatingDocker
+		wantResp  string
+		wantErr   error
+	}{
+		{
+			name:     "default-driver",
+			inName:   "some-network",
+			wantResp: "some-network",
+			wantState: &fakeNetworkCreatingDocker{
+				Name: "some-network",
+				Opts: network.CreateOptions{Driver: "bridge"},
+			},
+		},
+		{
+			name:     "macvlan-with-subnets-and-labels",
+			inName:   "bgp",
+			inDriver: "macvlan",
+			inOpts: []options.Option{
+				options.WithNetworkDriverOpts(map[string]string{"parent": "eth1"}),
+				options.WithNetworkSubnets([]options.Subnet{
+					{Subnet: "192.0.2.0/24", Gateway: "192.0.2.1", IPRange: "192.0.2.128/25"},
+					{Subnet: "2001:db8::/64"},
+				}),
+				options.WithNetworkLabels(map[string]string{"app": "bgp"}),
+			},
+			wantResp: "bgp",
+			wantState: &fakeNetworkCreatingDocker{
+				Name: "bgp",
+				Opts: network.CreateOptions{
+					Driver:     "macvlan",
+					EnableIPv6: &ipv6,
+					IPAM: &network.IPAM{
+						Config: []network.IPAMConfig{
+							{Subnet: "192.0.2.0/24", Gateway: "192.0.2.1", IPRange: "192.0.2.128/25"},
+							{Subnet: "2001:db8::/64"},
+						},
+					},
+					Options: map[string]string{"parent": "eth1"},
+					Labels:  map[string]string{"app": "bgp"},
+				},
+			},
+		},
+		{
+			name:    "no-name",
+			wantErr: status.Error(codes.InvalidArgument, "a network name must be supplied"),
+		},
+		{
+			name:     "unsupported-driver",
+			inName:   "some-network",
+			inDriver: "overlay",
+			wantErr:  status.Errorf(codes.InvalidArgument, "network driver %q is not supported", "overlay"),
+		},
+		{
+			name:   "gateway-outside-subnet",
+			inName: "some-network",
+			inOpts: []options.Option{
+				options.WithNetworkSubnets([]options.Subnet{{Subnet: "192.0.2.0/24", Gateway: "198.51.100.1"}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "gateway %q is not an address in subnet %s", "198.51.100.1", "192.0.2.0/24"),
+		},
+		{
+			name:   "ip-range-outside-subnet",
+			inName: "some-network",
+			inOpts: []options.Option{
+				options.WithNetworkSubnets([]options.Subnet{{Subnet: "192.0.2.0/24", IPRange: "192.0.0.0/16"}}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "invalid ip range for subnet %s: %s is not contained in %s", "192.0.2.0/24", "192.0.0.0/16", "192.0.2.0/24"),
+		},
+		{
+			name:    "already-exists",
+			inName:  "some-network",
+			inNets:  []network.Summary{{Name: "some-network"}},
+			wantErr: status.Errorf(codes.AlreadyExists, "network %s already exists", "some-network"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fnd := &fakeNetworkCreatingDocker{nets: tc.inNets}
+			mgr := New(fnd)
+
+			resp, err := mgr.NetworkCreate(context.Background(), tc.inName, tc.inDriver, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("NetworkCreate(%q, %q, %+v) returned unexpected error (-want, +got):\n%s", tc.inName, tc.inDriver, tc.inOpts, diff)
+			}
+
+			if tc.wantState != nil {
+				if diff := cmp.Diff(tc.wantState, fnd, cmpopts.IgnoreUnexported(fakeNetworkCreatingDocker{})); diff != "" {
+					t.Errorf("NetworkCreate(%q, %q, %+v) returned diff(-want, +got):\n%s", tc.inName, tc.inDriver, tc.inOpts, diff)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantResp, resp); diff != "" {
+				t.Errorf("NetworkCreate(%q, %q, %+v) returned diff(-want, +got):\n%s", tc.inName, tc.inDriver, tc.inOpts, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"sort"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NetworkList lists the networks present on the target.
+func (m *Manager) NetworkList(ctx context.Context, opts ...options.Option) ([]*options.NetworkInfo, error) {
+	optionz := options.ApplyOptions(opts...)
+	kvPairs := []filters.KeyValuePair{}
+	for key, values := range optionz.Filter {
+		for _, value := range values {
+			kvPairs = append(kvPairs, filters.KeyValuePair{Key: string(key), Value: value})
+		}
+	}
+
+	nets, err := m.client.NetworkList(ctx, network.ListOptions{
+		Filters: filters.NewArgs(kvPairs...),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list networks: %v", err)
+	}
+
+	infos := make([]*options.NetworkInfo, 0, len(nets))
+	for _, n := range nets {
+		infos = append(infos, networkInfo(n))
+	}
+	return infos, nil
+}
+
+func networkInfo(n network.Inspect) *options.NetworkInfo {
+	info := &options.NetworkInfo{
+		ID:      n.ID,
+		Name:    n.Name,
+		Driver:  n.Driver,
+		Created: n.Created,
+		Options: n.Options,
+		Labels:  n.Labels,
+	}
+	for _, cfg := range n.IPAM.Config {
+		info.Subnets = append(info.Subnets, options.Subnet{
+			Subnet:  cfg.Subnet,
+			Gateway: cfg.Gateway,
+			IPRange: cfg.IPRange,
+		})
+	}
+	for _, endpoint := range n.Containers {
+		info.Containers = append(info.Containers, endpoint.Name)
+	}
+	sort.Strings(info.Containers)
+	return info
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+)
+
+type fakeNetworkListingDocker struct {
+	fakeDocker
+	nets []network.Summary
+
+	Opts network.ListOptions
+}
+
+func (f *fakeNetworkListingDocker) NetworkList(_ context.Context, opts network.ListOptions) ([]network.Summary, error) {
+	f.Opts = opts
+	return f.nets, nil
+}
+
+func TestNetworkList(t *testing.T) {
+	created := time.Date(2024, 2, 9, 13, 7, 31, 0, time.UTC)
+	tests := []struct {
+		name      string
+		inOpts    []options.Option
+		inNets    []network.Summary
+		wantState *fakeNetworkListingDocker
+		wantResp  []*options.NetworkInfo
+	}{
+		{
+			name: "no-networks",
+			wantState: &fakeNetworkListingDocker{
+				Opts: network.ListOptions{Filters: filters.NewArgs()},
+			},
+		},
+		{
+			name: "networks-with-filter",
+			inOpts: []options.Option{
+				options.WithFilter(map[options.FilterKey][]string{"driver": []string{"macvlan"}}),
+			},
+			inNets: []network.Summary{
+				{
+					ID:      "some-id",
+					Name:    "bgp",
+					Driver:  "macvlan",
+					Created: created,
+					IPAM: network.IPAM{
+						Config: []network.IPAMConfig{{Subnet: "192.0.2.0/24", Gateway: "192.0.2.1"}},
+					},
+					Options: map[string]string{"parent": "eth1"},
+					Labels:  map[string]string{"app": "bgp"},
+					Containers: map[string]network.EndpointResource{
+						"id-2": {Name: "speaker"},
+						"id-1": {Name: "exporter"},
+					},
+				},
+			},
+			wantState: &fakeNetworkListingDocker{
+				Opts: network.ListOptions{Filters: filters.NewArgs(filters.Arg("driver", "macvlan"))},
+			},
+			wantResp: []*options.NetworkInfo{
+				{
+					ID:         "some-id",
+					Name:       "bgp",
+					Driver:     "macvlan",
+					Created:    created,
+					Subnets:    []options.Subnet{{Subnet: "192.0.2.0/24", Gateway: "192.0.2.1"}},
+					Options:    map[string]string{"parent": "eth1"},
+					Labels:     map[string]string{"app": "bgp"},
+					Containers: []string{"exporter", "speaker"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fnd := &fakeNetworkListingDocker{nets: tc.inNets}
+			mgr := New(fnd)
+
+			resp, err := mgr.NetworkList(context.Background(), tc.inOpts...)
+			if err != nil {
+				t.Fatalf("NetworkList(%+v) returned error: %v", tc.inOpts, err)
+			}
+
+			if diff := cmp.Diff(tc.wantState, fnd, cmpopts.IgnoreUnexported(fakeNetworkListingDocker{}), cmp.AllowUnexported(filters.Args{})); diff != "" {
+				t.Errorf("NetworkList(%+v) returned diff(-want, +got):\n%s", tc.inOpts, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantResp, resp, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("NetworkList(%+v) returned diff(-want, +got):\n%s", tc.inOpts, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// predefinedNetworks are the networks created by the container runtime itself. They cannot be
+// removed.
+var predefinedNetworks = map[string]bool{
+	"bridge": true,
+	"host":   true,
+	"none":   true,
+}
+
+// NetworkRemove removes a network. If containers are still attached to the network, the removal
+// fails unless the Force option is set, in which case the containers are disconnected first.
+func (m *Manager) NetworkRemove(ctx context.Context, name string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+
+	if predefinedNetworks[name] {
+		return status.Errorf(codes.FailedPrecondition, "network %s is predefined and cannot be removed", name)
+	}
+
+	n, err := m.client.NetworkInspect(ctx, name, network.InspectOptions{})
+	if err != nil {
+		return status.Errorf(codes.NotFound, "network %s not found: %v", name, err)
+	}
+
+	info := networkInfo(n)
+	if len(info.Containers) > 0 {
+		if !optionz.Force {
+			return status.Errorf(codes.FailedPrecondition, "network %s is in use by containers %s", name, strings.Join(info.Containers, ", "))
+		}
+		for id, endpoint := range n.Containers {
+			klog.Infof("disconnecting container %s from network %s", endpoint.Name, name)
+			if err := m.client.NetworkDisconnect(ctx, n.ID, id, true); err != nil {
+				return status.Errorf(codes.Internal, "unable to disconnect container %s from network %s: %v", endpoint.Name, name, err)
+			}
+		}
+	}
+
+	if err := m.client.NetworkRemove(ctx, n.ID); err != nil {
+		return status.Errorf(codes.Internal, "unable to remove network %s: %v", name, err)
+	}
+	return nil
+}
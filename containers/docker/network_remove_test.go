@@ -0,0 +1,120 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeNetworkRemovingDocker struct {
+	fakeDocker
+	nets map[string]network.Inspect
+
+	Disconnected []string
+	Removed      string
+}
+
+func (f *fakeNetworkRemovingDocker) NetworkInspect(_ context.Context, name string, _ network.InspectOptions) (network.Inspect, error) {
+	n, ok := f.nets[name]
+	if !ok {
+		return network.Inspect{}, fmt.Errorf("no such network")
+	}
+	return n, nil
+}
+
+func (f *fakeNetworkRemovingDocker) NetworkDisconnect(_ context.Context, _, containerID string, _ bool) error {
+	f.Disconnected = append(f.Disconnected, containerID)
+	return nil
+}
+
+func (f *fakeNetworkRemovingDocker) NetworkRemove(_ context.Context, id string) error {
+	f.Removed = id
+	return nil
+}
+
+func TestNetworkRemove(t *testing.T) {
+	nets := map[string]network.Inspect{
+		"unused": {ID: "unused-id", Name: "unused"},
+		"in-use": {
+			ID:         "in-use-id",
+			Name:       "in-use",
+			Containers: map[string]network.EndpointResource{"cnt-id": {Name: "speaker"}},
+		},
+	}
+	tests := []struct {
+		name      string
+		inName    string
+		inOpts    []options.Option
+		wantState *fakeNetworkRemovingDocker
+		wantErr   error
+	}{
+		{
+			name:      "unused",
+			inName:    "unused",
+			wantState: &fakeNetworkRemovingDocker{Removed: "unused-id"},
+		},
+		{
+			name:    "in-use",
+			inName:  "in-use",
+			wantErr: status.Errorf(codes.FailedPrecondition, "network %s is in use by containers %s", "in-use", "speaker"),
+		},
+		{
+			name:   "in-use-forced",
+			inName: "in-use",
+			inOpts: []options.Option{options.Force()},
+			wantState: &fakeNetworkRemovingDocker{
+				Disconnected: []string{"cnt-id"},
+				Removed:      "in-use-id",
+			},
+		},
+		{
+			name:    "predefined",
+			inName:  "host",
+			wantErr: status.Errorf(codes.FailedPrecondition, "network %s is predefined and cannot be removed", "host"),
+		},
+		{
+			name:    "not-found",
+			inName:  "no-such-network",
+			wantErr: status.Errorf(codes.NotFound, "network %s not found: %v", "no-such-network", "no such network"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fnd := &fakeNetworkRemovingDocker{nets: nets}
+			mgr := New(fnd)
+
+			err := mgr.NetworkRemove(context.Background(), tc.inName, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("NetworkRemove(%q, %+v) returned unexpected error (-want, +got):\n%s", tc.inName, tc.inOpts, diff)
+			}
+
+			if tc.wantState != nil {
+				if diff := cmp.Diff(tc.wantState, fnd, cmpopts.IgnoreUnexported(fakeNetworkRemovingDocker{})); diff != "" {
+					t.Errorf("NetworkRemove(%q, %+v) returned diff(-want, +got):\n%s", tc.inName, tc.inOpts, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// archAliases maps the architecture names reported by kernels and some build tools to the ones
+// used by images.
+var archAliases = map[string]string{
+	"x86_64":  "amd64",
+	"x86-64":  "amd64",
+	"aarch64": "arm64",
+	"armhf":   "arm",
+	"armel":   "arm",
+}
+
+// hostPlatform returns the platform containerz runs on, which images must be built for.
+func hostPlatform() ocispec.Platform {
+	return normalizePlatform(ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH})
+}
+
+// ParsePlatform parses a platform of the format <os>/<arch>[/<variant>], e.g. linux/arm64/v8.
+func ParsePlatform(spec string) (ocispec.Platform, error) {
+	parts := strings.Split(spec, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return ocispec.Platform{}, fmt.Errorf("invalid platform %q: expected <os>/<arch>[/<variant>]", spec)
+	}
+	p := ocispec.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	return normalizePlatform(p), nil
+}
+
+// formatPlatform returns the platform in the format accepted by ParsePlatform.
+func formatPlatform(p ocispec.Platform) string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
+
+// normalizePlatform returns the platform with its names lowercased and architecture aliases
+// resolved. The variant of arm64, which only has one, defaults to v8.
+func normalizePlatform(p ocispec.Platform) ocispec.Platform {
+	p.OS = strings.ToLower(p.OS)
+	p.Architecture = strings.ToLower(p.Architecture)
+	p.Variant = strings.ToLower(p.Variant)
+	if arch, ok := archAliases[p.Architecture]; ok {
+		p.Architecture = arch
+	}
+	if p.Architecture == "arm64" && p.Variant == "" {
+		p.Variant = "v8"
+	}
+	return p
+}
+
+// matchPlatform returns whether an image built for the platform got runs on the platform want. An
+// arm image runs on any later arm variant, and a missing variant matches any.
+func matchPlatform(want, got ocispec.Platform) bool {
+	want, got = normalizePlatform(want), normalizePlatform(got)
+	if want.OS != got.OS || want.Architecture != got.Architecture {
+		return false
+	}
+	if want.Variant == "" || got.Variant == "" || want.Variant == got.Variant {
+		return true
+	}
+	if want.Architecture != "arm" {
+		return false
+	}
+	wantV, err := strconv.Atoi(strings.TrimPrefix(want.Variant, "v"))
+	if err != nil {
+		return false
+	}
+	gotV, err := strconv.Atoi(strings.TrimPrefix(got.Variant, "v"))
+	if err != nil {
+		return false
+	}
+	return gotV <= wantV
+}
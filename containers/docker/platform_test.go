@@ -0,0 +1,137 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		name         string
+		inSpec       string
+		wantPlatform ocispec.Platform
+		wantErr      bool
+	}{
+		{
+			name:         "os-arch",
+			inSpec:       "linux/amd64",
+			wantPlatform: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+		},
+		{
+			name:         "variant",
+			inSpec:       "linux/arm/v7",
+			wantPlatform: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+		},
+		{
+			name:         "alias",
+			inSpec:       "Linux/aarch64",
+			wantPlatform: ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		},
+		{
+			name:    "no-arch",
+			inSpec:  "linux",
+			wantErr: true,
+		},
+		{
+			name:    "empty-arch",
+			inSpec:  "linux/",
+			wantErr: true,
+		},
+		{
+			name:    "too-many-parts",
+			inSpec:  "linux/arm/v7/extra",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePlatform(tc.inSpec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParsePlatform(%q) returned error %v, want error: %t", tc.inSpec, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantPlatform, got); diff != "" {
+				t.Errorf("ParsePlatform(%q) returned diff (-want +got):\n%s", tc.inSpec, diff)
+			}
+		})
+	}
+}
+
+func TestMatchPlatform(t *testing.T) {
+	tests := []struct {
+		name   string
+		inWant ocispec.Platform
+		inGot  ocispec.Platform
+		want   bool
+	}{
+		{
+			name:   "same",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			inGot:  ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			want:   true,
+		},
+		{
+			name:   "other-arch",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			inGot:  ocispec.Platform{OS: "linux", Architecture: "arm64"},
+		},
+		{
+			name:   "other-os",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			inGot:  ocispec.Platform{OS: "windows", Architecture: "amd64"},
+		},
+		{
+			name:   "alias",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "arm64"},
+			inGot:  ocispec.Platform{OS: "linux", Architecture: "aarch64", Variant: "v8"},
+			want:   true,
+		},
+		{
+			name:   "missing-variant",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			inGot:  ocispec.Platform{OS: "linux", Architecture: "arm"},
+			want:   true,
+		},
+		{
+			name:   "older-arm-variant",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			inGot:  ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+			want:   true,
+		},
+		{
+			name:   "newer-arm-variant",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+			inGot:  ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+		},
+		{
+			name:   "unknown",
+			inWant: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			inGot:  ocispec.Platform{OS: "unknown", Architecture: "unknown"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := matchPlatform(tc.inWant, tc.inGot); got != tc.want {
+				t.Errorf("matchPlatform(%v, %v) = %t, want %t", tc.inWant, tc.inGot, got, tc.want)
+			}
+		})
+	}
+}
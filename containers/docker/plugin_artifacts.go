@@ -0,0 +1,245 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// pluginArtifactExt is the extension of the plugin tarballs in the plugin location.
+	pluginArtifactExt = ".tar"
+
+	// pluginRecordsFile is the file of the plugin location recording the tarball each plugin was
+	// created from.
+	pluginRecordsFile = ".plugins.json"
+)
+
+// PluginArtifactList returns the plugin tarballs deployed to the plugin location, sorted by name,
+// along with the plugins created from each of them.
+func (m *Manager) PluginArtifactList(ctx context.Context) ([]*options.PluginArtifact, error) {
+	entries, err := os.ReadDir(m.pluginLocation)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list plugin artifacts: %v", err)
+	}
+
+	users, err := m.pluginArtifactUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []*options.PluginArtifact
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), pluginArtifactExt)
+		if !ok || strings.HasPrefix(name, ".") || !e.Type().IsRegular() {
+			continue
+		}
+		artifact, err := m.pluginArtifact(name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // removed while listing
+			}
+			return nil, status.Errorf(codes.Internal, "failed to read plugin artifact %s: %v", name, err)
+		}
+		artifact.Plugins = users[name]
+		if artifact.Plugins == nil {
+			artifact.Plugins = []string{}
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// PluginArtifactRemove removes the named plugin tarball. Unless the Force option is passed, it
+// refuses to remove a tarball that plugins were created from.
+func (m *Manager) PluginArtifactRemove(ctx context.Context, name string, opts ...options.Option) error {
+	if !filepath.IsLocal(name) {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin artifact name %q", name)
+	}
+	path := filepath.Join(m.pluginLocation, name+pluginArtifactExt)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return status.Errorf(codes.NotFound, "plugin artifact %s not found", name)
+		}
+		return status.Errorf(codes.Internal, "failed to read plugin artifact %s: %v", name, err)
+	}
+
+	if !options.ApplyOptions(opts...).Force {
+		users, err := m.pluginArtifactUsers(ctx)
+		if err != nil {
+			return err
+		}
+		if len(users[name]) > 0 {
+			return status.Errorf(codes.FailedPrecondition, "plugin artifact %s is used by plugins %s", name, strings.Join(users[name], ", "))
+		}
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return status.Errorf(codes.Internal, "failed to remove plugin artifact %s: %v", name, err)
+	}
+	return nil
+}
+
+// PluginArtifactPrune removes the plugin tarballs uploaded more than retention ago that no
+// installed plugin uses any more, and returns them.
+//
+// Only the tarballs that plugins were recorded to be created from are removed: the others were
+// either never started or deployed before the records were kept, so installed plugins unknown to
+// the records may still have been created from them. They are left for an explicit removal.
+func (m *Manager) PluginArtifactPrune(ctx context.Context, retention time.Duration) ([]*options.PluginArtifact, error) {
+	artifacts, err := m.PluginArtifactList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	records, err := m.pluginRecords()
+	m.mu.Unlock()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read plugin records: %v", err)
+	}
+	recorded := map[string]bool{}
+	for _, name := range records {
+		recorded[name] = true
+	}
+
+	var removed []*options.PluginArtifact
+	for _, a := range artifacts {
+		if len(a.Plugins) > 0 || !recorded[a.Name] || time.Since(a.Uploaded) < retention {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.pluginLocation, a.Name+pluginArtifactExt)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, status.Errorf(codes.Internal, "failed to remove plugin artifact %s: %v", a.Name, err)
+		}
+		removed = append(removed, a)
+	}
+	return removed, nil
+}
+
+// pluginArtifact describes the named plugin tarball, except for the plugins using it.
+func (m *Manager) pluginArtifact(name string) (*options.PluginArtifact, error) {
+	f, err := os.Open(filepath.Join(m.pluginLocation, name+pluginArtifactExt))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+
+	return &options.PluginArtifact{
+		Name:     name,
+		Size:     info.Size(),
+		Hash:     options.FormatChecksum(h.Sum(nil)),
+		Uploaded: info.ModTime(),
+	}, nil
+}
+
+// pluginArtifactUsers returns the sorted names of the installed plugins, by the name of the
+// tarball they were created from.
+func (m *Manager) pluginArtifactUsers(ctx context.Context) (map[string][]string, error) {
+	m.mu.Lock()
+	records, err := m.pluginRecords()
+	m.mu.Unlock()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read plugin records: %v", err)
+	}
+
+	plugins, err := m.client.PluginList(ctx, filters.Args{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list plugins: %v", err)
+	}
+
+	users := map[string][]string{}
+	for _, p := range plugins {
+		if name, ok := records[pluginRef(p.Name)]; ok {
+			users[name] = append(users[name], p.Name)
+		}
+	}
+	for _, u := range users {
+		sort.Strings(u)
+	}
+	return users, nil
+}
+
+// recordPluginArtifact records that the plugin named `instance` was created from the named
+// tarball. Failures are only logged as they merely leave the tarball unprotected from pruning.
+func (m *Manager) recordPluginArtifact(instance, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records, err := m.pluginRecords()
+	if err != nil {
+		klog.Warningf("unable to read plugin records: %v", err)
+		return
+	}
+	records[pluginRef(instance)] = name
+
+	buf, err := json.Marshal(records)
+	if err != nil {
+		klog.Warningf("unable to marshal plugin records: %v", err)
+		return
+	}
+	tmp := filepath.Join(m.pluginLocation, pluginRecordsFile+".tmp")
+	if err := os.WriteFile(tmp, buf, 0644); err != nil {
+		klog.Warningf("unable to write plugin records: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, filepath.Join(m.pluginLocation, pluginRecordsFile)); err != nil {
+		klog.Warningf("unable to write plugin records: %v", err)
+	}
+}
+
+// pluginRecords returns the tarball each plugin was created from, by plugin name. The caller must
+// hold m.mu.
+func (m *Manager) pluginRecords() (map[string]string, error) {
+	records := map[string]string{}
+	buf, err := os.ReadFile(filepath.Join(m.pluginLocation, pluginRecordsFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return records, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
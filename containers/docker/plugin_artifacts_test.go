@@ -0,0 +1,252 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakePluginArtifactsDocker struct {
+	fakeDocker
+	plugins []*types.Plugin
+}
+
+func (f *fakePluginArtifactsDocker) PluginList(context.Context, filters.Args) (types.PluginsListResponse, error) {
+	return f.plugins, nil
+}
+
+// writePluginArtifacts writes the plugin location holding the logger and sshfs tarballs, uploaded
+// at the given times, of which the installed logger:latest plugin was created from the first.
+func writePluginArtifacts(t *testing.T, logger, sshfs time.Time) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"logger.tar":      "logger",
+		"sshfs.tar":       "sshfs",
+		".tmp-1-upload":   "partial",
+		"notes.txt":       "not a plugin",
+		pluginRecordsFile: `{"logger:latest": "logger", "removed:latest": "sshfs"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "dir.tar"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, uploaded := range map[string]time.Time{"logger.tar": logger, "sshfs.tar": sshfs} {
+		if err := os.Chtimes(filepath.Join(dir, name), uploaded, uploaded); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func newPluginArtifactsManager(dir string) *Manager {
+	return New(&fakePluginArtifactsDocker{
+		plugins: []*types.Plugin{{Name: "logger:latest"}, {Name: "other:latest"}},
+	}, WithPluginLocation(dir))
+}
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return options.FormatChecksum(sum[:])
+}
+
+func TestPluginArtifactList(t *testing.T) {
+	uploaded := time.Date(2025, 1, 14, 13, 0, 0, 0, time.UTC)
+	dir := writePluginArtifacts(t, uploaded, uploaded.Add(time.Hour))
+
+	got, err := newPluginArtifactsManager(dir).PluginArtifactList(context.Background())
+	if err != nil {
+		t.Fatalf("PluginArtifactList() returned error: %v", err)
+	}
+
+	want := []*options.PluginArtifact{
+		{Name: "logger", Size: 6, Hash: checksum("logger"), Uploaded: uploaded, Plugins: []string{"logger:latest"}},
+		{Name: "sshfs", Size: 5, Hash: checksum("sshfs"), Uploaded: uploaded.Add(time.Hour), Plugins: []string{}},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+		t.Errorf("PluginArtifactList() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPluginArtifactListNoLocation(t *testing.T) {
+	got, err := newPluginArtifactsManager(filepath.Join(t.TempDir(), "missing")).PluginArtifactList(context.Background())
+	if err != nil {
+		t.Fatalf("PluginArtifactList() returned error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("PluginArtifactList() returned %d artifacts, want none", len(got))
+	}
+}
+
+func TestPluginArtifactRemove(t *testing.T) {
+	tests := []struct {
+		name     string
+		inName   string
+		inOpts   []options.Option
+		wantLeft []string
+		wantErr  error
+	}{
+		{
+			name:     "unused",
+			inName:   "sshfs",
+			wantLeft: []string{"logger"},
+		},
+		{
+			name:     "in-use",
+			inName:   "logger",
+			wantLeft: []string{"logger", "sshfs"},
+			wantErr:  status.Errorf(codes.FailedPrecondition, "plugin artifact %s is used by plugins %s", "logger", "logger:latest"),
+		},
+		{
+			name:     "forced",
+			inName:   "logger",
+			inOpts:   []options.Option{options.Force()},
+			wantLeft: []string{"sshfs"},
+		},
+		{
+			name:     "not-found",
+			inName:   "missing",
+			wantLeft: []string{"logger", "sshfs"},
+			wantErr:  status.Errorf(codes.NotFound, "plugin artifact %s not found", "missing"),
+		},
+		{
+			name:     "invalid-name",
+			inName:   "../logger",
+			wantLeft: []string{"logger", "sshfs"},
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin artifact name %q", "../logger"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writePluginArtifacts(t, time.Now(), time.Now())
+
+			err := newPluginArtifactsManager(dir).PluginArtifactRemove(context.Background(), tc.inName, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginArtifactRemove(%q) returned unexpected error (-want +got):\n%s", tc.inName, diff)
+			}
+
+			var left []string
+			for _, name := range []string{"logger", "sshfs"} {
+				if _, err := os.Stat(filepath.Join(dir, name+".tar")); err == nil {
+					left = append(left, name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantLeft, left); diff != "" {
+				t.Errorf("PluginArtifactRemove(%q) left unexpected artifacts (-want +got):\n%s", tc.inName, diff)
+			}
+		})
+	}
+}
+
+func TestPluginArtifactPrune(t *testing.T) {
+	tests := []struct {
+		name        string
+		inSshfs     time.Time
+		inRetention time.Duration
+		wantRemoved []string
+	}{
+		{
+			name:        "expired",
+			inSshfs:     time.Now().Add(-48 * time.Hour),
+			inRetention: 24 * time.Hour,
+			wantRemoved: []string{"sshfs"},
+		},
+		{
+			name:        "recent",
+			inSshfs:     time.Now().Add(-time.Hour),
+			inRetention: 24 * time.Hour,
+		},
+		{
+			name:        "no-retention",
+			inSshfs:     time.Now(),
+			wantRemoved: []string{"sshfs"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writePluginArtifacts(t, time.Now().Add(-48*time.Hour), tc.inSshfs)
+			// legacy was not recorded, so plugins unknown to the records may use it.
+			legacy := filepath.Join(dir, "legacy.tar")
+			if err := os.WriteFile(legacy, []byte("legacy"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(legacy, time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+
+			removed, err := newPluginArtifactsManager(dir).PluginArtifactPrune(context.Background(), tc.inRetention)
+			if err != nil {
+				t.Fatalf("PluginArtifactPrune(%v) returned error: %v", tc.inRetention, err)
+			}
+
+			var got []string
+			for _, a := range removed {
+				got = append(got, a.Name)
+				if _, err := os.Stat(filepath.Join(dir, a.Name+".tar")); !os.IsNotExist(err) {
+					t.Errorf("PluginArtifactPrune(%v) did not remove %s", tc.inRetention, a.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantRemoved, got); diff != "" {
+				t.Errorf("PluginArtifactPrune(%v) returned diff (-want +got):\n%s", tc.inRetention, diff)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "logger.tar")); err != nil {
+				t.Errorf("PluginArtifactPrune(%v) removed the used logger artifact: %v", tc.inRetention, err)
+			}
+			if _, err := os.Stat(legacy); err != nil {
+				t.Errorf("PluginArtifactPrune(%v) removed the unrecorded legacy artifact: %v", tc.inRetention, err)
+			}
+		})
+	}
+}
+
+func TestRecordPluginArtifact(t *testing.T) {
+	dir := t.TempDir()
+	mgr := New(&fakeDocker{}, WithPluginLocation(dir))
+
+	mgr.recordPluginArtifact("sshfs", "sshfs-v1")
+	mgr.recordPluginArtifact("logger:v2", "logger")
+	mgr.recordPluginArtifact("sshfs", "sshfs-v2")
+
+	got, err := mgr.pluginRecords()
+	if err != nil {
+		t.Fatalf("pluginRecords() returned error: %v", err)
+	}
+	want := map[string]string{
+		"sshfs:latest": "sshfs-v2",
+		"logger:v2":    "logger",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("pluginRecords() returned diff (-want +got):\n%s", diff)
+	}
+}
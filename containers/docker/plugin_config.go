@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validatePluginConfig checks that config is a plugin config, as documented in
+// https://docs.docker.com/engine/extend/config/, that docker is able to run the plugin with.
+func validatePluginConfig(config string) error {
+	// The interface types are checked first as docker panics decoding some malformed ones.
+	var iface struct {
+		Interface struct {
+			Types []string
+		}
+	}
+	if err := json.Unmarshal([]byte(config), &iface); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: %v", err)
+	}
+	if len(iface.Interface.Types) == 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: interface.types is empty")
+	}
+	for _, t := range iface.Interface.Types {
+		if !validPluginInterfaceType(t) {
+			return status.Errorf(codes.InvalidArgument, "invalid plugin config: interface type %q is not of the format <prefix>.<capability>/<version>", t)
+		}
+	}
+
+	dec := json.NewDecoder(strings.NewReader(config))
+	dec.DisallowUnknownFields()
+	var c types.PluginConfig
+	if err := dec.Decode(&c); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: %v", err)
+	}
+
+	if c.Interface.Socket == "" || path.Base(c.Interface.Socket) != c.Interface.Socket {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: interface.socket %q is not a file name", c.Interface.Socket)
+	}
+
+	if len(c.Entrypoint) == 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: entrypoint is empty")
+	}
+
+	switch c.Network.Type {
+	case "", "bridge", "host", "none":
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: network.type %q is not one of bridge, host or none", c.Network.Type)
+	}
+
+	if c.WorkDir != "" && !path.IsAbs(c.WorkDir) {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: workdir %q is not an absolute path", c.WorkDir)
+	}
+	if c.PropagatedMount != "" && !path.IsAbs(c.PropagatedMount) {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin config: propagatedmount %q is not an absolute path", c.PropagatedMount)
+	}
+	for _, m := range c.Mounts {
+		if !path.IsAbs(m.Destination) {
+			return status.Errorf(codes.InvalidArgument, "invalid plugin config: destination %q of mount %q is not an absolute path", m.Destination, m.Name)
+		}
+	}
+	for _, env := range c.Env {
+		if env.Name == "" || strings.Contains(env.Name, "=") {
+			return status.Errorf(codes.InvalidArgument, "invalid plugin config: env name %q is invalid", env.Name)
+		}
+	}
+
+	return nil
+}
+
+// validPluginInterfaceType returns whether t is of the format <prefix>.<capability>/<version>, e.g.
+// docker.volumedriver/1.0.
+func validPluginInterfaceType(t string) bool {
+	name, version, ok := strings.Cut(t, "/")
+	if !ok || version == "" {
+		return false
+	}
+	dot := strings.LastIndex(name, ".")
+	return dot > 0 && dot < len(name)-1
+}
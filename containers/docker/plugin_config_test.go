@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidatePluginConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		inConfig string
+		wantErr  error
+	}{
+		{
+			name:     "valid",
+			inConfig: validPluginConfig,
+		},
+		{
+			name: "full",
+			inConfig: `{
+				"description": "sshFS plugin for Docker",
+				"documentation": "https://docs.docker.com/engine/extend/plugins/",
+				"entrypoint": ["/docker-volume-sshfs"],
+				"env": [{"name": "DEBUG", "settable": ["value"], "value": "0"}],
+				"interface": {"socket": "sshfs.sock", "types": ["docker.volumedriver/1.0"]},
+				"linux": {"capabilities": ["CAP_SYS_ADMIN"], "devices": [{"path": "/dev/fuse"}]},
+				"mounts": [{"destination": "/mnt/state", "options": ["rbind"], "name": "state", "source": "/var/lib/docker/plugins/", "type": "bind"}],
+				"network": {"type": "host"},
+				"propagatedmount": "/mnt/volumes"
+			}`,
+		},
+		{
+			name:     "not-json",
+			inConfig: "{",
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: %v", "unexpected end of JSON input"),
+		},
+		{
+			name:     "unknown-field",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"types": ["docker.volumedriver/1.0"]}, "socket": "plugin.sock"}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: %v", `json: unknown field "socket"`),
+		},
+		{
+			name:     "trailing-data",
+			inConfig: validPluginConfig + "{}",
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: %v", "invalid character '{' after top-level value"),
+		},
+		{
+			name:     "no-types",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "plugin.sock"}}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: interface.types is empty"),
+		},
+		{
+			name:     "invalid-type",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "plugin.sock", "types": ["volumedriver"]}}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: interface type %q is not of the format <prefix>.<capability>/<version>", "volumedriver"),
+		},
+		{
+			name:     "socket-path",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "/run/plugin.sock", "types": ["docker.volumedriver/1.0"]}}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: interface.socket %q is not a file name", "/run/plugin.sock"),
+		},
+		{
+			name:     "no-entrypoint",
+			inConfig: `{"interface": {"socket": "plugin.sock", "types": ["docker.volumedriver/1.0"]}}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: entrypoint is empty"),
+		},
+		{
+			name:     "network-type",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "plugin.sock", "types": ["docker.volumedriver/1.0"]}, "network": {"type": "overlay"}}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: network.type %q is not one of bridge, host or none", "overlay"),
+		},
+		{
+			name:     "relative-propagated-mount",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "plugin.sock", "types": ["docker.volumedriver/1.0"]}, "propagatedmount": "volumes"}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: propagatedmount %q is not an absolute path", "volumes"),
+		},
+		{
+			name:     "relative-mount",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "plugin.sock", "types": ["docker.volumedriver/1.0"]}, "mounts": [{"name": "state", "destination": "state"}]}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: destination %q of mount %q is not an absolute path", "state", "state"),
+		},
+		{
+			name:     "env-name",
+			inConfig: `{"entrypoint": ["/plugin"], "interface": {"socket": "plugin.sock", "types": ["docker.volumedriver/1.0"]}, "env": [{"name": "DEBUG=1"}]}`,
+			wantErr:  status.Errorf(codes.InvalidArgument, "invalid plugin config: env name %q is invalid", "DEBUG=1"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePluginConfig(tc.inConfig)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("validatePluginConfig(%q) returned unexpected error (-want +got):\n%s", tc.inConfig, diff)
+			}
+		})
+	}
+}
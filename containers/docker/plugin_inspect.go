@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PluginInspect returns the details of the plugin named `instance`: whether it is enabled, the
+// capabilities it implements, its settings and the volumes using it.
+func (m *Manager) PluginInspect(ctx context.Context, instance string) (*options.PluginDetails, error) {
+	p, err := m.inspectPlugin(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := m.pluginReferences(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := []string{}
+	for _, t := range p.Config.Interface.Types {
+		caps = append(caps, t.String())
+	}
+
+	return &options.PluginDetails{
+		ID:           p.ID,
+		Name:         p.Name,
+		Enabled:      p.Enabled,
+		Capabilities: caps,
+		Env:          p.Settings.Env,
+		Args:         p.Settings.Args,
+		References:   refs,
+	}, nil
+}
+
+// inspectPlugin returns the plugin named `instance`.
+func (m *Manager) inspectPlugin(ctx context.Context, instance string) (*types.Plugin, error) {
+	p, _, err := m.client.PluginInspectWithRaw(ctx, instance)
+	if err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "plugin %s not found", instance)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to inspect plugin %s: %v", instance, err)
+	}
+	return p, nil
+}
+
+// pluginReferences returns the sorted names of the volumes provided by the named plugin.
+func (m *Manager) pluginReferences(ctx context.Context, plugin string) ([]string, error) {
+	resp, err := m.client.VolumeList(ctx, volume.ListOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list volumes: %v", err)
+	}
+
+	refs := []string{}
+	for _, v := range resp.Volumes {
+		if v != nil && pluginRef(v.Driver) == pluginRef(plugin) {
+			refs = append(refs, v.Name)
+		}
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
+
+// checkPluginUnused returns an error if volumes are provided by the named plugin, as they would
+// break if the plugin was stopped.
+func (m *Manager) checkPluginUnused(ctx context.Context, plugin string) error {
+	refs, err := m.pluginReferences(ctx, plugin)
+	if err != nil {
+		return err
+	}
+	if len(refs) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "plugin %s is in use by volumes %s", plugin, strings.Join(refs, ", "))
+	}
+	return nil
+}
+
+// pluginRef returns the name of a plugin with its tag, which defaults to latest.
+func pluginRef(name string) string {
+	if strings.Contains(path.Base(name), ":") {
+		return name
+	}
+	return name + ":latest"
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePluginLifecycleDocker keeps track of the plugins created, started, stopped and removed, and
+// of the calls made to do so. The errors of createErrs are returned by the successive creations.
+type fakePluginLifecycleDocker struct {
+	fakeDocker
+	plugins    map[string]*types.Plugin
+	volumes    []*volume.Volume
+	createErrs []error
+	Calls      []string
+}
+
+func (f *fakePluginLifecycleDocker) PluginInspectWithRaw(_ context.Context, name string) (*types.Plugin, []byte, error) {
+	p, ok := f.plugins[pluginRef(name)]
+	if !ok {
+		return nil, nil, cerrdefs.ErrNotFound
+	}
+	cp := *p
+	return &cp, nil, nil
+}
+
+func (f *fakePluginLifecycleDocker) PluginCreate(_ context.Context, createCtx io.Reader, opts types.PluginCreateOptions) error {
+	f.Calls = append(f.Calls, "create "+opts.RepoName)
+	if len(f.createErrs) > 0 {
+		err := f.createErrs[0]
+		f.createErrs = f.createErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	p := &types.Plugin{ID: "new-id", Name: pluginRef(opts.RepoName)}
+	tr := tar.NewReader(createCtx)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if strings.TrimPrefix(hdr.Name, "./") == "config.json" {
+			if err := json.NewDecoder(tr).Decode(&p.Config); err != nil {
+				return err
+			}
+		}
+	}
+	f.plugins[p.Name] = p
+	return nil
+}
+
+func (f *fakePluginLifecycleDocker) PluginEnable(_ context.Context, name string, _ types.PluginEnableOptions) error {
+	f.Calls = append(f.Calls, "enable "+name)
+	f.plugins[pluginRef(name)].Enabled = true
+	return nil
+}
+
+func (f *fakePluginLifecycleDocker) PluginDisable(_ context.Context, name string, opts types.PluginDisableOptions) error {
+	f.Calls = append(f.Calls, fmt.Sprintf("disable %s force=%t", name, opts.Force))
+	f.plugins[pluginRef(name)].Enabled = false
+	return nil
+}
+
+func (f *fakePluginLifecycleDocker) PluginRemove(_ context.Context, name string, opts types.PluginRemoveOptions) error {
+	f.Calls = append(f.Calls, fmt.Sprintf("remove %s force=%t", name, opts.Force))
+	delete(f.plugins, pluginRef(name))
+	return nil
+}
+
+func (f *fakePluginLifecycleDocker) PluginSet(_ context.Context, name string, args []string) error {
+	f.Calls = append(f.Calls, fmt.Sprintf("set %s %s", name, strings.Join(args, " ")))
+	p := f.plugins[pluginRef(name)]
+	for _, arg := range args {
+		key, value, _ := strings.Cut(arg, "=")
+		if key == p.Config.Args.Name {
+			p.Settings.Args = strings.Fields(value)
+			continue
+		}
+		p.Settings.Env = append(p.Settings.Env, arg)
+	}
+	return nil
+}
+
+func (f *fakePluginLifecycleDocker) VolumeList(context.Context, volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: f.volumes}, nil
+}
+
+// newFakePluginLifecycleDocker returns a fake with the running sshfs:latest volume driver plugin,
+// whose DEBUG variable was set, and the stopped logger:v2 plugin.
+func newFakePluginLifecycleDocker(volumes ...*volume.Volume) *fakePluginLifecycleDocker {
+	debug := "0"
+	return &fakePluginLifecycleDocker{
+		plugins: map[string]*types.Plugin{
+			"sshfs:latest": {
+				ID:      "sshfs-id",
+				Name:    "sshfs:latest",
+				Enabled: true,
+				Config: types.PluginConfig{
+					Interface: types.PluginConfigInterface{
+						Types: []types.PluginInterfaceType{{Prefix: "docker", Capability: "volumedriver", Version: "1.0"}},
+					},
+					Env:  []types.PluginEnv{{Name: "DEBUG", Settable: []string{"value"}, Value: &debug}},
+					Args: types.PluginConfigArgs{Name: "args", Settable: []string{"value"}},
+				},
+				Settings: types.PluginSettings{Env: []string{"DEBUG=1"}},
+			},
+			"logger:v2": {
+				ID:   "logger-id",
+				Name: "logger:v2",
+				Config: types.PluginConfig{
+					Interface: types.PluginConfigInterface{
+						Types: []types.PluginInterfaceType{{Prefix: "docker", Capability: "logdriver", Version: "1.0"}},
+					},
+				},
+			},
+		},
+		volumes: volumes,
+	}
+}
+
+func TestPluginInspect(t *testing.T) {
+	volumes := []*volume.Volume{
+		{Name: "remote", Driver: "sshfs:latest"},
+		{Name: "backup", Driver: "sshfs"},
+		{Name: "data", Driver: "local"},
+	}
+
+	tests := []struct {
+		name       string
+		inInstance string
+		want       *options.PluginDetails
+		wantErr    error
+	}{
+		{
+			name:       "volume-driver",
+			inInstance: "sshfs",
+			want: &options.PluginDetails{
+				ID:           "sshfs-id",
+				Name:         "sshfs:latest",
+				Enabled:      true,
+				Capabilities: []string{"docker.volumedriver/1.0"},
+				Env:          []string{"DEBUG=1"},
+				References:   []string{"backup", "remote"},
+			},
+		},
+		{
+			name:       "stopped",
+			inInstance: "logger:v2",
+			want: &options.PluginDetails{
+				ID:           "logger-id",
+				Name:         "logger:v2",
+				Capabilities: []string{"docker.logdriver/1.0"},
+				References:   []string{},
+			},
+		},
+		{
+			name:       "not-found",
+			inInstance: "logger",
+			wantErr:    status.Errorf(codes.NotFound, "plugin %s not found", "logger"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mgr := New(newFakePluginLifecycleDocker(volumes...))
+
+			got, err := mgr.PluginInspect(context.Background(), tc.inInstance)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginInspect(%q) returned unexpected error (-want +got):\n%s", tc.inInstance, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PluginInspect(%q) returned diff (-want +got):\n%s", tc.inInstance, diff)
+			}
+		})
+	}
+}
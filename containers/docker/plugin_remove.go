@@ -4,11 +4,29 @@ import (
 	"context"
 
 	"github.com/docker/docker/api/types"
+	"github.com/openconfig/containerz/containers"
 )
 
-// PluginRemove removes a plugin named `instance` from the target system.
-func (m *Manager) PluginRemove(ctx context.Context, instance string) error {
+// PluginRemove removes a plugin named `instance` from the target system. Unless forced, it refuses
+// to remove a plugin that volumes are using, and stops the plugin first if it is running.
+func (m *Manager) PluginRemove(ctx context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+	if !optionz.Force {
+		p, err := m.inspectPlugin(ctx, instance)
+		if err != nil {
+			return err
+		}
+		if err := m.checkPluginUnused(ctx, p.Name); err != nil {
+			return err
+		}
+		if p.Enabled {
+			if err := m.client.PluginDisable(ctx, p.Name, types.PluginDisableOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
 	return m.client.PluginRemove(ctx, instance, types.PluginRemoveOptions{
-		Force: true,
+		Force: optionz.Force,
 	})
 }
@@ -4,52 +4,67 @@ import (
 	"context"
 	"testing"
 
+	"github.com/docker/docker/api/types/volume"
 	"github.com/google/go-cmp/cmp"
-	"github.com/docker/docker/api/types"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-type fakeRemovingPluginDocker struct {
-	fakeDocker
-
-	instance string
-	options  types.PluginRemoveOptions
-}
-
-func (f *fakeRemovingPluginDocker) PluginRemove(ctx context.Context, instance string, options types.PluginRemoveOptions) error {
-	f.instance = instance
-	f.options = options
-	return nil
-}
-
 func TestPluginRemove(t *testing.T) {
 	tests := []struct {
 		name       string
 		inInstance string
-		wantState  *fakeRemovingPluginDocker
+		inOpts     []options.Option
+		inVolumes  []*volume.Volume
+		wantCalls  []string
+		wantErr    error
 	}{
 		{
-			name:       "success",
-			inInstance: "some-instance",
-			wantState: &fakeRemovingPluginDocker{
-				instance: "some-instance",
-				options: types.PluginRemoveOptions{
-					Force: true,
-				},
+			name:       "running",
+			inInstance: "sshfs",
+			wantCalls: []string{
+				"disable sshfs:latest force=false",
+				"remove sshfs force=false",
 			},
 		},
+		{
+			name:       "stopped",
+			inInstance: "logger:v2",
+			wantCalls:  []string{"remove logger:v2 force=false"},
+		},
+		{
+			name:       "forced",
+			inInstance: "sshfs",
+			inOpts:     []options.Option{options.Force()},
+			inVolumes:  []*volume.Volume{{Name: "remote", Driver: "sshfs:latest"}},
+			wantCalls:  []string{"remove sshfs force=true"},
+		},
+		{
+			name:       "in-use",
+			inInstance: "sshfs",
+			inVolumes:  []*volume.Volume{{Name: "remote", Driver: "sshfs:latest"}},
+			wantErr:    status.Errorf(codes.FailedPrecondition, "plugin %s is in use by volumes %s", "sshfs:latest", "remote"),
+		},
+		{
+			name:       "not-found",
+			inInstance: "missing",
+			wantErr:    status.Errorf(codes.NotFound, "plugin %s not found", "missing"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-			m := &Manager{
-				client: &fakeRemovingPluginDocker{},
-			}
-			if err := m.PluginRemove(ctx, tt.inInstance); err != nil {
-				t.Fatalf("PluginRemove() failed: %v", err)
+			fake := newFakePluginLifecycleDocker(tt.inVolumes...)
+			m := New(fake)
+
+			err := m.PluginRemove(context.Background(), tt.inInstance, tt.inOpts...)
+			if diff := cmp.Diff(tt.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginRemove() returned unexpected error (-want +got):\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.wantState, m.client, cmp.AllowUnexported(fakeRemovingPluginDocker{})); diff != "" {
-				t.Errorf("PluginRemove() returned diff (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tt.wantCalls, fake.Calls); diff != "" {
+				t.Errorf("PluginRemove() returned diff in calls (-want +got):\n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// PluginSet changes the settings of the plugin named `instance`. Docker only changes the settings
+// of stopped plugins, so a running plugin is stopped and started again, and must not be in use by
+// volumes.
+func (m *Manager) PluginSet(ctx context.Context, instance string, settings options.PluginSettings) error {
+	p, err := m.inspectPlugin(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	args, err := pluginSetArgs(p.Name, p.Config, settings)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return status.Errorf(codes.InvalidArgument, "no settings to change for plugin %s", p.Name)
+	}
+
+	return m.withPluginStopped(ctx, p, func() error {
+		return m.setPlugin(ctx, p.Name, args)
+	})
+}
+
+// pluginSetArgs returns the arguments of docker plugin set applying the settings to a plugin with
+// the given config.
+func pluginSetArgs(name string, config types.PluginConfig, settings options.PluginSettings) ([]string, error) {
+	var args []string
+	for _, env := range settings.Env {
+		if key, _, ok := strings.Cut(env, "="); !ok || key == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "plugin environment variable %q is not of the form NAME=value", env)
+		}
+		args = append(args, env)
+	}
+	if len(settings.Args) > 0 {
+		if config.Args.Name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "plugin %s does not take arguments", name)
+		}
+		args = append(args, config.Args.Name+"="+strings.Join(settings.Args, " "))
+	}
+	return args, nil
+}
+
+// setPlugin sets the settings of a stopped plugin.
+func (m *Manager) setPlugin(ctx context.Context, name string, args []string) error {
+	if err := m.client.PluginSet(ctx, name, args); err != nil {
+		if cerrdefs.IsInvalidArgument(err) {
+			return status.Errorf(codes.InvalidArgument, "invalid settings for plugin %s: %v", name, err)
+		}
+		return status.Errorf(codes.Internal, "failed to set plugin %s: %v", name, err)
+	}
+	return nil
+}
+
+// withPluginStopped runs change while the plugin is stopped. The plugin is started again afterwards,
+// even if change failed, if it was running.
+func (m *Manager) withPluginStopped(ctx context.Context, p *types.Plugin, change func() error) error {
+	if !p.Enabled {
+		return change()
+	}
+
+	if err := m.checkPluginUnused(ctx, p.Name); err != nil {
+		return err
+	}
+	if err := m.client.PluginDisable(ctx, p.Name, types.PluginDisableOptions{}); err != nil {
+		return status.Errorf(codes.Internal, "failed to stop plugin %s: %v", p.Name, err)
+	}
+
+	changeErr := change()
+	if err := m.client.PluginEnable(ctx, p.Name, types.PluginEnableOptions{}); err != nil {
+		if changeErr != nil {
+			klog.Warningf("failed to start plugin %s again: %v", p.Name, err)
+			return changeErr
+		}
+		return status.Errorf(codes.Internal, "failed to start plugin %s again: %v", p.Name, err)
+	}
+	return changeErr
+}
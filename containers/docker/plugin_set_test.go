@@ -0,0 +1,100 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPluginSet(t *testing.T) {
+	tests := []struct {
+		name       string
+		inInstance string
+		inSettings options.PluginSettings
+		inVolumes  []*volume.Volume
+		wantCalls  []string
+		wantErr    error
+	}{
+		{
+			name:       "running",
+			inInstance: "sshfs",
+			inSettings: options.PluginSettings{Env: []string{"DEBUG=2"}, Args: []string{"-o", "allow_other"}},
+			wantCalls: []string{
+				"disable sshfs:latest force=false",
+				"set sshfs:latest DEBUG=2 args=-o allow_other",
+				"enable sshfs:latest",
+			},
+		},
+		{
+			name:       "stopped",
+			inInstance: "logger:v2",
+			inSettings: options.PluginSettings{Env: []string{"LEVEL=info"}},
+			wantCalls:  []string{"set logger:v2 LEVEL=info"},
+		},
+		{
+			name:       "in-use",
+			inInstance: "sshfs",
+			inSettings: options.PluginSettings{Env: []string{"DEBUG=2"}},
+			inVolumes:  []*volume.Volume{{Name: "remote", Driver: "sshfs:latest"}},
+			wantErr:    status.Errorf(codes.FailedPrecondition, "plugin %s is in use by volumes %s", "sshfs:latest", "remote"),
+		},
+		{
+			name:       "invalid-env",
+			inInstance: "sshfs",
+			inSettings: options.PluginSettings{Env: []string{"DEBUG"}},
+			wantErr:    status.Errorf(codes.InvalidArgument, "plugin environment variable %q is not of the form NAME=value", "DEBUG"),
+		},
+		{
+			name:       "no-args",
+			inInstance: "logger:v2",
+			inSettings: options.PluginSettings{Args: []string{"-v"}},
+			wantErr:    status.Errorf(codes.InvalidArgument, "plugin %s does not take arguments", "logger:v2"),
+		},
+		{
+			name:       "no-settings",
+			inInstance: "sshfs",
+			wantErr:    status.Errorf(codes.InvalidArgument, "no settings to change for plugin %s", "sshfs:latest"),
+		},
+		{
+			name:       "not-found",
+			inInstance: "missing",
+			inSettings: options.PluginSettings{Env: []string{"DEBUG=2"}},
+			wantErr:    status.Errorf(codes.NotFound, "plugin %s not found", "missing"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakePluginLifecycleDocker(tc.inVolumes...)
+			mgr := New(fake)
+
+			err := mgr.PluginSet(context.Background(), tc.inInstance, tc.inSettings)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginSet(%q, %+v) returned unexpected error (-want +got):\n%s", tc.inInstance, tc.inSettings, diff)
+			}
+			if diff := cmp.Diff(tc.wantCalls, fake.Calls); diff != "" {
+				t.Errorf("PluginSet(%q, %+v) returned diff in calls (-want +got):\n%s", tc.inInstance, tc.inSettings, diff)
+			}
+		})
+	}
+}
@@ -1,33 +1,41 @@
 package docker
 
 import (
+	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 
-	"github.com/docker/docker/pkg/archive"
+	"github.com/moby/go-archive"
+	"github.com/moby/go-archive/compression"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/docker/docker/api/types"
 )
 
-var (
-	// pluginLocation is the location where plugins are expected to be written to.
-	pluginLocation = "/plugins"
+const (
+	// defaultPluginLocation is the default location where plugins are expected to be written to.
+	defaultPluginLocation = "/plugins"
 
-	// stagingLocation is the location where plugins are extracted to before being imported.
-	stagingLocation = "/staging"
-)
+	// defaultStagingLocation is the default location where plugins are extracted to before being
+	// imported.
+	defaultStagingLocation = "/staging"
 
-const (
+	// defaultPluginSizeLimit is the default limit on the size of the extracted rootfs of a plugin.
+	defaultPluginSizeLimit = 4 << 30
 
 	// rootfsDir is the name of the directory where the rootfs of a plugin is extracted to. Docker
 	// expects this directory to exist when importing a plugin.
 	rootfsDir = "rootfs"
 )
 
-// PluginStart loads the deployed plugin tarball (expected to be in /plugins) into the container
-// runtime.
+// PluginStart loads the deployed plugin tarball (expected to be in the plugin location) into the
+// container runtime.
 //
 // The operations performed here are based on this [documentation](https://docs.docker.com/engine/extend/#developing-a-plugin).
 // The process is as follows:
@@ -37,25 +45,57 @@ const (
 //  3. Tar up the result
 //  4. Push the tarball to docker and enable the plugin.
 func (m *Manager) PluginStart(ctx context.Context, name, instance, config string) error {
-	f, err := os.Open(filepath.Join(pluginLocation, fmt.Sprintf("%s.tar", name)))
+	if err := validatePluginConfig(config); err != nil {
+		return err
+	}
+
+	if err := m.createPlugin(ctx, name, instance, config); err != nil {
+		return err
+	}
+	m.recordPluginArtifact(instance, name)
+
+	if err := m.client.PluginEnable(ctx, instance, types.PluginEnableOptions{}); err != nil {
+		return fmt.Errorf("failed to enable plugin: %w", err)
+	}
+
+	return nil
+}
+
+// createPlugin performs the steps 1 to 3 of PluginStart and creates, but neither enables nor
+// records, the plugin. Each call stages the plugin in its own directory, so that plugins may be
+// created concurrently.
+func (m *Manager) createPlugin(ctx context.Context, name, instance, config string) error {
+	if !filepath.IsLocal(name) {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin name %q", name)
+	}
+
+	f, err := os.Open(filepath.Join(m.pluginLocation, fmt.Sprintf("%s.tar", name)))
 	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return status.Errorf(codes.NotFound, "plugin %s has not been deployed", name)
+		}
 		return fmt.Errorf("failed to open plugin tar: %w", err)
 	}
 	defer f.Close()
 
-	extractLocation := filepath.Join(stagingLocation, name)
-	if err := os.MkdirAll(filepath.Join(extractLocation, rootfsDir), 0755); err != nil {
-		return fmt.Errorf("failed to create plugin directory %s: %w", filepath.Join(pluginLocation, name), err)
+	if err := os.MkdirAll(m.stagingLocation, 0700); err != nil {
+		return fmt.Errorf("failed to create staging directory %s: %w", m.stagingLocation, err)
 	}
-	defer os.RemoveAll(stagingLocation)
+	extractLocation, err := os.MkdirTemp(m.stagingLocation, "plugin-")
+	if err != nil {
+		return fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+	defer os.RemoveAll(extractLocation)
 
-	if err := archive.Untar(f, filepath.Join(extractLocation, rootfsDir), &archive.TarOptions{
-		NoLchown: true,
-	}); err != nil {
-		return fmt.Errorf("failed to untar plugin: %w", err)
+	if err := os.Mkdir(filepath.Join(extractLocation, rootfsDir), 0755); err != nil {
+		return fmt.Errorf("failed to create plugin directory %s: %w", extractLocation, err)
+	}
+
+	if err := m.untarPlugin(f, filepath.Join(extractLocation, rootfsDir)); err != nil {
+		return err
 	}
 
-	if err := os.WriteFile(filepath.Join(extractLocation, "config.json"), []byte(config), 0666); err != nil {
+	if err := os.WriteFile(filepath.Join(extractLocation, "config.json"), []byte(config), 0644); err != nil {
 		return fmt.Errorf("failed to write plugin config: %w", err)
 	}
 
@@ -72,9 +112,98 @@ func (m *Manager) PluginStart(ctx context.Context, name, instance, config string
 		return fmt.Errorf("failed to create plugin: %w", err)
 	}
 
-	if err := m.client.PluginEnable(ctx, instance, types.PluginEnableOptions{}); err != nil {
-		return fmt.Errorf("failed to enable plugin: %w", err)
+	return nil
+}
+
+// untarPlugin extracts the, possibly compressed, plugin tarball read from r into dest. The
+// tarball is checked by checkPluginTar as it is extracted.
+func (m *Manager) untarPlugin(r io.Reader, dest string) error {
+	decompressed, err := compression.DecompressStream(r)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to decompress plugin tar: %v", err)
 	}
+	defer decompressed.Close()
 
+	pr, pw := io.Pipe()
+	checked := make(chan error, 1)
+	go func() {
+		err := checkPluginTar(pw, decompressed, m.pluginSizeLimit)
+		pw.CloseWithError(err)
+		checked <- err
+	}()
+
+	untarErr := archive.Untar(pr, dest, &archive.TarOptions{
+		NoLchown: true,
+	})
+	pr.CloseWithError(io.ErrClosedPipe) // unblocks the check if the extraction stopped early
+	if err := <-checked; err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		return err
+	}
+	if untarErr != nil {
+		return fmt.Errorf("failed to untar plugin: %w", untarErr)
+	}
 	return nil
 }
+
+// checkPluginTar copies the tarball read from r to w, failing if its entries would be extracted
+// outside of the destination directory, including through symlinks of the tarball, or if the size
+// of its files exceeds limit.
+func checkPluginTar(w io.Writer, r io.Reader, limit int64) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	symlinks := map[string]bool{}
+	var size int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return tw.Close()
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid plugin tar: %v", err)
+		}
+
+		name := filepath.Clean(hdr.Name)
+		if !filepath.IsLocal(name) || throughSymlink(name, symlinks) {
+			return status.Errorf(codes.InvalidArgument, "plugin tar entry %q is outside of the rootfs", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			if !filepath.IsAbs(hdr.Linkname) && !filepath.IsLocal(filepath.Join(filepath.Dir(name), hdr.Linkname)) {
+				return status.Errorf(codes.InvalidArgument, "plugin tar symlink %q to %q is outside of the rootfs", hdr.Name, hdr.Linkname)
+			}
+			symlinks[name] = true
+		case tar.TypeLink:
+			target := filepath.Clean(hdr.Linkname)
+			if !filepath.IsLocal(target) || throughSymlink(target, symlinks) {
+				return status.Errorf(codes.InvalidArgument, "plugin tar link %q to %q is outside of the rootfs", hdr.Name, hdr.Linkname)
+			}
+			if symlinks[target] {
+				symlinks[name] = true
+			}
+		default:
+			delete(symlinks, name)
+		}
+
+		if size += hdr.Size; size > limit {
+			return status.Errorf(codes.InvalidArgument, "plugin rootfs exceeds the size limit of %d bytes", limit)
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// throughSymlink returns whether any of the parent directories of name is one of symlinks.
+func throughSymlink(name string, symlinks map[string]bool) bool {
+	for dir := filepath.Dir(name); dir != "."; dir = filepath.Dir(dir) {
+		if symlinks[dir] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,19 +1,61 @@
 package docker
 
 import (
+	"archive/tar"
 	"context"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/docker/docker/api/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// validPluginConfig is the minimal config docker is able to run a plugin with.
+const validPluginConfig = `{
+	"description": "test plugin",
+	"entrypoint": ["/plugin"],
+	"interface": {"socket": "plugin.sock", "types": ["docker.volumedriver/1.0"]}
+}`
+
+// fakePluginStartingDocker records the files of the plugins created, by instance name.
 type fakePluginStartingDocker struct {
 	fakeDocker
+	mu      sync.Mutex
+	Created map[string][]string
 }
 
-
 func (f *fakePluginStartingDocker) PluginCreate(ctx context.Context, createCtx io.Reader, options types.PluginCreateOptions) error {
+	var files []string
+	tr := tar.NewReader(createCtx)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			files = append(files, hdr.Name)
+		}
+	}
+	sort.Strings(files)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Created == nil {
+		f.Created = map[string][]string{}
+	}
+	f.Created[options.RepoName] = files
 	return nil
 }
 
@@ -21,41 +63,185 @@ func (f *fakePluginStartingDocker) PluginEnable(ctx context.Context, name string
 	return nil
 }
 
+// tarEntry is an entry of a plugin tarball written by writePluginTar.
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func writePluginTar(t *testing.T, dir, name string, entries []tarEntry) {
+	t.Helper()
+	f, err := os.Create(filepath.Join(dir, name+".tar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644, Size: int64(len(e.content))}
+		if e.typeflag == tar.TypeDir {
+			hdr.Mode = 0755
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestPluginStart(t *testing.T) {
-	pluginLocation = "testdata/"
 	tests := []struct {
-		name       string
-		inName     string
-		inInstance string
-		inConfig   string
-		wantErr    bool
+		name        string
+		inName      string
+		inInstance  string
+		inConfig    string
+		inEntries   []tarEntry
+		wantCreated map[string][]string
+		wantErr     error
 	}{
 		{
 			name:       "valid-plugin",
 			inName:     "data",
 			inInstance: "test-instance",
-			inConfig:   "test-config",
+			inConfig:   validPluginConfig,
+			inEntries: []tarEntry{
+				{name: "./", typeflag: tar.TypeDir},
+				{name: "./bin/", typeflag: tar.TypeDir},
+				{name: "./bin/plugin", typeflag: tar.TypeReg, content: "binary"},
+				{name: "./plugin", typeflag: tar.TypeSymlink, linkname: "bin/plugin"},
+				{name: "./etc", typeflag: tar.TypeSymlink, linkname: "/etc"},
+			},
+			wantCreated: map[string][]string{
+				"test-instance": {"config.json", "rootfs/bin/plugin"},
+			},
 		},
 		{
-			name:       "invalid-plugin",
+			name:       "no-such-plugin",
 			inName:     "no-such-plugin",
 			inInstance: "test-instance",
+			inConfig:   validPluginConfig,
+			wantErr:    status.Errorf(codes.NotFound, "plugin %s has not been deployed", "no-such-plugin"),
+		},
+		{
+			name:       "invalid-name",
+			inName:     "../data",
+			inInstance: "test-instance",
+			inConfig:   validPluginConfig,
+			wantErr:    status.Errorf(codes.InvalidArgument, "invalid plugin name %q", "../data"),
+		},
+		{
+			name:       "invalid-config",
+			inName:     "data",
+			inInstance: "test-instance",
 			inConfig:   "test-config",
-			wantErr:    true,
+			inEntries:  []tarEntry{{name: "plugin", typeflag: tar.TypeReg}},
+			wantErr:    status.Errorf(codes.InvalidArgument, "invalid plugin config: %v", "invalid character 'e' in literal true (expecting 'r')"),
+		},
+		{
+			name:       "path-traversal",
+			inName:     "data",
+			inInstance: "test-instance",
+			inConfig:   validPluginConfig,
+			inEntries:  []tarEntry{{name: "../escaped", typeflag: tar.TypeReg, content: "data"}},
+			wantErr:    status.Errorf(codes.InvalidArgument, "plugin tar entry %q is outside of the rootfs", "../escaped"),
+		},
+		{
+			name:       "symlink-traversal",
+			inName:     "data",
+			inInstance: "test-instance",
+			inConfig:   validPluginConfig,
+			inEntries: []tarEntry{
+				{name: "etc", typeflag: tar.TypeSymlink, linkname: "/etc"},
+				{name: "etc/passwd", typeflag: tar.TypeReg, content: "root"},
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "plugin tar entry %q is outside of the rootfs", "etc/passwd"),
+		},
+		{
+			name:       "relative-symlink",
+			inName:     "data",
+			inInstance: "test-instance",
+			inConfig:   validPluginConfig,
+			inEntries:  []tarEntry{{name: "bin/host", typeflag: tar.TypeSymlink, linkname: "../../host"}},
+			wantErr:    status.Errorf(codes.InvalidArgument, "plugin tar symlink %q to %q is outside of the rootfs", "bin/host", "../../host"),
+		},
+		{
+			name:       "too-large",
+			inName:     "data",
+			inInstance: "test-instance",
+			inConfig:   validPluginConfig,
+			inEntries: []tarEntry{
+				{name: "small", typeflag: tar.TypeReg, content: strings.Repeat("a", 10)},
+				{name: "large", typeflag: tar.TypeReg, content: strings.Repeat("a", 100)},
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "plugin rootfs exceeds the size limit of %d bytes", 64),
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			stagingLocation = t.TempDir()
-			ctx := context.Background()
-			mgr := New(&fakePluginStartingDocker{})
-			if err := mgr.PluginStart(ctx, tc.inName, tc.inInstance, tc.inConfig); err != nil {
-				if tc.wantErr {
-					return
-				}
-				t.Errorf("PluginStart(%q, %q, %q) returned error: %v", tc.inName, tc.inInstance, tc.inConfig, err)
+			plugins, staging := t.TempDir(), t.TempDir()
+			if tc.inEntries != nil {
+				writePluginTar(t, plugins, tc.inName, tc.inEntries)
+			}
+			fake := &fakePluginStartingDocker{}
+			mgr := New(fake, WithPluginLocation(plugins), WithStagingLocation(staging), WithPluginSizeLimit(64))
+
+			err := mgr.PluginStart(context.Background(), tc.inName, tc.inInstance, tc.inConfig)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginStart(%q, %q, %q) returned unexpected error (-want +got):\n%s", tc.inName, tc.inInstance, tc.inConfig, diff)
+			}
+			if diff := cmp.Diff(tc.wantCreated, fake.Created); diff != "" {
+				t.Errorf("PluginStart(%q, %q, %q) created unexpected plugins (-want +got):\n%s", tc.inName, tc.inInstance, tc.inConfig, diff)
+			}
+
+			left, err := os.ReadDir(staging)
+			if err != nil {
+				t.Fatalf("staging location was removed: %v", err)
+			}
+			if len(left) != 0 {
+				t.Errorf("PluginStart(%q, %q, %q) left %d entries in the staging location", tc.inName, tc.inInstance, tc.inConfig, len(left))
 			}
 		})
 	}
 }
+
+func TestPluginStartConcurrent(t *testing.T) {
+	plugins := t.TempDir()
+	fake := &fakePluginStartingDocker{}
+	mgr := New(fake, WithPluginLocation(plugins), WithStagingLocation(t.TempDir()))
+
+	want := map[string][]string{}
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("plugin-%d", i)
+		writePluginTar(t, plugins, name, []tarEntry{{name: name, typeflag: tar.TypeReg, content: strings.Repeat("a", 4096)}})
+		want[name] = []string{"config.json", "rootfs/" + name}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(want))
+	for name := range want {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- mgr.PluginStart(context.Background(), name, name, validPluginConfig)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("PluginStart returned error: %v", err)
+		}
+	}
+	if diff := cmp.Diff(want, fake.Created); diff != "" {
+		t.Errorf("PluginStart created unexpected plugins (-want +got):\n%s", diff)
+	}
+}
@@ -4,11 +4,20 @@ import (
 	"context"
 
 	"github.com/docker/docker/api/types"
+	"github.com/openconfig/containerz/containers"
 )
 
-// PluginStop stops a plugin named `instance`.
-func (m *Manager) PluginStop(ctx context.Context, instance string) error {
+// PluginStop stops a plugin named `instance`. Unless forced, it refuses to stop a plugin that
+// volumes are using.
+func (m *Manager) PluginStop(ctx context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+	if !optionz.Force {
+		if err := m.checkPluginUnused(ctx, instance); err != nil {
+			return err
+		}
+	}
+
 	return m.client.PluginDisable(ctx, instance, types.PluginDisableOptions{
-		Force: true,
+		Force: optionz.Force,
 	})
 }
@@ -4,13 +4,19 @@ import (
 	"context"
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type fakeStoppingPluginDocker struct {
 	fakeDocker
 
+	volumes  []*volume.Volume
 	instance string
 	options  types.PluginDisableOptions
 }
@@ -21,32 +27,63 @@ func (f *fakeStoppingPluginDocker) PluginDisable(ctx context.Context, instance s
 	return nil
 }
 
+func (f *fakeStoppingPluginDocker) VolumeList(ctx context.Context, options volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: f.volumes}, nil
+}
+
 func TestPluginStop(t *testing.T) {
+	volumes := []*volume.Volume{
+		{Name: "data", Driver: "used-instance:latest"},
+		{Name: "logs", Driver: "used-instance"},
+		{Name: "other", Driver: "local"},
+	}
+
 	tests := []struct {
 		name       string
 		inInstance string
+		inOpts     []options.Option
 		wantState  *fakeStoppingPluginDocker
+		wantErr    error
 	}{
 		{
 			name:       "success",
 			inInstance: "some-instance",
 			wantState: &fakeStoppingPluginDocker{
+				volumes:  volumes,
 				instance: "some-instance",
+			},
+		},
+		{
+			name:       "forced",
+			inInstance: "used-instance",
+			inOpts:     []options.Option{options.Force()},
+			wantState: &fakeStoppingPluginDocker{
+				volumes:  volumes,
+				instance: "used-instance",
 				options: types.PluginDisableOptions{
 					Force: true,
 				},
 			},
 		},
+		{
+			name:       "in-use",
+			inInstance: "used-instance",
+			wantState: &fakeStoppingPluginDocker{
+				volumes: volumes,
+			},
+			wantErr: status.Errorf(codes.FailedPrecondition, "plugin %s is in use by volumes %s", "used-instance", "data, logs"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 			m := &Manager{
-				client: &fakeStoppingPluginDocker{},
+				client: &fakeStoppingPluginDocker{volumes: volumes},
 			}
-			if err := m.PluginStop(ctx, tt.inInstance); err != nil {
-				t.Fatalf("PluginStop() failed: %v", err)
+			err := m.PluginStop(ctx, tt.inInstance, tt.inOpts...)
+			if diff := cmp.Diff(tt.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginStop() returned unexpected error (-want +got):\n%s", diff)
 			}
 			if diff := cmp.Diff(tt.wantState, m.client, cmp.AllowUnexported(fakeStoppingPluginDocker{})); diff != "" {
 				t.Errorf("PluginStop() returned diff (-want +got):\n%s", diff)
@@ -0,0 +1,157 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// PluginUpgrade replaces the plugin named `instance` with the deployed plugin tarball `name`. The
+// environment and arguments set on the plugin are carried over, as is its config unless a new one
+// is provided. Like PluginSet, it refuses to upgrade a running plugin that volumes are using.
+//
+// The upgraded plugin is first staged under another name, so that a tarball, config or settings
+// that docker rejects leave the plugin untouched. Should the replacement fail nonetheless, the
+// previous plugin is created again from the tarball it was recorded to be created from, which is
+// why plugins without such a tarball cannot be upgraded.
+func (m *Manager) PluginUpgrade(ctx context.Context, name, instance, config string) error {
+	p, err := m.inspectPlugin(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(p.Config)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal plugin config: %v", err)
+	}
+	previousConfig := string(buf)
+	if config == "" {
+		config = previousConfig
+	} else if err := validatePluginConfig(config); err != nil {
+		return err
+	}
+	args := pluginChangedSettings(p)
+
+	previous, err := m.pluginPreviousArtifact(p.Name)
+	if err != nil {
+		return err
+	}
+
+	return m.withPluginStopped(ctx, p, func() error {
+		staged := stagedPluginName(p.Name)
+		if err := m.installPlugin(ctx, name, staged, config, args); err != nil {
+			return err
+		}
+		if err := m.client.PluginRemove(ctx, staged, types.PluginRemoveOptions{}); err != nil {
+			return status.Errorf(codes.Internal, "failed to remove staged plugin %s: %v", staged, err)
+		}
+
+		if err := m.client.PluginRemove(ctx, p.Name, types.PluginRemoveOptions{}); err != nil {
+			return status.Errorf(codes.Internal, "failed to remove plugin %s: %v", p.Name, err)
+		}
+		upgradeErr := m.installPlugin(ctx, name, p.Name, config, args)
+		if upgradeErr == nil {
+			m.recordPluginArtifact(p.Name, name)
+			return nil
+		}
+		if err := m.installPlugin(ctx, previous, p.Name, previousConfig, args); err != nil {
+			return status.Errorf(codes.Internal, "upgrade of plugin %s failed: %v; restoring its previous version failed: %v", p.Name, upgradeErr, err)
+		}
+		return status.Errorf(codes.Internal, "upgrade of plugin %s failed, its previous version was restored: %v", p.Name, upgradeErr)
+	})
+}
+
+// pluginPreviousArtifact returns the deployed tarball the named plugin was recorded to be created
+// from, which an upgrade of the plugin restores it from.
+func (m *Manager) pluginPreviousArtifact(plugin string) (string, error) {
+	m.mu.Lock()
+	records, err := m.pluginRecords()
+	m.mu.Unlock()
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to read plugin records: %v", err)
+	}
+
+	name, ok := records[pluginRef(plugin)]
+	if !ok {
+		return "", status.Errorf(codes.FailedPrecondition, "plugin %s cannot be upgraded as the tarball it was created from is unknown", plugin)
+	}
+	if _, err := os.Stat(filepath.Join(m.pluginLocation, name+pluginArtifactExt)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", status.Errorf(codes.FailedPrecondition, "plugin %s cannot be upgraded as the tarball %s it was created from was removed", plugin, name)
+		}
+		return "", status.Errorf(codes.Internal, "failed to read plugin artifact %s: %v", name, err)
+	}
+	return name, nil
+}
+
+// installPlugin creates, but does not enable, the plugin named `instance` from the tarball `name`
+// and applies args to it. The plugin is removed again if args cannot be applied.
+func (m *Manager) installPlugin(ctx context.Context, name, instance, config string, args []string) error {
+	if err := m.createPlugin(ctx, name, instance, config); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	if err := m.setPlugin(ctx, instance, args); err != nil {
+		if err := m.client.PluginRemove(ctx, instance, types.PluginRemoveOptions{Force: true}); err != nil {
+			klog.Warningf("failed to remove plugin %s: %v", instance, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// stagedPluginName returns the name the upgrade of the named plugin is staged under.
+func stagedPluginName(plugin string) string {
+	ref := pluginRef(plugin)
+	i := strings.LastIndex(ref, ":")
+	return ref[:i] + "-upgrade" + ref[i:]
+}
+
+// pluginChangedSettings returns the arguments of docker plugin set restoring the settings of the
+// plugin that differ from the defaults of its config.
+func pluginChangedSettings(p *types.Plugin) []string {
+	defaults := map[string]string{}
+	for _, env := range p.Config.Env {
+		if env.Value != nil {
+			defaults[env.Name] = *env.Value
+		}
+	}
+
+	var args []string
+	for _, env := range p.Settings.Env {
+		key, value, _ := strings.Cut(env, "=")
+		if d, ok := defaults[key]; !ok || d != value {
+			args = append(args, env)
+		}
+	}
+	if p.Config.Args.Name != "" && !slices.Equal(p.Settings.Args, p.Config.Args.Value) {
+		args = append(args, p.Config.Args.Name+"="+strings.Join(p.Settings.Args, " "))
+	}
+	return args
+}
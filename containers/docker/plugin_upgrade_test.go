@@ -0,0 +1,173 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPluginUpgrade(t *testing.T) {
+	errNoSpace := errors.New("no space left on device")
+
+	tests := []struct {
+		name       string
+		inInstance string
+		inConfig   string
+		inVolumes  []*volume.Volume
+		createErrs []error
+		wantCalls  []string
+		wantPlugin *types.Plugin
+		wantErr    error
+	}{
+		{
+			name:       "keeps-config-and-settings",
+			inInstance: "sshfs",
+			wantCalls: []string{
+				"disable sshfs:latest force=false",
+				"create sshfs-upgrade:latest",
+				"set sshfs-upgrade:latest DEBUG=1",
+				"remove sshfs-upgrade:latest force=false",
+				"remove sshfs:latest force=false",
+				"create sshfs:latest",
+				"set sshfs:latest DEBUG=1",
+				"enable sshfs:latest",
+			},
+			wantPlugin: &types.Plugin{
+				Name:     "sshfs:latest",
+				Enabled:  true,
+				Config:   newFakePluginLifecycleDocker().plugins["sshfs:latest"].Config,
+				Settings: types.PluginSettings{Env: []string{"DEBUG=1"}},
+			},
+		},
+		{
+			name:       "new-config",
+			inInstance: "logger:v2",
+			inConfig:   `{"Description": "new logger", "Entrypoint": ["/logger"], "Interface": {"Socket": "logger.sock", "Types": ["docker.logdriver/1.0"]}}`,
+			wantCalls: []string{
+				"create logger-upgrade:v2",
+				"remove logger-upgrade:v2 force=false",
+				"remove logger:v2 force=false",
+				"create logger:v2",
+			},
+			wantPlugin: &types.Plugin{
+				Name: "logger:v2",
+				Config: types.PluginConfig{
+					Description: "new logger",
+					Entrypoint:  []string{"/logger"},
+					Interface: types.PluginConfigInterface{
+						Socket: "logger.sock",
+						Types:  []types.PluginInterfaceType{{Prefix: "docker", Capability: "logdriver", Version: "1.0"}},
+					},
+				},
+			},
+		},
+		{
+			name:       "staging-failed",
+			inInstance: "sshfs",
+			createErrs: []error{errNoSpace},
+			wantCalls: []string{
+				"disable sshfs:latest force=false",
+				"create sshfs-upgrade:latest",
+				"enable sshfs:latest",
+			},
+			wantPlugin: newFakePluginLifecycleDocker().plugins["sshfs:latest"],
+			wantErr:    errNoSpace,
+		},
+		{
+			name:       "restored",
+			inInstance: "sshfs",
+			createErrs: []error{nil, errNoSpace},
+			wantCalls: []string{
+				"disable sshfs:latest force=false",
+				"create sshfs-upgrade:latest",
+				"set sshfs-upgrade:latest DEBUG=1",
+				"remove sshfs-upgrade:latest force=false",
+				"remove sshfs:latest force=false",
+				"create sshfs:latest",
+				"create sshfs:latest",
+				"set sshfs:latest DEBUG=1",
+				"enable sshfs:latest",
+			},
+			wantPlugin: &types.Plugin{
+				Name:     "sshfs:latest",
+				Enabled:  true,
+				Config:   newFakePluginLifecycleDocker().plugins["sshfs:latest"].Config,
+				Settings: types.PluginSettings{Env: []string{"DEBUG=1"}},
+			},
+			wantErr: status.Errorf(codes.Internal, "upgrade of plugin %s failed, its previous version was restored: %v", "sshfs:latest", "failed to create plugin: no space left on device"),
+		},
+		{
+			name:       "restore-failed",
+			inInstance: "logger:v2",
+			createErrs: []error{nil, errNoSpace, errNoSpace},
+			wantCalls: []string{
+				"create logger-upgrade:v2",
+				"remove logger-upgrade:v2 force=false",
+				"remove logger:v2 force=false",
+				"create logger:v2",
+				"create logger:v2",
+			},
+			wantErr: status.Errorf(codes.Internal, "upgrade of plugin %s failed: %v; restoring its previous version failed: %v", "logger:v2", "failed to create plugin: no space left on device", "failed to create plugin: no space left on device"),
+		},
+		{
+			name:       "in-use",
+			inInstance: "sshfs",
+			inVolumes:  []*volume.Volume{{Name: "remote", Driver: "sshfs"}},
+			wantErr:    status.Errorf(codes.FailedPrecondition, "plugin %s is in use by volumes %s", "sshfs:latest", "remote"),
+		},
+		{
+			name:       "invalid-config",
+			inInstance: "logger:v2",
+			inConfig:   `{"Description": "new logger"}`,
+			wantErr:    status.Errorf(codes.InvalidArgument, "invalid plugin config: interface.types is empty"),
+		},
+		{
+			name:       "not-found",
+			inInstance: "missing",
+			wantErr:    status.Errorf(codes.NotFound, "plugin %s not found", "missing"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakePluginLifecycleDocker(tc.inVolumes...)
+			fake.createErrs = tc.createErrs
+			mgr := New(fake, WithPluginLocation("testdata/"), WithStagingLocation(t.TempDir()))
+
+			err := mgr.PluginUpgrade(context.Background(), "data", tc.inInstance, tc.inConfig)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("PluginUpgrade(%q) returned unexpected error (-want +got):\n%s", tc.inInstance, diff)
+			}
+			if diff := cmp.Diff(tc.wantCalls, fake.Calls); diff != "" {
+				t.Errorf("PluginUpgrade(%q) returned diff in calls (-want +got):\n%s", tc.inInstance, diff)
+			}
+			if tc.wantPlugin == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantPlugin, fake.plugins[tc.wantPlugin.Name], cmpopts.IgnoreFields(types.Plugin{}, "ID")); diff != "" {
+				t.Errorf("PluginUpgrade(%q) returned diff in plugin (-want +got):\n%s", tc.inInstance, diff)
+			}
+		})
+	}
+}
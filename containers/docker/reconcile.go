@@ -0,0 +1,224 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// bootDependencyTimeout is how long the reconciliation waits for the dependencies of a container
+// that does not set its own dependency timeout.
+const bootDependencyTimeout = 5 * time.Minute
+
+// reconcile starts, in dependency order, the stopped containers that declare dependencies along
+// with the stopped containers they depend on, so that they come up in order after the device
+// boots. Docker does not start the containers that declare dependencies itself, as their docker
+// restart policy is no (see RestartPolicyLabel). Each container is started once its dependencies
+// are ready, and only if its restart policy asks for it to run and the operator did not stop it. The temporary and previous versions of the
+// instances are left alone. Containers that are part of a dependency cycle, or that depend on one,
+// are not started and the cycle is returned as an error.
+func (m *Manager) reconcile(ctx context.Context) error {
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to list containers: %v", err)
+	}
+
+	exists := make(map[string]bool, len(cnts))
+	for _, cnt := range cnts {
+		exists[cnt.ID] = true
+	}
+	stopped := m.stoppedIDs(exists)
+
+	byName := make(map[string]types.Container, len(cnts))
+	deps := map[string][]options.Dependency{}
+	for _, cnt := range cnts {
+		if len(cnt.Names) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(cnt.Names[0], "/")
+		if isVersion(name) {
+			continue
+		}
+		byName[name] = cnt
+		if label := cnt.Labels[options.DependsOnLabel]; label != "" {
+			cntDeps, err := parseDependencies(label)
+			if err != nil {
+				klog.Warningf("ignoring invalid dependencies of container %s: %v", name, err)
+				continue
+			}
+			deps[name] = cntDeps
+		}
+	}
+
+	// The graph is made of the containers declaring dependencies and the containers they depend on.
+	nodes := map[string]bool{}
+	for name, cntDeps := range deps {
+		nodes[name] = true
+		for _, dep := range cntDeps {
+			if _, ok := byName[dep.Instance]; ok {
+				nodes[dep.Instance] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	members := make([]options.GroupMember, 0, len(names))
+	for _, name := range names {
+		member := options.GroupMember{Name: name}
+		for _, dep := range deps[name] {
+			if nodes[dep.Instance] && dep.Instance != name {
+				member.DependsOn = append(member.DependsOn, dep)
+			}
+		}
+		members = append(members, member)
+	}
+
+	ordered, orderErr := startOrder(members)
+	if orderErr != nil {
+		orderErr = status.Errorf(codes.FailedPrecondition, "containers have a dependency cycle: %v", orderErr)
+	}
+
+	skipped := map[string]bool{}
+	for _, member := range ordered {
+		if reason := m.reconcileOne(ctx, member.Name, byName[member.Name], deps[member.Name], byName, stopped, skipped); reason != "" {
+			klog.Warningf("not starting container %s: %s", member.Name, reason)
+			skipped[member.Name] = true
+		}
+	}
+
+	return orderErr
+}
+
+// reconcileOne starts the container once its dependencies are ready, unless it is already running,
+// was stopped by the operator or its restart policy does not ask for it to run. It returns the
+// reason the container was not started, if any.
+func (m *Manager) reconcileOne(ctx context.Context, name string, cnt types.Container, deps []options.Dependency, byName map[string]types.Container, stopped, skipped map[string]bool) string {
+	if cnt.State == container.StateRunning {
+		return ""
+	}
+	if stopped[cnt.ID] {
+		return "stopped by the operator"
+	}
+	info, err := m.client.ContainerInspect(ctx, cnt.ID)
+	if err != nil {
+		return "unable to inspect container: " + err.Error()
+	}
+	if !shouldRun(info) {
+		return "its restart policy does not ask for it to run"
+	}
+
+	for _, dep := range deps {
+		if _, ok := byName[dep.Instance]; !ok {
+			return "dependency " + dep.Instance + " does not exist"
+		}
+		if skipped[dep.Instance] {
+			return "dependency " + dep.Instance + " was not started"
+		}
+	}
+
+	timeout := bootDependencyTimeout
+	if label := cnt.Labels[options.DependencyTimeoutLabel]; label != "" {
+		if d, err := time.ParseDuration(label); err == nil && d > 0 {
+			timeout = d
+		}
+	}
+	if err := m.waitDependencies(ctx, deps, timeout); err != nil {
+		return err.Error()
+	}
+
+	if err := m.client.ContainerStart(ctx, cnt.ID, container.StartOptions{}); err != nil {
+		return "unable to start container: " + err.Error()
+	}
+	klog.Infof("started container %s after its dependencies", name)
+	return ""
+}
+
+// shouldRun reports whether the restart policy of the stopped container asks for it to run: always
+// and unless-stopped containers should, as should on-failure containers that exited with an error.
+// The policy of a container that declares dependencies is held by its RestartPolicyLabel.
+func shouldRun(info types.ContainerJSON) bool {
+	if info.ContainerJSONBase == nil || info.HostConfig == nil {
+		return false
+	}
+	policy := info.HostConfig.RestartPolicy
+	if info.Config != nil {
+		if label := info.Config.Labels[options.RestartPolicyLabel]; label != "" {
+			var err error
+			if policy, err = parseRestartPolicyLabel(label); err != nil {
+				klog.Warningf("ignoring invalid restart policy of container %s: %v", info.Name, err)
+				return false
+			}
+		}
+	}
+	switch policy.Name {
+	case container.RestartPolicyAlways, container.RestartPolicyUnlessStopped:
+		return true
+	case container.RestartPolicyOnFailure:
+		return info.State != nil && info.State.ExitCode != 0
+	}
+	return false
+}
+
+// restartPolicyLabel returns the RestartPolicyLabel of the restart policy, e.g. on-failure:3.
+func restartPolicyLabel(policy container.RestartPolicy) string {
+	if policy.MaximumRetryCount > 0 {
+		return fmt.Sprintf("%s:%d", policy.Name, policy.MaximumRetryCount)
+	}
+	return string(policy.Name)
+}
+
+// parseRestartPolicyLabel parses a RestartPolicyLabel, e.g. on-failure:3.
+func parseRestartPolicyLabel(label string) (container.RestartPolicy, error) {
+	name, count, ok := strings.Cut(label, ":")
+	policy := container.RestartPolicy{Name: container.RestartPolicyMode(name)}
+	if ok {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 0 {
+			return container.RestartPolicy{}, fmt.Errorf("invalid maximum retry count %q", count)
+		}
+		policy.MaximumRetryCount = n
+	}
+	if err := container.ValidateRestartPolicy(policy); err != nil {
+		return container.RestartPolicy{}, err
+	}
+	return policy, nil
+}
+
+// isVersion reports whether the container is the next, previous or superseded version of an
+// instance, rather than an instance of its own.
+func isVersion(name string) bool {
+	for _, suffix := range []string{nextName(""), previousName(""), supersededName("")} {
+		if instance, ok := strings.CutSuffix(name, suffix); ok && instance != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReconcile(t *testing.T) {
+	healthPollInterval = time.Millisecond
+
+	dependsOn := func(deps string) map[string]string {
+		return map[string]string{options.DependsOnLabel: deps, options.DependencyTimeoutLabel: "10ms"}
+	}
+
+	withPolicy := func(labels map[string]string, policy string) map[string]string {
+		labels[options.RestartPolicyLabel] = policy
+		return labels
+	}
+
+	always := container.RestartPolicyAlways
+
+	tests := []struct {
+		name        string
+		inCnts      []fakeCnt
+		inStopped   []string
+		wantStarted []string
+		wantErr     error
+	}{
+		{
+			name: "dependency-order",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: always, Labels: dependsOn("db:healthy,cache")},
+				{ID: "db-id", Name: "db", Policy: always, Health: "healthy"},
+				{ID: "cache-id", Name: "cache", Policy: always, Labels: dependsOn("db")},
+				{ID: "other-id", Name: "other", Policy: always},
+			},
+			wantStarted: []string{"db", "cache", "collector"},
+		},
+		{
+			name: "dependency-running",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: always, Labels: dependsOn("db")},
+				{ID: "db-id", Name: "db", Policy: always, Running: true},
+			},
+			wantStarted: []string{"collector"},
+		},
+		{
+			name: "dependency-not-ready",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: always, Labels: dependsOn("db:healthy")},
+				{ID: "exporter-id", Name: "exporter", Policy: always, Labels: dependsOn("collector")},
+				{ID: "db-id", Name: "db", Policy: always},
+			},
+			wantStarted: []string{"db"},
+		},
+		{
+			name: "missing-dependency",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: always, Labels: dependsOn("db")},
+			},
+		},
+		{
+			name: "restart-policy",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: container.RestartPolicyUnlessStopped, Labels: dependsOn("db")},
+				{ID: "db-id", Name: "db", Policy: container.RestartPolicyOnFailure, Exit: 137},
+				{ID: "exporter-id", Name: "exporter", Policy: container.RestartPolicyOnFailure, Labels: dependsOn("db")},
+				{ID: "cache-id", Name: "cache", Policy: container.RestartPolicyDisabled},
+				{ID: "web-id", Name: "web", Policy: always, Labels: dependsOn("cache")},
+				{ID: "proxy-id", Name: "proxy", Labels: dependsOn("db")},
+			},
+			wantStarted: []string{"db", "collector"},
+		},
+		{
+			name: "restart-policy-label",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: container.RestartPolicyDisabled, Labels: withPolicy(dependsOn("db"), "unless-stopped")},
+				{ID: "exporter-id", Name: "exporter", Policy: container.RestartPolicyDisabled, Labels: withPolicy(dependsOn("db"), "on-failure:3")},
+				{ID: "proxy-id", Name: "proxy", Policy: container.RestartPolicyDisabled, Labels: withPolicy(dependsOn("db"), "sometimes")},
+				{ID: "db-id", Name: "db", Policy: always, Running: true},
+			},
+			wantStarted: []string{"collector"},
+		},
+		{
+			name: "stopped-by-operator",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: always, Labels: dependsOn("db")},
+				{ID: "exporter-id", Name: "exporter", Policy: always, Labels: dependsOn("db")},
+				{ID: "db-id", Name: "db", Policy: always},
+			},
+			inStopped:   []string{"exporter-id", "removed-id"},
+			wantStarted: []string{"db", "collector"},
+		},
+		{
+			name: "versions-left-alone",
+			inCnts: []fakeCnt{
+				{ID: "collector-id", Name: "collector", Policy: always, Running: true, Labels: dependsOn("db")},
+				{ID: "collector-previous-id", Name: "collector-previous", Policy: always, Labels: dependsOn("db")},
+				{ID: "collector-next-id", Name: "collector-next", Policy: always, Labels: dependsOn("db")},
+				{ID: "collector-superseded-id", Name: "collector-superseded", Policy: always, Labels: dependsOn("db")},
+				{ID: "db-id", Name: "db", Policy: always, Running: true},
+			},
+		},
+		{
+			name: "cycle",
+			inCnts: []fakeCnt{
+				{ID: "a-id", Name: "a", Policy: always, Labels: dependsOn("b")},
+				{ID: "b-id", Name: "b", Policy: always, Labels: dependsOn("a")},
+				{ID: "c-id", Name: "c", Policy: always, Labels: dependsOn("d")},
+				{ID: "d-id", Name: "d", Policy: always},
+			},
+			wantStarted: []string{"d", "c"},
+			wantErr: status.Errorf(codes.FailedPrecondition, "containers have a dependency cycle: %v",
+				status.Errorf(codes.InvalidArgument, "group members %s have a dependency cycle", "a, b")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fbd := &fakeBlueGreenDocker{Cnts: tc.inCnts}
+			mgr := New(fbd)
+			for _, id := range tc.inStopped {
+				mgr.stopped[id] = true
+			}
+
+			err := mgr.reconcile(context.Background())
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("reconcile() returned unexpected error (-want, +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantStarted, fbd.started, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("reconcile() started diff(-want, +got):\n%s", diff)
+			}
+			for _, id := range tc.inStopped {
+				if want := id != "removed-id"; mgr.stopped[id] != want {
+					t.Errorf("reconcile() kept container %s stopped by the operator: %v, want %v", id, mgr.stopped[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileAfterBoot(t *testing.T) {
+	healthPollInterval = time.Millisecond
+
+	fbd := &fakeBlueGreenDocker{}
+	mgr := New(fbd)
+
+	always := &cpb.StartContainerRequest_Restart{Policy: cpb.StartContainerRequest_Restart_ALWAYS}
+	starts := []struct {
+		name string
+		deps []options.Dependency
+	}{
+		{name: "db"},
+		{name: "collector", deps: []options.Dependency{{Instance: "db", Condition: options.ConditionRunning}}},
+		{name: "exporter", deps: []options.Dependency{{Instance: "collector", Condition: options.ConditionRunning}}},
+	}
+	for _, start := range starts {
+		opts := []options.Option{options.WithInstanceName(start.name), options.WithRestartPolicy(always)}
+		if len(start.deps) > 0 {
+			opts = append(opts, options.WithDependsOn(start.deps))
+		}
+		if _, err := mgr.ContainerStart(context.Background(), "my-image", "v2", "", opts...); err != nil {
+			t.Fatalf("ContainerStart(%q) returned error: %v", start.name, err)
+		}
+	}
+
+	// The device reboots: docker starts the containers whose docker restart policy asks for it,
+	// before containerz runs.
+	fbd.started = nil
+	for i := range fbd.Cnts {
+		fbd.Cnts[i].Running = fbd.Cnts[i].Policy == container.RestartPolicyAlways
+	}
+	var bootStarted []string
+	for _, cnt := range fbd.Cnts {
+		if cnt.Running {
+			bootStarted = append(bootStarted, cnt.Name)
+		}
+	}
+	if diff := cmp.Diff([]string{"db"}, bootStarted); diff != "" {
+		t.Fatalf("docker started diff at boot (-want, +got):\n%s", diff)
+	}
+
+	if err := New(fbd).reconcile(context.Background()); err != nil {
+		t.Fatalf("reconcile() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"collector", "exporter"}, fbd.started); diff != "" {
+		t.Errorf("reconcile() started diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestParseRestartPolicyLabel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    container.RestartPolicy
+		wantErr bool
+	}{
+		{in: "always", want: container.RestartPolicy{Name: container.RestartPolicyAlways}},
+		{in: "unless-stopped", want: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped}},
+		{in: "on-failure:3", want: container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 3}},
+		{in: "on-failure:many", wantErr: true},
+		{in: "always:3", wantErr: true},
+		{in: "sometimes", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := parseRestartPolicyLabel(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseRestartPolicyLabel(%q) returned error %v, want error: %t", tc.in, err, tc.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("parseRestartPolicyLabel(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && restartPolicyLabel(got) != tc.in {
+			t.Errorf("restartPolicyLabel(%+v) = %q, want %q", got, restartPolicyLabel(got), tc.in)
+		}
+	}
+}
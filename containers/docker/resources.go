@@ -0,0 +1,165 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/blkiodev"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ulimitNames are the resources for which docker accepts ulimits.
+var ulimitNames = map[string]bool{
+	"core": true, "cpu": true, "data": true, "fsize": true, "locks": true, "memlock": true,
+	"msgqueue": true, "nice": true, "nofile": true, "nproc": true, "rss": true, "rtprio": true,
+	"rttime": true, "sigpending": true, "stack": true,
+}
+
+// resourcesConfig returns the docker resource configuration of the CPU and memory limits and the
+// resource controls. The OOM score adjustment is validated but, being part of the host
+// configuration, is left to the caller to set.
+func resourcesConfig(cpus float64, softMemory, hardMemory int64, res options.Resources) (container.Resources, error) {
+	cpu, err := options.ParseCPUs(cpus)
+	if err != nil {
+		return container.Resources{}, fmt.Errorf("unable to parse cpu limit %f: %v", cpus, err)
+	}
+
+	for name, set := range map[string]string{"cpus": res.CPUSetCPUs, "memory nodes": res.CPUSetMems} {
+		if err := checkCPUSet(set); err != nil {
+			return container.Resources{}, status.Errorf(codes.InvalidArgument, "cpuset %s %q is invalid: %v", name, set, err)
+		}
+	}
+	// The kernel rejects weights below 2.
+	if res.CPUShares != 0 && res.CPUShares < 2 {
+		return container.Resources{}, status.Errorf(codes.InvalidArgument, "cpu shares must be at least 2, got %d", res.CPUShares)
+	}
+	if res.PidsLimit < -1 {
+		return container.Resources{}, status.Errorf(codes.InvalidArgument, "pids limit must be positive or -1 for no limit, got %d", res.PidsLimit)
+	}
+	if res.BlkioWeight != 0 && (res.BlkioWeight < 10 || res.BlkioWeight > 1000) {
+		return container.Resources{}, status.Errorf(codes.InvalidArgument, "blkio weight must be between 10 and 1000, got %d", res.BlkioWeight)
+	}
+	if res.OOMScoreAdj < -1000 || res.OOMScoreAdj > 1000 {
+		return container.Resources{}, status.Errorf(codes.InvalidArgument, "oom score adjustment must be between -1000 and 1000, got %d", res.OOMScoreAdj)
+	}
+	switch {
+	case res.MemorySwap == 0:
+	case hardMemory == 0:
+		return container.Resources{}, status.Errorf(codes.InvalidArgument, "memory swap requires a hard memory limit")
+	case res.MemorySwap < -1 || (res.MemorySwap > 0 && res.MemorySwap < hardMemory):
+		return container.Resources{}, status.Errorf(codes.InvalidArgument, "memory swap must be -1 or at least the hard memory limit %d, got %d", hardMemory, res.MemorySwap)
+	}
+
+	ulimits := make([]*units.Ulimit, 0, len(res.Ulimits))
+	seen := map[string]bool{}
+	for _, u := range res.Ulimits {
+		switch {
+		case !ulimitNames[u.Name]:
+			return container.Resources{}, status.Errorf(codes.InvalidArgument, "unknown ulimit %q", u.Name)
+		case seen[u.Name]:
+			return container.Resources{}, status.Errorf(codes.InvalidArgument, "ulimit %s is set more than once", u.Name)
+		case u.Soft > u.Hard:
+			return container.Resources{}, status.Errorf(codes.InvalidArgument, "ulimit %s has a soft limit above its hard limit", u.Name)
+		}
+		seen[u.Name] = true
+		ulimits = append(ulimits, &units.Ulimit{Name: u.Name, Soft: u.Soft, Hard: u.Hard})
+	}
+
+	readBps, err := throttleDevices(res.DeviceReadBPS)
+	if err != nil {
+		return container.Resources{}, err
+	}
+	writeBps, err := throttleDevices(res.DeviceWriteBPS)
+	if err != nil {
+		return container.Resources{}, err
+	}
+	readIOps, err := throttleDevices(res.DeviceReadIOPS)
+	if err != nil {
+		return container.Resources{}, err
+	}
+	writeIOps, err := throttleDevices(res.DeviceWriteIOPS)
+	if err != nil {
+		return container.Resources{}, err
+	}
+
+	resources := container.Resources{
+		NanoCPUs:             cpu,
+		Memory:               hardMemory, // hard
+		MemoryReservation:    softMemory, // soft
+		MemorySwap:           res.MemorySwap,
+		CpusetCpus:           res.CPUSetCPUs,
+		CpusetMems:           res.CPUSetMems,
+		CPUShares:            res.CPUShares,
+		BlkioWeight:          res.BlkioWeight,
+		BlkioDeviceReadBps:   readBps,
+		BlkioDeviceWriteBps:  writeBps,
+		BlkioDeviceReadIOps:  readIOps,
+		BlkioDeviceWriteIOps: writeIOps,
+	}
+	if res.PidsLimit != 0 {
+		limit := res.PidsLimit
+		resources.PidsLimit = &limit
+	}
+	if len(ulimits) > 0 {
+		resources.Ulimits = ulimits
+	}
+	return resources, nil
+}
+
+// checkCPUSet returns an error if the set is not a comma separated list of CPUs or memory nodes
+// and ranges of them, e.g. 0-3,6.
+func checkCPUSet(set string) error {
+	if set == "" {
+		return nil
+	}
+	for _, part := range strings.Split(set, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.ParseUint(first, 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid element %q", part)
+		}
+		if !isRange {
+			continue
+		}
+		end, err := strconv.ParseUint(last, 10, 16)
+		if err != nil || end < start {
+			return fmt.Errorf("invalid range %q", part)
+		}
+	}
+	return nil
+}
+
+// throttleDevices returns the docker rate limits of the devices.
+func throttleDevices(devices []options.ThrottleDevice) ([]*blkiodev.ThrottleDevice, error) {
+	if len(devices) == 0 {
+		return nil, nil
+	}
+	res := make([]*blkiodev.ThrottleDevice, 0, len(devices))
+	for _, dev := range devices {
+		if !filepath.IsAbs(dev.Path) {
+			return nil, status.Errorf(codes.InvalidArgument, "device path %q must be absolute", dev.Path)
+		}
+		res = append(res, &blkiodev.ThrottleDevice{Path: dev.Path, Rate: dev.Rate})
+	}
+	return res, nil
+}
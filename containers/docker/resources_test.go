@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/blkiodev"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResourcesConfig(t *testing.T) {
+	unlimited := int64(-1)
+
+	tests := []struct {
+		name    string
+		inCPU   float64
+		inSoft  int64
+		inHard  int64
+		in      options.Resources
+		want    container.Resources
+		wantErr error
+	}{
+		{
+			name:   "limits-only",
+			inCPU:  1.5,
+			inSoft: 1 << 20,
+			inHard: 1 << 30,
+			want: container.Resources{
+				NanoCPUs:          1500000000,
+				MemoryReservation: 1 << 20,
+				Memory:            1 << 30,
+			},
+		},
+		{
+			name:   "all-controls",
+			inHard: 1 << 30,
+			in: options.Resources{
+				CPUSetCPUs:      "0-3,6",
+				CPUSetMems:      "0",
+				CPUShares:       512,
+				PidsLimit:       -1,
+				Ulimits:         []options.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}, {Name: "core", Soft: 0, Hard: 0}},
+				BlkioWeight:     300,
+				DeviceReadBPS:   []options.ThrottleDevice{{Path: "/dev/sda", Rate: 1 << 20}},
+				DeviceWriteIOPS: []options.ThrottleDevice{{Path: "/dev/sda", Rate: 100}},
+				MemorySwap:      2 << 30,
+				OOMScoreAdj:     -500,
+			},
+			want: container.Resources{
+				Memory:               1 << 30,
+				MemorySwap:           2 << 30,
+				CpusetCpus:           "0-3,6",
+				CpusetMems:           "0",
+				CPUShares:            512,
+				PidsLimit:            &unlimited,
+				Ulimits:              []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}, {Name: "core", Soft: 0, Hard: 0}},
+				BlkioWeight:          300,
+				BlkioDeviceReadBps:   []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 1 << 20}},
+				BlkioDeviceWriteIOps: []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 100}},
+			},
+		},
+		{
+			name:    "invalid-cpuset",
+			in:      options.Resources{CPUSetCPUs: "3-1"},
+			wantErr: status.Errorf(codes.InvalidArgument, "cpuset %s %q is invalid: %v", "cpus", "3-1", `invalid range "3-1"`),
+		},
+		{
+			name:    "cpu-shares-too-low",
+			in:      options.Resources{CPUShares: 1},
+			wantErr: status.Errorf(codes.InvalidArgument, "cpu shares must be at least 2, got %d", 1),
+		},
+		{
+			name:    "blkio-weight-out-of-range",
+			in:      options.Resources{BlkioWeight: 5},
+			wantErr: status.Errorf(codes.InvalidArgument, "blkio weight must be between 10 and 1000, got %d", 5),
+		},
+		{
+			name:    "oom-score-out-of-range",
+			in:      options.Resources{OOMScoreAdj: 2000},
+			wantErr: status.Errorf(codes.InvalidArgument, "oom score adjustment must be between -1000 and 1000, got %d", 2000),
+		},
+		{
+			name:    "swap-without-memory-limit",
+			in:      options.Resources{MemorySwap: 1 << 30},
+			wantErr: status.Errorf(codes.InvalidArgument, "memory swap requires a hard memory limit"),
+		},
+		{
+			name:    "swap-below-memory-limit",
+			inHard:  1 << 30,
+			in:      options.Resources{MemorySwap: 1 << 20},
+			wantErr: status.Errorf(codes.InvalidArgument, "memory swap must be -1 or at least the hard memory limit %d, got %d", 1<<30, 1<<20),
+		},
+		{
+			name:    "unknown-ulimit",
+			in:      options.Resources{Ulimits: []options.Ulimit{{Name: "files", Soft: 1, Hard: 1}}},
+			wantErr: status.Errorf(codes.InvalidArgument, "unknown ulimit %q", "files"),
+		},
+		{
+			name:    "duplicate-ulimit",
+			in:      options.Resources{Ulimits: []options.Ulimit{{Name: "core"}, {Name: "core"}}},
+			wantErr: status.Errorf(codes.InvalidArgument, "ulimit %s is set more than once", "core"),
+		},
+		{
+			name:    "relative-device-path",
+			in:      options.Resources{DeviceWriteBPS: []options.ThrottleDevice{{Path: "sda", Rate: 1}}},
+			wantErr: status.Errorf(codes.InvalidArgument, "device path %q must be absolute", "sda"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resourcesConfig(tc.inCPU, tc.inSoft, tc.inHard, tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("resourcesConfig(%+v) returned unexpected error (-want, +got):\n%s", tc.in, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("resourcesConfig(%+v) returned diff (-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
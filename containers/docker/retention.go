@@ -0,0 +1,180 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// retainedFile is the file of the history location the retained versions are persisted to. Names
+// of containers cannot start with a dot, so it cannot clash with the history of an instance.
+const retainedFile = ".retained.json"
+
+// retainedVersion is the previous version of an instance kept for rollback until a deadline.
+type retainedVersion struct {
+	ID    string    `json:"id"`
+	Until time.Time `json:"until"`
+
+	timer *time.Timer
+}
+
+// retain keeps the previous version of the instance, the container with the given ID, for the
+// keep duration, after which it is removed. A zero duration removes it immediately.
+func (m *Manager) retain(instance, id string, keep time.Duration) {
+	if keep <= 0 {
+		m.discard(context.Background(), id)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keep(instance, &retainedVersion{ID: id, Until: time.Now().Add(keep)})
+	m.persistRetained()
+}
+
+// keep schedules the removal of the retained version of the instance, superseding any removal
+// scheduled earlier. m.mu must be held.
+func (m *Manager) keep(instance string, r *retainedVersion) {
+	if prev, ok := m.retained[instance]; ok {
+		prev.timer.Stop()
+	}
+	r.timer = time.AfterFunc(time.Until(r.Until), func() {
+		m.mu.Lock()
+		if m.retained[instance] != r {
+			// Released or superseded in the meantime.
+			m.mu.Unlock()
+			return
+		}
+		delete(m.retained, instance)
+		m.persistRetained()
+		m.mu.Unlock()
+
+		m.discard(context.Background(), r.ID)
+	})
+	m.retained[instance] = r
+}
+
+// release stops the pending removal of the previous version of the instance, if any, and returns
+// it so that it can be kept again with restoreRetained should the operation releasing it fail.
+func (m *Manager) release(instance string) *retainedVersion {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r, ok := m.retained[instance]
+	if !ok {
+		return nil
+	}
+	r.timer.Stop()
+	delete(m.retained, instance)
+	m.persistRetained()
+	return r
+}
+
+// restoreRetained keeps the released version of the instance again, if any.
+func (m *Manager) restoreRetained(instance string, r *retainedVersion) {
+	if r == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keep(instance, &retainedVersion{ID: r.ID, Until: r.Until})
+	m.persistRetained()
+}
+
+// retainedIDs returns the IDs of the containers kept for rollback.
+func (m *Manager) retainedIDs() map[string]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make(map[string]bool, len(m.retained))
+	for _, r := range m.retained {
+		ids[r.ID] = true
+	}
+	return ids
+}
+
+// resumeRetention schedules again the removal of the previous versions kept for rollback before
+// containerz restarted. The previous versions whose retention is unknown, e.g. as no history
+// location is set, are removed rather than left behind.
+func (m *Manager) resumeRetention(ctx context.Context) error {
+	records := map[string]*retainedVersion{}
+	if m.historyLocation != "" {
+		buf, err := os.ReadFile(filepath.Join(m.historyLocation, retainedFile))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			klog.Warningf("unable to read the retained versions: %v", err)
+		default:
+			if err := json.Unmarshal(buf, &records); err != nil {
+				klog.Warningf("unable to parse the retained versions: %v", err)
+			}
+		}
+	}
+
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "unable to list containers: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, cnt := range cnts {
+		for _, name := range cnt.Names {
+			instance, ok := strings.CutSuffix(strings.TrimPrefix(name, "/"), previousName(""))
+			if !ok || instance == "" {
+				continue
+			}
+			if _, err := findInstance(instance, cnts); err != nil {
+				continue
+			}
+			if r := records[instance]; r != nil && r.ID == cnt.ID {
+				m.keep(instance, r)
+				continue
+			}
+			if cnt.State == container.StateRunning {
+				continue
+			}
+			klog.Infof("removing previous version %s of instance %s, as its retention is unknown", cnt.ID, instance)
+			m.discard(ctx, cnt.ID)
+		}
+	}
+	m.persistRetained()
+	return nil
+}
+
+// persistRetained writes the retained versions to the history location, if any. m.mu must be held.
+func (m *Manager) persistRetained() {
+	if m.historyLocation == "" {
+		return
+	}
+	if err := writeJSON(filepath.Join(m.historyLocation, retainedFile), m.retained); err != nil {
+		klog.Warningf("unable to persist the retained versions: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestResumeRetention(t *testing.T) {
+	dir := t.TempDir()
+	fbd := &fakeBlueGreenDocker{
+		Cnts: []fakeCnt{
+			{ID: "app-id", Name: "app", Running: true},
+			{ID: "old-id", Name: "app-previous"},
+			{ID: "other-id", Name: "other", Running: true},
+			{ID: "leaked-id", Name: "other-previous"},
+			{ID: "lone-id", Name: "lone-previous"},
+		},
+	}
+	first := New(fbd, WithHistoryLocation(dir))
+	first.retain("app", "old-id", time.Hour)
+	first.retained["app"].timer.Stop()
+
+	// A manager started afterwards, e.g. once containerz restarted, keeps the retained version for
+	// the rest of its retention, and removes the one it knows nothing about.
+	mgr := New(fbd, WithHistoryLocation(dir))
+	if err := mgr.resumeRetention(context.Background()); err != nil {
+		t.Fatalf("resumeRetention() returned error: %v", err)
+	}
+	defer mgr.release("app")
+
+	want := []fakeCnt{
+		{ID: "app-id", Name: "app", Running: true},
+		{ID: "old-id", Name: "app-previous"},
+		{ID: "other-id", Name: "other", Running: true},
+		{ID: "lone-id", Name: "lone-previous"},
+	}
+	if diff := cmp.Diff(want, fbd.Cnts, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("resumeRetention() returned diff(-want, +got):\n%s", diff)
+	}
+	r, ok := mgr.retained["app"]
+	if !ok || r.ID != "old-id" || time.Until(r.Until) < 59*time.Minute {
+		t.Errorf("resumeRetention() retained %+v, want old-id for about an hour", r)
+	}
+}
+
+func TestPruneContainersKeepsRetained(t *testing.T) {
+	fbd := &fakeBlueGreenDocker{
+		Cnts: []fakeCnt{
+			{ID: "old-id", Name: "app-previous"},
+			{ID: "stopped-id", Name: "stopped"},
+		},
+	}
+	mgr := New(fbd)
+	mgr.retain("app", "old-id", time.Hour)
+	defer mgr.release("app")
+
+	report, err := mgr.janitor.pruneContainers(context.Background())
+	if err != nil {
+		t.Fatalf("pruneContainers() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"stopped-id"}, report.ContainersDeleted); diff != "" {
+		t.Errorf("pruneContainers() removed diff(-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]fakeCnt{{ID: "old-id", Name: "app-previous"}}, fbd.Cnts); diff != "" {
+		t.Errorf("pruneContainers() left diff(-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaskedPaths and defaultReadonlyPaths are the paths the runtime masks or makes read-only
+// in every unprivileged container. Setting either list on a container replaces the runtime
+// defaults, so extra paths are appended to them.
+var (
+	defaultMaskedPaths = []string{
+		"/proc/asound", "/proc/acpi", "/proc/interrupts", "/proc/kcore", "/proc/keys",
+		"/proc/latency_stats", "/proc/timer_list", "/proc/timer_stats", "/proc/sched_debug",
+		"/proc/scsi", "/sys/firmware", "/sys/devices/virtual/powercap",
+	}
+	defaultReadonlyPaths = []string{
+		"/proc/bus", "/proc/fs", "/proc/irq", "/proc/sys", "/proc/sysrq-trigger",
+	}
+)
+
+// selinuxOptions are the prefixes of the SELinux label options docker accepts.
+var selinuxOptions = []string{"user:", "role:", "type:", "level:"}
+
+// applySecurity sets the security settings on the host configuration. Named seccomp profiles are
+// read from the profile directory of the manager.
+func (m *Manager) applySecurity(ctx context.Context, hostConfig *container.HostConfig, sec options.Security) error {
+	switch sec.Seccomp {
+	case "", options.SeccompDefault:
+	case options.Unconfined:
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+options.Unconfined)
+	default:
+		profile, err := m.seccompProfile(sec.Seccomp)
+		if err != nil {
+			return err
+		}
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+profile)
+	}
+
+	if sec.AppArmor != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "apparmor="+sec.AppArmor)
+	}
+
+	for _, opt := range sec.SELinux {
+		if !validSELinuxOption(opt) {
+			return status.Errorf(codes.InvalidArgument, "invalid selinux option %q", opt)
+		}
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "label="+opt)
+	}
+
+	if sec.NoNewPrivileges {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "no-new-privileges=true")
+	}
+
+	hostConfig.ReadonlyRootfs = sec.ReadOnlyRootfs
+	for path := range sec.Tmpfs {
+		if !filepath.IsAbs(path) {
+			return status.Errorf(codes.InvalidArgument, "tmpfs mount point %q must be absolute", path)
+		}
+	}
+	if len(sec.Tmpfs) > 0 {
+		hostConfig.Tmpfs = sec.Tmpfs
+	}
+
+	masked, err := extendPaths("masked", defaultMaskedPaths, sec.MaskedPaths)
+	if err != nil {
+		return err
+	}
+	hostConfig.MaskedPaths = masked
+	readonly, err := extendPaths("readonly", defaultReadonlyPaths, sec.ReadonlyPaths)
+	if err != nil {
+		return err
+	}
+	hostConfig.ReadonlyPaths = readonly
+
+	switch sec.UsernsMode {
+	case "":
+	case options.UsernsHost:
+		hostConfig.UsernsMode = container.UsernsMode(sec.UsernsMode)
+	case options.UsernsPrivate:
+		// Docker has no private mode: containers get a private user namespace when the runtime
+		// remaps user namespaces, which is the only way to ensure they do.
+		if err := m.checkUsernsRemap(ctx); err != nil {
+			return err
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid user namespace mode %q", sec.UsernsMode)
+	}
+	return nil
+}
+
+// checkUsernsRemap returns an error unless the runtime remaps the user namespaces of containers.
+func (m *Manager) checkUsernsRemap(ctx context.Context) error {
+	info, err := m.client.Info(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to get the runtime security options: %v", err)
+	}
+	for _, opt := range info.SecurityOptions {
+		if opt == "name=userns" {
+			return nil
+		}
+	}
+	return status.Errorf(codes.FailedPrecondition, "user namespace mode %q requested but the runtime does not remap user namespaces", options.UsernsPrivate)
+}
+
+// seccompProfile returns the JSON seccomp profile stored on the device under the name.
+func (m *Manager) seccompProfile(name string) (string, error) {
+	if m.seccompProfileDir == "" {
+		return "", status.Errorf(codes.FailedPrecondition, "seccomp profile %q requested but no profile directory is configured", name)
+	}
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", status.Errorf(codes.InvalidArgument, "invalid seccomp profile name %q", name)
+	}
+
+	profile, err := os.ReadFile(filepath.Join(m.seccompProfileDir, name+".json"))
+	switch {
+	case os.IsNotExist(err):
+		return "", status.Errorf(codes.NotFound, "seccomp profile %q not found", name)
+	case err != nil:
+		return "", status.Errorf(codes.Internal, "unable to read seccomp profile %q: %v", name, err)
+	}
+	return string(profile), nil
+}
+
+// validSELinuxOption returns whether docker accepts the SELinux label option.
+func validSELinuxOption(opt string) bool {
+	if opt == "disable" {
+		return true
+	}
+	for _, prefix := range selinuxOptions {
+		if strings.HasPrefix(opt, prefix) && len(opt) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// extendPaths returns the default paths followed by the extra ones, or nil to keep the runtime
+// defaults if there are no extra paths.
+func extendPaths(kind string, defaults, extra []string) ([]string, error) {
+	if len(extra) == 0 {
+		return nil, nil
+	}
+	paths := append([]string{}, defaults...)
+	for _, path := range extra {
+		if !filepath.IsAbs(path) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s path %q must be absolute", kind, path)
+		}
+		if !containsPath(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
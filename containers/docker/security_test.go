@@ -0,0 +1,168 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeInfoDocker struct {
+	fakeDocker
+	info system.Info
+}
+
+func (f *fakeInfoDocker) Info(context.Context) (system.Info, error) {
+	return f.info, nil
+}
+
+func TestApplySecurity(t *testing.T) {
+	dir := t.TempDir()
+	profile := `{"defaultAction":"SCMP_ACT_ERRNO"}`
+	if err := os.WriteFile(filepath.Join(dir, "strict.json"), []byte(profile), 0644); err != nil {
+		t.Fatalf("unable to write seccomp profile: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		inDir    string
+		inDocker docker
+		in       options.Security
+		want     *container.HostConfig
+		wantErr  error
+	}{
+		{
+			name: "runtime-defaults",
+			in:   options.Security{Seccomp: options.SeccompDefault},
+			want: &container.HostConfig{},
+		},
+		{
+			name:  "all-settings",
+			inDir: dir,
+			in: options.Security{
+				Seccomp:         "strict",
+				AppArmor:        "docker-default",
+				SELinux:         []string{"type:svirt_apache_t", "level:s0:c100,c200"},
+				ReadOnlyRootfs:  true,
+				Tmpfs:           map[string]string{"/run": "rw,size=64m", "/tmp": ""},
+				NoNewPrivileges: true,
+				MaskedPaths:     []string{"/proc/kcore", "/etc/secret"},
+				ReadonlyPaths:   []string{"/etc"},
+				UsernsMode:      "host",
+			},
+			want: &container.HostConfig{
+				SecurityOpt: []string{
+					"seccomp=" + profile,
+					"apparmor=docker-default",
+					"label=type:svirt_apache_t",
+					"label=level:s0:c100,c200",
+					"no-new-privileges=true",
+				},
+				ReadonlyRootfs: true,
+				Tmpfs:          map[string]string{"/run": "rw,size=64m", "/tmp": ""},
+				MaskedPaths:    append(append([]string{}, defaultMaskedPaths...), "/etc/secret"),
+				ReadonlyPaths:  append(append([]string{}, defaultReadonlyPaths...), "/etc"),
+				UsernsMode:     "host",
+			},
+		},
+		{
+			name: "unconfined",
+			in:   options.Security{Seccomp: options.Unconfined, AppArmor: options.Unconfined, SELinux: []string{"disable"}},
+			want: &container.HostConfig{
+				SecurityOpt: []string{"seccomp=unconfined", "apparmor=unconfined", "label=disable"},
+			},
+		},
+		{
+			name:    "no-profile-dir",
+			in:      options.Security{Seccomp: "strict"},
+			wantErr: status.Errorf(codes.FailedPrecondition, "seccomp profile %q requested but no profile directory is configured", "strict"),
+		},
+		{
+			name:    "missing-profile",
+			inDir:   dir,
+			in:      options.Security{Seccomp: "lax"},
+			wantErr: status.Errorf(codes.NotFound, "seccomp profile %q not found", "lax"),
+		},
+		{
+			name:    "profile-outside-dir",
+			inDir:   dir,
+			in:      options.Security{Seccomp: "../strict"},
+			wantErr: status.Errorf(codes.InvalidArgument, "invalid seccomp profile name %q", "../strict"),
+		},
+		{
+			name:    "invalid-selinux-option",
+			in:      options.Security{SELinux: []string{"kind:x"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "invalid selinux option %q", "kind:x"),
+		},
+		{
+			name:    "relative-tmpfs",
+			in:      options.Security{Tmpfs: map[string]string{"run": ""}},
+			wantErr: status.Errorf(codes.InvalidArgument, "tmpfs mount point %q must be absolute", "run"),
+		},
+		{
+			name:    "relative-masked-path",
+			in:      options.Security{MaskedPaths: []string{"etc"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "%s path %q must be absolute", "masked", "etc"),
+		},
+		{
+			name:     "private-userns",
+			inDocker: &fakeInfoDocker{info: system.Info{SecurityOptions: []string{"name=seccomp,profile=builtin", "name=userns"}}},
+			in:       options.Security{UsernsMode: options.UsernsPrivate},
+			want:     &container.HostConfig{},
+		},
+		{
+			name:     "private-userns-not-remapped",
+			inDocker: &fakeInfoDocker{info: system.Info{SecurityOptions: []string{"name=seccomp,profile=builtin"}}},
+			in:       options.Security{UsernsMode: options.UsernsPrivate},
+			wantErr:  status.Errorf(codes.FailedPrecondition, "user namespace mode %q requested but the runtime does not remap user namespaces", "private"),
+		},
+		{
+			name:    "invalid-userns",
+			in:      options.Security{UsernsMode: "shared"},
+			wantErr: status.Errorf(codes.InvalidArgument, "invalid user namespace mode %q", "shared"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.inDocker == nil {
+				tc.inDocker = &fakeDocker{}
+			}
+			mgr := New(tc.inDocker, WithSeccompProfileDir(tc.inDir))
+
+			got := &container.HostConfig{}
+			err := mgr.applySecurity(context.Background(), got, tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("applySecurity(%+v) returned unexpected error (-want, +got):\n%s", tc.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("applySecurity(%+v) returned diff (-want, +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/klog/v2"
+)
+
+// stoppedFile is the file of the history location the containers stopped by the operator are
+// persisted to. Names of containers cannot start with a dot, so it cannot clash with the history
+// of an instance.
+const stoppedFile = ".stopped.json"
+
+// markStopped records that the operator stopped the container with the given ID, so that it is
+// left alone when the device boots.
+func (m *Manager) markStopped(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped[id] {
+		return
+	}
+	m.stopped[id] = true
+	m.persistStopped()
+}
+
+// unmarkStopped records that the container with the given ID was started again by the operator.
+func (m *Manager) unmarkStopped(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.stopped[id] {
+		return
+	}
+	delete(m.stopped, id)
+	m.persistStopped()
+}
+
+// stoppedIDs returns the IDs of the containers stopped by the operator, forgetting those that no
+// longer exist, i.e. that are not in the given set.
+func (m *Manager) stoppedIDs(exists map[string]bool) map[string]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make(map[string]bool, len(m.stopped))
+	for id := range m.stopped {
+		if !exists[id] {
+			delete(m.stopped, id)
+			continue
+		}
+		ids[id] = true
+	}
+	m.persistStopped()
+	return ids
+}
+
+// loadStopped reads the containers stopped by the operator before containerz restarted from the
+// history location, if any.
+func (m *Manager) loadStopped() {
+	if m.historyLocation == "" {
+		return
+	}
+	buf, err := os.ReadFile(filepath.Join(m.historyLocation, stoppedFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return
+	case err != nil:
+		klog.Warningf("unable to read the stopped containers: %v", err)
+		return
+	}
+	var ids []string
+	if err := json.Unmarshal(buf, &ids); err != nil {
+		klog.Warningf("unable to parse the stopped containers: %v", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		m.stopped[id] = true
+	}
+}
+
+// persistStopped writes the containers stopped by the operator to the history location, if any.
+// m.mu must be held.
+func (m *Manager) persistStopped() {
+	if m.historyLocation == "" {
+		return
+	}
+	ids := make([]string, 0, len(m.stopped))
+	for id := range m.stopped {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if err := writeJSON(filepath.Join(m.historyLocation, stoppedFile), ids); err != nil {
+		klog.Warningf("unable to persist the stopped containers: %v", err)
+	}
+}
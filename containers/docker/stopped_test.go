@@ -0,0 +1,51 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStoppedPersisted(t *testing.T) {
+	dir := t.TempDir()
+	fbd := &fakeBlueGreenDocker{
+		Cnts: []fakeCnt{
+			{ID: "app-id", Name: "app", Running: true, Policy: container.RestartPolicyAlways},
+			{ID: "collector-id", Name: "collector", Running: true, Policy: container.RestartPolicyAlways},
+		},
+	}
+	first := New(fbd, WithHistoryLocation(dir))
+	if err := first.ContainerStop(context.Background(), "app"); err != nil {
+		t.Fatalf("ContainerStop(app) returned error: %v", err)
+	}
+	if err := first.ContainerStop(context.Background(), "collector"); err != nil {
+		t.Fatalf("ContainerStop(collector) returned error: %v", err)
+	}
+	if err := first.ContainerRestart(context.Background(), "collector"); err != nil {
+		t.Fatalf("ContainerRestart(collector) returned error: %v", err)
+	}
+
+	// A manager started afterwards, e.g. once the device booted, knows the containers the operator
+	// stopped and did not start again.
+	mgr := New(fbd, WithHistoryLocation(dir))
+	mgr.loadStopped()
+	if diff := cmp.Diff(map[string]bool{"app-id": true}, mgr.stopped); diff != "" {
+		t.Errorf("loadStopped() returned diff(-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// volumeArchivePath is where volumes are mounted in the helper containers.
+const volumeArchivePath = "/volume"
+
+// VolumeExport writes a gzip compressed tar archive of the contents of the named volume to w. The
+// contents are read through a helper container, that is never started, mounting the volume.
+func (m *Manager) VolumeExport(ctx context.Context, name string, w io.Writer, opts ...options.Option) error {
+	if _, err := m.client.VolumeInspect(ctx, name); err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return status.Errorf(codes.NotFound, "volume %s not found", name)
+		}
+		return status.Errorf(codes.Internal, "failed to inspect volume %s: %v", name, err)
+	}
+
+	id, err := m.createVolumeHelper(ctx, name, true)
+	if err != nil {
+		return err
+	}
+	defer m.removeVolumeHelper(ctx, id)
+
+	rc, _, err := m.client.CopyFromContainer(ctx, id, volumeArchivePath+"/.")
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read volume %s: %v", name, err)
+	}
+	defer rc.Close()
+
+	gz := gzip.NewWriter(w)
+	if _, err := io.Copy(gz, rc); err != nil {
+		return status.Errorf(codes.Internal, "failed to export volume %s: %v", name, err)
+	}
+	if err := gz.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to export volume %s: %v", name, err)
+	}
+	return nil
+}
+
+// VolumeImport extracts the gzip compressed tar archive read from r into the named volume,
+// creating it with the local driver if it does not exist. Files of the volume that are not in the
+// archive are left in place. A volume created by a failed import is removed.
+func (m *Manager) VolumeImport(ctx context.Context, name string, r io.Reader, opts ...options.Option) (err error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "volume archive is not gzip compressed: %v", err)
+	}
+	defer gz.Close()
+
+	_, err = m.client.VolumeInspect(ctx, name)
+	switch {
+	case cerrdefs.IsNotFound(err):
+		if _, err := m.client.VolumeCreate(ctx, volume.CreateOptions{Name: name, Driver: "local"}); err != nil {
+			return status.Errorf(codes.Internal, "failed to create volume %s: %v", name, err)
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if rerr := m.client.VolumeRemove(context.WithoutCancel(ctx), name, true); rerr != nil {
+				klog.Warningf("failed to remove volume %s after its import failed: %v", name, rerr)
+			}
+		}()
+	case err != nil:
+		return status.Errorf(codes.Internal, "failed to inspect volume %s: %v", name, err)
+	}
+
+	id, err := m.createVolumeHelper(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	defer m.removeVolumeHelper(ctx, id)
+
+	if err := m.client.CopyToContainer(ctx, id, volumeArchivePath, gz, container.CopyToContainerOptions{}); err != nil {
+		if cerrdefs.IsInvalidArgument(err) {
+			return status.Errorf(codes.InvalidArgument, "volume archive is invalid: %v", err)
+		}
+		return status.Errorf(codes.Internal, "failed to import volume %s: %v", name, err)
+	}
+	return nil
+}
+
+// createVolumeHelper creates, but does not start, a container of the helper image mounting the
+// named volume at volumeArchivePath and returns its ID. The volume is mounted without copying the
+// contents of the image at volumeArchivePath into it. The container is labelled as a volume helper
+// so that the janitor does not remove it while the volume is accessed.
+func (m *Manager) createVolumeHelper(ctx context.Context, name string, readOnly bool) (string, error) {
+	if m.helperImage == "" {
+		return "", status.Error(codes.FailedPrecondition, "no volume helper image is configured to access volumes with")
+	}
+
+	config := &container.Config{
+		Image:  m.helperImage,
+		Cmd:    []string{"true"},
+		Labels: map[string]string{options.VolumeHelperLabel: name},
+	}
+	hostConfig := &container.HostConfig{
+		Mounts: []mount.Mount{{
+			Type:          mount.TypeVolume,
+			Source:        name,
+			Target:        volumeArchivePath,
+			ReadOnly:      readOnly,
+			VolumeOptions: &mount.VolumeOptions{NoCopy: true},
+		}},
+	}
+
+	resp, err := m.client.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to create helper container for volume %s: %v", name, err)
+	}
+	return resp.ID, nil
+}
+
+// removeVolumeHelper removes a container created by createVolumeHelper. The removal is attempted
+// even if ctx was cancelled so that helpers are not left behind.
+func (m *Manager) removeVolumeHelper(ctx context.Context, id string) {
+	if err := m.client.ContainerRemove(context.WithoutCancel(ctx), id, container.RemoveOptions{Force: true}); err != nil {
+		klog.Warningf("failed to remove volume helper container %s: %v", id, err)
+	}
+}
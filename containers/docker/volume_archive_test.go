@@ -0,0 +1,278 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVolumeArchiveDocker keeps the contents of each volume as an uncompressed tar archive, as
+// the docker daemon copies them to and from containers.
+type fakeVolumeArchiveDocker struct {
+	fakeDocker
+	volumes        map[string][]byte
+	helpers        map[string]string // helper container ID to volume name
+	copyErr        error
+	Created        []string
+	RemovedVolumes []string
+	Removed        []string
+	Images         []string
+	ReadOnly       []bool
+	NoCopy         []bool
+	Helpers        []string // volume helper label of each container created
+}
+
+func (f *fakeVolumeArchiveDocker) VolumeInspect(_ context.Context, name string) (volume.Volume, error) {
+	if _, ok := f.volumes[name]; !ok {
+		return volume.Volume{}, cerrdefs.ErrNotFound
+	}
+	return volume.Volume{Name: name, Driver: "local"}, nil
+}
+
+func (f *fakeVolumeArchiveDocker) VolumeCreate(_ context.Context, opts volume.CreateOptions) (volume.Volume, error) {
+	f.Created = append(f.Created, opts.Name)
+	f.volumes[opts.Name] = nil
+	return volume.Volume{Name: opts.Name, Driver: opts.Driver}, nil
+}
+
+func (f *fakeVolumeArchiveDocker) VolumeRemove(_ context.Context, name string, _ bool) error {
+	f.RemovedVolumes = append(f.RemovedVolumes, name)
+	delete(f.volumes, name)
+	return nil
+}
+
+func (f *fakeVolumeArchiveDocker) ContainerCreate(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *ocispec.Platform, _ string) (container.CreateResponse, error) {
+	id := fmt.Sprintf("helper-%d", len(f.helpers))
+	f.helpers[id] = hostConfig.Mounts[0].Source
+	f.Images = append(f.Images, config.Image)
+	f.ReadOnly = append(f.ReadOnly, hostConfig.Mounts[0].ReadOnly)
+	f.NoCopy = append(f.NoCopy, hostConfig.Mounts[0].VolumeOptions.NoCopy)
+	f.Helpers = append(f.Helpers, config.Labels[options.VolumeHelperLabel])
+	return container.CreateResponse{ID: id}, nil
+}
+
+func (f *fakeVolumeArchiveDocker) ContainerRemove(_ context.Context, id string, _ container.RemoveOptions) error {
+	f.Removed = append(f.Removed, id)
+	return nil
+}
+
+func (f *fakeVolumeArchiveDocker) CopyFromContainer(_ context.Context, id, path string) (io.ReadCloser, container.PathStat, error) {
+	if path != "/volume/." {
+		return nil, container.PathStat{}, fmt.Errorf("unexpected path %s", path)
+	}
+	return io.NopCloser(bytes.NewReader(f.volumes[f.helpers[id]])), container.PathStat{}, nil
+}
+
+func (f *fakeVolumeArchiveDocker) CopyToContainer(_ context.Context, id, path string, content io.Reader, _ container.CopyToContainerOptions) error {
+	if path != "/volume" {
+		return fmt.Errorf("unexpected path %s", path)
+	}
+	if f.copyErr != nil {
+		return f.copyErr
+	}
+	buf, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	f.volumes[f.helpers[id]] = buf
+	return nil
+}
+
+func volumeArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"config.yaml", "state/db"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func archiveFiles(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("archive is not gzip compressed: %v", err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("archive is not a tar archive: %v", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(content)
+	}
+}
+
+func TestVolumeArchiveRoundTrip(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": "port: 8080\n",
+		"state/db":    "some state",
+	}
+
+	tests := []struct {
+		name        string
+		inVolumes   map[string][]byte
+		wantCreated []string
+	}{
+		{
+			name:        "new-volume",
+			inVolumes:   map[string][]byte{},
+			wantCreated: []string{"data"},
+		},
+		{
+			name:      "existing-volume",
+			inVolumes: map[string][]byte{"data": nil},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeVolumeArchiveDocker{
+				volumes: tc.inVolumes,
+				helpers: map[string]string{},
+			}
+			mgr := New(fake, WithHelperImage("busybox:latest"))
+
+			if err := mgr.VolumeImport(ctx, "data", bytes.NewReader(volumeArchive(t, files))); err != nil {
+				t.Fatalf("VolumeImport(data) returned error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := mgr.VolumeExport(ctx, "data", &buf); err != nil {
+				t.Fatalf("VolumeExport(data) returned error: %v", err)
+			}
+
+			if diff := cmp.Diff(files, archiveFiles(t, buf.Bytes())); diff != "" {
+				t.Errorf("VolumeExport(data) returned unexpected files (-want, +got):\n%s", diff)
+			}
+
+			want := &fakeVolumeArchiveDocker{
+				Created:  tc.wantCreated,
+				Removed:  []string{"helper-0", "helper-1"},
+				Images:   []string{"busybox:latest", "busybox:latest"},
+				ReadOnly: []bool{false, true},
+				NoCopy:   []bool{true, true},
+				Helpers:  []string{"data", "data"},
+			}
+			if diff := cmp.Diff(want, fake, cmpopts.IgnoreUnexported(fakeVolumeArchiveDocker{})); diff != "" {
+				t.Errorf("round trip returned diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVolumeArchiveError(t *testing.T) {
+	tests := []struct {
+		name        string
+		inImage     string
+		inArchive   []byte
+		inCopyErr   error
+		wantExport  error
+		wantImport  error
+		wantRemoved []string
+	}{
+		{
+			name:        "no-helper-image",
+			inArchive:   volumeArchive(t, nil),
+			wantExport:  status.Errorf(codes.NotFound, "volume %s not found", "data"),
+			wantImport:  status.Errorf(codes.FailedPrecondition, "no volume helper image is configured to access volumes with"),
+			wantRemoved: []string{"data"},
+		},
+		{
+			name:       "not-compressed",
+			inImage:    "busybox:latest",
+			inArchive:  []byte("plain"),
+			wantExport: status.Errorf(codes.NotFound, "volume %s not found", "data"),
+			wantImport: status.Errorf(codes.InvalidArgument, "volume archive is not gzip compressed: %v", "unexpected EOF"),
+		},
+		{
+			name:        "invalid-archive",
+			inImage:     "busybox:latest",
+			inArchive:   volumeArchive(t, nil),
+			inCopyErr:   cerrdefs.ErrInvalidArgument,
+			wantExport:  status.Errorf(codes.NotFound, "volume %s not found", "data"),
+			wantImport:  status.Errorf(codes.InvalidArgument, "volume archive is invalid: %v", cerrdefs.ErrInvalidArgument),
+			wantRemoved: []string{"data"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeVolumeArchiveDocker{
+				volumes: map[string][]byte{},
+				helpers: map[string]string{},
+				copyErr: tc.inCopyErr,
+			}
+			mgr := New(fake, WithHelperImage(tc.inImage))
+
+			err := mgr.VolumeExport(ctx, "data", io.Discard)
+			if diff := cmp.Diff(tc.wantExport, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("VolumeExport(data) returned unexpected error (-want, +got):\n%s", diff)
+			}
+
+			err = mgr.VolumeImport(ctx, "data", bytes.NewReader(tc.inArchive))
+			if diff := cmp.Diff(tc.wantImport, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("VolumeImport(data) returned unexpected error (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRemoved, fake.RemovedVolumes); diff != "" {
+				t.Errorf("VolumeImport(data) removed unexpected volumes (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,9 +2,15 @@ package docker
 
 import (
 	"context"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-units"
 	"github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,6 +20,8 @@ import (
 
 // VolumeCreate creates a volume with the provided name using the driver specified. The driver
 // default to LOCAL if it is not specified. The name is autogenerated by the target if it is empty.
+// The CUSTOM driver is provided by the plugin named in the VolumeDriverLabel, or by the
+// custom:latest plugin if the label is not set.
 func (m *Manager) VolumeCreate(ctx context.Context, name string, driver cpb.Driver, opts ...options.Option) (string, error) {
 	optionz := options.ApplyOptions(opts...)
 
@@ -22,21 +30,32 @@ func (m *Manager) VolumeCreate(ctx context.Context, name string, driver cpb.Driv
 	switch driver {
 	case cpb.Driver_DS_UNSPECIFIED, cpb.Driver_DS_LOCAL:
 		kind = "local"
-		if optionz.VolumeDriverOptions != nil {
-			vopts, ok := optionz.VolumeDriverOptions.(*cpb.LocalDriverOptions)
-			if !ok {
-				return "", status.Error(codes.InvalidArgument, "driver is marked as local but options are not LocalDriverOptions")
+		mountType, hasMountType := optionz.VolumeLabels[options.VolumeMountTypeLabel]
+		if optionz.VolumeDriverOptions != nil || hasMountType {
+			vopts := &cpb.LocalDriverOptions{}
+			if optionz.VolumeDriverOptions != nil {
+				var ok bool
+				if vopts, ok = optionz.VolumeDriverOptions.(*cpb.LocalDriverOptions); !ok {
+					return "", status.Error(codes.InvalidArgument, "driver is marked as local but options are not LocalDriverOptions")
+				}
 			}
-			switch vopts.GetType() {
-			case cpb.LocalDriverOptions_TYPE_UNSPECIFIED, cpb.LocalDriverOptions_TYPE_NONE:
-				volOpts["type"] = "none"
+			if !hasMountType {
+				mountType = "none"
 			}
 
-			volOpts["o"] = strings.Join(vopts.GetOptions(), ",")
-			volOpts["device"] = vopts.GetMountpoint()
+			var err error
+			if volOpts, err = localDriverOptions(mountType, vopts); err != nil {
+				return "", err
+			}
 		}
 	case cpb.Driver_DS_CUSTOM:
 		kind = "custom:latest"
+		if name, ok := optionz.VolumeLabels[options.VolumeDriverLabel]; ok {
+			var err error
+			if kind, err = m.volumeDriver(ctx, name); err != nil {
+				return "", err
+			}
+		}
 		if optionz.VolumeDriverOptions != nil {
 			vopts, ok := optionz.VolumeDriverOptions.(*cpb.CustomOptions)
 			if !ok {
@@ -46,6 +65,18 @@ func (m *Manager) VolumeCreate(ctx context.Context, name string, driver cpb.Driv
 		}
 	}
 
+	if _, ok := optionz.VolumeLabels[options.VolumeDriverLabel]; ok && driver != cpb.Driver_DS_CUSTOM {
+		return "", status.Errorf(codes.InvalidArgument, "%q label is only supported with the custom driver", options.VolumeDriverLabel)
+	}
+
+	quota, err := volumeQuota(kind, volOpts, optionz.VolumeLabels)
+	if err != nil {
+		return "", err
+	}
+	if quota > 0 {
+		volOpts["size"] = strconv.FormatInt(quota, 10)
+	}
+
 	create := volume.CreateOptions{
 		Name:       name,
 		Driver:     kind,
@@ -54,8 +85,134 @@ func (m *Manager) VolumeCreate(ctx context.Context, name string, driver cpb.Driv
 	}
 
 	v, err := m.client.VolumeCreate(ctx, create)
-	if err != nil {
+	switch {
+	case err == nil:
+		return v.Name, nil
+	case quota > 0 && cerrdefs.IsInvalidArgument(err):
+		return "", status.Errorf(codes.FailedPrecondition, "volume quotas are not supported on this device: %v", err)
+	default:
 		return "", err
 	}
-	return v.Name, nil
+}
+
+// localMountOptions are the mount options allowed for volumes of the local driver, by mount type.
+// Options taking a value, such as size=64m, are matched on their name. The options of the "" mount
+// type are allowed for all mount types.
+var localMountOptions = map[string][]string{
+	"":      {"ro", "rw", "nosuid", "nodev", "noexec", "noatime", "nodiratime", "relatime", "strictatime", "sync", "async"},
+	"none":  {"bind", "rbind", "private", "rprivate", "shared", "rshared", "slave", "rslave"},
+	"tmpfs": {"size", "mode", "uid", "gid", "nr_inodes"},
+	"ext4":  {"discard", "nodiscard", "errors", "data", "commit", "barrier", "nobarrier"},
+	"xfs":   {"discard", "nodiscard", "nouuid", "logbufs", "logbsize", "allocsize"},
+	"btrfs": {"discard", "nodiscard", "subvol", "subvolid", "compress", "ssd", "nossd"},
+}
+
+// localDriverOptions returns the options of the local driver mounting a volume with the given
+// mount type, see options.VolumeMountTypeLabel.
+func localDriverOptions(mountType string, vopts *cpb.LocalDriverOptions) (map[string]string, error) {
+	allowed, ok := localMountOptions[mountType]
+	if !ok || mountType == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: unsupported mount type %q", options.VolumeMountTypeLabel, mountType)
+	}
+
+	var opts []string
+	bind := false
+	for _, opt := range vopts.GetOptions() {
+		if opt == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(opt, "=")
+		if !slices.Contains(localMountOptions[""], key) && !slices.Contains(allowed, key) {
+			return nil, status.Errorf(codes.InvalidArgument, "mount option %q is not allowed for %s volumes", opt, mountType)
+		}
+		switch key {
+		case "size":
+			if size, err := units.RAMInBytes(value); err != nil || size <= 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "mount option %q is invalid: %q is not a positive size", opt, value)
+			}
+		case "mode":
+			if _, err := strconv.ParseUint(value, 8, 32); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "mount option %q is invalid: %q is not an octal mode", opt, value)
+			}
+		case "bind", "rbind":
+			bind = true
+		}
+		opts = append(opts, opt)
+	}
+
+	device := vopts.GetMountpoint()
+	switch mountType {
+	case "none":
+		if !filepath.IsAbs(device) {
+			return nil, status.Errorf(codes.InvalidArgument, "mountpoint %q of a bind volume must be an absolute path", device)
+		}
+		if !bind {
+			opts = append([]string{"bind"}, opts...)
+		}
+	case "tmpfs":
+		if device != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "tmpfs volumes do not take a mountpoint, got %q", device)
+		}
+		device = "tmpfs"
+	default:
+		if !strings.HasPrefix(filepath.Clean(device), "/dev/") {
+			return nil, status.Errorf(codes.InvalidArgument, "mountpoint %q of a %s volume must be a block device", device, mountType)
+		}
+	}
+
+	return map[string]string{
+		"type":   mountType,
+		"o":      strings.Join(opts, ","),
+		"device": device,
+	}, nil
+}
+
+// volumeQuota returns the size quota in bytes requested in the volume labels, or 0 if there is
+// none. Quotas are enforced by the local driver on volumes it stores itself, and so are not
+// supported by other drivers nor for volumes backed by a device or host path.
+func volumeQuota(driver string, driverOpts, labels map[string]string) (int64, error) {
+	value, ok := labels[options.VolumeQuotaLabel]
+	if !ok {
+		return 0, nil
+	}
+	quota, err := units.RAMInBytes(value)
+	if err != nil || quota <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%q label is invalid: %q is not a positive size", options.VolumeQuotaLabel, value)
+	}
+	if driver != "local" {
+		return 0, status.Errorf(codes.InvalidArgument, "volume quotas are only supported by the local driver")
+	}
+	if driverOpts["device"] != "" {
+		return 0, status.Errorf(codes.InvalidArgument, "volume quotas are not supported for volumes backed by %s", driverOpts["device"])
+	}
+	return quota, nil
+}
+
+// volumeDriver returns the name of the installed plugin providing the named volume driver. The name
+// may omit the tag of the plugin, in which case it defaults to latest.
+func (m *Manager) volumeDriver(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return "", status.Errorf(codes.InvalidArgument, "%q label is invalid: the driver name is empty", options.VolumeDriverLabel)
+	}
+	want := pluginRef(name)
+
+	plugins, err := m.client.PluginList(ctx, filters.Args{})
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to list plugins: %v", err)
+	}
+	for _, plugin := range plugins {
+		if plugin.Name != want {
+			continue
+		}
+		if !plugin.Enabled {
+			return "", status.Errorf(codes.FailedPrecondition, "volume driver plugin %s is not enabled", plugin.Name)
+		}
+		for _, t := range plugin.Config.Interface.Types {
+			if t.Capability == "volumedriver" {
+				return plugin.Name, nil
+			}
+		}
+		return "", status.Errorf(codes.FailedPrecondition, "plugin %s is not a volume driver", plugin.Name)
+	}
+	return "", status.Errorf(codes.NotFound, "volume driver plugin %s not found", name)
 }
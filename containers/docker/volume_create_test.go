@@ -2,13 +2,19 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type fakeVolumeCreatingDocker struct {
@@ -71,8 +77,8 @@ func TestVolumeCreate(t *testing.T) {
 			inOpts: []options.Option{
 				options.WithVolumeDriverOpts(&cpb.LocalDriverOptions{
 					Type:       cpb.LocalDriverOptions_TYPE_NONE,
-					Options:    []string{"some-option"},
-					Mountpoint: "some-mountpoint",
+					Options:    []string{"ro"},
+					Mountpoint: "/some-mountpoint",
 				}),
 				options.WithVolumeLabels(map[string]string{"some-label": "some-label"}),
 			},
@@ -82,8 +88,8 @@ func TestVolumeCreate(t *testing.T) {
 					Driver: "local",
 					Options: map[string]string{
 						"type":   "none",
-						"o":      "some-option",
-						"device": "some-mountpoint",
+						"o":      "bind,ro",
+						"device": "/some-mountpoint",
 					},
 					Labels: map[string]string{"some-label": "some-label"},
 				},
@@ -136,3 +142,283 @@ func TestVolumeCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestVolumeCreateLocal(t *testing.T) {
+	tests := []struct {
+		name        string
+		inMountType string
+		inOpts      *cpb.LocalDriverOptions
+		want        map[string]string
+		wantErr     error
+	}{
+		{
+			name:   "bind",
+			inOpts: &cpb.LocalDriverOptions{Type: cpb.LocalDriverOptions_TYPE_NONE, Mountpoint: "/data", Options: []string{"ro", "rshared"}},
+			want:   map[string]string{"type": "none", "o": "bind,ro,rshared", "device": "/data"},
+		},
+		{
+			name:   "rbind",
+			inOpts: &cpb.LocalDriverOptions{Mountpoint: "/data", Options: []string{"rbind"}},
+			want:   map[string]string{"type": "none", "o": "rbind", "device": "/data"},
+		},
+		{
+			name:    "bind-relative",
+			inOpts:  &cpb.LocalDriverOptions{Mountpoint: "data"},
+			wantErr: status.Errorf(codes.InvalidArgument, "mountpoint %q of a bind volume must be an absolute path", "data"),
+		},
+		{
+			name:        "tmpfs",
+			inMountType: "tmpfs",
+			inOpts:      &cpb.LocalDriverOptions{Options: []string{"size=64m", "mode=1770", "uid=1000", "noexec"}},
+			want:        map[string]string{"type": "tmpfs", "o": "size=64m,mode=1770,uid=1000,noexec", "device": "tmpfs"},
+		},
+		{
+			name:        "tmpfs-without-options",
+			inMountType: "tmpfs",
+			want:        map[string]string{"type": "tmpfs", "o": "", "device": "tmpfs"},
+		},
+		{
+			name:        "tmpfs-invalid-size",
+			inMountType: "tmpfs",
+			inOpts:      &cpb.LocalDriverOptions{Options: []string{"size=lots"}},
+			wantErr:     status.Errorf(codes.InvalidArgument, "mount option %q is invalid: %q is not a positive size", "size=lots", "lots"),
+		},
+		{
+			name:        "tmpfs-invalid-mode",
+			inMountType: "tmpfs",
+			inOpts:      &cpb.LocalDriverOptions{Options: []string{"mode=999"}},
+			wantErr:     status.Errorf(codes.InvalidArgument, "mount option %q is invalid: %q is not an octal mode", "mode=999", "999"),
+		},
+		{
+			name:        "tmpfs-mountpoint",
+			inMountType: "tmpfs",
+			inOpts:      &cpb.LocalDriverOptions{Mountpoint: "/data"},
+			wantErr:     status.Errorf(codes.InvalidArgument, "tmpfs volumes do not take a mountpoint, got %q", "/data"),
+		},
+		{
+			name:        "ext4",
+			inMountType: "ext4",
+			inOpts:      &cpb.LocalDriverOptions{Mountpoint: "/dev/sdb1", Options: []string{"noatime", "errors=remount-ro"}},
+			want:        map[string]string{"type": "ext4", "o": "noatime,errors=remount-ro", "device": "/dev/sdb1"},
+		},
+		{
+			name:        "xfs",
+			inMountType: "xfs",
+			inOpts:      &cpb.LocalDriverOptions{Mountpoint: "/dev/disk/by-label/data", Options: []string{"nouuid"}},
+			want:        map[string]string{"type": "xfs", "o": "nouuid", "device": "/dev/disk/by-label/data"},
+		},
+		{
+			name:        "filesystem-not-a-device",
+			inMountType: "ext4",
+			inOpts:      &cpb.LocalDriverOptions{Mountpoint: "/dev/../data"},
+			wantErr:     status.Errorf(codes.InvalidArgument, "mountpoint %q of a %s volume must be a block device", "/dev/../data", "ext4"),
+		},
+		{
+			name:        "option-of-other-type",
+			inMountType: "xfs",
+			inOpts:      &cpb.LocalDriverOptions{Mountpoint: "/dev/sdb1", Options: []string{"bind"}},
+			wantErr:     status.Errorf(codes.InvalidArgument, "mount option %q is not allowed for %s volumes", "bind", "xfs"),
+		},
+		{
+			name:    "option-not-allowed",
+			inOpts:  &cpb.LocalDriverOptions{Mountpoint: "/data", Options: []string{"suid"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "mount option %q is not allowed for %s volumes", "suid", "none"),
+		},
+		{
+			name:        "unsupported-type",
+			inMountType: "nfs",
+			wantErr:     status.Errorf(codes.InvalidArgument, "%q label is invalid: unsupported mount type %q", options.VolumeMountTypeLabel, "nfs"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fcd := &fakeVolumeCreatingDocker{}
+			mgr := New(fcd)
+
+			var opts []options.Option
+			if tc.inOpts != nil {
+				opts = append(opts, options.WithVolumeDriverOpts(tc.inOpts))
+			}
+			if tc.inMountType != "" {
+				opts = append(opts, options.WithVolumeLabels(map[string]string{options.VolumeMountTypeLabel: tc.inMountType}))
+			}
+
+			_, err := mgr.VolumeCreate(context.Background(), "some-volume", cpb.Driver_DS_LOCAL, opts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("VolumeCreate(%+v) returned unexpected error (-want, +got):\n%s", tc.inOpts, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, fcd.V.Options); diff != "" {
+				t.Errorf("VolumeCreate(%+v) returned diff in driver options (-want, +got):\n%s", tc.inOpts, diff)
+			}
+		})
+	}
+}
+
+func TestVolumeCreateQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		inDriver cpb.Driver
+		inOpts   []options.Option
+		want     map[string]string
+		wantErr  error
+	}{
+		{
+			name:   "quota",
+			inOpts: []options.Option{options.WithVolumeLabels(map[string]string{options.VolumeQuotaLabel: "64m"})},
+			want:   map[string]string{"size": "67108864"},
+		},
+		{
+			name:    "invalid-quota",
+			inOpts:  []options.Option{options.WithVolumeLabels(map[string]string{options.VolumeQuotaLabel: "lots"})},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %q is not a positive size", options.VolumeQuotaLabel, "lots"),
+		},
+		{
+			name:     "custom-driver",
+			inDriver: cpb.Driver_DS_CUSTOM,
+			inOpts:   []options.Option{options.WithVolumeLabels(map[string]string{options.VolumeQuotaLabel: "1g"})},
+			wantErr:  status.Errorf(codes.InvalidArgument, "volume quotas are only supported by the local driver"),
+		},
+		{
+			name:     "device-backed",
+			inDriver: cpb.Driver_DS_LOCAL,
+			inOpts: []options.Option{
+				options.WithVolumeDriverOpts(&cpb.LocalDriverOptions{Type: cpb.LocalDriverOptions_TYPE_NONE, Mountpoint: "/data"}),
+				options.WithVolumeLabels(map[string]string{options.VolumeQuotaLabel: "1g"}),
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "volume quotas are not supported for volumes backed by %s", "/data"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fcd := &fakeVolumeCreatingDocker{}
+			mgr := New(fcd)
+
+			_, err := mgr.VolumeCreate(context.Background(), "some-volume", tc.inDriver, tc.inOpts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("VolumeCreate(%+v) returned unexpected error (-want, +got):\n%s", tc.inOpts, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, fcd.V.Options); diff != "" {
+				t.Errorf("VolumeCreate(%+v) returned diff in driver options (-want, +got):\n%s", tc.inOpts, diff)
+			}
+		})
+	}
+}
+
+func TestVolumeCreateQuotaUnsupported(t *testing.T) {
+	mgr := New(&fakeUnsupportedQuotaDocker{})
+
+	opts := []options.Option{options.WithVolumeLabels(map[string]string{options.VolumeQuotaLabel: "64m"})}
+	_, err := mgr.VolumeCreate(context.Background(), "some-volume", cpb.Driver_DS_LOCAL, opts...)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("VolumeCreate(%+v) returned error %v, want code %v", opts, err, codes.FailedPrecondition)
+	}
+}
+
+type fakeUnsupportedQuotaDocker struct {
+	fakeDocker
+}
+
+func (f *fakeUnsupportedQuotaDocker) VolumeCreate(_ context.Context, opts volume.CreateOptions) (volume.Volume, error) {
+	return volume.Volume{}, fmt.Errorf("quota size requested but no quota support: %w", cerrdefs.ErrInvalidArgument)
+}
+
+type fakeVolumeDriverDocker struct {
+	fakeVolumeCreatingDocker
+	plugins types.PluginsListResponse
+}
+
+func (f *fakeVolumeDriverDocker) PluginList(context.Context, filters.Args) (types.PluginsListResponse, error) {
+	return f.plugins, nil
+}
+
+func TestVolumeCreateDriver(t *testing.T) {
+	volumeDriver := types.PluginConfig{
+		Interface: types.PluginConfigInterface{
+			Types: []types.PluginInterfaceType{{Prefix: "docker", Capability: "volumedriver", Version: "1.0"}},
+		},
+	}
+	plugins := types.PluginsListResponse{
+		{Name: "vieux/sshfs:latest", Enabled: true, Config: volumeDriver},
+		{Name: "rexray/ebs:0.11", Enabled: true, Config: volumeDriver},
+		{Name: "disabled:latest", Config: volumeDriver},
+		{Name: "logger:latest", Enabled: true, Config: types.PluginConfig{
+			Interface: types.PluginConfigInterface{
+				Types: []types.PluginInterfaceType{{Prefix: "docker", Capability: "logdriver", Version: "1.0"}},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		inDriver   cpb.Driver
+		inLabel    string
+		wantDriver string
+		wantErr    error
+	}{
+		{
+			name:       "untagged",
+			inDriver:   cpb.Driver_DS_CUSTOM,
+			inLabel:    "vieux/sshfs",
+			wantDriver: "vieux/sshfs:latest",
+		},
+		{
+			name:       "tagged",
+			inDriver:   cpb.Driver_DS_CUSTOM,
+			inLabel:    "rexray/ebs:0.11",
+			wantDriver: "rexray/ebs:0.11",
+		},
+		{
+			name:     "wrong-tag",
+			inDriver: cpb.Driver_DS_CUSTOM,
+			inLabel:  "rexray/ebs",
+			wantErr:  status.Errorf(codes.NotFound, "volume driver plugin %s not found", "rexray/ebs"),
+		},
+		{
+			name:     "disabled",
+			inDriver: cpb.Driver_DS_CUSTOM,
+			inLabel:  "disabled",
+			wantErr:  status.Errorf(codes.FailedPrecondition, "volume driver plugin %s is not enabled", "disabled:latest"),
+		},
+		{
+			name:     "not-a-volume-driver",
+			inDriver: cpb.Driver_DS_CUSTOM,
+			inLabel:  "logger",
+			wantErr:  status.Errorf(codes.FailedPrecondition, "plugin %s is not a volume driver", "logger:latest"),
+		},
+		{
+			name:     "empty",
+			inDriver: cpb.Driver_DS_CUSTOM,
+			wantErr:  status.Errorf(codes.InvalidArgument, "%q label is invalid: the driver name is empty", options.VolumeDriverLabel),
+		},
+		{
+			name:     "local-driver",
+			inDriver: cpb.Driver_DS_LOCAL,
+			inLabel:  "vieux/sshfs",
+			wantErr:  status.Errorf(codes.InvalidArgument, "%q label is only supported with the custom driver", options.VolumeDriverLabel),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fcd := &fakeVolumeDriverDocker{plugins: plugins}
+			mgr := New(fcd)
+
+			opts := []options.Option{options.WithVolumeLabels(map[string]string{options.VolumeDriverLabel: tc.inLabel})}
+			_, err := mgr.VolumeCreate(context.Background(), "some-volume", tc.inDriver, opts...)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("VolumeCreate(%q) returned unexpected error (-want, +got):\n%s", tc.inLabel, diff)
+			}
+			if fcd.V.Driver != tc.wantDriver {
+				t.Errorf("VolumeCreate(%q) created a volume with driver %q, want %q", tc.inLabel, fcd.V.Driver, tc.wantDriver)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// VolumeInspect returns the details of the named volume, including its disk usage and the
+// containers using it. Missing usage data is logged and left out rather than failing.
+func (m *Manager) VolumeInspect(ctx context.Context, name string) (*options.VolumeDetails, error) {
+	vol, err := m.client.VolumeInspect(ctx, name)
+	if err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "volume %s not found", name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to inspect volume %s: %v", name, err)
+	}
+
+	created, err := time.Parse(time.RFC3339, vol.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse creation time: %v", err)
+	}
+
+	return &options.VolumeDetails{
+		Name:       vol.Name,
+		Driver:     vol.Driver,
+		Created:    created,
+		Options:    vol.Options,
+		Labels:     vol.Labels,
+		Usage:      m.volumeUsage(ctx, vol.Name),
+		Containers: m.volumeUsers(ctx, vol.Name),
+	}, nil
+}
+
+// volumeUsage returns the disk space in bytes used by the named volume, or -1 if it cannot be
+// computed, e.g. as the driver of the volume does not report it.
+func (m *Manager) volumeUsage(ctx context.Context, name string) int64 {
+	if size, ok := m.volumeUsages(ctx)[name]; ok {
+		return size
+	}
+	return -1
+}
+
+// volumeUsages returns the disk space in bytes used by each volume whose driver reports it, or -1
+// if the driver reports it as unknown. It takes a single disk usage query for all the volumes and
+// returns nil if the query fails.
+func (m *Manager) volumeUsages(ctx context.Context) map[string]int64 {
+	du, err := m.client.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		klog.Warningf("unable to get the disk usage of volumes: %v", err)
+		return nil
+	}
+	usage := make(map[string]int64, len(du.Volumes))
+	for _, vol := range du.Volumes {
+		if vol.UsageData != nil {
+			usage[vol.Name] = vol.UsageData.Size
+		}
+	}
+	return usage
+}
+
+// volumeUsers returns the sorted names of the containers, running or not, that mount the named
+// volume.
+func (m *Manager) volumeUsers(ctx context.Context, name string) []string {
+	cnts, err := m.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("volume", name)),
+	})
+	if err != nil {
+		klog.Warningf("unable to list the containers using volume %s: %v", name, err)
+		return nil
+	}
+	var users []string
+	for _, cnt := range cnts {
+		if len(cnt.Names) == 0 {
+			continue
+		}
+		// The filter also matches containers mounting anything at a path equal to the name.
+		for _, mnt := range cnt.Mounts {
+			if mnt.Type == mount.TypeVolume && mnt.Name == name {
+				users = append(users, strings.TrimPrefix(cnt.Names[0], "/"))
+				break
+			}
+		}
+	}
+	sort.Strings(users)
+	return users
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeVolumeInspectDocker struct {
+	fakeDocker
+	volumes map[string]volume.Volume
+	usage   []*volume.Volume
+	cnts    []types.Container
+}
+
+func (f *fakeVolumeInspectDocker) VolumeInspect(ctx context.Context, name string) (volume.Volume, error) {
+	vol, ok := f.volumes[name]
+	if !ok {
+		return volume.Volume{}, cerrdefs.ErrNotFound
+	}
+	return vol, nil
+}
+
+func (f *fakeVolumeInspectDocker) DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	return types.DiskUsage{Volumes: f.usage}, nil
+}
+
+func (f *fakeVolumeInspectDocker) ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error) {
+	return f.cnts, nil
+}
+
+func TestVolumeInspect(t *testing.T) {
+	ts, err := time.Parse(time.RFC3339, "2024-02-09T13:07:31+01:00")
+	if err != nil {
+		t.Fatalf("time.Parse(%q) returned error: %v", "2024-02-09T13:07:31+01:00", err)
+	}
+	fsd := &fakeVolumeInspectDocker{
+		volumes: map[string]volume.Volume{
+			"data":   {Name: "data", Driver: "local", CreatedAt: "2024-02-09T13:07:31+01:00", Labels: map[string]string{options.VolumeQuotaLabel: "1g"}},
+			"remote": {Name: "remote", Driver: "custom:latest", CreatedAt: "2024-02-09T13:07:31+01:00"},
+		},
+		usage: []*volume.Volume{
+			{Name: "data", UsageData: &volume.UsageData{Size: 4096, RefCount: 2}},
+			{Name: "remote", UsageData: &volume.UsageData{Size: -1, RefCount: 0}},
+		},
+		cnts: []types.Container{
+			{Names: []string{"/web"}, Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "data"}}},
+			{Names: []string{"/db"}, Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "data"}}},
+			{Names: []string{"/exporter"}, Mounts: []types.MountPoint{{Type: mount.TypeBind, Destination: "data"}}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		inName  string
+		want    *options.VolumeDetails
+		wantErr error
+	}{
+		{
+			name:   "in-use",
+			inName: "data",
+			want: &options.VolumeDetails{
+				Name:       "data",
+				Driver:     "local",
+				Created:    ts,
+				Labels:     map[string]string{options.VolumeQuotaLabel: "1g"},
+				Usage:      4096,
+				Containers: []string{"db", "web"},
+			},
+		},
+		{
+			name:   "usage-not-reported",
+			inName: "remote",
+			want: &options.VolumeDetails{
+				Name:    "remote",
+				Driver:  "custom:latest",
+				Created: ts,
+				Usage:   -1,
+			},
+		},
+		{
+			name:    "not-found",
+			inName:  "missing",
+			wantErr: status.Errorf(codes.NotFound, "volume %s not found", "missing"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := New(fsd).VolumeInspect(context.Background(), tc.inName)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("VolumeInspect(%q) returned unexpected error (-want +got):\n%s", tc.inName, diff)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+				t.Errorf("VolumeInspect(%q) returned diff (-want +got):\n%s", tc.inName, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"time"
 
 	tpb "google.golang.org/protobuf/types/known/timestamppb"
@@ -46,17 +47,29 @@ func (m *Manager) VolumeList(ctx context.Context, srv options.ListVolumeStreamer
 		return err
 	}
 
+	// The usage of all the volumes is computed at once rather than per volume.
+	usage := m.volumeUsages(ctx)
 	for _, vol := range resp.Volumes {
 		t, err := time.Parse(time.RFC3339, vol.CreatedAt)
 		if err != nil {
 			return fmt.Errorf("unable to parse creation time: %v", err)
 		}
+
+		labels := vol.Labels
+		if size, ok := usage[vol.Name]; ok && size >= 0 {
+			labels = make(map[string]string, len(vol.Labels)+1)
+			for key, value := range vol.Labels {
+				labels[key] = value
+			}
+			labels[options.VolumeUsageLabel] = strconv.FormatInt(size, 10)
+		}
+
 		if err := srv.Send(&cpb.ListVolumeResponse{
 			Name:    vol.Name,
 			Created: tpb.New(t),
 			Driver:  vol.Driver,
 			Options: vol.Options,
-			Labels:  vol.Labels,
+			Labels:  labels,
 		}); err != nil {
 			if err == io.EOF {
 				return nil
@@ -16,12 +16,14 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	tpb "google.golang.org/protobuf/types/known/timestamppb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -40,7 +42,9 @@ func (f *fakeListVolumeStreamer) Send(msg *cpb.ListVolumeResponse) error {
 
 type fakeVolumeListingDocker struct {
 	fakeDocker
-	volumes []*volume.Volume
+	volumes    []*volume.Volume
+	usage      []*volume.Volume
+	usageCalls int
 
 	Opts volume.ListOptions
 }
@@ -53,6 +57,14 @@ func (f *fakeVolumeListingDocker) VolumeList(ctx context.Context, options volume
 	}, nil
 }
 
+func (f *fakeVolumeListingDocker) DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	f.usageCalls++
+	if f.usage == nil {
+		return types.DiskUsage{}, fmt.Errorf("not implemented")
+	}
+	return types.DiskUsage{Volumes: f.usage}, nil
+}
+
 func TestListVolume(t *testing.T) {
 	ts, err := time.Parse(time.RFC3339, "2024-02-09T13:07:31+01:00")
 	if err != nil {
@@ -154,3 +166,54 @@ func TestListVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestListVolumeUsage(t *testing.T) {
+	ts, err := time.Parse(time.RFC3339, "2024-02-09T13:07:31+01:00")
+	if err != nil {
+		t.Fatalf("time.Parse(%q) returned error: %v", "2024-02-09T13:07:31+01:00", err)
+	}
+	fsd := &fakeVolumeListingDocker{
+		volumes: []*volume.Volume{
+			{Name: "data", Driver: "local", CreatedAt: "2024-02-09T13:07:31+01:00", Labels: map[string]string{"app": "bgp"}},
+			{Name: "logs", Driver: "local", CreatedAt: "2024-02-09T13:07:31+01:00"},
+			{Name: "remote", Driver: "custom:latest", CreatedAt: "2024-02-09T13:07:31+01:00"},
+		},
+		usage: []*volume.Volume{
+			{Name: "data", UsageData: &volume.UsageData{Size: 4096, RefCount: 1}},
+			{Name: "logs", UsageData: &volume.UsageData{Size: 0, RefCount: 0}},
+			{Name: "remote", UsageData: &volume.UsageData{Size: -1, RefCount: 0}},
+		},
+	}
+	mgr := New(fsd)
+
+	stream := &fakeListVolumeStreamer{}
+	if err := mgr.VolumeList(context.Background(), stream); err != nil {
+		t.Fatalf("VolumeList() returned error: %v", err)
+	}
+
+	want := []*cpb.ListVolumeResponse{
+		{
+			Name:    "data",
+			Driver:  "local",
+			Created: tpb.New(ts),
+			Labels:  map[string]string{"app": "bgp", options.VolumeUsageLabel: "4096"},
+		},
+		{
+			Name:    "logs",
+			Driver:  "local",
+			Created: tpb.New(ts),
+			Labels:  map[string]string{options.VolumeUsageLabel: "0"},
+		},
+		{
+			Name:    "remote",
+			Driver:  "custom:latest",
+			Created: tpb.New(ts),
+		},
+	}
+	if diff := cmp.Diff(want, stream.msgs, protocmp.Transform()); diff != "" {
+		t.Errorf("VolumeList() returned diff(-want, +got):\n%s", diff)
+	}
+	if fsd.usageCalls != 1 {
+		t.Errorf("VolumeList() queried the disk usage %d times, want 1", fsd.usageCalls)
+	}
+}
@@ -2,12 +2,30 @@ package docker
 
 import (
 	"context"
+	"strings"
 
+	cerrdefs "github.com/containerd/errdefs"
 	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// VolumeRemove removes a volume.
+// VolumeRemove removes a volume. A volume that is in use by containers is only removed if the
+// removal is forced; otherwise the error names the containers using it.
 func (m *Manager) VolumeRemove(ctx context.Context, name string, opts ...options.Option) error {
 	optionz := options.ApplyOptions(opts...)
-	return m.client.VolumeRemove(ctx, name, optionz.Force)
+	err := m.client.VolumeRemove(ctx, name, optionz.Force)
+	switch {
+	case err == nil:
+		return nil
+	case cerrdefs.IsNotFound(err):
+		return status.Errorf(codes.NotFound, "volume %s not found", name)
+	case cerrdefs.IsConflict(err):
+		if users := m.volumeUsers(ctx, name); len(users) != 0 {
+			return status.Errorf(codes.FailedPrecondition, "volume %s is in use by containers %s", name, strings.Join(users, ", "))
+		}
+		return status.Errorf(codes.FailedPrecondition, "volume %s is in use: %v", name, err)
+	default:
+		return status.Errorf(codes.Internal, "failed to remove volume %s: %v", name, err)
+	}
 }
@@ -2,11 +2,18 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type fakeVolumeRemovingDocker struct {
@@ -21,6 +28,20 @@ func (f *fakeVolumeRemovingDocker) VolumeRemove(_ context.Context, id string, fo
 	return nil
 }
 
+type fakeVolumeInUseDocker struct {
+	fakeDocker
+	cnts []types.Container
+	err  error
+}
+
+func (f *fakeVolumeInUseDocker) VolumeRemove(_ context.Context, id string, force bool) error {
+	return f.err
+}
+
+func (f *fakeVolumeInUseDocker) ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error) {
+	return f.cnts, nil
+}
+
 func TestVolumeRemove(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -65,3 +86,54 @@ func TestVolumeRemove(t *testing.T) {
 		})
 	}
 }
+
+func TestVolumeRemoveError(t *testing.T) {
+	cnts := []types.Container{
+		{Names: []string{"/web"}, Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "data"}}},
+		{Names: []string{"/db"}, Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "data"}, {Type: mount.TypeBind, Source: "/var/log"}}},
+		{Names: []string{"/cache"}, Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "other"}}},
+	}
+
+	tests := []struct {
+		name    string
+		inName  string
+		inErr   error
+		wantErr error
+	}{
+		{
+			name:    "in-use",
+			inName:  "data",
+			inErr:   cerrdefs.ErrConflict,
+			wantErr: status.Errorf(codes.FailedPrecondition, "volume %s is in use by containers %s", "data", "db, web"),
+		},
+		{
+			name:    "in-use-by-unknown",
+			inName:  "unused",
+			inErr:   cerrdefs.ErrConflict,
+			wantErr: status.Errorf(codes.FailedPrecondition, "volume %s is in use: %v", "unused", cerrdefs.ErrConflict),
+		},
+		{
+			name:    "not-found",
+			inName:  "missing",
+			inErr:   cerrdefs.ErrNotFound,
+			wantErr: status.Errorf(codes.NotFound, "volume %s not found", "missing"),
+		},
+		{
+			name:    "other",
+			inName:  "data",
+			inErr:   errors.New("disk on fire"),
+			wantErr: status.Errorf(codes.Internal, "failed to remove volume %s: %v", "data", "disk on fire"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mgr := New(&fakeVolumeInUseDocker{cnts: cnts, err: tc.inErr})
+
+			err := mgr.VolumeRemove(context.Background(), tc.inName)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("VolumeRemove(%s) returned unexpected error (-want, +got):\n%s", tc.inName, diff)
+			}
+		})
+	}
+}
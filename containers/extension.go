@@ -0,0 +1,411 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The containerz API has no RPC for some of the operations containerz supports, e.g. creating a
+// network. Rather than overloading unrelated RPCs, these operations are served by the Extension
+// service, registered on the same gRPC server as the containerz service:
+//
+//	service Extension {
+//	  // Call performs an operation and returns its result.
+//	  rpc Call(google.protobuf.Struct) returns (google.protobuf.Value) {}
+//
+//	  // Download performs an operation producing data, e.g. the backup of a volume, and streams
+//	  // the data in chunks.
+//	  rpc Download(google.protobuf.Struct) returns (stream google.protobuf.BytesValue) {}
+//
+//	  // Upload performs an operation consuming data, e.g. the backup of a volume, streamed in
+//	  // chunks. The first message holds the request as JSON, the following ones the data.
+//	  rpc Upload(stream google.protobuf.BytesValue) returns (google.protobuf.Value) {}
+//	}
+//
+// A request is an ExtensionRequest, i.e. an object holding the Operation and its arguments, e.g.
+// {"operation": "RemoveNetwork", "args": {"name": "mgmt"}}. The arguments and the result of each
+// operation are the JSON encoding of the types it documents. Errors are reported as gRPC status
+// errors, with the Unimplemented code for operations unknown to the target.
+//
+// Once the data of a download is streamed, the trailer of the stream holds its checksum under
+// ChecksumTrailer.
+
+const (
+	// ExtensionService is the name of the Extension service.
+	ExtensionService = "net.openconfig.containerz.Extension"
+
+	// ChecksumTrailer is the trailer key of the checksum, formatted by FormatChecksum, of the data
+	// streamed by a download.
+	ChecksumTrailer = "checksum"
+)
+
+// Operation names an operation of the Extension service.
+type Operation string
+
+const (
+	// InspectImage returns the ImageDetails of the image of the ImageArgs, referenced by name,
+	// name:tag or ID.
+	InspectImage Operation = "InspectImage"
+
+	// InspectPlugin returns the PluginDetails of the plugin instance of the PluginArgs.
+	InspectPlugin Operation = "InspectPlugin"
+
+	// UpgradePlugin upgrades the plugin instance of the PluginArgs to the rootfs of the deployed
+	// plugin Name, keeping its settings. Its config is kept too if Config is empty.
+	UpgradePlugin Operation = "UpgradePlugin"
+
+	// SetPlugin changes the Settings of the plugin instance of the PluginArgs.
+	SetPlugin Operation = "SetPlugin"
+
+	// StopPlugin stops the plugin instance of the PluginArgs, even if volumes still use it if
+	// Force is set.
+	StopPlugin Operation = "StopPlugin"
+
+	// RemovePlugin removes the plugin instance of the PluginArgs, even if volumes still use it if
+	// Force is set.
+	RemovePlugin Operation = "RemovePlugin"
+
+	// ListPluginArtifacts returns the PluginArtifact of each plugin tarball deployed to the
+	// target, or only of the one of the PluginArtifactArgs if it names one.
+	ListPluginArtifacts Operation = "ListPluginArtifacts"
+
+	// RemovePluginArtifact removes the plugin tarball of the PluginArtifactArgs, even if plugins
+	// were created from it if Force is set.
+	RemovePluginArtifact Operation = "RemovePluginArtifact"
+
+	// PrunePluginArtifacts removes the plugin tarballs no installed plugin uses any more and
+	// returns their PluginArtifact. As it removes them all, Confirm must be set in the
+	// PluginArtifactArgs.
+	PrunePluginArtifacts Operation = "PrunePluginArtifacts"
+
+	// PauseContainer suspends all processes of the running container of the ContainerArgs.
+	PauseContainer Operation = "PauseContainer"
+
+	// ResumeContainer resumes all processes of the paused container of the ContainerArgs.
+	ResumeContainer Operation = "ResumeContainer"
+
+	// KillContainer sends the Signal, SIGKILL if empty, to the main process of the running or
+	// paused container of the ContainerArgs.
+	KillContainer Operation = "KillContainer"
+
+	// RestartContainer restarts the container of the ContainerArgs in place, preserving its ID and
+	// logs. Unlike a restart through StopContainer, the container may be given a Timeout to stop
+	// before it is killed, or killed immediately if Force is set.
+	RestartContainer Operation = "RestartContainer"
+
+	// ListRevisions returns the Revision history of the container of the ContainerArgs, oldest
+	// first. The last revision is the one currently deployed.
+	ListRevisions Operation = "ListRevisions"
+
+	// RollbackContainer restores the Revision, by number or name, of the container of the
+	// ContainerArgs, or its previous revision if none is given. The replaced version is kept for
+	// KeepPrevious, so that the rollback can itself be rolled back.
+	RollbackContainer Operation = "RollbackContainer"
+
+	// StartGroup starts the Members of the application group of the GroupArgs, each one after the
+	// members it depends on, and returns their names in the order they were started. If a member
+	// fails to start, the members already started are removed.
+	StartGroup Operation = "StartGroup"
+
+	// StopGroup stops the running members of the application group of the GroupArgs, each one
+	// before the members it depends on. The members are killed if they do not stop in time if
+	// Force is set.
+	StopGroup Operation = "StopGroup"
+
+	// UpdateGroup updates the application group of the GroupArgs to its Members: existing members
+	// are updated, new ones are started and the others are removed. If a member fails to update,
+	// the whole group is rolled back.
+	UpdateGroup Operation = "UpdateGroup"
+
+	// RemoveGroup removes the members of the application group of the GroupArgs. Running members
+	// are only removed if Force is set, otherwise nothing is removed.
+	RemoveGroup Operation = "RemoveGroup"
+
+	// CreateNetwork creates the network of the NetworkArgs with their Driver, bridge if empty,
+	// and returns its name.
+	CreateNetwork Operation = "CreateNetwork"
+
+	// ListNetworks returns the NetworkInfo of each network of the target, or only of the one of
+	// the NetworkArgs if it names one.
+	ListNetworks Operation = "ListNetworks"
+
+	// RemoveNetwork removes the network of the NetworkArgs. Containers still attached to it are
+	// disconnected first if Force is set, otherwise the removal fails.
+	RemoveNetwork Operation = "RemoveNetwork"
+
+	// InspectVolume returns the VolumeDetails of the volume of the VolumeArgs, including its disk
+	// usage and the containers using it, which VolumeList does not report as they are costly to
+	// compute for every volume.
+	InspectVolume Operation = "InspectVolume"
+
+	// ExportVolume downloads a gzip compressed tar archive of the contents of the volume of the
+	// VolumeArchiveArgs.
+	ExportVolume Operation = "ExportVolume"
+
+	// ImportVolume restores the volume of the VolumeArchiveArgs, creating it if needed, from the
+	// uploaded gzip compressed tar archive. The archive is only restored once all of its Size
+	// bytes were received and found to match its Checksum.
+	ImportVolume Operation = "ImportVolume"
+)
+
+// ExtensionRequest is a request of the Extension service.
+type ExtensionRequest struct {
+	Operation Operation       `json:"operation"`
+	Args      json.RawMessage `json:"args,omitempty"`
+}
+
+// NewExtensionRequest returns the request of an operation with the given arguments.
+func NewExtensionRequest(op Operation, args any) (*structpb.Struct, error) {
+	buf, err := MarshalExtensionRequest(op, args)
+	if err != nil {
+		return nil, err
+	}
+	req := &structpb.Struct{}
+	if err := protojson.Unmarshal(buf, req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request of operation %s: %v", op, err)
+	}
+	return req, nil
+}
+
+// MarshalExtensionRequest returns the request of an operation with the given arguments as JSON, as
+// sent in the first message of an upload.
+func MarshalExtensionRequest(op Operation, args any) ([]byte, error) {
+	buf, err := json.Marshal(args)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid arguments of operation %s: %v", op, err)
+	}
+	return json.Marshal(ExtensionRequest{Operation: op, Args: buf})
+}
+
+// ParseExtensionRequest parses a request of the Extension service.
+func ParseExtensionRequest(in *structpb.Struct) (*ExtensionRequest, error) {
+	buf, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	return UnmarshalExtensionRequest(buf)
+}
+
+// UnmarshalExtensionRequest parses a request of the Extension service encoded as JSON.
+func UnmarshalExtensionRequest(buf []byte) (*ExtensionRequest, error) {
+	req := &ExtensionRequest{}
+	if err := json.Unmarshal(buf, req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	if req.Operation == "" {
+		return nil, status.Error(codes.InvalidArgument, "the operation of the request is missing")
+	}
+	return req, nil
+}
+
+// NewExtensionResult returns the result of an operation.
+func NewExtensionResult(result any) (*structpb.Value, error) {
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal result: %v", err)
+	}
+	out := &structpb.Value{}
+	if err := protojson.Unmarshal(buf, out); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal result: %v", err)
+	}
+	return out, nil
+}
+
+// ParseExtensionResult parses the result of an operation into result.
+func ParseExtensionResult(in *structpb.Value, result any) error {
+	buf, err := protojson.Marshal(in)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid result: %v", err)
+	}
+	if err := json.Unmarshal(buf, result); err != nil {
+		return status.Errorf(codes.Internal, "invalid result: %v", err)
+	}
+	return nil
+}
+
+// ImageArgs are the arguments of the operations on an image.
+type ImageArgs struct {
+	Image string `json:"image"`
+}
+
+// ContainerArgs are the arguments of the operations on a container instance.
+type ContainerArgs struct {
+	Instance string        `json:"instance"`
+	Signal   string        `json:"signal,omitempty"`
+	Force    bool          `json:"force,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+
+	Revision     string        `json:"revision,omitempty"`
+	KeepPrevious time.Duration `json:"keep_previous,omitempty"`
+}
+
+// PluginArgs are the arguments of the operations on a plugin instance.
+type PluginArgs struct {
+	Instance string          `json:"instance"`
+	Name     string          `json:"name,omitempty"`
+	Config   string          `json:"config,omitempty"`
+	Settings *PluginSettings `json:"settings,omitempty"`
+	Force    bool            `json:"force,omitempty"`
+}
+
+// PluginArtifactArgs are the arguments of the operations on plugin tarballs.
+type PluginArtifactArgs struct {
+	Name  string `json:"name,omitempty"`
+	Force bool   `json:"force,omitempty"`
+
+	// Confirm confirms the removal of several tarballs at once.
+	Confirm bool `json:"confirm,omitempty"`
+}
+
+// GroupArgs are the arguments of the operations on an application group. Each member is the
+// containerz StartContainerRequest, encoded by protojson, that would start it on its own: its
+// instance name names the member, and its DependsOnLabel lists the members it depends on.
+type GroupArgs struct {
+	Group   string            `json:"group"`
+	Members []json.RawMessage `json:"members,omitempty"`
+	Force   bool              `json:"force,omitempty"`
+}
+
+// NetworkArgs are the arguments of the operations on a network.
+type NetworkArgs struct {
+	Name    string            `json:"name"`
+	Driver  string            `json:"driver,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+	Subnets []Subnet          `json:"subnets,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Force   bool              `json:"force,omitempty"`
+}
+
+// VolumeArgs are the arguments of the operations on a volume.
+type VolumeArgs struct {
+	Name string `json:"name"`
+}
+
+// VolumeArchiveArgs are the arguments of the operations on the archives of volumes.
+type VolumeArchiveArgs struct {
+	Name     string `json:"name"`
+	Size     uint64 `json:"size,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// ExtensionServer is the server API of the Extension service.
+type ExtensionServer interface {
+	Call(context.Context, *structpb.Struct) (*structpb.Value, error)
+	Download(*structpb.Struct, grpc.ServerStreamingServer[wrapperspb.BytesValue]) error
+	Upload(grpc.ClientStreamingServer[wrapperspb.BytesValue, structpb.Value]) error
+}
+
+// RegisterExtensionServer registers the Extension service on a gRPC server.
+func RegisterExtensionServer(s grpc.ServiceRegistrar, srv ExtensionServer) {
+	s.RegisterService(&extensionServiceDesc, srv)
+}
+
+var extensionServiceDesc = grpc.ServiceDesc{
+	ServiceName: ExtensionService,
+	HandlerType: (*ExtensionServer)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Call",
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := &structpb.Struct{}
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return srv.(ExtensionServer).Call(ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + ExtensionService + "/Call",
+			}
+			return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+				return srv.(ExtensionServer).Call(ctx, req.(*structpb.Struct))
+			})
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName: "Download",
+		Handler: func(srv any, stream grpc.ServerStream) error {
+			in := &structpb.Struct{}
+			if err := stream.RecvMsg(in); err != nil {
+				return err
+			}
+			return srv.(ExtensionServer).Download(in, &grpc.GenericServerStream[structpb.Struct, wrapperspb.BytesValue]{ServerStream: stream})
+		},
+		ServerStreams: true,
+	}, {
+		StreamName: "Upload",
+		Handler: func(srv any, stream grpc.ServerStream) error {
+			return srv.(ExtensionServer).Upload(&grpc.GenericServerStream[wrapperspb.BytesValue, structpb.Value]{ServerStream: stream})
+		},
+		ClientStreams: true,
+	}},
+}
+
+// ExtensionClient is the client API of the Extension service.
+type ExtensionClient interface {
+	Call(context.Context, *structpb.Struct, ...grpc.CallOption) (*structpb.Value, error)
+	Download(context.Context, *structpb.Struct, ...grpc.CallOption) (grpc.ServerStreamingClient[wrapperspb.BytesValue], error)
+	Upload(context.Context, ...grpc.CallOption) (grpc.ClientStreamingClient[wrapperspb.BytesValue, structpb.Value], error)
+}
+
+// NewExtensionClient returns a client of the Extension service.
+func NewExtensionClient(cc grpc.ClientConnInterface) ExtensionClient {
+	return &extensionClient{cc: cc}
+}
+
+type extensionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *extensionClient) Call(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Value, error) {
+	out := &structpb.Value{}
+	if err := c.cc.Invoke(ctx, "/"+ExtensionService+"/Call", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionClient) Download(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (grpc.ServerStreamingClient[wrapperspb.BytesValue], error) {
+	stream, err := c.cc.NewStream(ctx, &extensionServiceDesc.Streams[0], "/"+ExtensionService+"/Download", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[structpb.Struct, wrapperspb.BytesValue]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (c *extensionClient) Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[wrapperspb.BytesValue, structpb.Value], error) {
+	stream, err := c.cc.NewStream(ctx, &extensionServiceDesc.Streams[1], "/"+ExtensionService+"/Upload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpc.GenericClientStream[wrapperspb.BytesValue, structpb.Value]{ClientStream: stream}, nil
+}
@@ -16,10 +16,17 @@
 package options
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/docker/go-units"
 	cpb "github.com/openconfig/gnoi/containerz"
 	tpb "github.com/openconfig/gnoi/types"
 	"google.golang.org/protobuf/proto"
@@ -61,13 +68,1001 @@ const (
 	// Container filters by container name.
 	Container = "container"
 
-	// State filters by container state.
+	// State filters by container state, either a ListContainerResponse status (RUNNING, STOPPED or
+	// PRESENT) or a runtime state (created, running, paused, restarting, removing, exited or dead).
 	State = "state"
 
 	// Volume filters by volume name.
 	Volume = "volume"
+
+	// Application filters containers by the application group they belong to.
+	Application = "application"
+
+	// Health filters containers by health status: starting, healthy, unhealthy or none.
+	Health = "health"
+)
+
+// ImageDigestTrailer is the key of the gRPC trailer through which Deploy reports the ID, i.e. the
+// digest of the configuration, of the image it loaded, as ImageTransferSuccess has no field for it.
+const ImageDigestTrailer = "containerz-image-digest"
+
+const (
+	// LabelPrefix is the namespace of the labels reserved by containerz. These labels carry start
+	// options that have no dedicated field in the containerz API.
+	LabelPrefix = "net.openconfig.containerz."
+
+	// PortsLabel holds a comma separated list of port bindings (see ParsePortBinding) that cannot
+	// be expressed as a plain TCP port in the containerz API.
+	PortsLabel = LabelPrefix + "ports"
+
+	// HostnameLabel holds the hostname of the container.
+	HostnameLabel = LabelPrefix + "hostname"
+
+	// DNSLabel holds a comma separated list of DNS server addresses.
+	DNSLabel = LabelPrefix + "dns"
+
+	// DNSSearchLabel holds a comma separated list of DNS search domains.
+	DNSSearchLabel = LabelPrefix + "dns-search"
+
+	// ExtraHostsLabel holds a comma separated list of <hostname>:<ip> entries to add to the
+	// container's /etc/hosts.
+	ExtraHostsLabel = LabelPrefix + "extra-hosts"
+
+	// IPv4AddressLabel holds the static IPv4 address of the container on its network.
+	IPv4AddressLabel = LabelPrefix + "ipv4-address"
+
+	// IPv6AddressLabel holds the static IPv6 address of the container on its network.
+	IPv6AddressLabel = LabelPrefix + "ipv6-address"
+
+	// NetworksLabel holds a comma separated list of network attachments (see
+	// ParseNetworkAttachment).
+	NetworksLabel = LabelPrefix + "networks"
+
+	// UpdateStrategyLabel holds the UpdateStrategy used to update the container.
+	UpdateStrategyLabel = LabelPrefix + "update-strategy"
+
+	// KeepPreviousLabel holds how long, as a Go duration, the previous container is kept for
+	// rollback after a blue/green update.
+	KeepPreviousLabel = LabelPrefix + "keep-previous"
+
+	// HealthTimeoutLabel holds how long, as a Go duration, a blue/green update waits for the new
+	// container to become healthy.
+	HealthTimeoutLabel = LabelPrefix + "health-timeout"
+
+	// GroupLabel holds the name of the application group the container belongs to.
+	GroupLabel = LabelPrefix + "group"
+
+	// DependsOnLabel holds a comma separated list of the dependencies of the container (see
+	// ParseDependency).
+	DependsOnLabel = LabelPrefix + "depends-on"
+
+	// DependencyTimeoutLabel holds how long, as a Go duration, the start of the container waits for
+	// its dependencies to be ready.
+	DependencyTimeoutLabel = LabelPrefix + "dependency-timeout"
+
+	// RestartPolicyLabel holds the restart policy, e.g. always, unless-stopped or on-failure:3, of
+	// a container that declares dependencies. Docker would start such a container when it starts,
+	// regardless of its dependencies, so its docker restart policy is set to no and containerz
+	// starts it, in dependency order, according to this label instead. As a result, docker does not
+	// restart the container when it exits.
+	RestartPolicyLabel = LabelPrefix + "restart-policy"
+
+	// HealthCmdLabel holds the shell command run to check the health of the container, or "none" to
+	// disable the health check defined by the image.
+	HealthCmdLabel = LabelPrefix + "health-cmd"
+
+	// HealthIntervalLabel holds the time, as a Go duration, between two health checks.
+	HealthIntervalLabel = LabelPrefix + "health-interval"
+
+	// HealthCheckTimeoutLabel holds how long, as a Go duration, a health check may run before it
+	// is considered to have failed.
+	HealthCheckTimeoutLabel = LabelPrefix + "health-check-timeout"
+
+	// HealthRetriesLabel holds the number of consecutive failed health checks after which the
+	// container is unhealthy.
+	HealthRetriesLabel = LabelPrefix + "health-retries"
+
+	// HealthStartPeriodLabel holds how long, as a Go duration, the container is given to start
+	// before failed health checks count.
+	HealthStartPeriodLabel = LabelPrefix + "health-start-period"
+
+	// HealthLabel holds the health status of the container, starting, healthy or unhealthy, in
+	// ListContainer responses. It is not set on containers.
+	HealthLabel = LabelPrefix + "health"
+
+	// StateLabel holds the runtime state of the container, created, running, paused, restarting,
+	// removing, exited or dead, in ListContainer responses. It is not set on containers.
+	StateLabel = LabelPrefix + "state"
+
+	// ExitCodeLabel holds the exit code of the last run of the container in ListContainer
+	// responses. It is not set on containers.
+	ExitCodeLabel = LabelPrefix + "exit-code"
+
+	// RestartCountLabel holds the number of times the container has been restarted by its restart
+	// policy in ListContainer responses. It is not set on containers.
+	RestartCountLabel = LabelPrefix + "restart-count"
+
+	// RevisionLabel holds the name of the revision created by starting or updating the container.
+	RevisionLabel = LabelPrefix + "revision"
+
+	// CPUSetCPUsLabel holds the CPUs, e.g. 0-3,6, the container may run on.
+	CPUSetCPUsLabel = LabelPrefix + "cpuset-cpus"
+
+	// CPUSetMemsLabel holds the memory nodes, e.g. 0,1, the container may allocate memory from.
+	CPUSetMemsLabel = LabelPrefix + "cpuset-mems"
+
+	// CPUSharesLabel holds the relative CPU weight of the container.
+	CPUSharesLabel = LabelPrefix + "cpu-shares"
+
+	// PidsLimitLabel holds the maximum number of processes in the container, or -1 for no limit.
+	PidsLimitLabel = LabelPrefix + "pids-limit"
+
+	// UlimitsLabel holds the comma separated ulimits of the container, each of the format
+	// <name>=<soft>[:<hard>].
+	UlimitsLabel = LabelPrefix + "ulimits"
+
+	// BlkioWeightLabel holds the relative block IO weight of the container, between 10 and 1000.
+	BlkioWeightLabel = LabelPrefix + "blkio-weight"
+
+	// DeviceReadBPSLabel holds the comma separated read rate limits, in bytes per second, each of
+	// the format <device path>:<rate>.
+	DeviceReadBPSLabel = LabelPrefix + "device-read-bps"
+
+	// DeviceWriteBPSLabel holds the comma separated write rate limits, in bytes per second, each
+	// of the format <device path>:<rate>.
+	DeviceWriteBPSLabel = LabelPrefix + "device-write-bps"
+
+	// DeviceReadIOPSLabel holds the comma separated read rate limits, in IO operations per
+	// second, each of the format <device path>:<rate>.
+	DeviceReadIOPSLabel = LabelPrefix + "device-read-iops"
+
+	// DeviceWriteIOPSLabel holds the comma separated write rate limits, in IO operations per
+	// second, each of the format <device path>:<rate>.
+	DeviceWriteIOPSLabel = LabelPrefix + "device-write-iops"
+
+	// MemorySwapLabel holds the limit, in bytes, of memory plus swap of the container, or -1 for
+	// unlimited swap.
+	MemorySwapLabel = LabelPrefix + "memory-swap"
+
+	// OOMScoreAdjLabel holds the adjustment, between -1000 and 1000, of the OOM killer score of
+	// the container.
+	OOMScoreAdjLabel = LabelPrefix + "oom-score-adj"
+
+	// SeccompLabel holds the seccomp profile of the container: default, unconfined or the name of a
+	// profile stored on the device.
+	SeccompLabel = LabelPrefix + "seccomp"
+
+	// AppArmorLabel holds the AppArmor profile of the container, or unconfined.
+	AppArmorLabel = LabelPrefix + "apparmor"
+
+	// SELinuxLabel holds the comma separated SELinux label options of the container, e.g.
+	// type:svirt_apache_t, or disable.
+	SELinuxLabel = LabelPrefix + "selinux"
+
+	// ReadOnlyRootfsLabel holds whether, true or false, the root filesystem of the container is
+	// mounted read-only.
+	ReadOnlyRootfsLabel = LabelPrefix + "read-only"
+
+	// TmpfsLabel holds the semicolon separated tmpfs mounts of the container, each of the format
+	// <mount point>[:<mount options>], e.g. /run:rw,size=64m.
+	TmpfsLabel = LabelPrefix + "tmpfs"
+
+	// NoNewPrivilegesLabel holds whether, true or false, the processes of the container are
+	// prevented from gaining privileges.
+	NoNewPrivilegesLabel = LabelPrefix + "no-new-privileges"
+
+	// MaskedPathsLabel holds the comma separated paths masked in the container, in addition to
+	// those masked by the runtime.
+	MaskedPathsLabel = LabelPrefix + "masked-paths"
+
+	// ReadonlyPathsLabel holds the comma separated paths that are read-only in the container, in
+	// addition to those made read-only by the runtime.
+	ReadonlyPathsLabel = LabelPrefix + "readonly-paths"
+
+	// UsernsModeLabel holds the user namespace mode of the container. It is either unset, to use
+	// the remapping configured on the runtime, if any, host to opt out of it or private to require
+	// it.
+	UsernsModeLabel = LabelPrefix + "userns"
+
+	// MountsLabel holds the semicolon separated bind and tmpfs mounts of the container, each in the
+	// format accepted by ParseMount.
+	MountsLabel = LabelPrefix + "mounts"
+
+	// VolumeUsageLabel holds the disk space, in bytes, used by a volume. It is reported by
+	// VolumeList for volumes whose driver supports it and is not set on volumes.
+	VolumeUsageLabel = LabelPrefix + "usage"
+
+	// VolumeQuotaLabel holds the maximum size, in bytes or with a unit, e.g. 64m, of a volume. It is
+	// set when creating a volume with the local driver and is enforced with project quotas of the
+	// filesystem holding the volumes.
+	VolumeQuotaLabel = LabelPrefix + "quota"
+
+	// VolumeMountTypeLabel holds how a volume of the local driver is mounted: none, the default,
+	// to bind mount the host directory given as mountpoint, tmpfs to keep the volume in memory, or
+	// the filesystem type, ext4, xfs or btrfs, of the block device given as mountpoint.
+	VolumeMountTypeLabel = LabelPrefix + "mount-type"
+
+	// VolumeDriverLabel holds the name of the plugin, e.g. vieux/sshfs or vieux/sshfs:latest,
+	// providing the volume driver of a volume created with the custom driver. The plugin must be
+	// installed, enabled and implement the volume driver capability.
+	VolumeDriverLabel = LabelPrefix + "driver"
+
+	// VolumeHelperLabel holds the name of the volume mounted by a helper container created to export
+	// or import the contents of the volume. As helper containers are never started, the janitor
+	// does not prune the stopped containers carrying it.
+	VolumeHelperLabel = LabelPrefix + "volume-helper"
+)
+
+// PluginSettings are the settings of a plugin that can be changed once it is created.
+type PluginSettings struct {
+	// Env holds the environment variables, each of the format NAME=value, to set.
+	Env []string `json:"env,omitempty"`
+
+	// Args replaces the arguments of the plugin, if not empty.
+	Args []string `json:"args,omitempty"`
+}
+
+// PluginDetails describes a plugin instance.
+type PluginDetails struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+
+	// Capabilities holds the interfaces implemented by the plugin, e.g. docker.volumedriver/1.0.
+	Capabilities []string `json:"capabilities"`
+
+	Env  []string `json:"env"`
+	Args []string `json:"args"`
+
+	// References holds the names of the volumes using the plugin.
+	References []string `json:"references"`
+}
+
+// PluginArtifact describes a plugin tarball deployed to the target.
+type PluginArtifact struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+
+	// Hash is the SHA-256 digest of the tarball, of the format sha256:<hex>.
+	Hash string `json:"hash"`
+
+	Uploaded time.Time `json:"uploaded"`
+
+	// Plugins holds the names of the plugins created from the tarball.
+	Plugins []string `json:"plugins"`
+}
+
+// ImageDetails describes an image present on the target.
+type ImageDetails struct {
+	ID          string    `json:"id"`
+	RepoTags    []string  `json:"repo_tags"`
+	RepoDigests []string  `json:"repo_digests"`
+	Created     time.Time `json:"created"`
+
+	// Size is the total size of the image, in bytes, including all of its layers.
+	Size int64 `json:"size"`
+
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+	OS           string `json:"os"`
+
+	Entrypoint []string `json:"entrypoint"`
+	Cmd        []string `json:"cmd"`
+
+	// ExposedPorts holds the ports exposed by the image, of the format <port>/<protocol>.
+	ExposedPorts []string `json:"exposed_ports"`
+
+	// Volumes holds the paths declared as volumes by the image.
+	Volumes []string `json:"volumes"`
+
+	Labels map[string]string `json:"labels"`
+
+	// Layers holds the digests of the layers of the image, from the bottom one up.
+	Layers []string `json:"layers"`
+}
+
+// UpdateStrategy selects how ContainerUpdate replaces a container.
+type UpdateStrategy string
+
+const (
+	// RecreateStrategy stops and removes the container before starting the new version. This is
+	// the default.
+	RecreateStrategy UpdateStrategy = "recreate"
+
+	// BlueGreenStrategy starts the new version under a temporary name while the old version keeps
+	// running, and only swaps them once the new version is healthy. Versions that would compete for
+	// the ports of the host, e.g. on the host network, cannot run side by side: the old version is
+	// then stopped while the new one starts, and started again should the new one fail.
+	BlueGreenStrategy UpdateStrategy = "blue-green"
+
+	// LiveStrategy changes the resource limits and restart policy of the running container in
+	// place, without restarting it. The image, command and other settings are left unchanged.
+	LiveStrategy UpdateStrategy = "live"
+)
+
+// Condition is the condition a dependency must meet to be ready.
+type Condition string
+
+const (
+	// ConditionRunning requires the dependency to be running. This is the default.
+	ConditionRunning Condition = "running"
+
+	// ConditionHealthy requires the health check of the dependency to report it healthy.
+	ConditionHealthy Condition = "healthy"
+
+	// ConditionTCP requires a TCP port of the dependency to accept connections.
+	ConditionTCP Condition = "tcp"
+)
+
+// Dependency describes a container instance that must be ready before another one is started.
+type Dependency struct {
+	// Instance is the name of the container depended upon.
+	Instance string
+
+	// Condition is the readiness condition of the dependency.
+	Condition Condition
+
+	// Port is the TCP port checked by ConditionTCP.
+	Port uint16
+}
+
+// String returns the dependency in the format accepted by ParseDependency.
+func (d Dependency) String() string {
+	switch d.Condition {
+	case "", ConditionRunning:
+		return d.Instance
+	case ConditionTCP:
+		return fmt.Sprintf("%s:%s/%d", d.Instance, d.Condition, d.Port)
+	default:
+		return d.Instance + ":" + string(d.Condition)
+	}
+}
+
+// ParseDependency parses a dependency of the format <instance>[:running|:healthy|:tcp/<port>].
+func ParseDependency(spec string) (Dependency, error) {
+	instance, condition, _ := strings.Cut(spec, ":")
+	if instance == "" {
+		return Dependency{}, fmt.Errorf("dependency %s has no instance", spec)
+	}
+
+	d := Dependency{Instance: instance, Condition: ConditionRunning}
+	switch {
+	case condition == "" || condition == string(ConditionRunning):
+	case condition == string(ConditionHealthy):
+		d.Condition = ConditionHealthy
+	case strings.HasPrefix(condition, string(ConditionTCP)+"/"):
+		port, err := strconv.ParseUint(strings.TrimPrefix(condition, string(ConditionTCP)+"/"), 10, 16)
+		if err != nil || port == 0 {
+			return Dependency{}, fmt.Errorf("dependency %s has invalid port", spec)
+		}
+		d.Condition = ConditionTCP
+		d.Port = uint16(port)
+	default:
+		return Dependency{}, fmt.Errorf("dependency %s has unknown condition %q", spec, condition)
+	}
+	return d, nil
+}
+
+// PortBinding describes how an internal container port is published on the host.
+type PortBinding struct {
+	// Internal is the port inside the container.
+	Internal uint32
+
+	// External is the port on the host.
+	External uint32
+
+	// Protocol is one of tcp, udp or sctp. If unset, tcp is used.
+	Protocol string
+
+	// HostIP is the host address to bind to. If unset, the port is bound on all IPv4 and IPv6
+	// addresses.
+	HostIP string
+}
+
+// String returns the binding in the format accepted by ParsePortBinding.
+func (b PortBinding) String() string {
+	s := fmt.Sprintf("%d:%d", b.Internal, b.External)
+	switch {
+	case strings.Contains(b.HostIP, ":"):
+		s = fmt.Sprintf("[%s]:%s", b.HostIP, s)
+	case b.HostIP != "":
+		s = fmt.Sprintf("%s:%s", b.HostIP, s)
+	}
+	if b.Protocol != "" {
+		s = fmt.Sprintf("%s/%s", s, b.Protocol)
+	}
+	return s
+}
+
+// ParsePortBinding parses a port binding of the format
+// [<host_ip>:]<internal_port>:<external_port>[/<protocol>]. IPv6 host addresses must be enclosed
+// in square brackets.
+func ParsePortBinding(spec string) (PortBinding, error) {
+	var b PortBinding
+	rest, proto, ok := strings.Cut(spec, "/")
+	if ok {
+		switch proto = strings.ToLower(proto); proto {
+		case "tcp", "udp", "sctp":
+			b.Protocol = proto
+		default:
+			return PortBinding{}, fmt.Errorf("port definition %s has unknown protocol %q", spec, proto)
+		}
+	}
+
+	idx := strings.LastIndex(rest, ":")
+	if idx < 0 {
+		return PortBinding{}, fmt.Errorf("port definition %s is invalid", spec)
+	}
+	external, err := strconv.ParseUint(rest[idx+1:], 10, 16)
+	if err != nil {
+		return PortBinding{}, fmt.Errorf("port definition %s has invalid external port: %v", spec, err)
+	}
+	rest = rest[:idx]
+
+	if idx = strings.LastIndex(rest, ":"); idx >= 0 {
+		b.HostIP = strings.TrimSuffix(strings.TrimPrefix(rest[:idx], "["), "]")
+		if net.ParseIP(b.HostIP) == nil {
+			return PortBinding{}, fmt.Errorf("port definition %s has invalid host ip %q", spec, b.HostIP)
+		}
+		rest = rest[idx+1:]
+	}
+	internal, err := strconv.ParseUint(rest, 10, 16)
+	if err != nil {
+		return PortBinding{}, fmt.Errorf("port definition %s has invalid internal port: %v", spec, err)
+	}
+
+	b.Internal = uint32(internal)
+	b.External = uint32(external)
+	return b, nil
+}
+
+// NetworkAttachment describes how a container is attached to a network.
+type NetworkAttachment struct {
+	// Network is the name of the network.
+	Network string
+
+	// Aliases are additional names under which the container is reachable on the network.
+	Aliases []string
+
+	// IPv4Address is the optional static IPv4 address of the container on the network.
+	IPv4Address string
+
+	// IPv6Address is the optional static IPv6 address of the container on the network.
+	IPv6Address string
+}
+
+// String returns the attachment in the format accepted by ParseNetworkAttachment.
+func (a NetworkAttachment) String() string {
+	parts := []string{a.Network}
+	for _, alias := range a.Aliases {
+		parts = append(parts, "alias="+alias)
+	}
+	if a.IPv4Address != "" {
+		parts = append(parts, "ip="+a.IPv4Address)
+	}
+	if a.IPv6Address != "" {
+		parts = append(parts, "ip6="+a.IPv6Address)
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseNetworkAttachment parses a network attachment of the format
+// <network>[;alias=<alias>]...[;ip=<ipv4>][;ip6=<ipv6>].
+func ParseNetworkAttachment(spec string) (NetworkAttachment, error) {
+	parts := strings.Split(spec, ";")
+	a := NetworkAttachment{Network: parts[0]}
+	if a.Network == "" {
+		return NetworkAttachment{}, fmt.Errorf("network attachment %s has no network", spec)
+	}
+
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return NetworkAttachment{}, fmt.Errorf("network attachment %s has invalid option %q", spec, part)
+		}
+		switch key {
+		case "alias":
+			a.Aliases = append(a.Aliases, value)
+		case "ip":
+			if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+				return NetworkAttachment{}, fmt.Errorf("network attachment %s has invalid IPv4 address %q", spec, value)
+			}
+			a.IPv4Address = value
+		case "ip6":
+			if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+				return NetworkAttachment{}, fmt.Errorf("network attachment %s has invalid IPv6 address %q", spec, value)
+			}
+			a.IPv6Address = value
+		default:
+			return NetworkAttachment{}, fmt.Errorf("network attachment %s has unknown option %q", spec, key)
+		}
+	}
+	return a, nil
+}
+
+// MountType is the type of a mount.
+type MountType string
+
+// Supported mount types.
+const (
+	// VolumeMount mounts a named volume.
+	VolumeMount MountType = "volume"
+
+	// BindMount mounts a path of the host.
+	BindMount MountType = "bind"
+
+	// TmpfsMount mounts a tmpfs filesystem held in memory.
+	TmpfsMount MountType = "tmpfs"
+)
+
+// bindPropagations are the supported propagation modes of bind mounts.
+var bindPropagations = map[string]bool{
+	"private": true, "rprivate": true, "shared": true, "rshared": true, "slave": true, "rslave": true,
+}
+
+// Mount is a volume, bind or tmpfs mount of a container.
+type Mount struct {
+	// Type is the type of the mount.
+	Type MountType
+
+	// Source is the volume name or, for bind mounts, the absolute path on the host. Tmpfs mounts
+	// have no source.
+	Source string
+
+	// Target is the absolute path of the mount in the container.
+	Target string
+
+	// ReadOnly mounts the source read-only.
+	ReadOnly bool
+
+	// Propagation is the propagation mode of a bind mount, e.g. rslave.
+	Propagation string
+
+	// TmpfsSize is the size limit of a tmpfs mount in bytes, or 0 for no limit.
+	TmpfsSize int64
+
+	// TmpfsMode is the file mode of the root of a tmpfs mount, or 0 for the default.
+	TmpfsMode os.FileMode
+}
+
+// String returns the mount in the format accepted by ParseMount.
+func (m Mount) String() string {
+	parts := []string{"type=" + string(m.Type)}
+	if m.Source != "" {
+		parts = append(parts, "source="+m.Source)
+	}
+	parts = append(parts, "target="+m.Target)
+	if m.ReadOnly {
+		parts = append(parts, "readonly")
+	}
+	if m.Propagation != "" {
+		parts = append(parts, "bind-propagation="+m.Propagation)
+	}
+	if m.TmpfsSize != 0 {
+		parts = append(parts, fmt.Sprintf("tmpfs-size=%d", m.TmpfsSize))
+	}
+	if m.TmpfsMode != 0 {
+		parts = append(parts, fmt.Sprintf("tmpfs-mode=%o", m.TmpfsMode))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseMount parses a mount of the format
+// type=<volume|bind|tmpfs>,[source=<source>,]target=<path>[,readonly][,bind-propagation=<mode>]
+// [,tmpfs-size=<size>][,tmpfs-mode=<octal mode>]. The type defaults to volume, src and dst are
+// accepted as aliases of source and target and the tmpfs size accepts units, e.g. 64m.
+func ParseMount(spec string) (Mount, error) {
+	m := Mount{Type: VolumeMount}
+	for _, part := range strings.Split(spec, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "type":
+			m.Type = MountType(value)
+		case "source", "src":
+			m.Source = value
+		case "target", "dst", "destination":
+			m.Target = value
+		case "readonly", "ro":
+			if !hasValue {
+				m.ReadOnly = true
+				continue
+			}
+			ro, err := strconv.ParseBool(value)
+			if err != nil {
+				return Mount{}, fmt.Errorf("mount %s has invalid readonly option %q", spec, value)
+			}
+			m.ReadOnly = ro
+		case "bind-propagation":
+			if !bindPropagations[value] {
+				return Mount{}, fmt.Errorf("mount %s has invalid bind propagation %q", spec, value)
+			}
+			m.Propagation = value
+		case "tmpfs-size":
+			size, err := units.RAMInBytes(value)
+			if err != nil || size < 0 {
+				return Mount{}, fmt.Errorf("mount %s has invalid tmpfs size %q", spec, value)
+			}
+			m.TmpfsSize = size
+		case "tmpfs-mode":
+			mode, err := strconv.ParseUint(value, 8, 32)
+			if err != nil || mode > 0o7777 {
+				return Mount{}, fmt.Errorf("mount %s has invalid tmpfs mode %q", spec, value)
+			}
+			m.TmpfsMode = os.FileMode(mode)
+		default:
+			return Mount{}, fmt.Errorf("mount %s has unknown option %q", spec, key)
+		}
+	}
+
+	if m.Target == "" {
+		return Mount{}, fmt.Errorf("mount %s has no target", spec)
+	}
+	switch m.Type {
+	case VolumeMount, BindMount:
+		if m.Source == "" {
+			return Mount{}, fmt.Errorf("%s mount %s has no source", m.Type, spec)
+		}
+		if m.TmpfsSize != 0 || m.TmpfsMode != 0 {
+			return Mount{}, fmt.Errorf("%s mount %s has tmpfs options", m.Type, spec)
+		}
+		if m.Type == VolumeMount && m.Propagation != "" {
+			return Mount{}, fmt.Errorf("volume mount %s has a bind propagation", spec)
+		}
+	case TmpfsMount:
+		if m.Source != "" {
+			return Mount{}, fmt.Errorf("tmpfs mount %s has a source", spec)
+		}
+		if m.Propagation != "" {
+			return Mount{}, fmt.Errorf("tmpfs mount %s has a bind propagation", spec)
+		}
+	default:
+		return Mount{}, fmt.Errorf("mount %s has unknown type %q", spec, m.Type)
+	}
+	return m, nil
+}
+
+// Ulimit is a resource limit, e.g. nofile or core, set on the processes of a container.
+type Ulimit struct {
+	// Name is the name of the resource, as in ulimit(1) without the RLIMIT_ prefix.
+	Name string
+
+	// Soft is the soft limit.
+	Soft int64
+
+	// Hard is the hard limit. It is at least the soft limit.
+	Hard int64
+}
+
+// String returns the ulimit in the format accepted by ParseUlimit.
+func (u Ulimit) String() string {
+	if u.Soft == u.Hard {
+		return fmt.Sprintf("%s=%d", u.Name, u.Soft)
+	}
+	return fmt.Sprintf("%s=%d:%d", u.Name, u.Soft, u.Hard)
+}
+
+// ParseUlimit parses a ulimit of the format <name>=<soft>[:<hard>]. If the hard limit is omitted
+// it is the same as the soft limit.
+func ParseUlimit(spec string) (Ulimit, error) {
+	name, limits, ok := strings.Cut(spec, "=")
+	if !ok || name == "" {
+		return Ulimit{}, fmt.Errorf("ulimit %s is invalid", spec)
+	}
+	soft, hard, ok := strings.Cut(limits, ":")
+	if !ok {
+		hard = soft
+	}
+
+	u := Ulimit{Name: strings.ToLower(name)}
+	var err error
+	if u.Soft, err = strconv.ParseInt(soft, 10, 64); err != nil {
+		return Ulimit{}, fmt.Errorf("ulimit %s has invalid soft limit: %v", spec, err)
+	}
+	if u.Hard, err = strconv.ParseInt(hard, 10, 64); err != nil {
+		return Ulimit{}, fmt.Errorf("ulimit %s has invalid hard limit: %v", spec, err)
+	}
+	if u.Soft > u.Hard {
+		return Ulimit{}, fmt.Errorf("ulimit %s has a soft limit above its hard limit", spec)
+	}
+	return u, nil
+}
+
+// ThrottleDevice limits the rate of IO of a container on a block device.
+type ThrottleDevice struct {
+	// Path is the path of the device on the host, e.g. /dev/sda.
+	Path string
+
+	// Rate is the maximum rate, in bytes or IO operations per second.
+	Rate uint64
+}
+
+// String returns the limit in the format accepted by ParseThrottleDevice.
+func (d ThrottleDevice) String() string {
+	return fmt.Sprintf("%s:%d", d.Path, d.Rate)
+}
+
+// ParseThrottleDevice parses a device rate limit of the format <device path>:<rate>.
+func ParseThrottleDevice(spec string) (ThrottleDevice, error) {
+	idx := strings.LastIndex(spec, ":")
+	if idx < 0 {
+		return ThrottleDevice{}, fmt.Errorf("device limit %s is invalid", spec)
+	}
+	d := ThrottleDevice{Path: spec[:idx]}
+	if !strings.HasPrefix(d.Path, "/") {
+		return ThrottleDevice{}, fmt.Errorf("device limit %s has no absolute device path", spec)
+	}
+	rate, err := strconv.ParseUint(spec[idx+1:], 10, 64)
+	if err != nil {
+		return ThrottleDevice{}, fmt.Errorf("device limit %s has invalid rate: %v", spec, err)
+	}
+	d.Rate = rate
+	return d, nil
+}
+
+// Resources holds the resource controls of a container besides its CPU and memory limits. Unset
+// fields are left to the runtime defaults.
+type Resources struct {
+	// CPUSetCPUs are the CPUs, e.g. 0-3,6, the container may run on.
+	CPUSetCPUs string
+
+	// CPUSetMems are the memory nodes, e.g. 0,1, the container may allocate memory from.
+	CPUSetMems string
+
+	// CPUShares is the relative CPU weight of the container.
+	CPUShares int64
+
+	// PidsLimit is the maximum number of processes in the container, or -1 for no limit.
+	PidsLimit int64
+
+	// Ulimits are the resource limits set on the processes of the container.
+	Ulimits []Ulimit
+
+	// BlkioWeight is the relative block IO weight of the container, between 10 and 1000.
+	BlkioWeight uint16
+
+	// DeviceReadBPS limits the read rate, in bytes per second, from devices.
+	DeviceReadBPS []ThrottleDevice
+
+	// DeviceWriteBPS limits the write rate, in bytes per second, to devices.
+	DeviceWriteBPS []ThrottleDevice
+
+	// DeviceReadIOPS limits the read rate, in IO operations per second, from devices.
+	DeviceReadIOPS []ThrottleDevice
+
+	// DeviceWriteIOPS limits the write rate, in IO operations per second, to devices.
+	DeviceWriteIOPS []ThrottleDevice
+
+	// MemorySwap is the limit of memory plus swap, or -1 for unlimited swap. It requires a hard
+	// memory limit.
+	MemorySwap int64
+
+	// OOMScoreAdj adjusts the OOM killer score of the container, between -1000 and 1000.
+	OOMScoreAdj int
+}
+
+// Seccomp profiles which are not stored on the device.
+const (
+	// SeccompDefault is the default seccomp profile of the runtime.
+	SeccompDefault = "default"
+
+	// Unconfined disables seccomp or AppArmor confinement.
+	Unconfined = "unconfined"
 )
 
+// User namespace modes of a container.
+const (
+	// UsernsHost opts the container out of the user namespace remapping of the runtime.
+	UsernsHost = "host"
+
+	// UsernsPrivate runs the container in a user namespace remapped from the host, failing if the
+	// runtime does not remap user namespaces.
+	UsernsPrivate = "private"
+)
+
+// Security holds the security settings of a container, besides its capabilities. Unset fields are
+// left to the runtime defaults.
+type Security struct {
+	// Seccomp is the seccomp profile: SeccompDefault, Unconfined or the name of a profile stored on
+	// the device.
+	Seccomp string
+
+	// AppArmor is the AppArmor profile, or Unconfined.
+	AppArmor string
+
+	// SELinux are the SELinux label options, e.g. type:svirt_apache_t, or disable.
+	SELinux []string
+
+	// ReadOnlyRootfs mounts the root filesystem read-only.
+	ReadOnlyRootfs bool
+
+	// Tmpfs maps the mount points of tmpfs mounts to their mount options, e.g. rw,size=64m.
+	Tmpfs map[string]string
+
+	// NoNewPrivileges prevents the processes from gaining privileges, e.g. through setuid binaries.
+	NoNewPrivileges bool
+
+	// MaskedPaths are masked in addition to those masked by the runtime.
+	MaskedPaths []string
+
+	// ReadonlyPaths are read-only in addition to those made read-only by the runtime.
+	ReadonlyPaths []string
+
+	// UsernsMode is empty to use the user namespace remapping configured on the runtime, if any,
+	// UsernsHost to opt out of it or UsernsPrivate to require it.
+	UsernsMode string
+}
+
+// ParseTmpfs parses semicolon separated tmpfs mounts, each of the format
+// <mount point>[:<mount options>], into a map of mount points to mount options.
+func ParseTmpfs(spec string) (map[string]string, error) {
+	tmpfs := map[string]string{}
+	for _, part := range strings.Split(spec, ";") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		path, opts, _ := strings.Cut(part, ":")
+		if path == "" {
+			return nil, fmt.Errorf("tmpfs mount %s has no mount point", part)
+		}
+		if _, ok := tmpfs[path]; ok {
+			return nil, fmt.Errorf("tmpfs mount point %s is set more than once", path)
+		}
+		tmpfs[path] = opts
+	}
+	return tmpfs, nil
+}
+
+// FormatTmpfs returns the tmpfs mounts in the format parsed by ParseTmpfs, sorted by mount point.
+func FormatTmpfs(tmpfs map[string]string) string {
+	paths := make([]string, 0, len(tmpfs))
+	for path := range tmpfs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	parts := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if opts := tmpfs[path]; opts != "" {
+			path += ":" + opts
+		}
+		parts = append(parts, path)
+	}
+	return strings.Join(parts, ";")
+}
+
+// HealthCheck describes how the health of a container is checked. Unset fields are inherited
+// from the health check defined by the image.
+type HealthCheck struct {
+	// Test is the health check command, in the format used by docker: {"CMD", <args>...} runs the
+	// command directly, {"CMD-SHELL", <command>} runs it with the shell and {"NONE"} disables the
+	// health check.
+	Test []string
+
+	// Interval is the time between two health checks.
+	Interval time.Duration
+
+	// Timeout is how long a health check may run before it is considered to have failed.
+	Timeout time.Duration
+
+	// Retries is the number of consecutive failed health checks after which the container is
+	// unhealthy.
+	Retries int
+
+	// StartPeriod is how long the container is given to start before failed health checks count.
+	StartPeriod time.Duration
+}
+
+// Revision describes a version of a container instance recorded in its history.
+type Revision struct {
+	// Number identifies the revision within the instance's history, starting at 1.
+	Number int `json:"number"`
+
+	// Name is the optional name given to the revision when it was created.
+	Name string `json:"name,omitempty"`
+
+	// Image and Tag are the image reference the revision runs.
+	Image string `json:"image"`
+	Tag   string `json:"tag"`
+
+	// Created is when the revision was created.
+	Created time.Time `json:"created"`
+}
+
+// GroupMember describes a container of an application group.
+type GroupMember struct {
+	// Name is the instance name of the container.
+	Name string
+
+	// Image, Tag and Cmd are the image reference and command the container runs.
+	Image string
+	Tag   string
+	Cmd   string
+
+	// DependsOn lists the members of the group that must be ready before this one is started.
+	DependsOn []Dependency
+
+	// Options are the start options of the container, applied on top of the group wide ones.
+	Options []Option
+}
+
+// Subnet describes an IPAM subnet of a network.
+type Subnet struct {
+	// Subnet is the subnet in CIDR notation.
+	Subnet string `json:"subnet"`
+
+	// Gateway is the optional gateway address of the subnet.
+	Gateway string `json:"gateway,omitempty"`
+
+	// IPRange is an optional sub-range, in CIDR notation, from which container addresses are
+	// allocated.
+	IPRange string `json:"ip_range,omitempty"`
+}
+
+// String returns the subnet in the format accepted by ParseSubnet.
+func (s Subnet) String() string {
+	parts := []string{s.Subnet}
+	if s.Gateway != "" {
+		parts = append(parts, "gateway="+s.Gateway)
+	}
+	if s.IPRange != "" {
+		parts = append(parts, "ip_range="+s.IPRange)
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseSubnet parses a subnet of the format <subnet>[;gateway=<ip>][;ip_range=<subnet>]. The
+// addresses are validated when the network is created.
+func ParseSubnet(spec string) (Subnet, error) {
+	parts := strings.Split(spec, ";")
+	s := Subnet{Subnet: parts[0]}
+	if s.Subnet == "" {
+		return Subnet{}, fmt.Errorf("subnet %s has no subnet", spec)
+	}
+
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Subnet{}, fmt.Errorf("subnet %s has invalid option %q", spec, part)
+		}
+		switch key {
+		case "gateway":
+			s.Gateway = value
+		case "ip_range":
+			s.IPRange = value
+		default:
+			return Subnet{}, fmt.Errorf("subnet %s has unknown option %q", spec, key)
+		}
+	}
+	return s, nil
+}
+
+// NetworkInfo describes a network available in the container runtime.
+type NetworkInfo struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`
+	Created    time.Time         `json:"created"`
+	Subnets    []Subnet          `json:"subnets,omitempty"`
+	Options    map[string]string `json:"options,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Containers []string          `json:"containers,omitempty"`
+}
+
+// VolumeDetails describes a volume present on the target.
+type VolumeDetails struct {
+	Name    string            `json:"name"`
+	Driver  string            `json:"driver"`
+	Created time.Time         `json:"created"`
+	Options map[string]string `json:"options,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+
+	// Usage is the disk space used by the volume in bytes, or -1 if its driver does not report it.
+	Usage int64 `json:"usage"`
+
+	// Containers holds the names of the containers, running or not, that mount the volume.
+	Containers []string `json:"containers,omitempty"`
+}
+
 // Option takes an option and applies it to the set of options when the function is called.
 type Option func(*options)
 
@@ -96,6 +1091,9 @@ type options struct {
 	// PortMapping is a mapping of internal to external port for a container.
 	PortMapping map[uint32]uint32
 
+	// PortBindings are port mappings with an explicit protocol or host address.
+	PortBindings []PortBinding
+
 	// EnvMapping is a set of environment variables to set in the container
 	EnvMapping map[string]string
 
@@ -108,6 +1106,25 @@ type options struct {
 	// Since indicates until what time, relative to now, logs should be streamed.
 	Until time.Duration
 
+	// Timeout is how long to wait for a container to stop before it is killed.
+	Timeout time.Duration
+
+	// UpdateStrategy selects how a container is updated.
+	UpdateStrategy UpdateStrategy
+
+	// KeepPrevious is how long the previous container is kept for rollback after a blue/green
+	// update.
+	KeepPrevious time.Duration
+
+	// HealthTimeout is how long a blue/green update waits for the new container to become healthy.
+	HealthTimeout time.Duration
+
+	// RevisionName is the name given to the revision created by this operation.
+	RevisionName string
+
+	// Revision is the number or name of the revision to operate on.
+	Revision string
+
 	// All indicates that we should return all containers regardless of their state.
 	All bool
 
@@ -120,17 +1137,62 @@ type options struct {
 	// Volumes is a list of volumes to attach to a container.
 	Volumes []*cpb.Volume
 
+	// Mounts are the volume, bind and tmpfs mounts of a container, besides its volumes.
+	Mounts []Mount
+
 	// VolumeDriverOptions is the driver options for the volume.
 	VolumeDriverOptions proto.Message
 
 	// VolumeLabels are optional labels that should be applied to the volume.
 	VolumeLabels map[string]string
 
+	// NetworkDriverOptions are the driver specific options of a network, e.g. the parent interface
+	// of a macvlan network.
+	NetworkDriverOptions map[string]string
+
+	// NetworkSubnets are the IPAM subnets of a network.
+	NetworkSubnets []Subnet
+
+	// NetworkLabels are optional labels that should be applied to the network.
+	NetworkLabels map[string]string
+
 	// Network is an option parameter that should be applied to a container upon startup. It
 	// it represents the network to attach this container to. This could be 'host', 'bridged', or any
 	// other network available in the runtime.
 	Network string
 
+	// NetworkAttachments is the list of networks to attach this container to. The container is
+	// created on the first network and connected to the remaining ones before it is started.
+	NetworkAttachments []NetworkAttachment
+
+	// Hostname is the hostname of the container.
+	Hostname string
+
+	// DependsOn lists the containers that must be ready before this container is started.
+	DependsOn []Dependency
+
+	// HealthCheck overrides the health check defined by the image.
+	HealthCheck *HealthCheck
+
+	// DependencyTimeout is how long to wait for the dependencies to be ready. If zero, the start
+	// fails as soon as a dependency is not ready.
+	DependencyTimeout time.Duration
+
+	// DNS is the list of DNS servers the container should use.
+	DNS []string
+
+	// DNSSearch is the list of DNS search domains the container should use.
+	DNSSearch []string
+
+	// ExtraHosts is a list of <hostname>:<ip> entries to add to the container's /etc/hosts.
+	ExtraHosts []string
+
+	// IPv4Address is the static IPv4 address of the container on its network.
+	IPv4Address string
+
+	// IPv6Address is the static IPv6 address of the container on its network.
+	IPv6Address string
+
 	// Capabilities to be added/removed. Capabilities are first removed then added.
 	Capabilities proto.Message
 
@@ -156,6 +1218,12 @@ type options struct {
 	// HardMemory is the hard memory limit for the container.
 	HardMemory int64
 
+	// Resources holds the remaining resource controls for the container.
+	Resources Resources
+
+	// Security holds the security settings for the container.
+	Security Security
+
 	// Devices is the set of devices to attach to the container.
 	Devices []*cpb.Device
 }
@@ -189,7 +1257,8 @@ func WithStream(client Stream) Option {
 }
 
 // Force sets the force operation field in the image options.
-// Supported by: ContainerRemove, ContainerStop
+// Supported by: ContainerRemove, ContainerStop, ContainerRestart, NetworkRemove, PluginStop,
+// PluginRemove
 func Force() Option {
 	return func(p *options) {
 		p.Force = true
@@ -212,6 +1281,15 @@ func WithPorts(portMappings map[uint32]uint32) Option {
 	}
 }
 
+// WithPortBindings specifies exposed ports which require a protocol other than TCP or binding to
+// a specific host address.
+// Supported by: ContainerStart, ContainerUpdate
+func WithPortBindings(bindings []PortBinding) Option {
+	return func(p *options) {
+		p.PortBindings = bindings
+	}
+}
+
 // WithEnv specifies the set environment variables to set in the container.
 // Supported by: ContainerStart
 func WithEnv(envMapping map[string]string) Option {
@@ -241,14 +1319,74 @@ func WithSince(t time.Duration) Option {
 	}
 }
 
+// WithTimeout specifies how long to wait for a container to stop before killing it. A zero
+// timeout uses the container's StopTimeout, if set, otherwise the engine default.
+// Supported by: ContainerRestart
+func WithTimeout(t time.Duration) Option {
+	return func(p *options) {
+		p.Timeout = t
+	}
+}
+
+// WithUpdateStrategy selects how a container is updated.
+// Supported by: ContainerUpdate
+func WithUpdateStrategy(strategy UpdateStrategy) Option {
+	return func(p *options) {
+		p.UpdateStrategy = strategy
+	}
+}
+
+// WithKeepPrevious specifies how long the replaced container is kept, stopped, so that it can be
+// restored with ContainerRollback. A zero duration removes it immediately.
+// Supported by: ContainerUpdate, ContainerRollback
+func WithKeepPrevious(d time.Duration) Option {
+	return func(p *options) {
+		p.KeepPrevious = d
+	}
+}
+
+// WithHealthTimeout specifies how long to wait for the new container to become healthy during a
+// blue/green update.
+// Supported by: ContainerUpdate
+func WithHealthTimeout(d time.Duration) Option {
+	return func(p *options) {
+		p.HealthTimeout = d
+	}
+}
+
+// WithRevisionName names the revision created by this operation, so that it can later be
+// referred to by name.
+// Supported by: ContainerStart, ContainerUpdate
+func WithRevisionName(name string) Option {
+	return func(p *options) {
+		p.RevisionName = name
+	}
+}
+
+// WithRevision selects a revision, by number or name, to operate on.
+// Supported by: ContainerRollback
+func WithRevision(revision string) Option {
+	return func(p *options) {
+		p.Revision = revision
+	}
+}
+
 // WithFilter provides the filter option.
-// Supported by: ContainerList, VolumeList
+// Supported by: ContainerList, VolumeList, NetworkList
 func WithFilter(filter map[FilterKey][]string) Option {
 	return func(p *options) {
 		p.Filter = filter
 	}
 }
 
+// WithMounts sets the volume, bind and tmpfs mounts of a container.
+// Supported by: ContainerStart, ContainerUpdate
+func WithMounts(mounts []Mount) Option {
+	return func(p *options) {
+		p.Mounts = mounts
+	}
+}
+
 // WithVolumes sets the volumes to attach to a container.
 // Supported by: ContainerStart
 func WithVolumes(volumes []*cpb.Volume) Option {
@@ -273,6 +1411,30 @@ func WithVolumeLabels(labels map[string]string) Option {
 	}
 }
 
+// WithNetworkDriverOpts provides the network driver options.
+// Supported by: NetworkCreate
+func WithNetworkDriverOpts(opts map[string]string) Option {
+	return func(p *options) {
+		p.NetworkDriverOptions = opts
+	}
+}
+
+// WithNetworkSubnets provides the IPAM subnets of the network.
+// Supported by: NetworkCreate
+func WithNetworkSubnets(subnets []Subnet) Option {
+	return func(p *options) {
+		p.NetworkSubnets = subnets
+	}
+}
+
+// WithNetworkLabels provides the network labels.
+// Supported by: NetworkCreate
+func WithNetworkLabels(labels map[string]string) Option {
+	return func(p *options) {
+		p.NetworkLabels = labels
+	}
+}
+
 // WithNetwork provides the network to attach this container to.
 // Supported by: ContainerStart, ContainerUpdate
 func WithNetwork(network string) Option {
@@ -281,6 +1443,76 @@ func WithNetwork(network string) Option {
 	}
 }
 
+// WithNetworkAttachments provides the list of networks to attach this container to. It cannot be
+// combined with WithNetwork or WithIPAddresses.
+// Supported by: ContainerStart, ContainerUpdate
+func WithNetworkAttachments(attachments []NetworkAttachment) Option {
+	return func(p *options) {
+		p.NetworkAttachments = attachments
+	}
+}
+
+// WithHealthCheck provides the health check of the container, overriding the one defined by the
+// image.
+// Supported by: ContainerStart, ContainerUpdate
+func WithHealthCheck(check HealthCheck) Option {
+	return func(p *options) {
+		p.HealthCheck = &check
+	}
+}
+
+// WithDependsOn provides the containers that must be ready before this container is started.
+// Supported by: ContainerStart, ContainerUpdate
+func WithDependsOn(deps []Dependency) Option {
+	return func(p *options) {
+		p.DependsOn = deps
+	}
+}
+
+// WithDependencyTimeout provides how long to wait for the dependencies of the container to be
+// ready before failing the start.
+// Supported by: ContainerStart, ContainerUpdate
+func WithDependencyTimeout(d time.Duration) Option {
+	return func(p *options) {
+		p.DependencyTimeout = d
+	}
+}
+
+// WithHostname provides the hostname of the container.
+// Supported by: ContainerStart, ContainerUpdate
+func WithHostname(hostname string) Option {
+	return func(p *options) {
+		p.Hostname = hostname
+	}
+}
+
+// WithDNS provides the DNS servers and search domains the container should use.
+// Supported by: ContainerStart, ContainerUpdate
+func WithDNS(servers, search []string) Option {
+	return func(p *options) {
+		p.DNS = servers
+		p.DNSSearch = search
+	}
+}
+
+// WithExtraHosts provides <hostname>:<ip> entries to add to the container's /etc/hosts.
+// Supported by: ContainerStart, ContainerUpdate
+func WithExtraHosts(hosts []string) Option {
+	return func(p *options) {
+		p.ExtraHosts = hosts
+	}
+}
+
+// WithIPAddresses provides the static IPv4 and/or IPv6 address of the container. Static
+// addresses are only supported on user-defined networks.
+// Supported by: ContainerStart, ContainerUpdate
+func WithIPAddresses(ipv4, ipv6 string) Option {
+	return func(p *options) {
+		p.IPv4Address = ipv4
+		p.IPv6Address = ipv6
+	}
+}
+
 // WithCapabilities provides optional lists of added/removed container capabilities.
 // Supported by: ContainerStart, ContainerUpdate
 func WithCapabilities(opts proto.Message) Option {
@@ -337,6 +1569,24 @@ func WithHardLimit(mem int64) Option {
 	}
 }
 
+// WithResources provides resource controls, e.g. cpusets, a pids limit or ulimits, for the
+// container.
+// Supported by: ContainerStart, ContainerUpdate
+func WithResources(resources Resources) Option {
+	return func(p *options) {
+		p.Resources = resources
+	}
+}
+
+// WithSecurity provides the security settings, e.g. a seccomp profile or a read-only root
+// filesystem, for the container.
+// Supported by: ContainerStart, ContainerUpdate
+func WithSecurity(security Security) Option {
+	return func(p *options) {
+		p.Security = security
+	}
+}
+
 // WithDevices sets the devices to attach to a container.
 // Supported by: ContainerStart
 func WithDevices(devices []*cpb.Device) Option {
@@ -356,6 +1606,12 @@ func ParseCPUs(value float64) (int64, error) {
 	return nano.Num().Int64(), nil
 }
 
+// FormatChecksum returns the checksum, of the format sha256:<hex>, sent alongside a volume archive
+// given the SHA-256 digest of the archive.
+func FormatChecksum(sum []byte) string {
+	return "sha256:" + hex.EncodeToString(sum)
+}
+
 // ApplyOptions sets the passed options.
 func ApplyOptions(opts ...Option) *options { // NOLINT
 	options := &options{}
@@ -84,6 +84,51 @@ func TestWithPorts(t *testing.T) {
 	}
 }
 
+func TestWithPortBindings(t *testing.T) {
+	p := &options{}
+
+	in := []PortBinding{{Internal: 1, External: 2, Protocol: "udp", HostIP: "10.0.0.1"}}
+	WithPortBindings(in)(p)
+
+	if diff := cmp.Diff(p.PortBindings, in); diff != "" {
+		t.Errorf("WithPortBindings(%v) returned diff (-got, +want):\n%s", in, diff)
+	}
+}
+
+func TestParsePortBinding(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    PortBinding
+		wantErr bool
+	}{
+		{in: "1:2", want: PortBinding{Internal: 1, External: 2}},
+		{in: "1:2/UDP", want: PortBinding{Internal: 1, External: 2, Protocol: "udp"}},
+		{in: "10.0.0.1:1:2/sctp", want: PortBinding{Internal: 1, External: 2, Protocol: "sctp", HostIP: "10.0.0.1"}},
+		{in: "[fd00::1]:1:2", want: PortBinding{Internal: 1, External: 2, HostIP: "fd00::1"}},
+		{in: "1", wantErr: true},
+		{in: "1:2/icmp", wantErr: true},
+		{in: "1:70000", wantErr: true},
+		{in: "host:1:2", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParsePortBinding(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParsePortBinding(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParsePortBinding(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err != nil {
+			continue
+		}
+		// The canonical form must parse back to the same binding.
+		if roundTrip, err := ParsePortBinding(got.String()); err != nil || roundTrip != got {
+			t.Errorf("ParsePortBinding(%q) = %v, %v; want %v", got.String(), roundTrip, err, got)
+		}
+	}
+}
+
 func TestWithEnv(t *testing.T) {
 	p := &options{}
 
@@ -185,6 +230,220 @@ func TestWithNetwork(t *testing.T) {
 	}
 }
 
+func TestWithNetworkAttachments(t *testing.T) {
+	p := &options{}
+
+	in := []NetworkAttachment{{Network: "mgmt"}, {Network: "data", Aliases: []string{"speaker"}}}
+	WithNetworkAttachments(in)(p)
+
+	if diff := cmp.Diff(p.NetworkAttachments, in); diff != "" {
+		t.Errorf("WithNetworkAttachments(%v) returned diff (-got, +want):\n%s", in, diff)
+	}
+}
+
+func TestParseNetworkAttachment(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    NetworkAttachment
+		wantErr bool
+	}{
+		{in: "mgmt", want: NetworkAttachment{Network: "mgmt"}},
+		{
+			in: "data;alias=speaker;alias=bgp;ip=192.0.2.2;ip6=2001:db8::2",
+			want: NetworkAttachment{
+				Network:     "data",
+				Aliases:     []string{"speaker", "bgp"},
+				IPv4Address: "192.0.2.2",
+				IPv6Address: "2001:db8::2",
+			},
+		},
+		{in: "", wantErr: true},
+		{in: "data;alias", wantErr: true},
+		{in: "data;ip=2001:db8::2", wantErr: true},
+		{in: "data;ip6=192.0.2.2", wantErr: true},
+		{in: "data;mac=02:42:ac:11:00:02", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseNetworkAttachment(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseNetworkAttachment(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseNetworkAttachment(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && got.String() != tc.in {
+			t.Errorf("ParseNetworkAttachment(%q).String() = %q, want %q", tc.in, got.String(), tc.in)
+		}
+	}
+}
+
+func TestParseSubnet(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Subnet
+		wantErr bool
+	}{
+		{in: "192.0.2.0/24", want: Subnet{Subnet: "192.0.2.0/24"}},
+		{
+			in:   "2001:db8::/64;gateway=2001:db8::1;ip_range=2001:db8::/80",
+			want: Subnet{Subnet: "2001:db8::/64", Gateway: "2001:db8::1", IPRange: "2001:db8::/80"},
+		},
+		{in: "", wantErr: true},
+		{in: ";gateway=192.0.2.1", wantErr: true},
+		{in: "192.0.2.0/24;gateway", wantErr: true},
+		{in: "192.0.2.0/24;aux=192.0.2.2", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseSubnet(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseSubnet(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseSubnet(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && got.String() != tc.in {
+			t.Errorf("ParseSubnet(%q).String() = %q, want %q", tc.in, got.String(), tc.in)
+		}
+	}
+}
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Dependency
+		wantStr string
+		wantErr bool
+	}{
+		{in: "db", want: Dependency{Instance: "db", Condition: ConditionRunning}, wantStr: "db"},
+		{in: "db:running", want: Dependency{Instance: "db", Condition: ConditionRunning}, wantStr: "db"},
+		{in: "db:healthy", want: Dependency{Instance: "db", Condition: ConditionHealthy}, wantStr: "db:healthy"},
+		{in: "db:tcp/5432", want: Dependency{Instance: "db", Condition: ConditionTCP, Port: 5432}, wantStr: "db:tcp/5432"},
+		{in: "", wantErr: true},
+		{in: ":healthy", wantErr: true},
+		{in: "db:tcp", wantErr: true},
+		{in: "db:tcp/0", wantErr: true},
+		{in: "db:tcp/70000", wantErr: true},
+		{in: "db:ready", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseDependency(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseDependency(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseDependency(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && got.String() != tc.wantStr {
+			t.Errorf("ParseDependency(%q).String() = %q, want %q", tc.in, got.String(), tc.wantStr)
+		}
+	}
+}
+
+func TestWithDependsOn(t *testing.T) {
+	p := &options{}
+
+	deps := []Dependency{{Instance: "db", Condition: ConditionHealthy}}
+	WithDependsOn(deps)(p)
+	WithDependencyTimeout(time.Minute)(p)
+
+	if diff := cmp.Diff(deps, p.DependsOn); diff != "" || p.DependencyTimeout != time.Minute {
+		t.Errorf("WithDependsOn(%v), WithDependencyTimeout(1m) returned incorrect values: %+v", deps, p)
+	}
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	p := &options{}
+
+	check := HealthCheck{Test: []string{"CMD-SHELL", "curl -f localhost"}, Interval: time.Second, Retries: 3}
+	WithHealthCheck(check)(p)
+
+	if diff := cmp.Diff(&check, p.HealthCheck); diff != "" {
+		t.Errorf("WithHealthCheck(%+v) returned diff (-want, +got):\n%s", check, diff)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	p := &options{}
+
+	WithTimeout(5 * time.Second)(p)
+
+	if p.Timeout != 5*time.Second {
+		t.Errorf("WithTimeout(5s) did not set the timeout field")
+	}
+}
+
+func TestWithUpdateStrategy(t *testing.T) {
+	p := &options{}
+
+	WithUpdateStrategy(BlueGreenStrategy)(p)
+	WithKeepPrevious(time.Hour)(p)
+	WithHealthTimeout(time.Minute)(p)
+
+	if p.UpdateStrategy != BlueGreenStrategy || p.KeepPrevious != time.Hour || p.HealthTimeout != time.Minute {
+		t.Errorf("WithUpdateStrategy(blue-green), WithKeepPrevious(1h), WithHealthTimeout(1m) returned incorrect values: %+v", p)
+	}
+}
+
+func TestWithRevision(t *testing.T) {
+	p := &options{}
+
+	WithRevisionName("stable")(p)
+	WithRevision("3")(p)
+
+	if p.RevisionName != "stable" || p.Revision != "3" {
+		t.Errorf("WithRevisionName(stable), WithRevision(3) returned incorrect values: %+v", p)
+	}
+}
+
+func TestWithHostname(t *testing.T) {
+	p := &options{}
+
+	WithHostname("router-app")(p)
+
+	if p.Hostname != "router-app" {
+		t.Errorf("WithHostname(router-app) did not set the hostname field")
+	}
+}
+
+func TestWithDNS(t *testing.T) {
+	p := &options{}
+
+	servers := []string{"192.0.2.53"}
+	search := []string{"example.com"}
+	WithDNS(servers, search)(p)
+
+	if diff := cmp.Diff(p.DNS, servers); diff != "" {
+		t.Errorf("WithDNS(%v, %v) returned diff (-got, +want):\n%s", servers, search, diff)
+	}
+	if diff := cmp.Diff(p.DNSSearch, search); diff != "" {
+		t.Errorf("WithDNS(%v, %v) returned diff (-got, +want):\n%s", servers, search, diff)
+	}
+}
+
+func TestWithExtraHosts(t *testing.T) {
+	p := &options{}
+
+	in := []string{"collector:192.0.2.10"}
+	WithExtraHosts(in)(p)
+
+	if diff := cmp.Diff(p.ExtraHosts, in); diff != "" {
+		t.Errorf("WithExtraHosts(%v) returned diff (-got, +want):\n%s", in, diff)
+	}
+}
+
+func TestWithIPAddresses(t *testing.T) {
+	p := &options{}
+
+	WithIPAddresses("192.0.2.2", "2001:db8::2")(p)
+
+	if p.IPv4Address != "192.0.2.2" || p.IPv6Address != "2001:db8::2" {
+		t.Errorf("WithIPAddresses(192.0.2.2, 2001:db8::2) returned incorrect values: %+v", p)
+	}
+}
+
 func TestWithLabels(t *testing.T) {
 	p := &options{}
 
@@ -225,6 +484,183 @@ func TestWithHardLimit(t *testing.T) {
 	}
 }
 
+func TestParseUlimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Ulimit
+		wantStr string
+		wantErr bool
+	}{
+		{in: "nofile=1024", want: Ulimit{Name: "nofile", Soft: 1024, Hard: 1024}, wantStr: "nofile=1024"},
+		{in: "nofile=1024:4096", want: Ulimit{Name: "nofile", Soft: 1024, Hard: 4096}, wantStr: "nofile=1024:4096"},
+		{in: "CORE=0", want: Ulimit{Name: "core", Soft: 0, Hard: 0}, wantStr: "core=0"},
+		{in: "nofile", wantErr: true},
+		{in: "=1024", wantErr: true},
+		{in: "nofile=many", wantErr: true},
+		{in: "nofile=1024:lots", wantErr: true},
+		{in: "nofile=4096:1024", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseUlimit(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseUlimit(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseUlimit(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && got.String() != tc.wantStr {
+			t.Errorf("ParseUlimit(%q).String() = %q, want %q", tc.in, got.String(), tc.wantStr)
+		}
+	}
+}
+
+func TestParseMount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mount
+		wantStr string
+		wantErr bool
+	}{
+		{
+			in:      "type=bind,source=/var/log/app,target=/logs,readonly,bind-propagation=rslave",
+			want:    Mount{Type: BindMount, Source: "/var/log/app", Target: "/logs", ReadOnly: true, Propagation: "rslave"},
+			wantStr: "type=bind,source=/var/log/app,target=/logs,readonly,bind-propagation=rslave",
+		},
+		{
+			in:      "type=tmpfs,dst=/cache,tmpfs-size=64m,tmpfs-mode=1770",
+			want:    Mount{Type: TmpfsMount, Target: "/cache", TmpfsSize: 64 << 20, TmpfsMode: 0o1770},
+			wantStr: "type=tmpfs,target=/cache,tmpfs-size=67108864,tmpfs-mode=1770",
+		},
+		{
+			in:      "src=data,target=/data,ro=false",
+			want:    Mount{Type: VolumeMount, Source: "data", Target: "/data"},
+			wantStr: "type=volume,source=data,target=/data",
+		},
+		{in: "type=bind,source=/var/log", wantErr: true},
+		{in: "type=bind,target=/logs", wantErr: true},
+		{in: "type=tmpfs,source=/tmp,target=/tmp", wantErr: true},
+		{in: "type=tmpfs,target=/tmp,bind-propagation=shared", wantErr: true},
+		{in: "type=bind,source=/a,target=/b,tmpfs-size=1m", wantErr: true},
+		{in: "type=bind,source=/a,target=/b,bind-propagation=up", wantErr: true},
+		{in: "type=tmpfs,target=/tmp,tmpfs-size=lots", wantErr: true},
+		{in: "type=tmpfs,target=/tmp,tmpfs-mode=999", wantErr: true},
+		{in: "type=nfs,source=a,target=/b", wantErr: true},
+		{in: "type=bind,source=/a,target=/b,consistency=cached", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseMount(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseMount(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseMount(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && got.String() != tc.wantStr {
+			t.Errorf("ParseMount(%q).String() = %q, want %q", tc.in, got.String(), tc.wantStr)
+		}
+	}
+}
+
+func TestWithMounts(t *testing.T) {
+	p := &options{}
+
+	mounts := []Mount{{Type: TmpfsMount, Target: "/cache", TmpfsSize: 1 << 20}}
+	WithMounts(mounts)(p)
+
+	if diff := cmp.Diff(mounts, p.Mounts); diff != "" {
+		t.Errorf("WithMounts(%+v) returned diff (-want, +got):\n%s", mounts, diff)
+	}
+}
+
+func TestParseTmpfs(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[string]string
+		wantStr string
+		wantErr bool
+	}{
+		{in: "/tmp", want: map[string]string{"/tmp": ""}, wantStr: "/tmp"},
+		{in: "/tmp; /run:rw,size=64m", want: map[string]string{"/tmp": "", "/run": "rw,size=64m"}, wantStr: "/run:rw,size=64m;/tmp"},
+		{in: "", want: map[string]string{}, wantStr: ""},
+		{in: ":size=1m", wantErr: true},
+		{in: "/tmp;/tmp:size=1m", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseTmpfs(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseTmpfs(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseTmpfs(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && FormatTmpfs(got) != tc.wantStr {
+			t.Errorf("FormatTmpfs(ParseTmpfs(%q)) = %q, want %q", tc.in, FormatTmpfs(got), tc.wantStr)
+		}
+	}
+}
+
+func TestParseThrottleDevice(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ThrottleDevice
+		wantErr bool
+	}{
+		{in: "/dev/sda:1048576", want: ThrottleDevice{Path: "/dev/sda", Rate: 1048576}},
+		{in: "/dev/disk/by-id/nvme:100", want: ThrottleDevice{Path: "/dev/disk/by-id/nvme", Rate: 100}},
+		{in: "/dev/sda", wantErr: true},
+		{in: "sda:100", wantErr: true},
+		{in: "/dev/sda:-1", wantErr: true},
+		{in: "/dev/sda:fast", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseThrottleDevice(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseThrottleDevice(%q) returned error %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseThrottleDevice(%q) returned diff (-want, +got):\n%s", tc.in, diff)
+		}
+		if err == nil && got.String() != tc.in {
+			t.Errorf("ParseThrottleDevice(%q).String() = %q, want %q", tc.in, got.String(), tc.in)
+		}
+	}
+}
+
+func TestWithResources(t *testing.T) {
+	p := &options{}
+
+	resources := Resources{
+		CPUSetCPUs: "2-3",
+		PidsLimit:  100,
+		Ulimits:    []Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}},
+	}
+	WithResources(resources)(p)
+
+	if diff := cmp.Diff(resources, p.Resources); diff != "" {
+		t.Errorf("WithResources(%+v) returned diff (-want, +got):\n%s", resources, diff)
+	}
+}
+
+func TestWithSecurity(t *testing.T) {
+	p := &options{}
+
+	security := Security{
+		Seccomp:         "strict",
+		ReadOnlyRootfs:  true,
+		Tmpfs:           map[string]string{"/run": "size=64m"},
+		NoNewPrivileges: true,
+	}
+	WithSecurity(security)(p)
+
+	if diff := cmp.Diff(security, p.Security); diff != "" {
+		t.Errorf("WithSecurity(%+v) returned diff (-want, +got):\n%s", security, diff)
+	}
+}
+
 func TestWithDevices(t *testing.T) {
 	p := &options{}
 
@@ -0,0 +1,35 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+// createNetwork creates a network and returns its name.
+func (s *Server) createNetwork(ctx context.Context, args options.NetworkArgs) (any, error) {
+	if args.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "the name of the network must be provided")
+	}
+	return s.mgr.NetworkCreate(ctx, args.Name, args.Driver,
+		options.WithNetworkDriverOpts(args.Options),
+		options.WithNetworkSubnets(args.Subnets),
+		options.WithNetworkLabels(args.Labels))
+}
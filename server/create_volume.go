@@ -24,6 +24,8 @@ import (
 // CreateVolume creates a volume. If the volume already exists, this operation returns an
 // error. A volume is expected to be backed by persistent datastore and is
 // expected exist across device reboots along with the data it contained.
+// Volumes of the local driver bind mounting a host path are subject to the same allowlist as
+// bind mounts, and are created from the host path with its symbolic links resolved.
 func (s *Server) CreateVolume(ctx context.Context, request *cpb.CreateVolumeRequest) (*cpb.CreateVolumeResponse, error) {
 	opts := []options.Option{options.WithVolumeLabels(request.GetLabels())}
 
@@ -34,7 +36,11 @@ func (s *Server) CreateVolume(ctx context.Context, request *cpb.CreateVolumeRequ
 		driver = cpb.Driver_DS_LOCAL
 	case cpb.Driver_DS_LOCAL:
 		driver = cpb.Driver_DS_LOCAL
-		opts = append(opts, options.WithVolumeDriverOpts(request.GetLocalMountOptions()))
+		vopts, err := s.localVolumeAllowed(request.GetLabels(), request.GetLocalMountOptions())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, options.WithVolumeDriverOpts(vopts))
 	case cpb.Driver_DS_CUSTOM:
 		driver = cpb.Driver_DS_CUSTOM
 		opts = append(opts, options.WithVolumeDriverOpts(request.GetCustomOptions()))
@@ -6,6 +6,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	cpb "github.com/openconfig/gnoi/containerz"
@@ -20,6 +23,7 @@ func TestCreateVolume(t *testing.T) {
 		wantName  string
 		wantState *fakeContainerManager
 		wantResp  *cpb.CreateVolumeResponse
+		wantErr   error
 	}{
 		{
 			name:     "empty request",
@@ -40,19 +44,19 @@ func TestCreateVolume(t *testing.T) {
 				Driver: cpb.Driver_DS_LOCAL,
 				Options: &cpb.CreateVolumeRequest_LocalMountOptions{
 					LocalMountOptions: &cpb.LocalDriverOptions{
-						Mountpoint: "some-mountpoint",
+						Mountpoint: "/data/some-mountpoint",
 					},
 				},
 				Labels: map[string]string{
 					"some-label": "some-value",
 				},
 			},
-			inOpts:   []Option{},
+			inOpts:   []Option{WithBindMountAllowlist([]string{"/data"})},
 			wantName: "",
 			wantState: &fakeContainerManager{
 				VolumeDriver: cpb.Driver_DS_LOCAL,
 				VolumeOpts: &cpb.LocalDriverOptions{
-					Mountpoint: "some-mountpoint",
+					Mountpoint: "/data/some-mountpoint",
 				},
 				VolumeLabel: map[string]string{
 					"some-label": "some-value",
@@ -62,6 +66,42 @@ func TestCreateVolume(t *testing.T) {
 				Name: "some-volume",
 			},
 		},
+		{
+			name: "bind-not-allowed",
+			inReq: &cpb.CreateVolumeRequest{
+				Name:   "some-volume",
+				Driver: cpb.Driver_DS_LOCAL,
+				Options: &cpb.CreateVolumeRequest_LocalMountOptions{
+					LocalMountOptions: &cpb.LocalDriverOptions{
+						Mountpoint: "/etc",
+					},
+				},
+				Labels: map[string]string{
+					options.VolumeMountTypeLabel: "none",
+				},
+			},
+			inOpts:    []Option{WithBindMountAllowlist([]string{"/data"})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "bind mount of host path %s is not allowed", "/etc"),
+		},
+		{
+			name: "device-not-allowed",
+			inReq: &cpb.CreateVolumeRequest{
+				Name:   "some-volume",
+				Driver: cpb.Driver_DS_LOCAL,
+				Options: &cpb.CreateVolumeRequest_LocalMountOptions{
+					LocalMountOptions: &cpb.LocalDriverOptions{
+						Mountpoint: "/dev/sda1",
+					},
+				},
+				Labels: map[string]string{
+					options.VolumeMountTypeLabel: "ext4",
+				},
+			},
+			inOpts:    []Option{WithVolumeDeviceAllowlist([]string{"/dev/sdb1"})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "volumes may not mount block device %s", "/dev/sda1"),
+		},
 		{
 			name: "with driver and options",
 			inReq: &cpb.CreateVolumeRequest{
@@ -106,8 +146,8 @@ func TestCreateVolume(t *testing.T) {
 			defer s.Halt(ctx)
 
 			resp, err := cli.CreateVolume(ctx, tc.inReq)
-			if err != nil {
-				t.Errorf("CreateVolume(%+v) returned error: %v", tc.inReq, err)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("CreateVolume(%+v) returned unexpected error (-want +got):\n%s", tc.inReq, diff)
 			}
 
 			if diff := cmp.Diff(tc.wantResp, resp, protocmp.Transform()); diff != "" {
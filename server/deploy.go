@@ -25,20 +25,18 @@ import (
 
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
-	"k8s.io/klog/v2"
 	"github.com/openconfig/containerz/chunker"
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"k8s.io/klog/v2"
 )
 
 // tmpFilePrefix is a prefix used in the naming of temp files written by moveFile
 const tmpFilePrefix = ".tmp-"
 
-// pluginLocation is the location where plugins are expected to be written to.
-var pluginLocation = "/plugins"
-
 // Deploy sets a container image on the target. The container is sent as
 // a sequential stream of messages containing up to 64KB of data. Upon
 // reception of a valid container, the target must load it into its registry.
@@ -86,6 +84,10 @@ func (s *Server) Deploy(srv cpb.Containerz_DeployServer) error {
 }
 
 func (s *Server) handleImageTransfer(ctx context.Context, srv cpb.Containerz_DeployServer, transfer *cpb.ImageTransfer) error {
+	if transfer.GetIsPlugin() && !filepath.IsLocal(transfer.GetName()) {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin name %q", transfer.GetName())
+	}
+
 	if err := checkDiskSpace(s.tmpLocation, transfer.GetImageSize()); err != nil {
 		return err
 	}
@@ -138,7 +140,7 @@ func (s *Server) handleImageTransfer(ctx context.Context, srv cpb.Containerz_Dep
 
 		case *cpb.DeployRequest_ImageTransferEnd:
 			if transfer.IsPlugin {
-				if err := moveFile(chunkWriter, filepath.Join(pluginLocation, fmt.Sprintf("%s.tar", transfer.GetName()))); err != nil {
+				if err := moveFile(chunkWriter, filepath.Join(s.pluginLocation, fmt.Sprintf("%s.tar", transfer.GetName()))); err != nil {
 					return status.Errorf(codes.Internal, "unable to move plugin: %v", err)
 				}
 
@@ -155,10 +157,13 @@ func (s *Server) handleImageTransfer(ctx context.Context, srv cpb.Containerz_Dep
 				return nil
 			}
 
-			image, tag, err := s.mgr.ImagePush(ctx, chunkWriter.File(), options.WithTarget(transfer.GetName(), transfer.GetTag()))
+			image, tag, id, err := s.mgr.ImagePush(ctx, chunkWriter.File(), options.WithTarget(transfer.GetName(), transfer.GetTag()))
 			if err != nil {
 				return err
 			}
+			if id != "" {
+				srv.SetTrailer(metadata.Pairs(options.ImageDigestTrailer, id))
+			}
 
 			if err := srv.Send(&cpb.DeployResponse{
 				Response: &cpb.DeployResponse_ImageTransferSuccess{
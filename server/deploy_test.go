@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -43,18 +45,46 @@ type fakeContainerManager struct {
 	Name          string
 	Config        string
 	Ports         map[uint32]uint32
+	PortBindings  []options.PortBinding
 	Envs          map[string]string
 	Force         bool
+	Restarted     bool
+	Timeout       time.Duration
+	Paused        bool
+	Unpaused      bool
+	Signal        string
 	Follow        bool
 	All           bool
 	Async         bool
+	Strategy      options.UpdateStrategy
+	KeepPrevious  time.Duration
+	HealthTimeout time.Duration
 	Limit         int32
 	Devices       []*cpb.Device
 	Volumes       []*cpb.Volume
 	VolumeDriver  cpb.Driver
 	VolumeOpts    proto.Message
 	VolumeLabel   map[string]string
+	NetworkDriver string
+	NetworkOpts   map[string]string
+	Subnets       []options.Subnet
 	Network       string
+	Attachments   []options.NetworkAttachment
+	Hostname      string
+	RevisionName  string
+	Revision      string
+	RolledBack    bool
+	DependsOn     []options.Dependency
+	DepTimeout    time.Duration
+	HealthCheck   *options.HealthCheck
+	Resources     options.Resources
+	Security      options.Security
+	Mounts        []options.Mount
+	DNS           []string
+	DNSSearch     []string
+	ExtraHosts    []string
+	IPv4Address   string
+	IPv6Address   string
 	Capabilities  proto.Message
 	RunAs         proto.Message
 	RestartPolicy proto.Message
@@ -63,12 +93,25 @@ type fakeContainerManager struct {
 	HardMemory    int64
 	SoftMemory    int64
 
+	Group        string
+	GroupMembers []string
+	GroupAction  string
+
+	Upgraded       bool
+	PluginSettings options.PluginSettings
+	Pruned         bool
+
+	revisions        []options.Revision
 	listVols         []*cpb.ListVolumeResponse
 	listCntMsgs      []*cpb.ListContainerResponse
 	listImgMsgs      []*cpb.ListImageResponse
 	listPluginMsgs   *cpb.ListPluginsResponse
+	pluginArtifacts  []*options.PluginArtifact
+	networks         []*options.NetworkInfo
 	createVolumeName string
+	imageID          string
 	msgs             []string
+	volumeArchive    []byte
 
 	removeError error
 }
@@ -79,13 +122,14 @@ func (f *fakeContainerManager) ImagePull(ctx context.Context, image string, tag
 	return nil
 }
 
-func (f *fakeContainerManager) ImagePush(ctx context.Context, file *os.File, opts ...options.Option) (string, string, error) {
+func (f *fakeContainerManager) ImagePush(ctx context.Context, file *os.File, opts ...options.Option) (string, string, string, error) {
 	buf, err := io.ReadAll(file)
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
 	}
 	f.Contents = string(buf)
-	return "", "", nil
+	optionz := options.ApplyOptions(opts...)
+	return optionz.TargetName, optionz.TargetTag, f.imageID, nil
 }
 
 func (f *fakeContainerManager) ContainerRemove(_ context.Context, instance string, opts ...options.Option) error {
@@ -108,11 +152,26 @@ func (f *fakeContainerManager) ContainerStart(_ context.Context, image string, t
 	f.Tag = tag
 	f.Cmd = cmd
 	f.Ports = optionz.PortMapping
+	f.PortBindings = optionz.PortBindings
 	f.Envs = optionz.EnvMapping
 	f.Instance = optionz.InstanceName
 	f.Volumes = optionz.Volumes
 	f.Devices = optionz.Devices
 	f.Network = optionz.Network
+	f.Attachments = optionz.NetworkAttachments
+	f.Hostname = optionz.Hostname
+	f.RevisionName = optionz.RevisionName
+	f.DependsOn = optionz.DependsOn
+	f.DepTimeout = optionz.DependencyTimeout
+	f.HealthCheck = optionz.HealthCheck
+	f.Resources = optionz.Resources
+	f.Security = optionz.Security
+	f.Mounts = optionz.Mounts
+	f.DNS = optionz.DNS
+	f.DNSSearch = optionz.DNSSearch
+	f.ExtraHosts = optionz.ExtraHosts
+	f.IPv4Address = optionz.IPv4Address
+	f.IPv6Address = optionz.IPv6Address
 	f.Capabilities = optionz.Capabilities
 	f.RunAs = optionz.RunAs
 	f.RestartPolicy = optionz.RestartPolicy
@@ -142,6 +201,50 @@ func (f *fakeContainerManager) ContainerList(ctx context.Context, all bool, limi
 	return nil
 }
 
+func (f *fakeContainerManager) ContainerRestart(_ context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+	f.Instance = instance
+	f.Force = optionz.Force
+	f.Timeout = optionz.Timeout
+	f.Restarted = true
+	return nil
+}
+
+func (f *fakeContainerManager) ContainerPause(_ context.Context, instance string, opts ...options.Option) error {
+	f.Instance = instance
+	f.Paused = true
+	return nil
+}
+
+func (f *fakeContainerManager) ContainerUnpause(_ context.Context, instance string, opts ...options.Option) error {
+	f.Instance = instance
+	f.Unpaused = true
+	return nil
+}
+
+func (f *fakeContainerManager) ContainerKill(_ context.Context, instance, signal string, opts ...options.Option) error {
+	f.Instance = instance
+	f.Signal = signal
+	return nil
+}
+
+func (f *fakeContainerManager) ContainerHistory(_ context.Context, instance string, opts ...options.Option) ([]options.Revision, error) {
+	f.Instance = instance
+	if len(f.revisions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no revisions of instance %s are known", instance)
+	}
+	return f.revisions, nil
+}
+
+func (f *fakeContainerManager) ContainerRollback(_ context.Context, instance string, opts ...options.Option) error {
+	optionz := options.ApplyOptions(opts...)
+	f.Instance = instance
+	f.Revision = optionz.Revision
+	f.KeepPrevious = optionz.KeepPrevious
+	f.RolledBack = true
+	return nil
+}
+
 func (f *fakeContainerManager) ContainerUpdate(_ context.Context, instance, image, tag, cmd string, async bool, opts ...options.Option) (string, error) {
 	optionz := options.ApplyOptions(opts...)
 	f.Instance = instance
@@ -149,18 +252,73 @@ func (f *fakeContainerManager) ContainerUpdate(_ context.Context, instance, imag
 	f.Tag = tag
 	f.Cmd = cmd
 	f.Async = async
+	f.Strategy = optionz.UpdateStrategy
+	f.KeepPrevious = optionz.KeepPrevious
+	f.HealthTimeout = optionz.HealthTimeout
 	f.Ports = optionz.PortMapping
+	f.PortBindings = optionz.PortBindings
 	f.Envs = optionz.EnvMapping
 	f.Volumes = optionz.Volumes
 	f.Devices = optionz.Devices
 	f.Labels = optionz.Labels
 	f.Network = optionz.Network
+	f.Attachments = optionz.NetworkAttachments
+	f.Hostname = optionz.Hostname
+	f.RevisionName = optionz.RevisionName
+	f.DependsOn = optionz.DependsOn
+	f.DepTimeout = optionz.DependencyTimeout
+	f.HealthCheck = optionz.HealthCheck
+	f.Resources = optionz.Resources
+	f.Security = optionz.Security
+	f.Mounts = optionz.Mounts
+	f.DNS = optionz.DNS
+	f.DNSSearch = optionz.DNSSearch
+	f.ExtraHosts = optionz.ExtraHosts
+	f.IPv4Address = optionz.IPv4Address
+	f.IPv6Address = optionz.IPv6Address
 	f.Capabilities = optionz.Capabilities
 	f.RunAs = optionz.RunAs
 	f.RestartPolicy = optionz.RestartPolicy
 	return instance, nil
 }
 
+func (f *fakeContainerManager) GroupStart(_ context.Context, group string, members []options.GroupMember, opts ...options.Option) ([]string, error) {
+	f.Group, f.GroupAction = group, "start"
+	f.recordMembers(members)
+	return f.GroupMembers, nil
+}
+
+func (f *fakeContainerManager) GroupStop(_ context.Context, group string, opts ...options.Option) error {
+	f.Group, f.GroupAction = group, "stop"
+	f.Force = options.ApplyOptions(opts...).Force
+	return nil
+}
+
+func (f *fakeContainerManager) GroupUpdate(_ context.Context, group string, members []options.GroupMember, opts ...options.Option) error {
+	f.Group, f.GroupAction = group, "update"
+	f.recordMembers(members)
+	return nil
+}
+
+func (f *fakeContainerManager) GroupRemove(_ context.Context, group string, opts ...options.Option) error {
+	f.Group, f.GroupAction = group, "remove"
+	f.Force = options.ApplyOptions(opts...).Force
+	return nil
+}
+
+// recordMembers records the members of a group as <name>=<image>:<tag>, along with the options
+// of the last one.
+func (f *fakeContainerManager) recordMembers(members []options.GroupMember) {
+	f.GroupMembers = nil
+	for _, member := range members {
+		f.GroupMembers = append(f.GroupMembers, member.Name+"="+member.Image+":"+member.Tag)
+		optionz := options.ApplyOptions(member.Options...)
+		f.DependsOn = member.DependsOn
+		f.Strategy = optionz.UpdateStrategy
+		f.Mounts = optionz.Mounts
+	}
+}
+
 func (f *fakeContainerManager) ContainerLogs(_ context.Context, instance string, srv options.LogStreamer, opts ...options.Option) error {
 	optionz := options.ApplyOptions(opts...)
 
@@ -188,6 +346,16 @@ func (f *fakeContainerManager) ImageList(ctx context.Context, all bool, limit in
 	return nil
 }
 
+func (f *fakeContainerManager) ImageInspect(ctx context.Context, image string) (*options.ImageDetails, error) {
+	f.Image = image
+	for _, msg := range f.listImgMsgs {
+		if msg.GetImageName()+":"+msg.GetTag() == image {
+			return &options.ImageDetails{ID: msg.GetId(), RepoTags: []string{image}}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "image %s not found", image)
+}
+
 func (f fakeContainerManager) ImageRemove(context.Context, string, string, ...options.Option) error {
 	return f.removeError
 }
@@ -197,8 +365,9 @@ func (f *fakeContainerManager) PluginList(ctx context.Context, instance string)
 	return f.listPluginMsgs, nil
 }
 
-func (f *fakeContainerManager) PluginRemove(ctx context.Context, instance string) error {
+func (f *fakeContainerManager) PluginRemove(ctx context.Context, instance string, opts ...options.Option) error {
 	f.Instance = instance
+	f.Force = options.ApplyOptions(opts...).Force
 	for _, plugin := range f.listPluginMsgs.GetPlugins() {
 		if plugin.GetInstanceName() == instance {
 			return nil
@@ -214,8 +383,9 @@ func (f *fakeContainerManager) PluginStart(ctx context.Context, name, instance,
 	return nil
 }
 
-func (f *fakeContainerManager) PluginStop(ctx context.Context, instance string) error {
+func (f *fakeContainerManager) PluginStop(ctx context.Context, instance string, opts ...options.Option) error {
 	f.Instance = instance
+	f.Force = options.ApplyOptions(opts...).Force
 	for _, plugin := range f.listPluginMsgs.GetPlugins() {
 		if plugin.GetInstanceName() == instance {
 			return nil
@@ -224,6 +394,75 @@ func (f *fakeContainerManager) PluginStop(ctx context.Context, instance string)
 	return status.Errorf(codes.NotFound, "plugin %s not found", instance)
 }
 
+func (f *fakeContainerManager) NetworkCreate(ctx context.Context, name, driver string, opts ...options.Option) (string, error) {
+	optionz := options.ApplyOptions(opts...)
+	f.Name = name
+	f.NetworkDriver = driver
+	f.NetworkOpts = optionz.NetworkDriverOptions
+	f.Subnets = optionz.NetworkSubnets
+	f.Labels = optionz.NetworkLabels
+	return name, nil
+}
+
+func (f *fakeContainerManager) NetworkList(ctx context.Context, opts ...options.Option) ([]*options.NetworkInfo, error) {
+	return f.networks, nil
+}
+
+func (f *fakeContainerManager) NetworkRemove(ctx context.Context, name string, opts ...options.Option) error {
+	f.Name = name
+	f.Force = options.ApplyOptions(opts...).Force
+	for _, n := range f.networks {
+		if n.Name == name {
+			return nil
+		}
+	}
+	return status.Errorf(codes.NotFound, "network %s not found", name)
+}
+
+func (f *fakeContainerManager) PluginUpgrade(ctx context.Context, name, instance, config string) error {
+	f.Name = name
+	f.Instance = instance
+	f.Config = config
+	f.Upgraded = true
+	return nil
+}
+
+func (f *fakeContainerManager) PluginSet(ctx context.Context, instance string, settings options.PluginSettings) error {
+	f.Instance = instance
+	f.PluginSettings = settings
+	return nil
+}
+
+func (f *fakeContainerManager) PluginInspect(ctx context.Context, instance string) (*options.PluginDetails, error) {
+	f.Instance = instance
+	for _, plugin := range f.listPluginMsgs.GetPlugins() {
+		if plugin.GetInstanceName() == instance {
+			return &options.PluginDetails{ID: plugin.GetId(), Name: instance, Enabled: true}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "plugin %s not found", instance)
+}
+
+func (f *fakeContainerManager) PluginArtifactList(ctx context.Context) ([]*options.PluginArtifact, error) {
+	return f.pluginArtifacts, nil
+}
+
+func (f *fakeContainerManager) PluginArtifactRemove(ctx context.Context, name string, opts ...options.Option) error {
+	f.Name = name
+	f.Force = options.ApplyOptions(opts...).Force
+	for _, artifact := range f.pluginArtifacts {
+		if artifact.Name == name {
+			return nil
+		}
+	}
+	return status.Errorf(codes.NotFound, "plugin artifact %s not found", name)
+}
+
+func (f *fakeContainerManager) PluginArtifactPrune(ctx context.Context, retention time.Duration) ([]*options.PluginArtifact, error) {
+	f.Pruned = true
+	return f.pluginArtifacts, nil
+}
+
 func (f *fakeContainerManager) VolumeList(ctx context.Context, srv options.ListVolumeStreamer, opts ...options.Option) error {
 	for _, msg := range f.listVols {
 		if err := srv.Send(msg); err != nil {
@@ -233,6 +472,21 @@ func (f *fakeContainerManager) VolumeList(ctx context.Context, srv options.ListV
 	return nil
 }
 
+func (f *fakeContainerManager) VolumeInspect(ctx context.Context, name string) (*options.VolumeDetails, error) {
+	f.Name = name
+	for _, vol := range f.listVols {
+		if vol.GetName() == name {
+			return &options.VolumeDetails{
+				Name:    vol.GetName(),
+				Driver:  vol.GetDriver(),
+				Created: vol.GetCreated().AsTime(),
+				Usage:   -1,
+			}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "volume %s not found", name)
+}
+
 func (f *fakeContainerManager) VolumeCreate(ctx context.Context, name string, driver cpb.Driver, opts ...options.Option) (string, error) {
 	optionz := options.ApplyOptions(opts...)
 
@@ -249,13 +503,31 @@ func (f *fakeContainerManager) VolumeRemove(ctx context.Context, name string, op
 	return nil
 }
 
+func (f *fakeContainerManager) VolumeExport(ctx context.Context, name string, w io.Writer, opts ...options.Option) error {
+	f.Name = name
+	_, err := w.Write(f.volumeArchive)
+	return err
+}
+
+func (f *fakeContainerManager) VolumeImport(ctx context.Context, name string, r io.Reader, opts ...options.Option) error {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.Name = name
+	f.Contents = string(buf)
+	return nil
+}
+
 func TestDeploy(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
 		name          string
 		inOpts        []Option
+		inImageID     string
 		inReqs        []*cpb.DeployRequest
 		wantResponses []*cpb.DeployResponse
+		wantDigest    string
 		wantState     *fakeContainerManager
 		wantErr       error
 	}{
@@ -275,6 +547,16 @@ func TestDeploy(t *testing.T) {
 			}),
 			wantErr: status.Error(codes.ResourceExhausted, "not enough space to store image"),
 		},
+		{
+			name:   "invalid-plugin-name",
+			inOpts: []Option{WithAddr("localhost:0")},
+			inReqs: buildRequests(t, &cpb.ImageTransfer{
+				Name:      "../some-plugin",
+				ImageSize: 16,
+				IsPlugin:  true,
+			}),
+			wantErr: status.Errorf(codes.InvalidArgument, "invalid plugin name %q", "../some-plugin"),
+		},
 		{
 			name:   "remote-download",
 			inOpts: []Option{WithAddr("localhost:0")},
@@ -324,8 +606,9 @@ func TestDeploy(t *testing.T) {
 			wantErr: status.Errorf(codes.InvalidArgument, "too much data received"),
 		},
 		{
-			name:   "successful-image-transfer",
-			inOpts: []Option{WithAddr("localhost:0"), WithChunkSize(8)},
+			name:      "successful-image-transfer",
+			inOpts:    []Option{WithAddr("localhost:0"), WithChunkSize(8)},
+			inImageID: "sha256:abc",
 			inReqs: buildRequests(t, &cpb.ImageTransfer{
 				Name:      "some-image",
 				Tag:       "some-tag",
@@ -346,8 +629,11 @@ func TestDeploy(t *testing.T) {
 			}, &cpb.ImageTransferProgress{
 				BytesReceived: 16,
 			}, &cpb.ImageTransferSuccess{
+				Name:      "some-image",
+				Tag:       "some-tag",
 				ImageSize: 16,
 			}),
+			wantDigest: "sha256:abc",
 			wantState: &fakeContainerManager{
 				Contents: "exactly 16 bytes",
 			},
@@ -385,9 +671,8 @@ func TestDeploy(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			pluginLocation = t.TempDir()
-			fake := &fakeContainerManager{}
-			cli, s := startServerAndReturnClient(ctx, t, fake, tc.inOpts)
+			fake := &fakeContainerManager{imageID: tc.inImageID}
+			cli, s := startServerAndReturnClient(ctx, t, fake, append(tc.inOpts, WithPluginLocation(t.TempDir())))
 			defer s.Halt(ctx)
 
 			dCli, err := cli.Deploy(ctx)
@@ -430,6 +715,14 @@ func TestDeploy(t *testing.T) {
 				}
 			}
 
+			// The trailer is only available once the stream has ended.
+			if _, err := dCli.Recv(); err != io.EOF {
+				t.Errorf("Recv() returned %v, want %v", err, io.EOF)
+			}
+			if got := strings.Join(dCli.Trailer().Get(options.ImageDigestTrailer), ","); got != tc.wantDigest {
+				t.Errorf("Deploy(ctx) reported image digest %q, want %q", got, tc.wantDigest)
+			}
+
 			if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{})); diff != "" {
 				t.Errorf("Deploy(ctx) returned diff (-want, +got):\n%s", diff)
 			}
@@ -0,0 +1,266 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+// downloadChunkSize is the size of the chunks of data streamed by downloads. It is well below the
+// default limit on the size of gRPC messages.
+const downloadChunkSize = 1 << 20
+
+// extensionCall performs an operation of the Extension service and returns its result.
+type extensionCall func(ctx context.Context, args json.RawMessage) (any, error)
+
+// extensionDownload performs an operation of the Extension service producing data, written to w.
+type extensionDownload func(ctx context.Context, args json.RawMessage, w io.Writer) error
+
+// extensionUpload performs an operation of the Extension service consuming the data read from r,
+// and returns its result.
+type extensionUpload func(ctx context.Context, args json.RawMessage, r io.Reader) (any, error)
+
+// call adapts an operation taking arguments of type A to an extensionCall.
+func call[A any](fn func(context.Context, A) (any, error)) extensionCall {
+	return func(ctx context.Context, raw json.RawMessage) (any, error) {
+		args, err := parseArgs[A](raw)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, args)
+	}
+}
+
+// download adapts an operation taking arguments of type A to an extensionDownload.
+func download[A any](fn func(context.Context, A, io.Writer) error) extensionDownload {
+	return func(ctx context.Context, raw json.RawMessage, w io.Writer) error {
+		args, err := parseArgs[A](raw)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, args, w)
+	}
+}
+
+// upload adapts an operation taking arguments of type A to an extensionUpload.
+func upload[A any](fn func(context.Context, A, io.Reader) (any, error)) extensionUpload {
+	return func(ctx context.Context, raw json.RawMessage, r io.Reader) (any, error) {
+		args, err := parseArgs[A](raw)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, args, r)
+	}
+}
+
+func parseArgs[A any](raw json.RawMessage) (A, error) {
+	var args A
+	if len(raw) == 0 {
+		return args, nil
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return args, status.Errorf(codes.InvalidArgument, "invalid arguments: %v", err)
+	}
+	return args, nil
+}
+
+// calls returns the operations served by Call.
+func (s *Server) calls() map[options.Operation]extensionCall {
+	return map[options.Operation]extensionCall{
+		options.InspectImage:  call(s.inspectImage),
+		options.InspectPlugin: call(s.inspectPlugin),
+		options.UpgradePlugin: call(s.upgradePlugin),
+		options.SetPlugin:     call(s.setPlugin),
+		options.StopPlugin:    call(s.stopPlugin),
+		options.RemovePlugin:  call(s.removePlugin),
+
+		options.PauseContainer:   call(s.pauseContainer),
+		options.ResumeContainer:  call(s.resumeContainer),
+		options.KillContainer:    call(s.killContainer),
+		options.RestartContainer: call(s.restartContainer),
+
+		options.ListRevisions:     call(s.listRevisions),
+		options.RollbackContainer: call(s.rollbackContainer),
+
+		options.StartGroup:  call(s.startGroup),
+		options.StopGroup:   call(s.stopGroup),
+		options.UpdateGroup: call(s.updateGroup),
+		options.RemoveGroup: call(s.removeGroup),
+
+		options.CreateNetwork: call(s.createNetwork),
+		options.ListNetworks:  call(s.listNetworks),
+		options.RemoveNetwork: call(s.removeNetwork),
+
+		options.InspectVolume: call(s.inspectVolume),
+
+		options.ListPluginArtifacts:  call(s.listPluginArtifacts),
+		options.RemovePluginArtifact: call(s.removePluginArtifact),
+		options.PrunePluginArtifacts: call(s.prunePluginArtifacts),
+	}
+}
+
+// downloads returns the operations served by Download.
+func (s *Server) downloads() map[options.Operation]extensionDownload {
+	return map[options.Operation]extensionDownload{
+		options.ExportVolume: download(s.exportVolume),
+	}
+}
+
+// uploads returns the operations served by Upload.
+func (s *Server) uploads() map[options.Operation]extensionUpload {
+	return map[options.Operation]extensionUpload{
+		options.ImportVolume: upload(s.importVolume),
+	}
+}
+
+// Call performs an operation of the Extension service.
+func (s *Server) Call(ctx context.Context, in *structpb.Struct) (*structpb.Value, error) {
+	req, err := options.ParseExtensionRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := s.calls()[req.Operation]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown operation %s", req.Operation)
+	}
+
+	result, err := fn(ctx, req.Args)
+	if err != nil {
+		return nil, err
+	}
+	return options.NewExtensionResult(result)
+}
+
+// Download performs an operation of the Extension service producing data, which is streamed in
+// chunks followed by its checksum in the trailer.
+func (s *Server) Download(in *structpb.Struct, srv grpc.ServerStreamingServer[wrapperspb.BytesValue]) error {
+	req, err := options.ParseExtensionRequest(in)
+	if err != nil {
+		return err
+	}
+	fn, ok := s.downloads()[req.Operation]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown operation %s", req.Operation)
+	}
+
+	w := &chunkStreamer{
+		srv:  srv,
+		buf:  make([]byte, 0, downloadChunkSize),
+		hash: sha256.New(),
+	}
+	if err := fn(srv.Context(), req.Args, w); err != nil {
+		return err
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	srv.SetTrailer(metadata.Pairs(options.ChecksumTrailer, options.FormatChecksum(w.hash.Sum(nil))))
+	return nil
+}
+
+// Upload performs an operation of the Extension service consuming data streamed in chunks.
+func (s *Server) Upload(srv grpc.ClientStreamingServer[wrapperspb.BytesValue, structpb.Value]) error {
+	msg, err := srv.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "the request of the upload is missing: %v", err)
+	}
+	req, err := options.UnmarshalExtensionRequest(msg.GetValue())
+	if err != nil {
+		return err
+	}
+	fn, ok := s.uploads()[req.Operation]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown operation %s", req.Operation)
+	}
+
+	result, err := fn(srv.Context(), req.Args, &chunkReceiver{srv: srv})
+	if err != nil {
+		return err
+	}
+	out, err := options.NewExtensionResult(result)
+	if err != nil {
+		return err
+	}
+	return srv.SendAndClose(out)
+}
+
+// chunkStreamer streams the data written to it in chunks, and hashes it.
+type chunkStreamer struct {
+	srv  grpc.ServerStreamingServer[wrapperspb.BytesValue]
+	buf  []byte
+	hash hash.Hash
+}
+
+func (c *chunkStreamer) Write(p []byte) (int, error) {
+	c.hash.Write(p)
+	n := len(p)
+	for len(p) > 0 {
+		k := min(cap(c.buf)-len(c.buf), len(p))
+		c.buf = append(c.buf, p[:k]...)
+		p = p[k:]
+		if len(c.buf) == cap(c.buf) {
+			if err := c.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (c *chunkStreamer) flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+	// The message may be used after it is sent, so the next chunk goes to a new buffer.
+	err := c.srv.Send(wrapperspb.Bytes(c.buf))
+	c.buf = make([]byte, 0, cap(c.buf))
+	return err
+}
+
+// chunkReceiver reads the data streamed to it in chunks.
+type chunkReceiver struct {
+	srv grpc.ClientStreamingServer[wrapperspb.BytesValue, structpb.Value]
+	buf []byte
+}
+
+func (c *chunkReceiver) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		msg, err := c.srv.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		c.buf = msg.GetValue()
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
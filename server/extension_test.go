@@ -0,0 +1,607 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// newExtensionClient returns a client of the Extension service served by s.
+func newExtensionClient(t *testing.T, s *Server) options.ExtensionClient {
+	t.Helper()
+	addr := s.lis.Addr().String()
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("received error when dialing grpcServer(%v): got err: %v, want: nil\n", addr, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return options.NewExtensionClient(conn)
+}
+
+func TestExtensionCall(t *testing.T) {
+	tests := []struct {
+		name     string
+		inReq    map[string]any
+		wantCode codes.Code
+	}{
+		{
+			name:     "unknown-operation",
+			inReq:    map[string]any{"operation": "Unknown"},
+			wantCode: codes.Unimplemented,
+		},
+		{
+			name:     "missing-operation",
+			inReq:    map[string]any{"args": map[string]any{"name": "mgmt"}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "bad-arguments",
+			inReq:    map[string]any{"operation": string(options.RemoveNetwork), "args": map[string]any{"name": 1.0}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, s := startServerAndReturnClient(ctx, t, &fakeContainerManager{}, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := structpb.NewStruct(tc.inReq)
+			if err != nil {
+				t.Fatalf("structpb.NewStruct(%v) returned error: %v", tc.inReq, err)
+			}
+			if _, err := ext.Call(ctx, req); status.Code(err) != tc.wantCode {
+				t.Errorf("Call(%v) returned error %v, want code %v", tc.inReq, err, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestPluginOperations(t *testing.T) {
+	plugins := &cpb.ListPluginsResponse{
+		Plugins: []*cpb.Plugin{{Id: "some-id", InstanceName: "test"}},
+	}
+	artifacts := []*options.PluginArtifact{
+		{Name: "logger", Size: 16, Hash: "sha256:01", Uploaded: time.Unix(0, 0).UTC(), Plugins: []string{"logger:latest"}},
+		{Name: "sshfs", Size: 32, Hash: "sha256:02", Uploaded: time.Unix(0, 0).UTC(), Plugins: []string{}},
+	}
+
+	tests := []struct {
+		name string
+
+		inOp   options.Operation
+		inArgs any
+
+		wantResult string
+		wantState  *fakeContainerManager
+		wantErr    bool
+	}{
+		{
+			name:       "inspect",
+			inOp:       options.InspectPlugin,
+			inArgs:     options.PluginArgs{Instance: "test"},
+			wantResult: `{"id":"some-id","name":"test","enabled":true,"capabilities":null,"env":null,"args":null,"references":null}`,
+			wantState:  &fakeContainerManager{Instance: "test"},
+		},
+		{
+			name:       "upgrade",
+			inOp:       options.UpgradePlugin,
+			inArgs:     options.PluginArgs{Instance: "test", Name: "test-v2"},
+			wantResult: "null",
+			wantState:  &fakeContainerManager{Name: "test-v2", Instance: "test", Upgraded: true},
+		},
+		{
+			name: "set",
+			inOp: options.SetPlugin,
+			inArgs: options.PluginArgs{
+				Instance: "test",
+				Settings: &options.PluginSettings{Env: []string{"DEBUG=1"}, Args: []string{"-v"}},
+			},
+			wantResult: "null",
+			wantState: &fakeContainerManager{
+				Instance:       "test",
+				PluginSettings: options.PluginSettings{Env: []string{"DEBUG=1"}, Args: []string{"-v"}},
+			},
+		},
+		{
+			name:      "set-without-settings",
+			inOp:      options.SetPlugin,
+			inArgs:    options.PluginArgs{Instance: "test"},
+			wantState: &fakeContainerManager{},
+			wantErr:   true,
+		},
+		{
+			name:       "stop-forced",
+			inOp:       options.StopPlugin,
+			inArgs:     options.PluginArgs{Instance: "test", Force: true},
+			wantResult: "null",
+			wantState:  &fakeContainerManager{Instance: "test", Force: true},
+		},
+		{
+			name:       "remove-forced",
+			inOp:       options.RemovePlugin,
+			inArgs:     options.PluginArgs{Instance: "test", Force: true},
+			wantResult: "null",
+			wantState:  &fakeContainerManager{Instance: "test", Force: true},
+		},
+		{
+			name:       "list-artifacts",
+			inOp:       options.ListPluginArtifacts,
+			inArgs:     options.PluginArtifactArgs{},
+			wantResult: `[{"name":"logger","size":16,"hash":"sha256:01","uploaded":"1970-01-01T00:00:00Z","plugins":["logger:latest"]},{"name":"sshfs","size":32,"hash":"sha256:02","uploaded":"1970-01-01T00:00:00Z","plugins":[]}]`,
+			wantState:  &fakeContainerManager{},
+		},
+		{
+			name:       "list-named-artifact",
+			inOp:       options.ListPluginArtifacts,
+			inArgs:     options.PluginArtifactArgs{Name: "sshfs"},
+			wantResult: `[{"name":"sshfs","size":32,"hash":"sha256:02","uploaded":"1970-01-01T00:00:00Z","plugins":[]}]`,
+			wantState:  &fakeContainerManager{},
+		},
+		{
+			name:       "remove-artifact",
+			inOp:       options.RemovePluginArtifact,
+			inArgs:     options.PluginArtifactArgs{Name: "sshfs"},
+			wantResult: "null",
+			wantState:  &fakeContainerManager{Name: "sshfs"},
+		},
+		{
+			name:       "remove-artifact-forced",
+			inOp:       options.RemovePluginArtifact,
+			inArgs:     options.PluginArtifactArgs{Name: "sshfs", Force: true},
+			wantResult: "null",
+			wantState:  &fakeContainerManager{Name: "sshfs", Force: true},
+		},
+		{
+			name:      "remove-missing-artifact",
+			inOp:      options.RemovePluginArtifact,
+			inArgs:    options.PluginArtifactArgs{Name: "missing"},
+			wantState: &fakeContainerManager{Name: "missing"},
+			wantErr:   true,
+		},
+		{
+			name:      "remove-unnamed-artifact",
+			inOp:      options.RemovePluginArtifact,
+			inArgs:    options.PluginArtifactArgs{Force: true},
+			wantState: &fakeContainerManager{},
+			wantErr:   true,
+		},
+		{
+			name:      "prune-artifacts-unconfirmed",
+			inOp:      options.PrunePluginArtifacts,
+			inArgs:    options.PluginArtifactArgs{},
+			wantState: &fakeContainerManager{},
+			wantErr:   true,
+		},
+		{
+			name:       "prune-artifacts",
+			inOp:       options.PrunePluginArtifacts,
+			inArgs:     options.PluginArtifactArgs{Confirm: true},
+			wantResult: `[{"name":"logger","size":16,"hash":"sha256:01","uploaded":"1970-01-01T00:00:00Z","plugins":["logger:latest"]},{"name":"sshfs","size":32,"hash":"sha256:02","uploaded":"1970-01-01T00:00:00Z","plugins":[]}]`,
+			wantState:  &fakeContainerManager{Pruned: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{
+				listPluginMsgs:  plugins,
+				pluginArtifacts: artifacts,
+			}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(tc.inOp, tc.inArgs)
+			if err != nil {
+				t.Fatalf("NewExtensionRequest(%s, %+v) returned error: %v", tc.inOp, tc.inArgs, err)
+			}
+			resp, err := ext.Call(ctx, req)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Call(%s, %+v) returned error: %v, want error: %v", tc.inOp, tc.inArgs, err, tc.wantErr)
+			}
+
+			if !tc.wantErr {
+				var want any
+				if err := json.Unmarshal([]byte(tc.wantResult), &want); err != nil {
+					t.Fatalf("json.Unmarshal(%q) returned error: %v", tc.wantResult, err)
+				}
+				if diff := cmp.Diff(want, resp.AsInterface()); diff != "" {
+					t.Errorf("Call(%s, %+v) returned diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{}), cmpopts.SortMaps(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Call(%s, %+v) returned diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+			}
+		})
+	}
+}
+
+func TestContainerOperations(t *testing.T) {
+	tests := []struct {
+		name      string
+		inOp      options.Operation
+		inArgs    options.ContainerArgs
+		wantState *fakeContainerManager
+		wantCode  codes.Code
+	}{
+		{
+			name:      "pause",
+			inOp:      options.PauseContainer,
+			inArgs:    options.ContainerArgs{Instance: "test"},
+			wantState: &fakeContainerManager{Instance: "test", Paused: true},
+		},
+		{
+			name:      "resume",
+			inOp:      options.ResumeContainer,
+			inArgs:    options.ContainerArgs{Instance: "test"},
+			wantState: &fakeContainerManager{Instance: "test", Unpaused: true},
+		},
+		{
+			name:      "kill",
+			inOp:      options.KillContainer,
+			inArgs:    options.ContainerArgs{Instance: "test", Signal: "HUP"},
+			wantState: &fakeContainerManager{Instance: "test", Signal: "HUP"},
+		},
+		{
+			name:      "restart-with-timeout",
+			inOp:      options.RestartContainer,
+			inArgs:    options.ContainerArgs{Instance: "test", Timeout: 30 * time.Second},
+			wantState: &fakeContainerManager{Instance: "test", Timeout: 30 * time.Second, Restarted: true},
+		},
+		{
+			name:      "restart-forced",
+			inOp:      options.RestartContainer,
+			inArgs:    options.ContainerArgs{Instance: "test", Force: true},
+			wantState: &fakeContainerManager{Instance: "test", Force: true, Restarted: true},
+		},
+		{
+			name:      "restart-negative-timeout",
+			inOp:      options.RestartContainer,
+			inArgs:    options.ContainerArgs{Instance: "test", Timeout: -time.Second},
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "rollback",
+			inOp:      options.RollbackContainer,
+			inArgs:    options.ContainerArgs{Instance: "test"},
+			wantState: &fakeContainerManager{Instance: "test", RolledBack: true},
+		},
+		{
+			name:      "rollback-to-revision",
+			inOp:      options.RollbackContainer,
+			inArgs:    options.ContainerArgs{Instance: "test", Revision: "stable", KeepPrevious: time.Hour},
+			wantState: &fakeContainerManager{Instance: "test", Revision: "stable", KeepPrevious: time.Hour, RolledBack: true},
+		},
+		{
+			name:      "history-unknown",
+			inOp:      options.ListRevisions,
+			inArgs:    options.ContainerArgs{Instance: "test"},
+			wantState: &fakeContainerManager{Instance: "test"},
+			wantCode:  codes.NotFound,
+		},
+		{
+			name:      "no-instance",
+			inOp:      options.PauseContainer,
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(tc.inOp, tc.inArgs)
+			if err != nil {
+				t.Fatalf("NewExtensionRequest(%s, %+v) returned error: %v", tc.inOp, tc.inArgs, err)
+			}
+			if _, err := ext.Call(ctx, req); status.Code(err) != tc.wantCode {
+				t.Errorf("Call(%s, %+v) returned error %v, want code %v", tc.inOp, tc.inArgs, err, tc.wantCode)
+			}
+
+			if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{})); diff != "" {
+				t.Errorf("Call(%s, %+v) returned diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+			}
+		})
+	}
+}
+
+func TestListRevisions(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeContainerManager{
+		revisions: []options.Revision{
+			{Number: 1, Name: "stable", Image: "server", Tag: "v1", Created: time.Unix(0, 0).UTC()},
+			{Number: 2, Image: "server", Tag: "v2", Created: time.Unix(60, 0).UTC()},
+		},
+	}
+	_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+	defer s.Halt(ctx)
+	ext := newExtensionClient(t, s)
+
+	req, err := options.NewExtensionRequest(options.ListRevisions, options.ContainerArgs{Instance: "test"})
+	if err != nil {
+		t.Fatalf("NewExtensionRequest() returned error: %v", err)
+	}
+	resp, err := ext.Call(ctx, req)
+	if err != nil {
+		t.Fatalf("Call(%+v) returned error: %v", req, err)
+	}
+
+	var got []options.Revision
+	if err := options.ParseExtensionResult(resp, &got); err != nil {
+		t.Fatalf("ParseExtensionResult(%v) returned error: %v", resp, err)
+	}
+	if diff := cmp.Diff(fake.revisions, got); diff != "" {
+		t.Errorf("Call(%+v) returned diff (-want +got):\n%s", req, diff)
+	}
+	if fake.Instance != "test" {
+		t.Errorf("Call(%+v) listed the revisions of %q, want %q", req, fake.Instance, "test")
+	}
+}
+
+func TestNetworkOperations(t *testing.T) {
+	networks := []*options.NetworkInfo{
+		{ID: "1", Name: "mgmt", Driver: "bridge", Created: time.Unix(0, 0).UTC(), Subnets: []options.Subnet{{Subnet: "192.0.2.0/24"}}},
+		{ID: "2", Name: "data", Driver: "macvlan", Created: time.Unix(60, 0).UTC(), Containers: []string{"bgp"}},
+	}
+
+	tests := []struct {
+		name       string
+		inOp       options.Operation
+		inArgs     options.NetworkArgs
+		wantResult any
+		wantState  *fakeContainerManager
+		wantCode   codes.Code
+	}{
+		{
+			name: "create",
+			inOp: options.CreateNetwork,
+			inArgs: options.NetworkArgs{
+				Name:    "data",
+				Driver:  "macvlan",
+				Options: map[string]string{"parent": "eth1"},
+				Subnets: []options.Subnet{{Subnet: "198.51.100.0/24", Gateway: "198.51.100.1"}},
+				Labels:  map[string]string{"role": "data"},
+			},
+			wantResult: "data",
+			wantState: &fakeContainerManager{
+				Name:          "data",
+				NetworkDriver: "macvlan",
+				NetworkOpts:   map[string]string{"parent": "eth1"},
+				Subnets:       []options.Subnet{{Subnet: "198.51.100.0/24", Gateway: "198.51.100.1"}},
+				Labels:        map[string]string{"role": "data"},
+			},
+		},
+		{
+			name:      "create-no-name",
+			inOp:      options.CreateNetwork,
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:       "list",
+			inOp:       options.ListNetworks,
+			wantResult: networks,
+			wantState:  &fakeContainerManager{},
+		},
+		{
+			name:       "list-named",
+			inOp:       options.ListNetworks,
+			inArgs:     options.NetworkArgs{Name: "data"},
+			wantResult: networks[1:],
+			wantState:  &fakeContainerManager{},
+		},
+		{
+			name:      "remove",
+			inOp:      options.RemoveNetwork,
+			inArgs:    options.NetworkArgs{Name: "data", Force: true},
+			wantState: &fakeContainerManager{Name: "data", Force: true},
+		},
+		{
+			name:      "remove-unknown",
+			inOp:      options.RemoveNetwork,
+			inArgs:    options.NetworkArgs{Name: "ctrl"},
+			wantState: &fakeContainerManager{Name: "ctrl"},
+			wantCode:  codes.NotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{networks: networks}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(tc.inOp, tc.inArgs)
+			if err != nil {
+				t.Fatalf("NewExtensionRequest(%s, %+v) returned error: %v", tc.inOp, tc.inArgs, err)
+			}
+			resp, err := ext.Call(ctx, req)
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("Call(%s, %+v) returned error %v, want code %v", tc.inOp, tc.inArgs, err, tc.wantCode)
+			}
+
+			if tc.wantResult != nil {
+				want, err := options.NewExtensionResult(tc.wantResult)
+				if err != nil {
+					t.Fatalf("NewExtensionResult(%+v) returned error: %v", tc.wantResult, err)
+				}
+				if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+					t.Errorf("Call(%s, %+v) returned diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{})); diff != "" {
+				t.Errorf("Call(%s, %+v) left diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+			}
+		})
+	}
+}
+
+func TestGroupOperations(t *testing.T) {
+	member := func(req *cpb.StartContainerRequest) json.RawMessage {
+		buf, err := protojson.Marshal(req)
+		if err != nil {
+			t.Fatalf("protojson.Marshal(%v) returned error: %v", req, err)
+		}
+		return buf
+	}
+	db := member(&cpb.StartContainerRequest{InstanceName: "db", ImageName: "postgres", Tag: "16"})
+	collector := member(&cpb.StartContainerRequest{
+		InstanceName: "collector",
+		ImageName:    "collector",
+		Tag:          "v2",
+		Labels: map[string]string{
+			options.DependsOnLabel:      "db:healthy",
+			options.UpdateStrategyLabel: string(options.BlueGreenStrategy),
+		},
+	})
+
+	tests := []struct {
+		name       string
+		inOp       options.Operation
+		inArgs     options.GroupArgs
+		wantResult any
+		wantState  *fakeContainerManager
+		wantCode   codes.Code
+	}{
+		{
+			name:       "start",
+			inOp:       options.StartGroup,
+			inArgs:     options.GroupArgs{Group: "telemetry", Members: []json.RawMessage{db, collector}},
+			wantResult: []string{"db=postgres:16", "collector=collector:v2"},
+			wantState: &fakeContainerManager{
+				Group:        "telemetry",
+				GroupAction:  "start",
+				GroupMembers: []string{"db=postgres:16", "collector=collector:v2"},
+				DependsOn:    []options.Dependency{{Instance: "db", Condition: options.ConditionHealthy}},
+			},
+		},
+		{
+			name:   "update",
+			inOp:   options.UpdateGroup,
+			inArgs: options.GroupArgs{Group: "telemetry", Members: []json.RawMessage{db, collector}},
+			wantState: &fakeContainerManager{
+				Group:        "telemetry",
+				GroupAction:  "update",
+				GroupMembers: []string{"db=postgres:16", "collector=collector:v2"},
+				DependsOn:    []options.Dependency{{Instance: "db", Condition: options.ConditionHealthy}},
+				Strategy:     options.BlueGreenStrategy,
+			},
+		},
+		{
+			name:      "stop",
+			inOp:      options.StopGroup,
+			inArgs:    options.GroupArgs{Group: "telemetry", Force: true},
+			wantState: &fakeContainerManager{Group: "telemetry", GroupAction: "stop", Force: true},
+		},
+		{
+			name:      "remove",
+			inOp:      options.RemoveGroup,
+			inArgs:    options.GroupArgs{Group: "telemetry"},
+			wantState: &fakeContainerManager{Group: "telemetry", GroupAction: "remove"},
+		},
+		{
+			name:      "no-group",
+			inOp:      options.StopGroup,
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "no-members",
+			inOp:      options.StartGroup,
+			inArgs:    options.GroupArgs{Group: "telemetry"},
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "unnamed-member",
+			inOp:      options.StartGroup,
+			inArgs:    options.GroupArgs{Group: "telemetry", Members: []json.RawMessage{member(&cpb.StartContainerRequest{ImageName: "postgres"})}},
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "bind-not-allowed",
+			inOp: options.StartGroup,
+			inArgs: options.GroupArgs{Group: "telemetry", Members: []json.RawMessage{member(&cpb.StartContainerRequest{
+				InstanceName: "db",
+				ImageName:    "postgres",
+				Labels:       map[string]string{options.MountsLabel: "type=bind,source=/etc,target=/etc"},
+			})}},
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(tc.inOp, tc.inArgs)
+			if err != nil {
+				t.Fatalf("NewExtensionRequest(%s, %+v) returned error: %v", tc.inOp, tc.inArgs, err)
+			}
+			resp, err := ext.Call(ctx, req)
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("Call(%s, %+v) returned error %v, want code %v", tc.inOp, tc.inArgs, err, tc.wantCode)
+			}
+
+			if tc.wantResult != nil {
+				want, err := options.NewExtensionResult(tc.wantResult)
+				if err != nil {
+					t.Fatalf("NewExtensionResult(%+v) returned error: %v", tc.wantResult, err)
+				}
+				if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+					t.Errorf("Call(%s, %+v) returned diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{})); diff != "" {
+				t.Errorf("Call(%s, %+v) left diff (-want +got):\n%s", tc.inOp, tc.inArgs, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+func TestInspectImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		inImage     string
+		wantDetails *options.ImageDetails
+		wantErr     error
+	}{
+		{
+			name:    "image",
+			inImage: "server:v1",
+			wantDetails: &options.ImageDetails{
+				ID:       "some-id",
+				RepoTags: []string{"server:v1"},
+			},
+		},
+		{
+			name:    "not-found",
+			inImage: "missing:v1",
+			wantErr: status.Errorf(codes.NotFound, "image %s not found", "missing:v1"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{
+				listImgMsgs: []*cpb.ListImageResponse{{Id: "some-id", ImageName: "server", Tag: "v1"}},
+			}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(options.InspectImage, options.ImageArgs{Image: tc.inImage})
+			if err != nil {
+				t.Fatalf("NewExtensionRequest(%q) returned error: %v", tc.inImage, err)
+			}
+
+			var gotDetails *options.ImageDetails
+			resp, gotErr := ext.Call(ctx, req)
+			if gotErr == nil {
+				gotDetails = &options.ImageDetails{}
+				if err := options.ParseExtensionResult(resp, gotDetails); err != nil {
+					t.Fatalf("ParseExtensionResult(%v) returned error: %v", resp, err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Call(%+v) returned unexpected error (-want, +got):\n%s", req, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantDetails, gotDetails); diff != "" {
+				t.Errorf("Call(%+v) returned diff (-want, +got):\n%s", req, diff)
+			}
+			if fake.Image != tc.inImage {
+				t.Errorf("Call(%+v) inspected image %q, want %q", req, fake.Image, tc.inImage)
+			}
+		})
+	}
+}
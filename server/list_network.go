@@ -0,0 +1,39 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+// listNetworks returns the options.NetworkInfo of the networks, or only of the named one if a name
+// is given.
+func (s *Server) listNetworks(ctx context.Context, args options.NetworkArgs) (any, error) {
+	networks, err := s.mgr.NetworkList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := []*options.NetworkInfo{}
+	for _, n := range networks {
+		if args.Name != "" && n.Name != args.Name {
+			continue
+		}
+		listed = append(listed, n)
+	}
+	return listed, nil
+}
@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
+
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
-// ListPlugins lists plugins on the target.
+// ListPlugins lists the plugins.
 func (s *Server) ListPlugins(ctx context.Context, request *cpb.ListPluginsRequest) (*cpb.ListPluginsResponse, error) {
 	resp, err := s.mgr.PluginList(ctx, request.GetInstanceName())
 	if err != nil {
@@ -14,3 +16,26 @@ func (s *Server) ListPlugins(ctx context.Context, request *cpb.ListPluginsReques
 
 	return resp, nil
 }
+
+// listPluginArtifacts returns the options.PluginArtifact of the plugin tarballs, or only of the
+// named one if a name is given.
+func (s *Server) listPluginArtifacts(ctx context.Context, args options.PluginArtifactArgs) (any, error) {
+	artifacts, err := s.mgr.PluginArtifactList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := []*options.PluginArtifact{}
+	for _, artifact := range artifacts {
+		if args.Name != "" && artifact.Name != args.Name {
+			continue
+		}
+		listed = append(listed, artifact)
+	}
+	return listed, nil
+}
+
+// inspectPlugin returns the options.PluginDetails of a plugin instance.
+func (s *Server) inspectPlugin(ctx context.Context, args options.PluginArgs) (any, error) {
+	return s.mgr.PluginInspect(ctx, args.Instance)
+}
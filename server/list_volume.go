@@ -15,6 +15,11 @@
 package server
 
 import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/openconfig/containerz/containers"
 
 	cpb "github.com/openconfig/gnoi/containerz"
@@ -33,3 +38,11 @@ func (s *Server) ListVolume(request *cpb.ListVolumeRequest, srv cpb.Containerz_L
 
 	return s.mgr.VolumeList(srv.Context(), srv, options.WithFilter(filters))
 }
+
+// inspectVolume returns the options.VolumeDetails of a volume.
+func (s *Server) inspectVolume(ctx context.Context, args options.VolumeArgs) (any, error) {
+	if args.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "the volume to inspect must be provided")
+	}
+	return s.mgr.VolumeInspect(ctx, args.Name)
+}
@@ -18,10 +18,16 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
+	tpb "google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -102,3 +108,67 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestInspectVolume(t *testing.T) {
+	vols := []*cpb.ListVolumeResponse{
+		{Name: "data", Driver: "local", Created: tpb.New(time.Unix(60, 0))},
+	}
+
+	tests := []struct {
+		name       string
+		inArgs     options.VolumeArgs
+		wantResult *options.VolumeDetails
+		wantState  *fakeContainerManager
+		wantCode   codes.Code
+	}{
+		{
+			name:       "inspect",
+			inArgs:     options.VolumeArgs{Name: "data"},
+			wantResult: &options.VolumeDetails{Name: "data", Driver: "local", Created: time.Unix(60, 0).UTC(), Usage: -1},
+			wantState:  &fakeContainerManager{Name: "data"},
+		},
+		{
+			name:      "no-name",
+			wantState: &fakeContainerManager{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "not-found",
+			inArgs:    options.VolumeArgs{Name: "logs"},
+			wantState: &fakeContainerManager{Name: "logs"},
+			wantCode:  codes.NotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{listVols: vols}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(options.InspectVolume, tc.inArgs)
+			if err != nil {
+				t.Fatalf("NewExtensionRequest(%+v) returned error: %v", tc.inArgs, err)
+			}
+			resp, err := ext.Call(ctx, req)
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("Call(%+v) returned error %v, want code %v", tc.inArgs, err, tc.wantCode)
+			}
+
+			if tc.wantResult != nil {
+				want, err := options.NewExtensionResult(tc.wantResult)
+				if err != nil {
+					t.Fatalf("NewExtensionResult(%+v) returned error: %v", tc.wantResult, err)
+				}
+				if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+					t.Errorf("Call(%+v) returned diff (-want +got):\n%s", tc.inArgs, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{})); diff != "" {
+				t.Errorf("Call(%+v) left diff (-want +got):\n%s", tc.inArgs, diff)
+			}
+		})
+	}
+}
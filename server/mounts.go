@@ -0,0 +1,129 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// mounts returns the volume, bind and tmpfs mounts carried in the labels. Bind mounts must have a
+// source within one of the host paths allowed by the server, and their source is replaced by the
+// path the allowlist was checked against, with its symbolic links resolved, so that a symbolic link
+// swapped in after the check is not followed.
+func (s *Server) mounts(labels map[string]string) ([]options.Mount, error) {
+	spec, ok := labels[options.MountsLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	var mounts []options.Mount
+	for _, part := range strings.Split(spec, ";") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		m, err := options.ParseMount(part)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.MountsLabel, err)
+		}
+		if m.Type == options.BindMount {
+			source, ok := s.bindAllowed(m.Source)
+			if !ok {
+				return nil, status.Errorf(codes.PermissionDenied, "bind mount of host path %s is not allowed", m.Source)
+			}
+			m.Source = source
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// localVolumeAllowed returns an error if the local driver options bind mount a host path that is
+// not allowed to be bind mounted, or mount a block device that volumes are not allowed to mount.
+// Volumes are bind mounts unless they set another mount type. It returns the options to create
+// the volume with, whose mountpoint is the path the allowlist was checked against, with its
+// symbolic links resolved.
+func (s *Server) localVolumeAllowed(labels map[string]string, vopts *cpb.LocalDriverOptions) (*cpb.LocalDriverOptions, error) {
+	mountType, ok := labels[options.VolumeMountTypeLabel]
+	if !ok {
+		mountType = "none"
+	}
+	path := vopts.GetMountpoint()
+	var resolved string
+	switch {
+	case path == "", mountType == "tmpfs":
+		// The docker manager rejects the options that are missing a path or set one needlessly.
+		return vopts, nil
+	case mountType == "none":
+		if resolved, ok = s.bindAllowed(path); !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "bind mount of host path %s is not allowed", path)
+		}
+	default:
+		if resolved, ok = s.deviceAllowed(path); !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "volumes may not mount block device %s", path)
+		}
+	}
+	vopts = proto.Clone(vopts).(*cpb.LocalDriverOptions)
+	vopts.Mountpoint = resolved
+	return vopts, nil
+}
+
+// deviceAllowed returns whether the block device is one that volumes are allowed to mount, along
+// with the device with its symbolic links resolved. Symbolic links, such as those of
+// /dev/disk/by-label, are resolved on both sides.
+func (s *Server) deviceAllowed(device string) (string, bool) {
+	if !filepath.IsAbs(device) {
+		return "", false
+	}
+	device = resolvePath(device)
+	for _, allowed := range s.volumeDeviceAllowlist {
+		if resolvePath(allowed) == device {
+			return device, true
+		}
+	}
+	return "", false
+}
+
+// bindAllowed returns whether the host path is, or is below, one of the paths allowed to be bind
+// mounted, along with the path with its symbolic links resolved. Symbolic links are resolved so
+// that they cannot point outside of the allowed paths.
+func (s *Server) bindAllowed(path string) (string, bool) {
+	if !filepath.IsAbs(path) {
+		return "", false
+	}
+	path = resolvePath(path)
+	for _, allowed := range s.bindMountAllowlist {
+		rel, err := filepath.Rel(resolvePath(allowed), path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// resolvePath returns the cleaned path with its symbolic links resolved, if it exists.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/containerz/containers"
+
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+func TestBindAllowed(t *testing.T) {
+	dir := t.TempDir()
+	allowed := filepath.Join(dir, "allowed")
+	if err := os.MkdirAll(filepath.Join(allowed, "app"), 0755); err != nil {
+		t.Fatalf("unable to create allowed directory: %v", err)
+	}
+	if err := os.Symlink(dir, filepath.Join(allowed, "escape")); err != nil {
+		t.Fatalf("unable to create symlink: %v", err)
+	}
+
+	if err := os.Symlink(filepath.Join(allowed, "app"), filepath.Join(dir, "app")); err != nil {
+		t.Fatalf("unable to create symlink: %v", err)
+	}
+	resolved := resolvePath(allowed)
+
+	s := &Server{bindMountAllowlist: []string{allowed}}
+	tests := []struct {
+		in       string
+		wantPath string
+		want     bool
+	}{
+		{in: allowed, wantPath: resolved, want: true},
+		{in: filepath.Join(allowed, "app"), wantPath: filepath.Join(resolved, "app"), want: true},
+		{in: filepath.Join(dir, "app"), wantPath: filepath.Join(resolved, "app"), want: true},
+		{in: filepath.Join(allowed, "missing", "file"), wantPath: filepath.Join(resolved, "missing", "file"), want: true},
+		{in: filepath.Join(allowed, "..", "other"), want: false},
+		{in: allowed + "-sibling", want: false},
+		{in: filepath.Join(allowed, "escape"), want: false},
+		{in: "allowed", want: false},
+	}
+
+	for _, tc := range tests {
+		if got, ok := s.bindAllowed(tc.in); got != tc.wantPath || ok != tc.want {
+			t.Errorf("bindAllowed(%q) = %q, %v, want %q, %v", tc.in, got, ok, tc.wantPath, tc.want)
+		}
+	}
+
+	if _, ok := (&Server{}).bindAllowed(allowed); ok {
+		t.Errorf("bindAllowed(%q) = true without an allowlist, want false", allowed)
+	}
+}
+
+func TestDeviceAllowed(t *testing.T) {
+	dir := t.TempDir()
+	device := filepath.Join(dir, "sdb1")
+	if err := os.WriteFile(device, nil, 0600); err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+	label := filepath.Join(dir, "data")
+	if err := os.Symlink(device, label); err != nil {
+		t.Fatalf("unable to create symlink: %v", err)
+	}
+
+	resolved := resolvePath(device)
+
+	s := &Server{volumeDeviceAllowlist: []string{label}}
+	tests := []struct {
+		in       string
+		wantPath string
+		want     bool
+	}{
+		{in: label, wantPath: resolved, want: true},
+		{in: device, wantPath: resolved, want: true},
+		{in: filepath.Join(dir, "sdb2"), want: false},
+		{in: filepath.Join(dir, "sdb1", ".."), want: false},
+		{in: "sdb1", want: false},
+	}
+
+	for _, tc := range tests {
+		if got, ok := s.deviceAllowed(tc.in); got != tc.wantPath || ok != tc.want {
+			t.Errorf("deviceAllowed(%q) = %q, %v, want %q, %v", tc.in, got, ok, tc.wantPath, tc.want)
+		}
+	}
+
+	if _, ok := (&Server{}).deviceAllowed(device); ok {
+		t.Errorf("deviceAllowed(%q) = true without an allowlist, want false", device)
+	}
+}
+
+func TestMountsResolveSource(t *testing.T) {
+	dir := t.TempDir()
+	allowed := filepath.Join(dir, "allowed")
+	if err := os.MkdirAll(filepath.Join(allowed, "app"), 0755); err != nil {
+		t.Fatalf("unable to create allowed directory: %v", err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(filepath.Join(allowed, "app"), link); err != nil {
+		t.Fatalf("unable to create symlink: %v", err)
+	}
+	want := filepath.Join(resolvePath(allowed), "app")
+
+	s := &Server{bindMountAllowlist: []string{allowed}}
+	mounts, err := s.mounts(map[string]string{options.MountsLabel: "type=bind,source=" + link + ",target=/data"})
+	if err != nil {
+		t.Fatalf("mounts() returned unexpected error: %v", err)
+	}
+	if len(mounts) != 1 || mounts[0].Source != want {
+		t.Errorf("mounts() = %+v, want a single mount with source %q", mounts, want)
+	}
+
+	vopts := &cpb.LocalDriverOptions{Mountpoint: link}
+	got, err := s.localVolumeAllowed(nil, vopts)
+	if err != nil {
+		t.Fatalf("localVolumeAllowed() returned unexpected error: %v", err)
+	}
+	if got.GetMountpoint() != want {
+		t.Errorf("localVolumeAllowed() mountpoint = %q, want %q", got.GetMountpoint(), want)
+	}
+	if vopts.GetMountpoint() != link {
+		t.Errorf("localVolumeAllowed() modified the request mountpoint to %q", vopts.GetMountpoint())
+	}
+}
@@ -36,6 +36,14 @@ func WithTempLocation(tmp string) Option {
 	}
 }
 
+// WithPluginLocation sets the location where plugin tarballs should be written to. It must match
+// the plugin location of the container manager.
+func WithPluginLocation(dir string) Option {
+	return func(s *Server) {
+		s.pluginLocation = dir
+	}
+}
+
 // WithChunkSize sets the chunkSize supported by the server
 func WithChunkSize(chunkSize int) Option {
 	return func(s *Server) {
@@ -43,6 +51,30 @@ func WithChunkSize(chunkSize int) Option {
 	}
 }
 
+// WithSecurityPolicy sets the security settings enforced on every container started or updated
+// through the server.
+func WithSecurityPolicy(policy SecurityPolicy) Option {
+	return func(s *Server) {
+		s.securityPolicy = policy
+	}
+}
+
+// WithBindMountAllowlist sets the host paths, along with everything below them, that containers
+// and volumes of the local driver may bind mount. Bind mounts are rejected if no paths are allowed.
+func WithBindMountAllowlist(paths []string) Option {
+	return func(s *Server) {
+		s.bindMountAllowlist = paths
+	}
+}
+
+// WithVolumeDeviceAllowlist sets the block devices that volumes of the local driver may mount.
+// Volumes backed by a block device are rejected if no devices are allowed.
+func WithVolumeDeviceAllowlist(devices []string) Option {
+	return func(s *Server) {
+		s.volumeDeviceAllowlist = devices
+	}
+}
+
 // UseALTS sets up the grpc server to use ALTS authentication.
 // See https://cloud.google.com/docs/security/encryption-in-transit/application-layer-transport-security
 // for more information.
@@ -17,6 +17,8 @@ package server
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/containerz/containers"
 	"google.golang.org/grpc"
 )
 
@@ -40,6 +42,16 @@ func TestWithTempLocation(t *testing.T) {
 	}
 }
 
+func TestWithPluginLocation(t *testing.T) {
+	s := &Server{}
+
+	WithPluginLocation("plugin-location")(s)
+
+	if s.pluginLocation != "plugin-location" {
+		t.Errorf("WithPluginLocation('plugin-location') returned %s", s.pluginLocation)
+	}
+}
+
 func TestWithChunkSize(t *testing.T) {
 	s := &Server{}
 
@@ -50,6 +62,43 @@ func TestWithChunkSize(t *testing.T) {
 	}
 }
 
+func TestWithSecurityPolicy(t *testing.T) {
+	s := &Server{}
+
+	policy := SecurityPolicy{
+		Defaults:           options.Security{Seccomp: options.SeccompDefault, NoNewPrivileges: true},
+		SeccompProfiles:    []string{"strict"},
+		RequireUsernsRemap: true,
+	}
+	WithSecurityPolicy(policy)(s)
+
+	if diff := cmp.Diff(policy, s.securityPolicy); diff != "" {
+		t.Errorf("WithSecurityPolicy(%+v) returned diff (-want, +got):\n%s", policy, diff)
+	}
+}
+
+func TestWithBindMountAllowlist(t *testing.T) {
+	s := &Server{}
+
+	paths := []string{"/var/log", "/run/nos"}
+	WithBindMountAllowlist(paths)(s)
+
+	if diff := cmp.Diff(paths, s.bindMountAllowlist); diff != "" {
+		t.Errorf("WithBindMountAllowlist(%v) returned diff (-want, +got):\n%s", paths, diff)
+	}
+}
+
+func TestWithVolumeDeviceAllowlist(t *testing.T) {
+	s := &Server{}
+
+	devices := []string{"/dev/sdb1", "/dev/disk/by-label/data"}
+	WithVolumeDeviceAllowlist(devices)(s)
+
+	if diff := cmp.Diff(devices, s.volumeDeviceAllowlist); diff != "" {
+		t.Errorf("WithVolumeDeviceAllowlist(%v) returned diff (-want, +got):\n%s", devices, diff)
+	}
+}
+
 func TestWithGrpcServer(t *testing.T) {
 	s := &Server{}
 
@@ -0,0 +1,40 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+// pauseContainer suspends all processes of a running container.
+func (s *Server) pauseContainer(ctx context.Context, args options.ContainerArgs) (any, error) {
+	if args.Instance == "" {
+		return nil, status.Error(codes.InvalidArgument, "the instance to pause must be provided")
+	}
+	return nil, s.mgr.ContainerPause(ctx, args.Instance)
+}
+
+// resumeContainer resumes all processes of a paused container.
+func (s *Server) resumeContainer(ctx context.Context, args options.ContainerArgs) (any, error) {
+	if args.Instance == "" {
+		return nil, status.Error(codes.InvalidArgument, "the instance to resume must be provided")
+	}
+	return nil, s.mgr.ContainerUnpause(ctx, args.Instance)
+}
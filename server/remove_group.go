@@ -0,0 +1,32 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+// removeGroup removes the members of an application group, even the running ones if forced.
+func (s *Server) removeGroup(ctx context.Context, args options.GroupArgs) (any, error) {
+	if args.Group == "" {
+		return nil, status.Error(codes.InvalidArgument, "the name of the group must be provided")
+	}
+	return nil, s.mgr.GroupRemove(ctx, args.Group, forceOption(args.Force)...)
+}
@@ -4,13 +4,51 @@ import (
 	"context"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
-// RemovePlugin removes a plugin. If the plugin does not exist this operation is a no-op.
+// RemovePlugin removes a plugin, unless volumes use it.
 func (s *Server) RemovePlugin(ctx context.Context, request *cpb.RemovePluginRequest) (*cpb.RemovePluginResponse, error) {
 	if err := s.mgr.PluginRemove(ctx, request.GetInstanceName()); err != nil {
 		return nil, fmt.Errorf("unable to remove plugin: %w", err)
 	}
 	return &cpb.RemovePluginResponse{}, nil
 }
+
+// removePlugin removes a plugin, even if volumes use it if forced.
+func (s *Server) removePlugin(ctx context.Context, args options.PluginArgs) (any, error) {
+	if err := s.mgr.PluginRemove(ctx, args.Instance, forceOption(args.Force)...); err != nil {
+		return nil, fmt.Errorf("unable to remove plugin: %w", err)
+	}
+	return nil, nil
+}
+
+// removePluginArtifact removes a plugin tarball, even if plugins were created from it if forced.
+func (s *Server) removePluginArtifact(ctx context.Context, args options.PluginArtifactArgs) (any, error) {
+	if args.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "the name of the plugin artifact must be provided")
+	}
+	if err := s.mgr.PluginArtifactRemove(ctx, args.Name, forceOption(args.Force)...); err != nil {
+		return nil, fmt.Errorf("unable to remove plugin artifact: %w", err)
+	}
+	return nil, nil
+}
+
+// prunePluginArtifacts removes the plugin tarballs no installed plugin uses any more, and returns
+// them. The removal must be confirmed.
+func (s *Server) prunePluginArtifacts(ctx context.Context, args options.PluginArtifactArgs) (any, error) {
+	if !args.Confirm {
+		return nil, status.Error(codes.FailedPrecondition, "pruning plugin artifacts removes all the unused ones and must be confirmed")
+	}
+	removed, err := s.mgr.PluginArtifactPrune(ctx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prune plugin artifacts: %w", err)
+	}
+	klog.Infof("pruned %d plugin artifacts", len(removed))
+	return removed, nil
+}
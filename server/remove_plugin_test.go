@@ -62,9 +62,13 @@ func TestRemovePlugin(t *testing.T) {
 			cli, s := startServerAndReturnClient(ctx, t, fake, tc.inOpts)
 			defer s.Halt(ctx)
 
-			if _, err := cli.RemovePlugin(ctx, tc.inReq); err != nil && !tc.wantErr {
+			_, err := cli.RemovePlugin(ctx, tc.inReq)
+			if err != nil && !tc.wantErr {
 				t.Errorf("RemovePlugin(%+v) returned error: %v", tc.inReq, err)
 			}
+			if err == nil && tc.wantErr {
+				t.Errorf("RemovePlugin(%+v) did not return an error", tc.inReq)
+			}
 
 			if tc.wantState != nil {
 				if diff := cmp.Diff(tc.wantState, fake, cmpopts.IgnoreUnexported(fakeContainerManager{}), cmpopts.SortMaps(func(a, b string) bool { return a < b })); diff != "" {
@@ -0,0 +1,174 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/containerz/containers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SecurityPolicy holds the security settings the server enforces on every container.
+type SecurityPolicy struct {
+	// Defaults are applied to containers that leave a setting unset. Enabled settings, e.g. a
+	// read-only root filesystem, and paths cannot be turned off or removed by a request, and a
+	// default seccomp or AppArmor profile cannot be replaced by unconfined.
+	Defaults options.Security
+
+	// SeccompProfiles are the named seccomp profiles, or SeccompDefault, containers may request
+	// besides the default one of the policy. Containers may only run unconfined if Unconfined is
+	// listed. If it is empty, containers may request any profile unless the policy has a default
+	// seccomp profile, which they may then not replace.
+	SeccompProfiles []string
+
+	// RequireUsernsRemap rejects containers that opt out of the user namespace remapping.
+	RequireUsernsRemap bool
+}
+
+// securityFromLabels returns the security settings carried in the labels.
+func securityFromLabels(labels map[string]string) (options.Security, error) {
+	sec := options.Security{
+		Seccomp:       labels[options.SeccompLabel],
+		AppArmor:      labels[options.AppArmorLabel],
+		SELinux:       splitLabel(labels[options.SELinuxLabel]),
+		MaskedPaths:   splitLabel(labels[options.MaskedPathsLabel]),
+		ReadonlyPaths: splitLabel(labels[options.ReadonlyPathsLabel]),
+		UsernsMode:    labels[options.UsernsModeLabel],
+	}
+	for label, field := range map[string]*bool{
+		options.ReadOnlyRootfsLabel:  &sec.ReadOnlyRootfs,
+		options.NoNewPrivilegesLabel: &sec.NoNewPrivileges,
+	} {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return options.Security{}, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", label, err)
+		}
+		*field = b
+	}
+	if value, ok := labels[options.TmpfsLabel]; ok {
+		tmpfs, err := options.ParseTmpfs(value)
+		if err != nil {
+			return options.Security{}, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.TmpfsLabel, err)
+		}
+		sec.Tmpfs = tmpfs
+	}
+	return sec, nil
+}
+
+// security returns the security settings of a container, which are those requested in the labels
+// combined with the policy of the server.
+func (s *Server) security(labels map[string]string) (options.Security, error) {
+	sec, err := securityFromLabels(labels)
+	if err != nil {
+		return options.Security{}, err
+	}
+	defaults := s.securityPolicy.Defaults
+
+	for _, profile := range []struct {
+		kind      string
+		requested *string
+		def       string
+	}{
+		{"seccomp", &sec.Seccomp, defaults.Seccomp},
+		{"apparmor", &sec.AppArmor, defaults.AppArmor},
+	} {
+		switch {
+		case *profile.requested == "":
+			*profile.requested = profile.def
+		case *profile.requested == options.Unconfined && profile.def != "" && profile.def != options.Unconfined:
+			return options.Security{}, status.Errorf(codes.PermissionDenied, "%s confinement is required by the server policy", profile.kind)
+		}
+	}
+
+	if err := s.checkSeccompProfile(sec.Seccomp); err != nil {
+		return options.Security{}, err
+	}
+
+	switch {
+	case len(sec.SELinux) == 0:
+		sec.SELinux = defaults.SELinux
+	case len(defaults.SELinux) != 0 && containsString(sec.SELinux, "disable") && !containsString(defaults.SELinux, "disable"):
+		return options.Security{}, status.Errorf(codes.PermissionDenied, "selinux labelling is required by the server policy")
+	}
+
+	sec.ReadOnlyRootfs = sec.ReadOnlyRootfs || defaults.ReadOnlyRootfs
+	sec.NoNewPrivileges = sec.NoNewPrivileges || defaults.NoNewPrivileges
+	sec.MaskedPaths = unionStrings(defaults.MaskedPaths, sec.MaskedPaths)
+	sec.ReadonlyPaths = unionStrings(defaults.ReadonlyPaths, sec.ReadonlyPaths)
+	if len(defaults.Tmpfs) != 0 {
+		tmpfs := make(map[string]string, len(defaults.Tmpfs)+len(sec.Tmpfs))
+		for path, opts := range defaults.Tmpfs {
+			tmpfs[path] = opts
+		}
+		for path, opts := range sec.Tmpfs {
+			tmpfs[path] = opts
+		}
+		sec.Tmpfs = tmpfs
+	}
+
+	switch {
+	case sec.UsernsMode == "":
+		sec.UsernsMode = defaults.UsernsMode
+	case strings.EqualFold(sec.UsernsMode, "host") && s.securityPolicy.RequireUsernsRemap:
+		return options.Security{}, status.Errorf(codes.PermissionDenied, "user namespace remapping is required by the server policy")
+	}
+	return sec, nil
+}
+
+// checkSeccompProfile returns an error if the policy does not let containers request the seccomp
+// profile. Without an allowlist of profiles, unconfined containers are checked along with the
+// AppArmor profile.
+func (s *Server) checkSeccompProfile(profile string) error {
+	policy := s.securityPolicy
+	switch {
+	case profile == "", profile == policy.Defaults.Seccomp, containsString(policy.SeccompProfiles, profile):
+		return nil
+	case len(policy.SeccompProfiles) != 0:
+		// Containers may only run unconfined if the allowlist says so.
+	case profile == options.Unconfined, policy.Defaults.Seccomp == "":
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "seccomp profile %q is not allowed by the server policy", profile)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// unionStrings returns the values of a followed by those of b that are not in a.
+func unionStrings(a, b []string) []string {
+	res := append([]string{}, a...)
+	for _, v := range b {
+		if !containsString(res, v) {
+			res = append(res, v)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
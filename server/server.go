@@ -18,8 +18,10 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"time"
 
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
@@ -60,7 +62,9 @@ type containerManager interface {
 	// It returns:
 	// - image (string): the container image name of the container that was pushed.
 	// - tag (string): the container image tag of the container that was pushed
-	ImagePush(context.Context, *os.File, ...options.Option) (string, string, error)
+	// - id (string): the ID, i.e. the digest of the configuration, of the image that was pushed,
+	//   or an empty string if it cannot be resolved.
+	ImagePush(context.Context, *os.File, ...options.Option) (string, string, string, error)
 
 	// ContainerRemove removes an container provided that it is not running.
 	//
@@ -91,6 +95,41 @@ type containerManager interface {
 	// It returns an error indicating whether the result was successful
 	ContainerStop(context.Context, string, ...options.Option) error
 
+	// ContainerRestart restarts a container in place, preserving its ID and logs. If the Force
+	// option is passed the container is killed immediately, otherwise it is given the Timeout
+	// option (or the system default) to stop.
+	//
+	// It takes:
+	// - instance (string): the instance name of the container.
+	//
+	// It returns an error indicating whether the result was successful
+	ContainerRestart(context.Context, string, ...options.Option) error
+
+	// ContainerPause suspends all processes of a running container.
+	//
+	// It takes:
+	// - instance (string): the instance name of the running container.
+	//
+	// It returns an error indicating whether the result was successful
+	ContainerPause(context.Context, string, ...options.Option) error
+
+	// ContainerUnpause resumes all processes of a paused container.
+	//
+	// It takes:
+	// - instance (string): the instance name of the paused container.
+	//
+	// It returns an error indicating whether the result was successful
+	ContainerUnpause(context.Context, string, ...options.Option) error
+
+	// ContainerKill sends a signal to the main process of a container.
+	//
+	// It takes:
+	// - instance (string): the instance name of the running container.
+	// - signal (string): the signal name or number, SIGKILL if empty.
+	//
+	// It returns an error indicating whether the result was successful
+	ContainerKill(context.Context, string, string, ...options.Option) error
+
 	// ContainerUpdates updates an existing container.
 	//
 	// It takes:
@@ -104,6 +143,63 @@ type containerManager interface {
 	// started container.
 	ContainerUpdate(ctx context.Context, instance, image, tag, cmd string, async bool, opts ...options.Option) (string, error)
 
+	// ContainerHistory returns the revisions of an instance, oldest first. The last revision is
+	// the one currently deployed.
+	//
+	// It takes:
+	// - instance (string): the instance name of the container.
+	//
+	// It returns the revisions or an error indicating why they are not available.
+	ContainerHistory(context.Context, string, ...options.Option) ([]options.Revision, error)
+
+	// ContainerRollback restores an earlier version of an instance. The Revision option selects
+	// the revision to restore, by default the previous one, and the KeepPrevious option how long
+	// the replaced version is kept for.
+	//
+	// It takes:
+	// - instance (string): the instance name of the container.
+	//
+	// It returns an error indicating whether the result was successful.
+	ContainerRollback(context.Context, string, ...options.Option) error
+
+	// GroupStart starts the members of an application group, each one after the members it
+	// depends on.
+	//
+	// It takes:
+	// - group (string): the name of the group.
+	// - members (GroupMember slice): the containers of the group.
+	//
+	// It returns the names of the members in the order they were started, or an error indicating
+	// why the group could not be started.
+	GroupStart(context.Context, string, []options.GroupMember, ...options.Option) ([]string, error)
+
+	// GroupStop stops the running members of an application group, each one before the members it
+	// depends on. The options are passed to ContainerStop.
+	//
+	// It takes:
+	// - group (string): the name of the group.
+	//
+	// It returns an error indicating whether the result was successful.
+	GroupStop(context.Context, string, ...options.Option) error
+
+	// GroupUpdate updates an application group to the given members, as a whole or not at all.
+	//
+	// It takes:
+	// - group (string): the name of the group.
+	// - members (GroupMember slice): the containers of the group.
+	//
+	// It returns an error indicating whether the result was successful.
+	GroupUpdate(context.Context, string, []options.GroupMember, ...options.Option) error
+
+	// GroupRemove removes the members of an application group. Running members are only removed
+	// if the Force option is passed.
+	//
+	// It takes:
+	// - group (string): the name of the group.
+	//
+	// It returns an error indicating whether the result was successful.
+	GroupRemove(context.Context, string, ...options.Option) error
+
 	// ContainerLogs fetches the logs from a container. It can optionally follow the logs
 	// and send them back to the client.
 	//
@@ -123,6 +219,14 @@ type containerManager interface {
 	// It returns an error indicating the result of the operation.
 	ImageList(context.Context, bool, int32, options.ListImageStreamer, ...options.Option) error
 
+	// ImageInspect returns the details of an image.
+	//
+	// It takes:
+	// - image (string): the image to inspect, referenced by name, name:tag or ID.
+	//
+	// It returns the details of the image or an error indicating why they are not available.
+	ImageInspect(context.Context, string) (*options.ImageDetails, error)
+
 	// ImageRemove removes an image provided it is not linked to any running containers.
 	//
 	// It takes:
@@ -145,9 +249,10 @@ type containerManager interface {
 	//
 	// It takes:
 	// - instance (string): the instance name of the plugin to remove.
+	// - opts (Option slice): a set of options.
 	//
 	// It returns an error indicating whether the operation was successful or not.
-	PluginRemove(context.Context, string) error
+	PluginRemove(context.Context, string, ...options.Option) error
 
 	// PluginStart starts a plugin on the target.
 	//
@@ -163,9 +268,84 @@ type containerManager interface {
 	//
 	// It takes:
 	// - instance (string): the instance name of the plugin to stop.
+	// - opts (Option slice): a set of options.
+	//
+	// It returns an error indicating whether the operation was successful or not.
+	PluginStop(context.Context, string, ...options.Option) error
+
+	// PluginUpgrade replaces a plugin with a newly deployed one, keeping its settings.
+	//
+	// It takes:
+	// - name (string): the name of the deployed plugin to upgrade to.
+	// - instance (string): the instance name of the plugin to upgrade.
+	// - config (string): the new configuration of the plugin, or empty to keep the current one.
+	//
+	// It returns an error indicating whether the operation was successful or not.
+	PluginUpgrade(context.Context, string, string, string) error
+
+	// PluginSet changes the settings of a plugin.
+	//
+	// It takes:
+	// - instance (string): the instance name of the plugin to change.
+	// - settings (PluginSettings): the settings to change.
 	//
 	// It returns an error indicating whether the operation was successful or not.
-	PluginStop(context.Context, string) error
+	PluginSet(context.Context, string, options.PluginSettings) error
+
+	// PluginInspect returns the details of a plugin.
+	//
+	// It takes:
+	// - instance (string): the instance name of the plugin to inspect.
+	//
+	// It returns the details of the plugin or an error indicating why they are not available.
+	PluginInspect(context.Context, string) (*options.PluginDetails, error)
+
+	// PluginArtifactList lists the plugin tarballs deployed to the target.
+	//
+	// It returns the tarballs, along with the plugins created from them, or an error indicating
+	// why they are not available.
+	PluginArtifactList(context.Context) ([]*options.PluginArtifact, error)
+
+	// PluginArtifactRemove removes a plugin tarball. If the Force option is passed, the tarball
+	// is removed even if plugins were created from it.
+	//
+	// It takes:
+	// - name (string): the name of the tarball to remove.
+	//
+	// It returns an error indicating whether the result was successful.
+	PluginArtifactRemove(context.Context, string, ...options.Option) error
+
+	// PluginArtifactPrune removes the plugin tarballs no plugin was created from.
+	//
+	// It takes:
+	// - retention (time.Duration): how long tarballs are kept for after they were uploaded.
+	//
+	// It returns the removed tarballs or an error indicating why they could not be removed.
+	PluginArtifactPrune(context.Context, time.Duration) ([]*options.PluginArtifact, error)
+
+	// NetworkCreate creates a network. It will optionally apply driver options, subnets or labels
+	// to the network creation.
+	//
+	// It takes:
+	// - name (string): the name of the network to create.
+	// - driver (string): the driver of the network, bridge if empty.
+	//
+	// It returns the name of the network or an error indicating why it could not be created.
+	NetworkCreate(context.Context, string, string, ...options.Option) (string, error)
+
+	// NetworkList lists the networks on the target.
+	//
+	// It returns the networks or an error indicating why they are not available.
+	NetworkList(context.Context, ...options.Option) ([]*options.NetworkInfo, error)
+
+	// NetworkRemove removes a network. If the Force option is passed, the containers attached to
+	// it are disconnected first.
+	//
+	// It takes:
+	// - name (string): the name of the network to remove.
+	//
+	// It returns an error indicating whether the result was successful.
+	NetworkRemove(context.Context, string, ...options.Option) error
 
 	// VolumeList lists volumes on the target.
 	//
@@ -175,6 +355,13 @@ type containerManager interface {
 	// It returns an error indicating the result of the operation.
 	VolumeList(context.Context, options.ListVolumeStreamer, ...options.Option) error
 
+	// VolumeInspect returns the details of a volume, including its disk usage and the containers
+	// using it.
+	//
+	// It takes:
+	// - name (string): The name of the volume to inspect.
+	VolumeInspect(context.Context, string) (*options.VolumeDetails, error)
+
 	// VolumeCreate creates a volume. It will optionally apply labels or driver options to the volume
 	// creation.
 	//
@@ -190,6 +377,21 @@ type containerManager interface {
 	// It takes:
 	// - name (string): The name of the volume to remove.
 	VolumeRemove(context.Context, string, ...options.Option) error
+
+	// VolumeExport writes a gzip compressed tar archive of the contents of a volume.
+	//
+	// It takes:
+	// - name (string): The name of the volume to export.
+	// - w (io.Writer): The writer the archive is written to.
+	VolumeExport(context.Context, string, io.Writer, ...options.Option) error
+
+	// VolumeImport restores a gzip compressed tar archive into a volume, creating the volume if it
+	// does not exist.
+	//
+	// It takes:
+	// - name (string): The name of the volume to restore.
+	// - r (io.Reader): The reader the archive is read from.
+	VolumeImport(context.Context, string, io.Reader, ...options.Option) error
 }
 
 // Server represents a containerz service.
@@ -200,11 +402,16 @@ type Server struct {
 	grpcServer *grpc.Server
 	lis        net.Listener
 
-	addr        string
-	dockerHost  string
-	tmpLocation string
+	addr           string
+	dockerHost     string
+	tmpLocation    string
+	pluginLocation string
 
 	chunkSize int
+
+	securityPolicy        SecurityPolicy
+	bindMountAllowlist    []string
+	volumeDeviceAllowlist []string
 }
 
 // New constructs a new containerz server
@@ -216,6 +423,7 @@ func New(mgr containerManager, opts ...Option) *Server {
 	defaultOptions := []Option{
 		WithGrpcServer(grpc.NewServer()),
 		WithTempLocation("/tmp"),
+		WithPluginLocation("/plugins"),
 		WithChunkSize(5e6), // 5mb chunks,
 		WithAddr(":9999"),
 	}
@@ -249,6 +457,7 @@ func (s *Server) Serve(_ context.Context) error {
 
 	klog.Info("server-start")
 	cpb.RegisterContainerzServer(s.grpcServer, s)
+	options.RegisterExtensionServer(s.grpcServer, s)
 
 	klog.Infof("Starting up on Containerz server, listening on: %s", s.lis.Addr())
 	klog.Info("server-ready")
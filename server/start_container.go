@@ -16,6 +16,9 @@ package server
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
@@ -34,7 +37,7 @@ const (
 // should provide one. If the instance name already exists, the target should
 // return an error.
 func (s *Server) StartContainer(ctx context.Context, request *cpb.StartContainerRequest) (*cpb.StartContainerResponse, error) {
-	opts, err := optionsFromStartContainerRequest(request)
+	opts, err := s.startOptions(request)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +55,28 @@ func (s *Server) StartContainer(ctx context.Context, request *cpb.StartContainer
 	}, nil
 }
 
+// startOptions returns the options of the container the request starts, once checked against the
+// security policy and the bind mount allowlist of the server.
+func (s *Server) startOptions(request *cpb.StartContainerRequest) ([]options.Option, error) {
+	opts, err := optionsFromStartContainerRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	sec, err := s.security(request.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, options.WithSecurity(sec))
+	mounts, err := s.mounts(request.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	if len(mounts) != 0 {
+		opts = append(opts, options.WithMounts(mounts))
+	}
+	return opts, nil
+}
+
 func optionsFromStartContainerRequest(request *cpb.StartContainerRequest) ([]options.Option, error) {
 	var opts []options.Option
 	if len(request.GetPorts()) != 0 {
@@ -90,6 +115,66 @@ func optionsFromStartContainerRequest(request *cpb.StartContainerRequest) ([]opt
 		return nil, err
 	}
 
+	if spec, ok := labels[options.PortsLabel]; ok {
+		bindings, err := portBindingsFromLabel(spec)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, options.WithPortBindings(bindings))
+	}
+	if spec, ok := labels[options.NetworksLabel]; ok {
+		attachments, err := networkAttachmentsFromLabel(spec)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, options.WithNetworkAttachments(attachments))
+	}
+	if spec, ok := labels[options.DependsOnLabel]; ok {
+		deps, err := dependenciesFromLabel(spec)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, options.WithDependsOn(deps))
+	}
+	if value, ok := labels[options.DependencyTimeoutLabel]; ok {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.DependencyTimeoutLabel, err)
+		}
+		opts = append(opts, options.WithDependencyTimeout(d))
+	}
+	check, err := healthCheckFromLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	if check != nil {
+		opts = append(opts, options.WithHealthCheck(*check))
+	}
+	resources, err := resourcesFromLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	if resources != nil {
+		opts = append(opts, options.WithResources(*resources))
+	}
+	if name := labels[options.RevisionLabel]; name != "" {
+		opts = append(opts, options.WithRevisionName(name))
+	}
+	if hostname := labels[options.HostnameLabel]; hostname != "" {
+		opts = append(opts, options.WithHostname(hostname))
+	}
+	dns, search := splitLabel(labels[options.DNSLabel]), splitLabel(labels[options.DNSSearchLabel])
+	if len(dns) != 0 || len(search) != 0 {
+		opts = append(opts, options.WithDNS(dns, search))
+	}
+	if hosts := splitLabel(labels[options.ExtraHostsLabel]); len(hosts) != 0 {
+		opts = append(opts, options.WithExtraHosts(hosts))
+	}
+	ipv4, ipv6 := labels[options.IPv4AddressLabel], labels[options.IPv6AddressLabel]
+	if ipv4 != "" || ipv6 != "" {
+		opts = append(opts, options.WithIPAddresses(ipv4, ipv6))
+	}
+
 	opts = append(opts, options.WithLabels(labels), options.WithEnv(request.GetEnvironment()), options.WithInstanceName(request.GetInstanceName()), options.WithVolumes(request.GetVolumes()), options.WithDevices(request.GetDevices()))
 	return opts, nil
 }
@@ -117,3 +202,180 @@ func labelsWithLocation(request *cpb.StartContainerRequest) (map[string]string,
 	}
 	return labels, nil
 }
+
+// portBindingsFromLabel parses the port bindings carried in the ports label.
+func portBindingsFromLabel(spec string) ([]options.PortBinding, error) {
+	var bindings []options.PortBinding
+	for _, part := range splitLabel(spec) {
+		binding, err := options.ParsePortBinding(part)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.PortsLabel, err)
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+// networkAttachmentsFromLabel parses the network attachments carried in the networks label.
+func networkAttachmentsFromLabel(spec string) ([]options.NetworkAttachment, error) {
+	var attachments []options.NetworkAttachment
+	for _, part := range splitLabel(spec) {
+		attachment, err := options.ParseNetworkAttachment(part)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.NetworksLabel, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// dependenciesFromLabel parses the comma separated dependencies held in the depends-on label.
+func dependenciesFromLabel(spec string) ([]options.Dependency, error) {
+	var deps []options.Dependency
+	for _, part := range splitLabel(spec) {
+		dep, err := options.ParseDependency(part)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.DependsOnLabel, err)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// healthCheckFromLabels returns the health check carried in the labels, or nil if there is none.
+// The command is run with the shell, unless it is "none" which disables the image's health check.
+func healthCheckFromLabels(labels map[string]string) (*options.HealthCheck, error) {
+	var check options.HealthCheck
+	found := false
+	if cmd, ok := labels[options.HealthCmdLabel]; ok {
+		found = true
+		switch cmd {
+		case "":
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: empty command", options.HealthCmdLabel)
+		case "none":
+			check.Test = []string{"NONE"}
+		default:
+			check.Test = []string{"CMD-SHELL", cmd}
+		}
+	}
+	for label, field := range map[string]*time.Duration{
+		options.HealthIntervalLabel:     &check.Interval,
+		options.HealthCheckTimeoutLabel: &check.Timeout,
+		options.HealthStartPeriodLabel:  &check.StartPeriod,
+	} {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", label, err)
+		}
+		*field = d
+		found = true
+	}
+	if value, ok := labels[options.HealthRetriesLabel]; ok {
+		retries, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.HealthRetriesLabel, err)
+		}
+		check.Retries = retries
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return &check, nil
+}
+
+// resourcesFromLabels returns the resource controls carried in the labels, or nil if there are
+// none.
+func resourcesFromLabels(labels map[string]string) (*options.Resources, error) {
+	var res options.Resources
+	found := false
+	for label, field := range map[string]*string{
+		options.CPUSetCPUsLabel: &res.CPUSetCPUs,
+		options.CPUSetMemsLabel: &res.CPUSetMems,
+	} {
+		if value, ok := labels[label]; ok {
+			*field = value
+			found = true
+		}
+	}
+	for label, field := range map[string]*int64{
+		options.CPUSharesLabel:  &res.CPUShares,
+		options.PidsLimitLabel:  &res.PidsLimit,
+		options.MemorySwapLabel: &res.MemorySwap,
+	} {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", label, err)
+		}
+		*field = n
+		found = true
+	}
+	if value, ok := labels[options.BlkioWeightLabel]; ok {
+		weight, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.BlkioWeightLabel, err)
+		}
+		res.BlkioWeight = uint16(weight)
+		found = true
+	}
+	if value, ok := labels[options.OOMScoreAdjLabel]; ok {
+		adj, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.OOMScoreAdjLabel, err)
+		}
+		res.OOMScoreAdj = adj
+		found = true
+	}
+	if value, ok := labels[options.UlimitsLabel]; ok {
+		for _, part := range splitLabel(value) {
+			u, err := options.ParseUlimit(part)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.UlimitsLabel, err)
+			}
+			res.Ulimits = append(res.Ulimits, u)
+		}
+		found = true
+	}
+	for label, field := range map[string]*[]options.ThrottleDevice{
+		options.DeviceReadBPSLabel:   &res.DeviceReadBPS,
+		options.DeviceWriteBPSLabel:  &res.DeviceWriteBPS,
+		options.DeviceReadIOPSLabel:  &res.DeviceReadIOPS,
+		options.DeviceWriteIOPSLabel: &res.DeviceWriteIOPS,
+	} {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		for _, part := range splitLabel(value) {
+			dev, err := options.ParseThrottleDevice(part)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", label, err)
+			}
+			*field = append(*field, dev)
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return &res, nil
+}
+
+// splitLabel splits a comma separated label value, dropping empty elements.
+func splitLabel(value string) []string {
+	var res []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			res = append(res, part)
+		}
+	}
+	return res
+}
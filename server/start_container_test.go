@@ -18,11 +18,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	options "github.com/openconfig/containerz/containers"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -91,6 +94,515 @@ func TestContainerStart(t *testing.T) {
 				Ports: map[uint32]uint32{1: 2, 3: 4},
 			},
 		},
+		{
+			name: "port-bindings",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.PortsLabel: "514:5514/udp,[fd00::1]:179:179",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.PortsLabel: "514:5514/udp,[fd00::1]:179:179",
+					locationLabel:      cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				PortBindings: []options.PortBinding{
+					{Internal: 514, External: 5514, Protocol: "udp"},
+					{Internal: 179, External: 179, HostIP: "fd00::1"},
+				},
+			},
+		},
+		{
+			name: "invalid-port-bindings",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.PortsLabel: "514:5514/icmp",
+				},
+			},
+			wantState: &fakeContainerManager{},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.PortsLabel,
+				"port definition 514:5514/icmp has unknown protocol \"icmp\""),
+		},
+		{
+			name: "network-settings",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Network:   "mgmt",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.HostnameLabel:    "router-app",
+					options.DNSLabel:         "192.0.2.53,2001:db8::53",
+					options.DNSSearchLabel:   "example.com",
+					options.ExtraHostsLabel:  "collector:192.0.2.10",
+					options.IPv4AddressLabel: "192.0.2.2",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.HostnameLabel:    "router-app",
+					options.DNSLabel:         "192.0.2.53,2001:db8::53",
+					options.DNSSearchLabel:   "example.com",
+					options.ExtraHostsLabel:  "collector:192.0.2.10",
+					options.IPv4AddressLabel: "192.0.2.2",
+					locationLabel:            cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image:       "some-image",
+				Tag:         "some-tag",
+				Cmd:         "some-cmd",
+				Network:     "mgmt",
+				Hostname:    "router-app",
+				DNS:         []string{"192.0.2.53", "2001:db8::53"},
+				DNSSearch:   []string{"example.com"},
+				ExtraHosts:  []string{"collector:192.0.2.10"},
+				IPv4Address: "192.0.2.2",
+			},
+		},
+		{
+			name: "network-attachments",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.NetworksLabel: "mgmt;ip=192.0.2.2,data;alias=speaker",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.NetworksLabel: "mgmt;ip=192.0.2.2,data;alias=speaker",
+					locationLabel:         cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				Attachments: []options.NetworkAttachment{
+					{Network: "mgmt", IPv4Address: "192.0.2.2"},
+					{Network: "data", Aliases: []string{"speaker"}},
+				},
+			},
+		},
+		{
+			name: "invalid-network-attachments",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.NetworksLabel: "mgmt;ip=not-an-ip",
+				},
+			},
+			wantState: &fakeContainerManager{},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.NetworksLabel,
+				"network attachment mgmt;ip=not-an-ip has invalid IPv4 address \"not-an-ip\""),
+		},
+		{
+			name: "dependencies",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.DependsOnLabel:         "db:healthy,broker:tcp/9092",
+					options.DependencyTimeoutLabel: "2m",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.DependsOnLabel:         "db:healthy,broker:tcp/9092",
+					options.DependencyTimeoutLabel: "2m",
+					locationLabel:                  cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				DependsOn: []options.Dependency{
+					{Instance: "db", Condition: options.ConditionHealthy},
+					{Instance: "broker", Condition: options.ConditionTCP, Port: 9092},
+				},
+				DepTimeout: 2 * time.Minute,
+			},
+		},
+		{
+			name: "health-check",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.HealthCmdLabel:          "curl -f http://localhost:8080/healthz",
+					options.HealthIntervalLabel:     "10s",
+					options.HealthCheckTimeoutLabel: "2s",
+					options.HealthRetriesLabel:      "3",
+					options.HealthStartPeriodLabel:  "30s",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.HealthCmdLabel:          "curl -f http://localhost:8080/healthz",
+					options.HealthIntervalLabel:     "10s",
+					options.HealthCheckTimeoutLabel: "2s",
+					options.HealthRetriesLabel:      "3",
+					options.HealthStartPeriodLabel:  "30s",
+					locationLabel:                   cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				HealthCheck: &options.HealthCheck{
+					Test:        []string{"CMD-SHELL", "curl -f http://localhost:8080/healthz"},
+					Interval:    10 * time.Second,
+					Timeout:     2 * time.Second,
+					Retries:     3,
+					StartPeriod: 30 * time.Second,
+				},
+			},
+		},
+		{
+			name: "health-check-disabled",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.HealthCmdLabel: "none",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.HealthCmdLabel: "none",
+					locationLabel:          cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image:       "some-image",
+				Tag:         "some-tag",
+				Cmd:         "some-cmd",
+				HealthCheck: &options.HealthCheck{Test: []string{"NONE"}},
+			},
+		},
+		{
+			name: "invalid-health-retries",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.HealthRetriesLabel: "three",
+				},
+			},
+			wantState: &fakeContainerManager{},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.HealthRetriesLabel,
+				"strconv.Atoi: parsing \"three\": invalid syntax"),
+		},
+		{
+			name: "resources",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.CPUSetCPUsLabel:    "2-3",
+					options.PidsLimitLabel:     "256",
+					options.UlimitsLabel:       "nofile=1024:4096,core=0",
+					options.BlkioWeightLabel:   "500",
+					options.DeviceReadBPSLabel: "/dev/sda:1048576",
+					options.MemorySwapLabel:    "-1",
+					options.OOMScoreAdjLabel:   "-100",
+				},
+			},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.CPUSetCPUsLabel:    "2-3",
+					options.PidsLimitLabel:     "256",
+					options.UlimitsLabel:       "nofile=1024:4096,core=0",
+					options.BlkioWeightLabel:   "500",
+					options.DeviceReadBPSLabel: "/dev/sda:1048576",
+					options.MemorySwapLabel:    "-1",
+					options.OOMScoreAdjLabel:   "-100",
+					locationLabel:              cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				Resources: options.Resources{
+					CPUSetCPUs:    "2-3",
+					PidsLimit:     256,
+					Ulimits:       []options.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}, {Name: "core"}},
+					BlkioWeight:   500,
+					DeviceReadBPS: []options.ThrottleDevice{{Path: "/dev/sda", Rate: 1048576}},
+					MemorySwap:    -1,
+					OOMScoreAdj:   -100,
+				},
+			},
+		},
+		{
+			name: "invalid-ulimits",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.UlimitsLabel: "nofile",
+				},
+			},
+			wantState: &fakeContainerManager{},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.UlimitsLabel,
+				"ulimit nofile is invalid"),
+		},
+		{
+			name: "security",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.SeccompLabel:         "strict",
+					options.SELinuxLabel:         "type:svirt_apache_t",
+					options.ReadOnlyRootfsLabel:  "true",
+					options.TmpfsLabel:           "/run:size=64m;/tmp",
+					options.MaskedPathsLabel:     "/etc/secret",
+					options.NoNewPrivilegesLabel: "false",
+				},
+			},
+			inOpts: []Option{WithSecurityPolicy(SecurityPolicy{
+				Defaults: options.Security{
+					Seccomp:         options.SeccompDefault,
+					AppArmor:        "docker-default",
+					Tmpfs:           map[string]string{"/tmp": "size=16m", "/var/run": ""},
+					NoNewPrivileges: true,
+					MaskedPaths:     []string{"/proc/kcore"},
+				},
+				SeccompProfiles: []string{"strict"},
+			})},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.SeccompLabel:         "strict",
+					options.SELinuxLabel:         "type:svirt_apache_t",
+					options.ReadOnlyRootfsLabel:  "true",
+					options.TmpfsLabel:           "/run:size=64m;/tmp",
+					options.MaskedPathsLabel:     "/etc/secret",
+					options.NoNewPrivilegesLabel: "false",
+					locationLabel:                cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				Security: options.Security{
+					Seccomp:         "strict",
+					AppArmor:        "docker-default",
+					SELinux:         []string{"type:svirt_apache_t"},
+					ReadOnlyRootfs:  true,
+					Tmpfs:           map[string]string{"/run": "size=64m", "/tmp": "", "/var/run": ""},
+					NoNewPrivileges: true,
+					MaskedPaths:     []string{"/proc/kcore", "/etc/secret"},
+				},
+			},
+		},
+		{
+			name: "unconfined-denied-by-policy",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.SeccompLabel: options.Unconfined,
+				},
+			},
+			inOpts:    []Option{WithSecurityPolicy(SecurityPolicy{Defaults: options.Security{Seccomp: options.SeccompDefault}})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "%s confinement is required by the server policy", "seccomp"),
+		},
+		{
+			name: "unconfined-denied-by-allowlist",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.SeccompLabel: options.Unconfined,
+				},
+			},
+			inOpts:    []Option{WithSecurityPolicy(SecurityPolicy{SeccompProfiles: []string{"strict"}})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "seccomp profile %q is not allowed by the server policy", options.Unconfined),
+		},
+		{
+			name: "seccomp-profile-denied-by-policy",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.SeccompLabel: "lax",
+				},
+			},
+			inOpts: []Option{WithSecurityPolicy(SecurityPolicy{
+				Defaults:        options.Security{Seccomp: options.SeccompDefault},
+				SeccompProfiles: []string{"strict"},
+			})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "seccomp profile %q is not allowed by the server policy", "lax"),
+		},
+		{
+			name: "seccomp-default-not-replaced",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.SeccompLabel: options.SeccompDefault,
+				},
+			},
+			inOpts:    []Option{WithSecurityPolicy(SecurityPolicy{Defaults: options.Security{Seccomp: "strict"}})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "seccomp profile %q is not allowed by the server policy", options.SeccompDefault),
+		},
+		{
+			name: "host-userns-denied-by-policy",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.UsernsModeLabel: "host",
+				},
+			},
+			inOpts:    []Option{WithSecurityPolicy(SecurityPolicy{RequireUsernsRemap: true})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "user namespace remapping is required by the server policy"),
+		},
+		{
+			name: "invalid-read-only",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.ReadOnlyRootfsLabel: "yes",
+				},
+			},
+			wantState: &fakeContainerManager{},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.ReadOnlyRootfsLabel,
+				`strconv.ParseBool: parsing "yes": invalid syntax`),
+		},
+		{
+			name: "mounts",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.MountsLabel: "type=bind,source=/var/log/app,target=/logs,bind-propagation=rslave;type=tmpfs,target=/cache,tmpfs-size=1m",
+				},
+			},
+			inOpts: []Option{WithBindMountAllowlist([]string{"/var/log"})},
+			wantResp: &cpb.StartContainerResponse{
+				Response: &cpb.StartContainerResponse_StartOk{
+					StartOk: &cpb.StartOK{},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Labels: map[string]string{
+					options.MountsLabel: "type=bind,source=/var/log/app,target=/logs,bind-propagation=rslave;type=tmpfs,target=/cache,tmpfs-size=1m",
+					locationLabel:       cpb.StartContainerRequest_L_PRIMARY.String()},
+				Image: "some-image",
+				Tag:   "some-tag",
+				Cmd:   "some-cmd",
+				Mounts: []options.Mount{
+					{Type: options.BindMount, Source: "/var/log/app", Target: "/logs", Propagation: "rslave"},
+					{Type: options.TmpfsMount, Target: "/cache", TmpfsSize: 1 << 20},
+				},
+			},
+		},
+		{
+			name: "bind-mount-not-allowed",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.MountsLabel: "type=bind,source=/var/log/../../etc,target=/etc-host",
+				},
+			},
+			inOpts:    []Option{WithBindMountAllowlist([]string{"/var/log"})},
+			wantState: &fakeContainerManager{},
+			wantErr:   status.Errorf(codes.PermissionDenied, "bind mount of host path %s is not allowed", "/var/log/../../etc"),
+		},
+		{
+			name: "invalid-dependencies",
+			inReq: &cpb.StartContainerRequest{
+				ImageName: "some-image",
+				Tag:       "some-tag",
+				Cmd:       "some-cmd",
+				Location:  cpb.StartContainerRequest_L_PRIMARY,
+				Labels: map[string]string{
+					options.DependsOnLabel: "db:ready",
+				},
+			},
+			wantState: &fakeContainerManager{},
+			wantErr: status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", options.DependsOnLabel,
+				"dependency db:ready has unknown condition \"ready\""),
+		},
 		{
 			name: "env+port+instance",
 			inReq: &cpb.StartContainerRequest{
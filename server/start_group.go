@@ -0,0 +1,85 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
+)
+
+// startGroup starts the members of an application group and returns their names in the order they
+// were started.
+func (s *Server) startGroup(ctx context.Context, args options.GroupArgs) (any, error) {
+	members, err := s.groupMembers(args, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.mgr.GroupStart(ctx, args.Group, members)
+}
+
+// groupMembers returns the members of the group, each built from the StartContainerRequest
+// starting it, and checked against the security policy and the bind mount allowlist of the
+// server. The update options of the members are only honoured when updating the group.
+func (s *Server) groupMembers(args options.GroupArgs, update bool) ([]options.GroupMember, error) {
+	if args.Group == "" {
+		return nil, status.Error(codes.InvalidArgument, "the name of the group must be provided")
+	}
+	if len(args.Members) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "group %s has no members", args.Group)
+	}
+
+	members := make([]options.GroupMember, 0, len(args.Members))
+	for _, raw := range args.Members {
+		request := &cpb.StartContainerRequest{}
+		if err := protojson.Unmarshal(raw, request); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid member of group %s: %v", args.Group, err)
+		}
+		if request.GetInstanceName() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "members of group %s must have an instance name", args.Group)
+		}
+
+		opts, err := s.startOptions(request)
+		if err != nil {
+			return nil, err
+		}
+		if update {
+			updateOpts, err := updateOptionsFromLabels(request.GetLabels())
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, updateOpts...)
+		}
+		deps, err := dependenciesFromLabel(request.GetLabels()[options.DependsOnLabel])
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, options.GroupMember{
+			Name:      request.GetInstanceName(),
+			Image:     request.GetImageName(),
+			Tag:       request.GetTag(),
+			Cmd:       request.GetCmd(),
+			DependsOn: deps,
+			Options:   opts,
+		})
+	}
+	return members, nil
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
+// StartPlugin starts a deployed plugin.
 func (s *Server) StartPlugin(ctx context.Context, request *cpb.StartPluginRequest) (*cpb.StartPluginResponse, error) {
 	if err := s.mgr.PluginStart(ctx, request.GetName(), request.GetInstanceName(), request.GetConfig()); err != nil {
 		return nil, fmt.Errorf("unable to start plugin: %w", err)
@@ -15,3 +20,22 @@ func (s *Server) StartPlugin(ctx context.Context, request *cpb.StartPluginReques
 		InstanceName: request.GetInstanceName(),
 	}, nil
 }
+
+// upgradePlugin upgrades a plugin instance to a deployed plugin, keeping its settings.
+func (s *Server) upgradePlugin(ctx context.Context, args options.PluginArgs) (any, error) {
+	if err := s.mgr.PluginUpgrade(ctx, args.Name, args.Instance, args.Config); err != nil {
+		return nil, fmt.Errorf("unable to upgrade plugin: %w", err)
+	}
+	return nil, nil
+}
+
+// setPlugin changes the settings of a plugin instance.
+func (s *Server) setPlugin(ctx context.Context, args options.PluginArgs) (any, error) {
+	if args.Settings == nil {
+		return nil, status.Error(codes.InvalidArgument, "the plugin settings must be provided")
+	}
+	if err := s.mgr.PluginSet(ctx, args.Instance, *args.Settings); err != nil {
+		return nil, fmt.Errorf("unable to set plugin: %w", err)
+	}
+	return nil, nil
+}
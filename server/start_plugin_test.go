@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/testing/protocmp"
+
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -45,7 +46,7 @@ func TestStartPlugin(t *testing.T) {
 			defer s.Halt(ctx)
 
 			resp, err := cli.StartPlugin(ctx, tc.inReq)
-			if err != nil {
+			if (err != nil) != tc.wantErr {
 				t.Errorf("Start(%+v) returned error: %v", tc.inReq, err)
 			}
 
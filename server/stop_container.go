@@ -17,13 +17,17 @@ package server
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
 // StopContainer stops a container. If the container does not exist or is not running
 // this operation returns an error. This operation can, optionally, force
-// (i.e. kill) a container.
+// (i.e. kill) a container. If restart is set, the container is restarted in place
+// instead, preserving its ID and logs.
 func (s *Server) StopContainer(ctx context.Context, request *cpb.StopContainerRequest) (*cpb.StopContainerResponse, error) {
 	// TODO (alshabib): Consider adding a timeout to the request or use a containerz default.s
 	opts := []options.Option{}
@@ -32,8 +36,35 @@ func (s *Server) StopContainer(ctx context.Context, request *cpb.StopContainerRe
 		opts = append(opts, options.Force())
 	}
 
+	if request.GetRestart() {
+		if err := s.mgr.ContainerRestart(ctx, request.GetInstanceName(), opts...); err != nil {
+			return nil, err
+		}
+		return &cpb.StopContainerResponse{}, nil
+	}
+
 	if err := s.mgr.ContainerStop(ctx, request.GetInstanceName(), opts...); err != nil {
 		return nil, err
 	}
 	return &cpb.StopContainerResponse{}, nil
 }
+
+// restartContainer restarts a container in place, giving it the timeout of the arguments, if any,
+// to stop before it is killed.
+func (s *Server) restartContainer(ctx context.Context, args options.ContainerArgs) (any, error) {
+	if args.Instance == "" {
+		return nil, status.Error(codes.InvalidArgument, "the instance to restart must be provided")
+	}
+	if args.Timeout < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid restart timeout %v", args.Timeout)
+	}
+
+	opts := []options.Option{}
+	if args.Force {
+		opts = append(opts, options.Force())
+	}
+	if args.Timeout > 0 {
+		opts = append(opts, options.WithTimeout(args.Timeout))
+	}
+	return nil, s.mgr.ContainerRestart(ctx, args.Instance, opts...)
+}
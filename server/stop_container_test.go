@@ -20,8 +20,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"google.golang.org/protobuf/testing/protocmp"
 	cpb "github.com/openconfig/gnoi/containerz"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestStop(t *testing.T) {
@@ -55,6 +55,18 @@ func TestStop(t *testing.T) {
 				Force:    true,
 			},
 		},
+		{
+			name: "restart",
+			inReq: &cpb.StopContainerRequest{
+				InstanceName: "some-name",
+				Restart:      true,
+			},
+			wantResp: &cpb.StopContainerResponse{},
+			wantState: &fakeContainerManager{
+				Instance:  "some-name",
+				Restarted: true,
+			},
+		},
 	}
 
 	for _, tc := range tests {
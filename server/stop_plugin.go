@@ -4,13 +4,22 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
-// StopPlugin stops a plugin. If the plugin does not exist this operation is a no-op.
+// StopPlugin stops a plugin, unless volumes use it.
 func (s *Server) StopPlugin(ctx context.Context, request *cpb.StopPluginRequest) (*cpb.StopPluginResponse, error) {
 	if err := s.mgr.PluginStop(ctx, request.GetInstanceName()); err != nil {
 		return nil, fmt.Errorf("unable to stop plugin: %w", err)
 	}
 	return &cpb.StopPluginResponse{}, nil
 }
+
+// stopPlugin stops a plugin, even if volumes use it if forced.
+func (s *Server) stopPlugin(ctx context.Context, args options.PluginArgs) (any, error) {
+	if err := s.mgr.PluginStop(ctx, args.Instance, forceOption(args.Force)...); err != nil {
+		return nil, fmt.Errorf("unable to stop plugin: %w", err)
+	}
+	return nil, nil
+}
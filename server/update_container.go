@@ -16,10 +16,12 @@ package server
 
 import (
 	"context"
+	"time"
 
+	options "github.com/openconfig/containerz/containers"
+	cpb "github.com/openconfig/gnoi/containerz"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	cpb "github.com/openconfig/gnoi/containerz"
 )
 
 // UpdateContainer updates a running container to the image specified in the
@@ -51,10 +53,15 @@ func (s *Server) UpdateContainer(ctx context.Context, request *cpb.UpdateContain
 		return nil, status.Errorf(codes.FailedPrecondition, "expected request to contain populated params, yet was nil")
 	}
 
-	opts, err := optionsFromStartContainerRequest(startReq)
+	opts, err := s.startOptions(startReq)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts, err := updateOptionsFromLabels(startReq.GetLabels())
 	if err != nil {
 		return nil, err
 	}
+	opts = append(opts, updateOpts...)
 	instance, err := s.mgr.ContainerUpdate(ctx, request.GetInstanceName(), startReq.GetImageName(), startReq.GetTag(), startReq.GetCmd(), request.GetAsync(), opts...)
 	if err != nil {
 		return nil, err
@@ -69,3 +76,26 @@ func (s *Server) UpdateContainer(ctx context.Context, request *cpb.UpdateContain
 		},
 	}, nil
 }
+
+// updateOptionsFromLabels returns the update strategy options carried in the labels.
+func updateOptionsFromLabels(labels map[string]string) ([]options.Option, error) {
+	var opts []options.Option
+	if strategy, ok := labels[options.UpdateStrategyLabel]; ok {
+		opts = append(opts, options.WithUpdateStrategy(options.UpdateStrategy(strategy)))
+	}
+	for label, opt := range map[string]func(time.Duration) options.Option{
+		options.KeepPreviousLabel:  options.WithKeepPrevious,
+		options.HealthTimeoutLabel: options.WithHealthTimeout,
+	} {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q label is invalid: %v", label, err)
+		}
+		opts = append(opts, opt(d))
+	}
+	return opts, nil
+}
@@ -17,6 +17,7 @@ package server
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -24,6 +25,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	options "github.com/openconfig/containerz/containers"
 	cpb "github.com/openconfig/gnoi/containerz"
 )
 
@@ -67,6 +69,88 @@ func TestContainerUpdate(t *testing.T) {
 					locationLabel: cpb.StartContainerRequest_L_ALL.String()},
 			},
 		},
+		{
+			name: "network-settings",
+			inReq: &cpb.UpdateContainerRequest{
+				InstanceName: "some-instance",
+				ImageName:    "some-image",
+				ImageTag:     "some-tag",
+				Params: &cpb.StartContainerRequest{
+					ImageName: "some-image",
+					Tag:       "some-tag",
+					Cmd:       "some-cmd",
+					Network:   "mgmt",
+					Location:  cpb.StartContainerRequest_L_ALL,
+					Labels: map[string]string{
+						options.HostnameLabel:    "router-app",
+						options.IPv6AddressLabel: "2001:db8::2",
+					},
+				},
+			},
+			wantResp: &cpb.UpdateContainerResponse{
+				Response: &cpb.UpdateContainerResponse_UpdateOk{
+					UpdateOk: &cpb.UpdateOK{
+						InstanceName: "some-instance",
+					},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Instance:    "some-instance",
+				Image:       "some-image",
+				Tag:         "some-tag",
+				Cmd:         "some-cmd",
+				Network:     "mgmt",
+				Hostname:    "router-app",
+				IPv6Address: "2001:db8::2",
+				Labels: map[string]string{
+					options.HostnameLabel:    "router-app",
+					options.IPv6AddressLabel: "2001:db8::2",
+					locationLabel:            cpb.StartContainerRequest_L_ALL.String()},
+			},
+		},
+		{
+			name: "blue-green",
+			inReq: &cpb.UpdateContainerRequest{
+				InstanceName: "some-instance",
+				ImageName:    "some-image",
+				ImageTag:     "some-tag",
+				Params: &cpb.StartContainerRequest{
+					ImageName: "some-image",
+					Tag:       "some-tag",
+					Cmd:       "some-cmd",
+					Location:  cpb.StartContainerRequest_L_ALL,
+					Labels: map[string]string{
+						options.UpdateStrategyLabel: "blue-green",
+						options.KeepPreviousLabel:   "1h",
+						options.HealthTimeoutLabel:  "30s",
+						options.RevisionLabel:       "release-2",
+					},
+				},
+			},
+			wantResp: &cpb.UpdateContainerResponse{
+				Response: &cpb.UpdateContainerResponse_UpdateOk{
+					UpdateOk: &cpb.UpdateOK{
+						InstanceName: "some-instance",
+					},
+				},
+			},
+			wantState: &fakeContainerManager{
+				Instance:      "some-instance",
+				Image:         "some-image",
+				Tag:           "some-tag",
+				Cmd:           "some-cmd",
+				Strategy:      options.BlueGreenStrategy,
+				KeepPrevious:  time.Hour,
+				HealthTimeout: 30 * time.Second,
+				RevisionName:  "release-2",
+				Labels: map[string]string{
+					options.UpdateStrategyLabel: "blue-green",
+					options.KeepPreviousLabel:   "1h",
+					options.HealthTimeoutLabel:  "30s",
+					options.RevisionLabel:       "release-2",
+					locationLabel:               cpb.StartContainerRequest_L_ALL.String()},
+			},
+		},
 		{
 			name: "only-inner-image-and-tag-used",
 			inReq: &cpb.UpdateContainerRequest{
@@ -0,0 +1,30 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+// updateGroup updates an application group to the given members, as a whole or not at all.
+func (s *Server) updateGroup(ctx context.Context, args options.GroupArgs) (any, error) {
+	members, err := s.groupMembers(args, true)
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.mgr.GroupUpdate(ctx, args.Group, members)
+}
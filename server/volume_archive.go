@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	"github.com/openconfig/containerz/chunker"
+	"github.com/openconfig/containerz/containers"
+)
+
+// exportVolume writes a compressed archive of the contents of the volume to w.
+func (s *Server) exportVolume(ctx context.Context, args options.VolumeArchiveArgs, w io.Writer) error {
+	if args.Name == "" {
+		return status.Error(codes.InvalidArgument, "the volume to export must be provided")
+	}
+	return s.mgr.VolumeExport(ctx, args.Name, w)
+}
+
+// importVolume receives a compressed archive of the contents of a volume, verifies its size and
+// checksum and restores the archive into the volume.
+func (s *Server) importVolume(ctx context.Context, args options.VolumeArchiveArgs, r io.Reader) (any, error) {
+	if args.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "the volume to import must be provided")
+	}
+	if args.Checksum == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "the checksum of the archive of volume %s is missing", args.Name)
+	}
+	if err := checkDiskSpace(s.tmpLocation, args.Size); err != nil {
+		return nil, err
+	}
+
+	archive, err := chunker.NewWriter(s.tmpLocation, s.chunkSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	defer func() {
+		if err := archive.Cleanup(); err != nil {
+			klog.Error(err)
+		}
+	}()
+
+	// One more byte than announced is read to detect, without storing, archives that are too large.
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(archive, h), io.LimitReader(r, int64(args.Size)+1)); err != nil {
+		return nil, err
+	}
+	if archive.Size() != args.Size {
+		return nil, status.Errorf(codes.InvalidArgument, "volume archive does not have the announced size of %d bytes", args.Size)
+	}
+	if sum := options.FormatChecksum(h.Sum(nil)); sum != args.Checksum {
+		return nil, status.Errorf(codes.DataLoss, "volume archive checksum %s does not match %s", sum, args.Checksum)
+	}
+
+	f := archive.File()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if err := s.mgr.VolumeImport(ctx, args.Name, f); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
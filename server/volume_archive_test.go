@@ -0,0 +1,173 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/openconfig/containerz/containers"
+)
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return options.FormatChecksum(sum[:])
+}
+
+func TestExportVolume(t *testing.T) {
+	large := bytes.Repeat([]byte("0123456789abcdef"), downloadChunkSize/16+1)
+
+	tests := []struct {
+		name       string
+		inArchive  []byte
+		wantChunks [][]byte
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:       "single-chunk",
+			inArchive:  []byte("some archive"),
+			wantChunks: [][]byte{[]byte("some archive")},
+		},
+		{
+			name:       "many-chunks",
+			inArchive:  large,
+			wantChunks: [][]byte{large[:downloadChunkSize], large[downloadChunkSize:]},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{volumeArchive: tc.inArchive}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0")})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.NewExtensionRequest(options.ExportVolume, options.VolumeArchiveArgs{Name: "data"})
+			if err != nil {
+				t.Fatalf("NewExtensionRequest() returned error: %v", err)
+			}
+			dCli, err := ext.Download(ctx, req)
+			if err != nil {
+				t.Fatalf("Download(%v) returned error: %v", req, err)
+			}
+
+			var gotChunks [][]byte
+			for {
+				msg, err := dCli.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Recv() returned error: %v", err)
+				}
+				gotChunks = append(gotChunks, msg.GetValue())
+			}
+
+			if diff := cmp.Diff(tc.wantChunks, gotChunks); diff != "" {
+				t.Errorf("Download(%v) returned diff (-want, +got):\n%s", req, diff)
+			}
+			if diff := cmp.Diff([]string{checksum(tc.inArchive)}, dCli.Trailer().Get(options.ChecksumTrailer)); diff != "" {
+				t.Errorf("Download(%v) returned unexpected checksum (-want, +got):\n%s", req, diff)
+			}
+			if fake.Name != "data" {
+				t.Errorf("Download(%v) exported volume %q, want %q", req, fake.Name, "data")
+			}
+		})
+	}
+}
+
+func TestImportVolume(t *testing.T) {
+	archive := []byte("some archive")
+
+	tests := []struct {
+		name       string
+		inArgs     options.VolumeArchiveArgs
+		wantErr    error
+		wantVolume string
+	}{
+		{
+			name:       "restored",
+			inArgs:     options.VolumeArchiveArgs{Name: "data", Size: uint64(len(archive)), Checksum: checksum(archive)},
+			wantVolume: "data",
+		},
+		{
+			name:    "checksum-mismatch",
+			inArgs:  options.VolumeArchiveArgs{Name: "data", Size: uint64(len(archive)), Checksum: checksum([]byte("other archive"))},
+			wantErr: status.Errorf(codes.DataLoss, "volume archive checksum %s does not match %s", checksum(archive), checksum([]byte("other archive"))),
+		},
+		{
+			name:    "checksum-missing",
+			inArgs:  options.VolumeArchiveArgs{Name: "data", Size: uint64(len(archive))},
+			wantErr: status.Errorf(codes.InvalidArgument, "the checksum of the archive of volume %s is missing", "data"),
+		},
+		{
+			name:    "too-large",
+			inArgs:  options.VolumeArchiveArgs{Name: "data", Size: 8, Checksum: checksum(archive[:8])},
+			wantErr: status.Errorf(codes.InvalidArgument, "volume archive does not have the announced size of %d bytes", 8),
+		},
+		{
+			name:    "too-small",
+			inArgs:  options.VolumeArchiveArgs{Name: "data", Size: 16, Checksum: checksum(archive)},
+			wantErr: status.Errorf(codes.InvalidArgument, "volume archive does not have the announced size of %d bytes", 16),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeContainerManager{}
+			_, s := startServerAndReturnClient(ctx, t, fake, []Option{WithAddr("localhost:0"), WithChunkSize(8)})
+			defer s.Halt(ctx)
+			ext := newExtensionClient(t, s)
+
+			req, err := options.MarshalExtensionRequest(options.ImportVolume, tc.inArgs)
+			if err != nil {
+				t.Fatalf("MarshalExtensionRequest() returned error: %v", err)
+			}
+			uCli, err := ext.Upload(ctx)
+			if err != nil {
+				t.Fatalf("Upload() returned error: %v", err)
+			}
+			for _, msg := range [][]byte{req, archive[:8], archive[8:]} {
+				if err := uCli.Send(wrapperspb.Bytes(msg)); err != nil {
+					break
+				}
+			}
+			_, gotErr := uCli.CloseAndRecv()
+
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Upload(%+v) returned unexpected error (-want, +got):\n%s", tc.inArgs, diff)
+			}
+			if fake.Name != tc.wantVolume {
+				t.Errorf("Upload(%+v) restored volume %q, want %q", tc.inArgs, fake.Name, tc.wantVolume)
+			}
+			if tc.wantVolume != "" && fake.Contents != string(archive) {
+				t.Errorf("Upload(%+v) restored %q, want %q", tc.inArgs, fake.Contents, archive)
+			}
+		})
+	}
+}